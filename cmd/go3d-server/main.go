@@ -0,0 +1,540 @@
+// go3d-server 渲染服务：通过 HTTP 提交场景描述（JSON，见 go3d.SceneFile）和动画参数，
+// 查询任务进度，下载渲染好的帧或视频。适合在容器集群中批量渲染
+//
+//	go run ./cmd/go3d-server -addr :8080 -data /var/lib/go3d -jobs 2 -workers 4 -retention 24h
+//
+// 只提供 HTTP 接口，没有 gRPC。任务的渲染线程数不超过 -workers，场景的对象数、光源数、星星数、
+// 文字长度和相机关键帧数不超过 -max-objects 等上限，结束超过 -retention 的任务连同文件一起删除。接口：
+//
+//	POST   /jobs                  提交任务 {"scene": {...}, "animation": {...}}，返回 202 和任务信息
+//	GET    /jobs                  列出所有任务
+//	GET    /jobs/{id}             任务状态和进度
+//	GET    /jobs/{id}/frames/{n}  下载第 n 帧（PNG，帧号从 1 开始）
+//	GET    /jobs/{id}/video       下载视频（任务需要设置 "video": true）
+//	DELETE /jobs/{id}             删除排队中或已结束的任务及其文件
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	go3d "github.com/novvoo/go-3d/pkg"
+)
+
+// 任务状态
+const (
+	statusQueued  = "queued"
+	statusRunning = "running"
+	statusDone    = "done"
+	statusFailed  = "failed"
+)
+
+// animationSpec 任务的动画参数，0 值使用 go3d.DefaultAnimationConfig 的默认值
+type animationSpec struct {
+	Width      int     `json:"width,omitempty"`
+	Height     int     `json:"height,omitempty"`
+	FPS        int     `json:"fps,omitempty"`
+	Duration   float64 `json:"duration,omitempty"`
+	Quality    int     `json:"quality,omitempty"`
+	Workers    int     `json:"workers,omitempty"`
+	StartFrame int     `json:"startFrame,omitempty"`
+	EndFrame   int     `json:"endFrame,omitempty"`
+	Step       int     `json:"step,omitempty"`
+	Video      bool    `json:"video,omitempty"`  // 是否用 ffmpeg 合成视频
+	Preset     string  `json:"preset,omitempty"` // 视频预设名称（见 go3d.ParseVideoPreset），默认 h264
+}
+
+// jobRequest 提交任务的请求体
+type jobRequest struct {
+	Scene     json.RawMessage `json:"scene"`
+	Animation animationSpec   `json:"animation"`
+}
+
+// job 一个渲染任务
+type job struct {
+	ID        string        `json:"id"`
+	Status    string        `json:"status"`
+	Done      int           `json:"done"`  // 已完成的帧数
+	Total     int           `json:"total"` // 需要渲染的帧数
+	Error     string        `json:"error,omitempty"`
+	Video     bool          `json:"video"`
+	Animation animationSpec `json:"animation"`
+	Created   time.Time     `json:"created"`
+	Finished  *time.Time    `json:"finished,omitempty"`
+
+	scene  *go3d.SceneFile
+	config go3d.AnimationConfig
+	dir    string
+	output string // 视频文件路径
+}
+
+// server 任务队列和 HTTP 处理
+type server struct {
+	dataDir    string
+	maxPixels  int
+	maxFrames  int
+	maxFPS     int
+	maxWorkers int
+	limits     go3d.SceneLimits
+	retention  time.Duration
+
+	mu    sync.Mutex
+	jobs  map[string]*job
+	queue chan *job
+}
+
+func main() {
+	addr := flag.String("addr", ":8080", "监听地址")
+	dataDir := flag.String("data", "go3d-jobs", "任务文件目录")
+	concurrency := flag.Int("jobs", 1, "同时运行的任务数")
+	maxPixels := flag.Int("max-pixels", 3840*2160, "单帧最大像素数")
+	maxFrames := flag.Int("max-frames", 18000, "单个任务最多渲染的帧数")
+	maxFPS := flag.Int("max-fps", 120, "动画最大帧率")
+	maxWorkers := flag.Int("workers", runtime.NumCPU(), "单个任务最多使用的渲染线程数")
+	maxObjects := flag.Int("max-objects", 1000, "场景最多包含的对象数")
+	maxLights := flag.Int("max-lights", 16, "场景最多包含的光源数")
+	maxStars := flag.Int("max-stars", 100000, "场景中星星的总数上限")
+	maxText := flag.Int("max-text", 256, "每个对象的文字字符数上限")
+	maxKeyframes := flag.Int("max-keyframes", 10000, "相机路径最多包含的关键帧数")
+	retention := flag.Duration("retention", 24*time.Hour, "任务结束后保留的时长，之后删除任务及其文件")
+	flag.Parse()
+
+	if err := os.MkdirAll(*dataDir, 0755); err != nil {
+		log.Fatalf("创建任务目录失败: %v", err)
+	}
+
+	s := &server{
+		dataDir:    *dataDir,
+		maxPixels:  *maxPixels,
+		maxFrames:  *maxFrames,
+		maxFPS:     *maxFPS,
+		maxWorkers: max(1, *maxWorkers),
+		retention:  *retention,
+		jobs:       make(map[string]*job),
+		queue:      make(chan *job, 1024),
+		limits: go3d.SceneLimits{
+			Objects:   *maxObjects,
+			Lights:    *maxLights,
+			Stars:     *maxStars,
+			TextRunes: *maxText,
+			Keyframes: *maxKeyframes,
+		},
+	}
+	for i := 0; i < max(1, *concurrency); i++ {
+		go s.worker()
+	}
+	go s.evictLoop()
+
+	log.Printf("渲染服务已启动: http://%s", *addr)
+	if err := http.ListenAndServe(*addr, s.routes()); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// routes 注册 HTTP 路由
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /jobs", s.handleSubmit)
+	mux.HandleFunc("GET /jobs", s.handleList)
+	mux.HandleFunc("GET /jobs/{id}", s.handleStatus)
+	mux.HandleFunc("GET /jobs/{id}/frames/{n}", s.handleFrame)
+	mux.HandleFunc("GET /jobs/{id}/video", s.handleVideo)
+	mux.HandleFunc("DELETE /jobs/{id}", s.handleDelete)
+	return mux
+}
+
+// handleSubmit 校验并提交任务
+func (s *server) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	var req jobRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, 8<<20))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("解析请求失败: %w", err))
+		return
+	}
+	if len(bytes.TrimSpace(req.Scene)) == 0 {
+		writeError(w, http.StatusBadRequest, errors.New("缺少 scene"))
+		return
+	}
+	scene, err := go3d.ParseSceneFile(req.Scene)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	// 图像背景和场景脚本会读取服务器上的文件，不对外开放
+	if scene.Background != nil && scene.Background.Type == "image" {
+		writeError(w, http.StatusBadRequest, errors.New("渲染服务不支持图像背景"))
+		return
+	}
+	if scene.Script != "" {
+		writeError(w, http.StatusBadRequest, errors.New("渲染服务不支持场景脚本"))
+		return
+	}
+	if err := scene.CheckLimits(s.limits); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := newJobID()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	j := &job{
+		ID:        id,
+		Status:    statusQueued,
+		Video:     req.Animation.Video,
+		Animation: req.Animation,
+		Created:   time.Now(),
+		scene:     scene,
+		dir:       filepath.Join(s.dataDir, id),
+	}
+	config, err := s.animationConfig(j)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	j.config = config
+	j.Total = config.FrameCount()
+
+	s.mu.Lock()
+	select {
+	case s.queue <- j:
+		s.jobs[id] = j
+	default:
+		s.mu.Unlock()
+		writeError(w, http.StatusServiceUnavailable, errors.New("任务队列已满"))
+		return
+	}
+	snapshot := *j
+	s.mu.Unlock()
+
+	log.Printf("任务 %s 已提交: %d 帧", id, j.Total)
+	w.Header().Set("Location", "/jobs/"+id)
+	writeJSON(w, http.StatusAccepted, &snapshot)
+}
+
+// animationConfig 按任务参数创建动画配置，输出到任务目录，渲染线程数不超过服务端上限
+func (s *server) animationConfig(j *job) (go3d.AnimationConfig, error) {
+	spec := j.Animation
+	config := go3d.DefaultAnimationConfig()
+	if spec.Width > 0 {
+		config.Width = spec.Width
+	}
+	if spec.Height > 0 {
+		config.Height = spec.Height
+	}
+	if spec.FPS > 0 {
+		config.FPS = spec.FPS
+	}
+	if spec.Duration > 0 {
+		config.Duration = spec.Duration
+	}
+	if spec.Quality > 0 {
+		config.Quality = spec.Quality
+	}
+	if spec.Workers > 0 {
+		config.Workers = min(spec.Workers, s.maxWorkers)
+	}
+	config.StartFrame, config.EndFrame, config.Step = spec.StartFrame, spec.EndFrame, spec.Step
+	config.TempDir = filepath.Join(j.dir, "frames")
+	config.OutputFile = filepath.Join(j.dir, "output.mp4")
+	config.CleanupTemp = false
+
+	if spec.Video && spec.Preset != "" {
+		preset, err := go3d.ParseVideoPreset(spec.Preset)
+		if err != nil {
+			return config, err
+		}
+		if err := config.ApplyVideoPreset(preset); err != nil {
+			return config, err
+		}
+	}
+
+	// 步长大于 1 时帧文件不连续，ffmpeg 只能读到第一帧
+	if spec.Video && config.Step > 1 {
+		return config, errors.New("步长大于 1 时不能合成视频")
+	}
+	// 分别检查宽高，避免乘积溢出
+	if config.Width <= 0 || config.Height <= 0 || config.Width > s.maxPixels/config.Height {
+		return config, fmt.Errorf("分辨率 %dx%d 超过上限（%d 像素）", config.Width, config.Height, s.maxPixels)
+	}
+	// 先检查帧率和时长再计算帧数，避免总帧数转换为 int 时溢出
+	if config.FPS > s.maxFPS {
+		return config, fmt.Errorf("帧率 %d 超过上限 %d", config.FPS, s.maxFPS)
+	}
+	if maxDuration := float64(s.maxFrames) / float64(config.FPS); config.Duration > maxDuration {
+		return config, fmt.Errorf("时长 %g 秒超过上限（%d fps 下 %g 秒）", config.Duration, config.FPS, maxDuration)
+	}
+	count := config.FrameCount()
+	if count == 0 {
+		return config, errors.New("帧范围为空")
+	}
+	if count > s.maxFrames {
+		return config, fmt.Errorf("帧数 %d 超过上限 %d", count, s.maxFrames)
+	}
+	return config, nil
+}
+
+// worker 依次运行队列中的任务
+func (s *server) worker() {
+	for j := range s.queue {
+		s.mu.Lock()
+		if s.jobs[j.ID] != j {
+			// 排队期间已被删除
+			s.mu.Unlock()
+			continue
+		}
+		j.Status = statusRunning
+		s.mu.Unlock()
+
+		log.Printf("任务 %s 开始渲染", j.ID)
+		err := s.run(j)
+
+		s.mu.Lock()
+		now := time.Now()
+		j.Finished = &now
+		if err != nil {
+			j.Status, j.Error = statusFailed, err.Error()
+		} else {
+			j.Status = statusDone
+		}
+		s.mu.Unlock()
+
+		if err != nil {
+			log.Printf("任务 %s 失败: %v", j.ID, err)
+		} else {
+			log.Printf("任务 %s 完成", j.ID)
+		}
+	}
+}
+
+// run 渲染任务的帧，需要时合成视频。场景或渲染器中的 panic 转换为任务错误，不影响服务和其它任务
+func (s *server) run(j *job) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("渲染时发生 panic: %v", v)
+		}
+	}()
+
+	config := j.config
+	config.Progress = func(done, total int) {
+		s.mu.Lock()
+		j.Done, j.Total = done, total
+		s.mu.Unlock()
+	}
+
+	// 多线程渲染时帧渲染函数运行在生成器的协程中，在这里捕获 panic；
+	// 出错后其余的帧不再渲染，生成器结束后任务失败
+	var (
+		panicMu  sync.Mutex
+		panicErr error
+	)
+	render := j.scene.FrameRenderer(nil)
+	safeRender := func(renderer *go3d.Renderer, frame int, t float64) {
+		panicMu.Lock()
+		failed := panicErr != nil
+		panicMu.Unlock()
+		if failed {
+			return
+		}
+		defer func() {
+			if v := recover(); v != nil {
+				panicMu.Lock()
+				if panicErr == nil {
+					panicErr = fmt.Errorf("渲染第 %d 帧时发生 panic: %v", frame, v)
+				}
+				panicMu.Unlock()
+			}
+		}()
+		render(renderer, frame, t)
+	}
+	renderErr := func() error {
+		panicMu.Lock()
+		defer panicMu.Unlock()
+		return panicErr
+	}
+
+	generator := go3d.NewAnimationGenerator(config, safeRender)
+	if !j.Video {
+		if err := generator.GenerateFrames(); err != nil {
+			return err
+		}
+		return renderErr()
+	}
+	if err := generator.CheckEncoder(); err != nil {
+		return err
+	}
+	if err := generator.GenerateFrames(); err != nil {
+		return err
+	}
+	if err := renderErr(); err != nil {
+		return err
+	}
+	if err := generator.ComposeVideo(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	j.output = config.OutputFile
+	s.mu.Unlock()
+	return nil
+}
+
+// evictLoop 定期删除结束超过保留时长的任务及其文件
+func (s *server) evictLoop() {
+	if s.retention <= 0 {
+		return
+	}
+	ticker := time.NewTicker(min(s.retention, time.Minute))
+	defer ticker.Stop()
+	for now := range ticker.C {
+		s.mu.Lock()
+		var expired []*job
+		for id, j := range s.jobs {
+			if j.Finished != nil && now.Sub(*j.Finished) > s.retention {
+				delete(s.jobs, id)
+				expired = append(expired, j)
+			}
+		}
+		s.mu.Unlock()
+
+		for _, j := range expired {
+			if err := os.RemoveAll(j.dir); err != nil {
+				log.Printf("删除过期任务 %s 的文件失败: %v", j.ID, err)
+			} else {
+				log.Printf("任务 %s 已过期删除", j.ID)
+			}
+		}
+	}
+}
+
+// lookup 按路径中的 id 查找任务，找不到时写入 404
+func (s *server) lookup(w http.ResponseWriter, r *http.Request) (job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j, ok := s.jobs[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, errors.New("任务不存在"))
+		return job{}, false
+	}
+	return *j, true
+}
+
+// handleList 按提交时间列出所有任务
+func (s *server) handleList(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	list := make([]job, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		list = append(list, *j)
+	}
+	s.mu.Unlock()
+	sort.Slice(list, func(a, b int) bool { return list[a].Created.Before(list[b].Created) })
+	writeJSON(w, http.StatusOK, list)
+}
+
+// handleStatus 返回任务状态
+func (s *server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if j, ok := s.lookup(w, r); ok {
+		writeJSON(w, http.StatusOK, &j)
+	}
+}
+
+// handleFrame 返回已渲染的一帧
+func (s *server) handleFrame(w http.ResponseWriter, r *http.Request) {
+	j, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	frame, err := strconv.Atoi(r.PathValue("n"))
+	if err != nil || frame < 1 {
+		writeError(w, http.StatusBadRequest, errors.New("无效的帧号"))
+		return
+	}
+	path := j.config.FramePath(frame)
+	if _, err := os.Stat(path); err != nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("第 %d 帧尚未渲染", frame))
+		return
+	}
+	w.Header().Set("Content-Type", "image/png")
+	http.ServeFile(w, r, path)
+}
+
+// handleVideo 返回合成好的视频
+func (s *server) handleVideo(w http.ResponseWriter, r *http.Request) {
+	j, ok := s.lookup(w, r)
+	if !ok {
+		return
+	}
+	if !j.Video {
+		writeError(w, http.StatusNotFound, errors.New("任务没有请求合成视频"))
+		return
+	}
+	if j.Status != statusDone || j.output == "" {
+		writeError(w, http.StatusConflict, fmt.Errorf("视频尚未完成（状态: %s）", j.Status))
+		return
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", j.ID+filepath.Ext(j.output)))
+	http.ServeFile(w, r, j.output)
+}
+
+// handleDelete 删除排队中或已结束的任务及其文件，运行中的任务不能删除
+func (s *server) handleDelete(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	s.mu.Lock()
+	j, ok := s.jobs[id]
+	if !ok {
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, errors.New("任务不存在"))
+		return
+	}
+	if j.Status == statusRunning {
+		s.mu.Unlock()
+		writeError(w, http.StatusConflict, errors.New("任务正在运行，不能删除"))
+		return
+	}
+	delete(s.jobs, id)
+	s.mu.Unlock()
+
+	if err := os.RemoveAll(j.dir); err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("删除任务文件失败: %w", err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// newJobID 生成随机的任务 ID
+func newJobID() (string, error) {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("生成任务 ID 失败: %w", err)
+	}
+	return hex.EncodeToString(b[:]), nil
+}
+
+// writeJSON 以 JSON 写入响应
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("写入响应失败: %v", err)
+	}
+}
+
+// writeError 以 JSON 写入错误信息
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,185 @@
+// go3d 命令行渲染工具：读取场景描述文件（JSON，见 go3d.SceneFile），渲染静帧、序列帧或视频，
+// 简单的渲染任务不需要编写 Go 代码
+//
+//	go3d -o still.png -t 0.25 scene.json                        # 静帧（.png 或 .svg），-t 为动画时间（0-1）
+//	go3d -o orbit.mp4 -width 1280 -height 720 -fps 30 scene.json # 视频（.mp4、.webm、.mov，需要 ffmpeg）
+//	go3d -o frames/ -duration 5 scene.json                       # 仅输出 PNG 序列帧（输出路径以 / 结尾或没有扩展名）
+//	go3d -o out.mp4 -camera path.json -mode toon scene.json      # 替换相机（CameraSpec JSON 或录制的 CSV 关键帧）和渲染模式
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	go3d "github.com/novvoo/go-3d/pkg"
+)
+
+func main() {
+	output := flag.String("o", "output.png", "输出文件：.png/.svg 为静帧，.mp4/.webm/.mov 为视频，目录为序列帧")
+	width := flag.Int("width", 1920, "画面宽度")
+	height := flag.Int("height", 1080, "画面高度")
+	fps := flag.Int("fps", 30, "帧率")
+	duration := flag.Float64("duration", 10, "动画时长（秒）")
+	still := flag.Float64("t", 0, "静帧的动画时间（0-1）")
+	mode := flag.String("mode", "", "渲染模式，覆盖场景文件中的设置（wireframe、flat、shaded、gouraud、toon、raytraced、pathtraced、objectid）")
+	camera := flag.String("camera", "", "相机描述文件（CameraSpec JSON，或 .csv 关键帧），替换场景文件中的相机")
+	preset := flag.String("preset", "", "视频预设（h264、h264-nvenc、hevc-vt、vp9、vp9-alpha、av1、prores4444），默认按输出扩展名选择")
+	quality := flag.Int("quality", 23, "视频质量（CRF，越小质量越高）")
+	workers := flag.Int("workers", 4, "并行渲染的线程数")
+	transparent := flag.Bool("transparent", false, "透明背景（PNG 和带 alpha 通道的视频预设）")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "用法: %s [选项] 场景文件.json\n", filepath.Base(os.Args[0]))
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	sf, err := go3d.LoadSceneFile(flag.Arg(0))
+	if err != nil {
+		fatal(err)
+	}
+	if *mode != "" {
+		sf.RenderMode = *mode
+	}
+	if *camera != "" {
+		if err := loadCamera(sf, *camera); err != nil {
+			fatal(err)
+		}
+	}
+	if err := sf.Validate(); err != nil {
+		fatal(err)
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(*output)); {
+	case ext == ".png" || ext == ".svg":
+		err = renderStill(sf, *output, *width, *height, *still, *transparent)
+	case ext == "" || strings.HasSuffix(*output, "/"):
+		config := animationConfig(*width, *height, *fps, *duration, *quality, *workers, *transparent)
+		err = go3d.NewAnimationGenerator(config, sf.FrameRenderer(nil)).GenerateFramesOnly(*output)
+	case ext == ".mp4" || ext == ".webm" || ext == ".mov":
+		config := animationConfig(*width, *height, *fps, *duration, *quality, *workers, *transparent)
+		name := *preset
+		if name == "" {
+			name = presetForExtension(ext, *transparent)
+		}
+		err = renderVideo(sf, config, *output, name)
+	default:
+		err = fmt.Errorf("不支持的输出格式 %s：静帧为 .png/.svg，视频为 .mp4/.webm/.mov，序列帧为目录", ext)
+	}
+	if err != nil {
+		fatal(err)
+	}
+}
+
+// renderVideo 按视频预设渲染动画并用 ffmpeg 合成视频，序列帧临时保存在输出文件旁的目录中
+func renderVideo(sf *go3d.SceneFile, config go3d.AnimationConfig, output, presetName string) error {
+	preset, err := go3d.ParseVideoPreset(presetName)
+	if err != nil {
+		return err
+	}
+	config.OutputFile = output
+	config.TempDir = strings.TrimSuffix(output, filepath.Ext(output)) + "_frames"
+	if err := config.ApplyVideoPreset(preset); err != nil {
+		return err
+	}
+	return go3d.NewAnimationGenerator(config, sf.FrameRenderer(nil)).Generate()
+}
+
+// animationConfig 按命令行参数创建动画配置
+func animationConfig(width, height, fps int, duration float64, quality, workers int, transparent bool) go3d.AnimationConfig {
+	config := go3d.DefaultAnimationConfig()
+	config.Width = width
+	config.Height = height
+	config.FPS = fps
+	config.Duration = duration
+	config.Quality = quality
+	config.Workers = workers
+	config.Transparent = transparent
+	return config
+}
+
+// presetForExtension 按输出文件扩展名选择视频预设
+func presetForExtension(ext string, transparent bool) string {
+	switch {
+	case ext == ".webm" && transparent:
+		return "vp9-alpha"
+	case ext == ".webm":
+		return "vp9"
+	case ext == ".mov":
+		return "prores4444"
+	default:
+		return "h264"
+	}
+}
+
+// loadCamera 读取相机描述文件，替换场景中的相机
+func loadCamera(sf *go3d.SceneFile, filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("读取相机文件失败: %w", err)
+	}
+	if strings.EqualFold(filepath.Ext(filename), ".csv") {
+		path, err := go3d.ReadCameraPathCSV(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("%s: %w", filename, err)
+		}
+		sf.Camera = path.Spec()
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var camera go3d.CameraSpec
+	if err := decoder.Decode(&camera); err != nil {
+		return fmt.Errorf("%s: 解析相机描述失败: %w", filename, err)
+	}
+	sf.Camera = camera
+	return nil
+}
+
+// renderStill 渲染时间 t 的一帧并保存为 PNG 或 SVG
+func renderStill(sf *go3d.SceneFile, output string, width, height int, t float64, transparent bool) error {
+	renderer := go3d.NewRenderer(width, height)
+	defer renderer.Destroy()
+	svg := strings.EqualFold(filepath.Ext(output), ".svg")
+	if svg {
+		renderer.RecordSVG()
+	}
+	if transparent {
+		renderer.TransparentBackground = true
+		renderer.Clear(0, 0, 0)
+	}
+	sf.FrameRenderer(nil)(renderer, 1, t)
+	if err := renderer.Err(); err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(output); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("创建输出目录失败: %w", err)
+		}
+	}
+	save := renderer.SaveToPNG
+	if svg {
+		save = renderer.SaveToSVG
+	}
+	if err := save(output); err != nil {
+		return err
+	}
+	fmt.Printf("已保存: %s\n", output)
+	return nil
+}
+
+// fatal 输出错误并退出
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "错误: %v\n", err)
+	os.Exit(1)
+}
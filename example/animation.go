@@ -119,90 +119,18 @@ func renderFrame(renderer *go3d.Renderer, frame int, t float64) {
 	scene.Render(renderer, animationTime)
 }
 
-// setupDynamicCamera 设置动态相机视角 - 同时绕X、Y、Z三个轴旋转
+// setupDynamicCamera 设置动态相机视角 - 水平环绕一圈，同时上下俯仰
 func setupDynamicCamera(renderer *go3d.Renderer, t float64) {
-	// 基础半径
-	baseRadius := 20.0
-
-	// 三个轴独立的旋转角度，使用不同的频率让运动更丰富
-	// Y轴旋转（水平环绕）：完整旋转一圈
-	angleY := t * 2 * math.Pi
-
-	// X轴旋转（垂直环绕）：上下大幅度旋转
-	angleX := t * 1.5 * math.Pi // 旋转270度
-
-	// Z轴旋转（前后环绕）：前后方向旋转
-	angleZ := t * 1.0 * math.Pi // 旋转180度
-
-	// 使用欧拉角计算相机位置
-	// 从初始位置 (0, 0, baseRadius) 开始，依次应用三个轴的旋转
-
-	// 初始位置：相机在Z轴正方向
-	x := 0.0
-	y := 0.0
-	z := baseRadius
-
-	// 应用X轴旋转（绕X轴旋转会改变Y和Z）
-	cosX := math.Cos(angleX)
-	sinX := math.Sin(angleX)
-	newY := y*cosX - z*sinX
-	newZ := y*sinX + z*cosX
-	y = newY
-	z = newZ
-
-	// 应用Y轴旋转（绕Y轴旋转会改变X和Z）
-	cosY := math.Cos(angleY)
-	sinY := math.Sin(angleY)
-	newX := x*cosY + z*sinY
-	newZ = -x*sinY + z*cosY
-	x = newX
-	z = newZ
-
-	// 应用Z轴旋转（绕Z轴旋转会改变X和Y）
-	cosZ := math.Cos(angleZ)
-	sinZ := math.Sin(angleZ)
-	newX = x*cosZ - y*sinZ
-	newY = x*sinZ + y*cosZ
-	x = newX
-	y = newY
-
-	cameraPos := go3d.NewVector3(x, y, z)
-
-	// 相机目标：始终看向太阳系中心
-	targetPos := go3d.NewVector3(0, 0, 0)
-
-	// 计算相机的上方向向量，让它随着相机旋转
-	// 初始上方向是 (0, 1, 0)
-	upX := 0.0
-	upY := 1.0
-	upZ := 0.0
-
-	// 应用相同的旋转变换到上方向向量
-	// X轴旋转
-	newUpY := upY*cosX - upZ*sinX
-	newUpZ := upY*sinX + upZ*cosX
-	upY = newUpY
-	upZ = newUpZ
-
-	// Y轴旋转
-	newUpX := upX*cosY + upZ*sinY
-	newUpZ = -upX*sinY + upZ*cosY
-	upX = newUpX
-	upZ = newUpZ
-
-	// Z轴旋转
-	newUpX = upX*cosZ - upY*sinZ
-	newUpY = upX*sinZ + upY*cosZ
-	upX = newUpX
-	upY = newUpY
-
-	upVector := go3d.NewVector3(upX, upY, upZ)
+	controller := go3d.NewOrbitController(go3d.NewVector3(0, 0, 0), 20.0)
 
-	// 视场角
-	fov := 0.75
+	// 水平环绕：完整旋转一圈
+	controller.Yaw = t * 2 * math.Pi
+
+	// 俯仰：在上下之间往复摆动，控制器会在两极附近自动钳制
+	controller.Rotate(0, math.Sin(t*1.5*math.Pi)*1.2)
 
-	renderer.Camera.Position = cameraPos
-	renderer.Camera.Target = targetPos
-	renderer.Camera.Up = upVector
-	renderer.Camera.FOV = fov
+	controller.Apply(renderer.Camera)
+
+	// 视场角
+	renderer.Camera.FOV = 0.75
 }
@@ -2,10 +2,13 @@ module github.com/novvoo/go-3d
 
 go 1.24.4
 
-require github.com/novvoo/go-cairo v0.0.0-20251218084434-61cf8da82043
+require (
+	github.com/novvoo/go-cairo v0.0.0-20251218084434-61cf8da82043
+	github.com/yuin/gopher-lua v1.1.2
+	golang.org/x/image v0.18.0
+)
 
 require (
 	github.com/go-text/typesetting v0.1.2 // indirect
-	golang.org/x/image v0.18.0 // indirect
 	golang.org/x/text v0.16.0 // indirect
 )
@@ -2,10 +2,14 @@ package go3d
 
 import (
 	"fmt"
+	"image"
+	"math"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 // AnimationConfig 动画配置
@@ -19,6 +23,48 @@ type AnimationConfig struct {
 	Quality     int     // 视频质量 (CRF: 0-51, 越小质量越高)
 	CleanupTemp bool    // 是否清理临时文件
 	Workers     int     // 并行渲染的工作线程数（默认为1，单线程）
+
+	// Transparent 透明背景：帧渲染器启用 TransparentBackground，PNG 帧带 alpha 通道，
+	// 合成视频需要支持透明的编码器和像素格式（见 VideoProRes4444、VideoVP9Alpha）
+	Transparent bool
+
+	// ffmpeg 调用参数，空值使用默认值
+	FFmpegPath  string   // ffmpeg 可执行文件路径（默认 ffmpeg）
+	VideoCodec  string   // 视频编码器（默认 libx264），常用组合见 ApplyVideoPreset
+	PixelFormat string   // 像素格式（默认 yuv420p）
+	Bitrate     string   // 目标码率（如 "8M"），设置后代替 Quality 的质量控制
+	Preset      string   // 编码器预设（如 libx264 的 "slow"）
+	ExtraArgs   []string // 追加在输出文件之前的其他 ffmpeg 参数
+
+	// TimeCurve 时间重映射曲线：把线性的动画进度（0-1）映射为传给帧渲染函数的时间，
+	// 可使用 EaseInOut 等缓动函数或 HoldTime，nil 表示不重映射
+	TimeCurve func(t float64) float64
+
+	// 帧范围：只渲染 StartFrame 到 EndFrame（含，帧号从 1 开始）之间每隔 Step 帧的一帧，
+	// 用于局部重渲染或快速预览；帧号和时间仍按 FPS 和 Duration 计算。0 表示使用默认值（第一帧、最后一帧、逐帧）
+	// Step 大于 1 时帧文件不连续，ffmpeg 只能读到第一帧，Generate 和 ComposeVideo 返回错误，只能用 GenerateFramesOnly 预览
+	StartFrame int
+	EndFrame   int
+	Step       int
+
+	// 联系表：生成帧时从本次渲染的帧中均匀选取 ContactColumns×ContactRows 帧，
+	// 缩略图排成网格保存为 PNG 文件 ContactSheet，便于快速检查；空表示不生成
+	ContactSheet      string
+	ContactColumns    int // 联系表列数，0 表示 4
+	ContactRows       int // 联系表行数，0 表示 3
+	ContactThumbWidth int // 缩略图宽度（像素），0 表示 320
+
+	// Preview 预览服务器：每完成一帧就推送给浏览器，需要先调用 Start；nil 表示不预览
+	Preview *PreviewServer
+
+	// Cuts 剪辑表：按视频进度切换相机路径，一次渲染得到带镜头切换（可交叉淡化）的成片。
+	// 生成器在调用帧渲染函数之前设置相机，帧渲染函数中的 ApplyCameraPath 不再生效，
+	// 也不应直接修改相机；nil 表示由帧渲染函数控制相机
+	Cuts []CameraCut
+
+	// Progress 每保存一帧调用一次，done 为已完成的帧数，total 为本次渲染的帧数；
+	// 多线程渲染时在同一个协程中依次调用。nil 表示不回调
+	Progress func(done, total int)
 }
 
 // DefaultAnimationConfig 返回默认动画配置
@@ -33,16 +79,44 @@ func DefaultAnimationConfig() AnimationConfig {
 		Quality:     23,
 		CleanupTemp: true,
 		Workers:     1, // 默认单线程
+
+		FFmpegPath:  "ffmpeg",
+		VideoCodec:  "libx264",
+		PixelFormat: "yuv420p",
+	}
+}
+
+// HoldTime 定格时间曲线：动画播放到 at（0-1）时停留，停留时长占整个动画的 length（0-1），
+// 其余部分匀速播放完整的动画
+func HoldTime(at, length float64) func(t float64) float64 {
+	at = math.Max(0, math.Min(1, at))
+	length = math.Max(0, math.Min(1, length))
+	return func(t float64) float64 {
+		if length >= 1 {
+			return at
+		}
+		start := at * (1 - length)
+		switch {
+		case t < start:
+			return t / (1 - length)
+		case t < start+length:
+			return at
+		default:
+			return (t - length) / (1 - length)
+		}
 	}
 }
 
-// FrameRenderer 帧渲染函数类型
+// FrameRenderer 帧渲染函数类型，出错时用 renderer.ReportError 报告
 type FrameRenderer func(renderer *Renderer, frame int, t float64)
 
 // AnimationGenerator 动画生成器
 type AnimationGenerator struct {
 	Config   AnimationConfig
 	Renderer FrameRenderer
+
+	sheetSlots  map[int]int   // 联系表选中的帧号到缩略图位置的映射
+	sheetThumbs []image.Image // 联系表缩略图
 }
 
 // NewAnimationGenerator 创建动画生成器
@@ -62,69 +136,235 @@ func CheckFFmpeg() bool {
 
 // GenerateFrames 生成所有帧
 func (ag *AnimationGenerator) GenerateFrames() error {
+	if err := validateCuts(ag.Config.Cuts); err != nil {
+		return err
+	}
+
 	// 创建临时目录
 	if err := os.MkdirAll(ag.Config.TempDir, 0755); err != nil {
 		return fmt.Errorf("创建临时目录失败: %w", err)
 	}
 
-	totalFrames := int(float64(ag.Config.FPS) * ag.Config.Duration)
+	totalFrames := ag.Config.TotalFrames()
+	frames := ag.Config.FrameNumbers()
 	workers := ag.Config.Workers
 	if workers < 1 {
 		workers = 1
 	}
 
-	fmt.Printf("生成 %d 帧动画 (%dx%d @ %d fps, %d 线程)...\n",
-		totalFrames, ag.Config.Width, ag.Config.Height, ag.Config.FPS, workers)
+	if len(frames) == totalFrames {
+		fmt.Printf("生成 %d 帧动画 (%dx%d @ %d fps, %d 线程)...\n",
+			totalFrames, ag.Config.Width, ag.Config.Height, ag.Config.FPS, workers)
+	} else {
+		fmt.Printf("生成 %d/%d 帧动画 (%dx%d @ %d fps, %d 线程)...\n",
+			len(frames), totalFrames, ag.Config.Width, ag.Config.Height, ag.Config.FPS, workers)
+	}
+	if len(frames) == 0 {
+		return nil
+	}
+
+	ag.sheetSlots, ag.sheetThumbs = nil, nil
+	if ag.Config.Preview != nil {
+		fmt.Printf("  预览: %s\n", ag.Config.Preview.URL())
+	}
 
-	// 如果只有一个工作线程，使用单线程模式
+	if ag.Config.ContactSheet != "" {
+		ag.sheetSlots = contactSheetSlots(frames, ag.contactColumns()*ag.contactRows())
+		ag.sheetThumbs = make([]image.Image, len(ag.sheetSlots))
+	}
+
+	var err error
 	if workers == 1 {
-		return ag.generateFramesSingleThread(totalFrames)
+		// 如果只有一个工作线程，使用单线程模式
+		err = ag.generateFramesSingleThread(frames, totalFrames)
+	} else {
+		// 多线程模式
+		err = ag.generateFramesMultiThread(frames, totalFrames, workers)
+	}
+	if err != nil {
+		return err
+	}
+
+	if ag.Config.ContactSheet != "" {
+		sheet := ContactSheet(ag.sheetThumbs, ag.contactColumns(), ag.contactThumbWidth())
+		if err := savePNG(ag.Config.ContactSheet, sheet); err != nil {
+			return fmt.Errorf("保存联系表失败: %w", err)
+		}
+		fmt.Printf("  联系表: %s\n", ag.Config.ContactSheet)
 	}
+	return nil
+}
 
-	// 多线程模式
-	return ag.generateFramesMultiThread(totalFrames, workers)
+// contactColumns 联系表列数
+func (ag *AnimationGenerator) contactColumns() int {
+	if ag.Config.ContactColumns > 0 {
+		return ag.Config.ContactColumns
+	}
+	return 4
 }
 
-// generateFramesSingleThread 单线程生成帧
-func (ag *AnimationGenerator) generateFramesSingleThread(totalFrames int) error {
-	// 从帧1开始，跳过帧0
-	for frame := 1; frame <= totalFrames; frame++ {
-		t := float64(frame-1) / float64(totalFrames)
+// contactRows 联系表行数
+func (ag *AnimationGenerator) contactRows() int {
+	if ag.Config.ContactRows > 0 {
+		return ag.Config.ContactRows
+	}
+	return 3
+}
 
-		// 创建渲染器
-		renderer := NewRenderer(ag.Config.Width, ag.Config.Height)
+// contactThumbWidth 联系表缩略图宽度
+func (ag *AnimationGenerator) contactThumbWidth() int {
+	if ag.Config.ContactThumbWidth > 0 {
+		return ag.Config.ContactThumbWidth
+	}
+	return 320
+}
 
-		// 调用用户提供的渲染函数
-		ag.Renderer(renderer, frame, t)
+// captureThumbnail 帧被联系表选中时保存缩略图（各帧写入不同位置，可并行调用）
+func (ag *AnimationGenerator) captureThumbnail(frame int, renderer *Renderer) {
+	slot, ok := ag.sheetSlots[frame]
+	if !ok {
+		return
+	}
+	width := ag.contactThumbWidth()
+	height := max(1, width*renderer.Height/max(1, renderer.Width))
+	ag.sheetThumbs[slot] = resizeImage(renderer.Image(), width, height)
+}
 
-		// 保存帧，使用frame编号
-		framePath := filepath.Join(ag.Config.TempDir, fmt.Sprintf("frame_%04d.png", frame))
-		if err := renderer.SaveToPNG(framePath); err != nil {
-			renderer.Destroy()
-			return fmt.Errorf("保存帧 %d 失败: %w", frame, err)
+// publishPreview 把完成的帧推送到预览服务器（预览失败不影响渲染）
+func (ag *AnimationGenerator) publishPreview(frame int, renderer *Renderer) {
+	if ag.Config.Preview == nil {
+		return
+	}
+	if err := ag.Config.Preview.Publish(frame, renderer.Image()); err != nil {
+		fmt.Printf("\n警告: 推送预览帧 %d 失败: %v\n", frame, err)
+	}
+}
+
+// TotalFrames 按 FPS 和 Duration 计算的总帧数
+func (c AnimationConfig) TotalFrames() int {
+	return int(float64(c.FPS) * c.Duration)
+}
+
+// frameRange 帧范围的第一帧、最后一帧（含）和步长
+func (c AnimationConfig) frameRange() (start, end, step int) {
+	start = max(1, c.StartFrame)
+	end = c.TotalFrames()
+	if c.EndFrame > 0 {
+		end = min(end, c.EndFrame)
+	}
+	return start, end, max(1, c.Step)
+}
+
+// FrameCount 按帧范围和步长需要渲染的帧数，等于 len(FrameNumbers()) 但不创建帧号列表，
+// 可以在渲染前先检查帧数是否过多
+func (c AnimationConfig) FrameCount() int {
+	start, end, step := c.frameRange()
+	if end < start {
+		return 0
+	}
+	return (end-start)/step + 1
+}
+
+// FrameNumbers 按帧范围和步长需要渲染的帧号（从 1 开始，不超过 TotalFrames）
+func (c AnimationConfig) FrameNumbers() []int {
+	start, end, step := c.frameRange()
+
+	frames := make([]int, 0, c.FrameCount())
+	for frame := start; frame <= end; frame += step {
+		frames = append(frames, frame)
+	}
+	return frames
+}
+
+// frameFilePattern 帧文件名格式，同时作为 ffmpeg 的输入文件名模式
+const frameFilePattern = "frame_%04d.png"
+
+// FramePath 第 frame 帧保存在 TempDir 中的文件路径
+func (c AnimationConfig) FramePath(frame int) string {
+	return filepath.Join(c.TempDir, fmt.Sprintf(frameFilePattern, frame))
+}
+
+// frameTime 第 frame 帧（从 1 开始）传给帧渲染函数的时间，已按 TimeCurve 重映射
+func (ag *AnimationGenerator) frameTime(frame, totalFrames int) float64 {
+	t := float64(frame-1) / float64(totalFrames)
+	if ag.Config.TimeCurve != nil {
+		t = ag.Config.TimeCurve(t)
+	}
+	return t
+}
+
+// newFrameRenderer 创建第 frame 帧使用的渲染器，透明背景时先清空为透明
+func (ag *AnimationGenerator) newFrameRenderer(frame int) *Renderer {
+	renderer := NewRenderer(ag.Config.Width, ag.Config.Height)
+	renderer.Frame = frame
+	if ag.Config.Transparent {
+		renderer.TransparentBackground = true
+		renderer.Clear(0, 0, 0)
+	}
+	return renderer
+}
+
+// generateFramesSingleThread 单线程生成帧
+func (ag *AnimationGenerator) generateFramesSingleThread(frames []int, totalFrames int) error {
+	for i, frame := range frames {
+		if err := ag.generateFrame(frame, totalFrames); err != nil {
+			return err
 		}
-		renderer.Destroy()
 
 		// 显示进度
-		if frame%10 == 0 || frame == totalFrames {
-			progress := float64(frame) / float64(totalFrames) * 100
-			fmt.Printf("\r  进度: %.1f%% (%d/%d)", progress, frame, totalFrames)
+		completed := i + 1
+		if ag.Config.Progress != nil {
+			ag.Config.Progress(completed, len(frames))
+		}
+		if completed%10 == 0 || completed == len(frames) {
+			progress := float64(completed) / float64(len(frames)) * 100
+			fmt.Printf("\r  进度: %.1f%% (%d/%d)", progress, completed, len(frames))
 		}
 	}
 	fmt.Println()
 	return nil
 }
 
+// generateFrame 渲染并保存一帧；渲染过程中的 panic 作为这一帧的错误返回，
+// 工作协程中的 panic 无法被调用方恢复，不拦截会使整个进程退出
+func (ag *AnimationGenerator) generateFrame(frame, totalFrames int) (err error) {
+	var renderer *Renderer
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("渲染帧 %d 失败: %v", frame, r)
+		}
+		if renderer != nil {
+			renderer.Destroy()
+		}
+	}()
+
+	// 创建渲染器并调用用户提供的渲染函数
+	renderer = ag.newFrameRenderer(frame)
+	ag.renderFrame(renderer, frame, totalFrames, ag.frameTime(frame, totalFrames))
+	if err := renderer.Err(); err != nil {
+		return fmt.Errorf("渲染帧 %d 失败: %w", frame, err)
+	}
+
+	// 保存帧，使用frame编号
+	if err := renderer.SaveToPNG(ag.Config.FramePath(frame)); err != nil {
+		return fmt.Errorf("保存帧 %d 失败: %w", frame, err)
+	}
+	ag.captureThumbnail(frame, renderer)
+	ag.publishPreview(frame, renderer)
+	return nil
+}
+
 // generateFramesMultiThread 多线程生成帧
-func (ag *AnimationGenerator) generateFramesMultiThread(totalFrames, workers int) error {
+func (ag *AnimationGenerator) generateFramesMultiThread(frames []int, totalFrames, workers int) error {
 	// 创建任务通道和错误通道
-	jobs := make(chan int, totalFrames)
+	jobs := make(chan int, len(frames))
 	errors := make(chan error, workers)
 
 	// 用于进度显示的通道
-	progress := make(chan int, totalFrames)
+	progress := make(chan int, len(frames))
 
 	var wg sync.WaitGroup
+	var failed atomic.Bool
 
 	// 启动工作协程
 	for w := 0; w < workers; w++ {
@@ -133,22 +373,15 @@ func (ag *AnimationGenerator) generateFramesMultiThread(totalFrames, workers int
 			defer wg.Done()
 
 			for frame := range jobs {
-				t := float64(frame-1) / float64(totalFrames)
-
-				// 创建渲染器
-				renderer := NewRenderer(ag.Config.Width, ag.Config.Height)
-
-				// 调用用户提供的渲染函数
-				ag.Renderer(renderer, frame, t)
-
-				// 保存帧，使用frame编号
-				framePath := filepath.Join(ag.Config.TempDir, fmt.Sprintf("frame_%04d.png", frame))
-				if err := renderer.SaveToPNG(framePath); err != nil {
-					renderer.Destroy()
-					errors <- fmt.Errorf("保存帧 %d 失败: %w", frame, err)
+				// 其他线程已经出错时不再渲染剩下的帧
+				if failed.Load() {
+					return
+				}
+				if err := ag.generateFrame(frame, totalFrames); err != nil {
+					failed.Store(true)
+					errors <- err
 					return
 				}
-				renderer.Destroy()
 
 				// 报告进度
 				progress <- 1
@@ -162,11 +395,14 @@ func (ag *AnimationGenerator) generateFramesMultiThread(totalFrames, workers int
 		completed := 0
 		for range progress {
 			completed++
-			if completed%10 == 0 || completed == totalFrames {
-				percent := float64(completed) / float64(totalFrames) * 100
-				fmt.Printf("\r  进度: %.1f%% (%d/%d)", percent, completed, totalFrames)
+			if ag.Config.Progress != nil {
+				ag.Config.Progress(completed, len(frames))
+			}
+			if completed%10 == 0 || completed == len(frames) {
+				percent := float64(completed) / float64(len(frames)) * 100
+				fmt.Printf("\r  进度: %.1f%% (%d/%d)", percent, completed, len(frames))
 			}
-			if completed == totalFrames {
+			if completed == len(frames) {
 				break
 			}
 		}
@@ -174,8 +410,8 @@ func (ag *AnimationGenerator) generateFramesMultiThread(totalFrames, workers int
 		done <- true
 	}()
 
-	// 分发任务，从帧1开始
-	for frame := 1; frame <= totalFrames; frame++ {
+	// 分发任务
+	for _, frame := range frames {
 		jobs <- frame
 	}
 	close(jobs)
@@ -196,20 +432,87 @@ func (ag *AnimationGenerator) generateFramesMultiThread(totalFrames, workers int
 	return nil
 }
 
+// ffmpegPath ffmpeg 可执行文件路径
+func (ag *AnimationGenerator) ffmpegPath() string {
+	if ag.Config.FFmpegPath != "" {
+		return ag.Config.FFmpegPath
+	}
+	return "ffmpeg"
+}
+
+// videoCodec 视频编码器
+func (ag *AnimationGenerator) videoCodec() string {
+	if ag.Config.VideoCodec != "" {
+		return ag.Config.VideoCodec
+	}
+	return "libx264"
+}
+
+// pixelFormat 像素格式
+func (ag *AnimationGenerator) pixelFormat() string {
+	if ag.Config.PixelFormat != "" {
+		return ag.Config.PixelFormat
+	}
+	return "yuv420p"
+}
+
+// FFmpegArgs 合成视频时传给 ffmpeg 的参数（不含可执行文件本身）
+func (ag *AnimationGenerator) FFmpegArgs() []string {
+	args := []string{
+		"-y",
+		"-framerate", fmt.Sprintf("%d", ag.Config.FPS),
+		"-start_number", fmt.Sprintf("%d", max(1, ag.Config.StartFrame)), // 从帧范围的第一帧开始
+		"-i", filepath.Join(ag.Config.TempDir, frameFilePattern),
+		"-c:v", ag.videoCodec(),
+		"-pix_fmt", ag.pixelFormat(),
+	}
+	if ag.Config.Bitrate != "" {
+		args = append(args, "-b:v", ag.Config.Bitrate)
+	} else {
+		args = append(args, videoQualityArgs(ag.videoCodec(), ag.Config.Quality)...)
+	}
+	if ag.Config.Preset != "" {
+		args = append(args, "-preset", ag.Config.Preset)
+	}
+	args = append(args, ag.Config.ExtraArgs...)
+	return append(args, ag.Config.OutputFile)
+}
+
+// CheckEncoder 检查 ffmpeg 是否可用并支持所选的视频编码器；透明背景时还检查像素格式是否带 alpha 通道
+func (ag *AnimationGenerator) CheckEncoder() error {
+	if ag.Config.Transparent && !pixelFormatHasAlpha(ag.pixelFormat()) {
+		return fmt.Errorf("透明背景需要带 alpha 通道的像素格式，%s 没有 alpha 通道", ag.pixelFormat())
+	}
+	output, err := exec.Command(ag.ffmpegPath(), "-hide_banner", "-encoders").Output()
+	if err != nil {
+		return fmt.Errorf("无法运行 %s: %w", ag.ffmpegPath(), err)
+	}
+	// 每行格式为 " V....D libx264  描述"，第二列是编码器名称
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[1] == ag.videoCodec() {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s 不支持视频编码器 %s", ag.ffmpegPath(), ag.videoCodec())
+}
+
+// checkVideoStep 帧文件不连续（Step 大于 1）时返回错误：ffmpeg 遇到缺号的帧文件就停止读取
+func (ag *AnimationGenerator) checkVideoStep() error {
+	if ag.Config.Step > 1 {
+		return fmt.Errorf("步长为 %d 时帧文件不连续，不能合成视频", ag.Config.Step)
+	}
+	return nil
+}
+
 // ComposeVideo 使用 ffmpeg 合成视频
 func (ag *AnimationGenerator) ComposeVideo() error {
+	if err := ag.checkVideoStep(); err != nil {
+		return err
+	}
 	fmt.Println("\n使用 ffmpeg 合成视频...")
 
-	cmd := exec.Command("ffmpeg",
-		"-y",
-		"-framerate", fmt.Sprintf("%d", ag.Config.FPS),
-		"-start_number", "1", // 从帧1开始
-		"-i", filepath.Join(ag.Config.TempDir, "frame_%04d.png"),
-		"-c:v", "libx264",
-		"-pix_fmt", "yuv420p",
-		"-crf", fmt.Sprintf("%d", ag.Config.Quality),
-		ag.Config.OutputFile,
-	)
+	cmd := exec.Command(ag.ffmpegPath(), ag.FFmpegArgs()...)
 
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -225,7 +528,15 @@ func (ag *AnimationGenerator) ComposeVideo() error {
 }
 
 // Generate 生成完整动画（帧 + 视频）
+// 渲染帧之前先检查步长和编码器，避免渲染完才发现无法合成
 func (ag *AnimationGenerator) Generate() error {
+	if err := ag.checkVideoStep(); err != nil {
+		return err
+	}
+	if err := ag.CheckEncoder(); err != nil {
+		return err
+	}
+
 	// 生成帧
 	if err := ag.GenerateFrames(); err != nil {
 		return err
@@ -262,9 +573,11 @@ func (ag *AnimationGenerator) GenerateFramesOnly(outputDir string) error {
 	}
 
 	fmt.Printf("\n✓ 序列帧已生成到目录: %s\n", outputDir)
+	if ag.checkVideoStep() != nil {
+		return nil
+	}
 	fmt.Println("\n要生成视频，请安装 ffmpeg 并运行:")
-	fmt.Printf("  ffmpeg -framerate %d -i %s/frame_%%04d.png -c:v libx264 -pix_fmt yuv420p animation.mp4\n",
-		ag.Config.FPS, outputDir)
+	fmt.Printf("  %s %s\n", ag.ffmpegPath(), strings.Join(ag.FFmpegArgs(), " "))
 
 	return nil
 }
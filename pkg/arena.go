@@ -0,0 +1,111 @@
+package go3d
+
+import (
+	"slices"
+	"sync"
+)
+
+// frameArena 渲染器在一帧内复用的临时缓冲区：
+//   - triangles：各绘制方法收集待排序三角形的切片，绘制完成后归还，下一次绘制直接复用
+//   - batch：批量绘制（BSP 排序、光线追踪）期间累积的三角形
+//   - scratch：DrawMeshTransformed 等方法的变换结果网格
+//   - morph：混合形变目标后的网格
+//
+// 渲染器第一次绘制时从进程内共享的池中借用，Destroy 时归还。动画每帧创建新的渲染器，
+// 下一帧借到的缓冲已经增长到足够的容量，大网格和 4K 画面的渲染不会每次绘制都重新分配。
+// 缓冲只在绘制调用内部使用，不会被返回给调用者
+type frameArena struct {
+	triangles []triangleWithDepth
+	batch     []triangleWithDepth
+	scratch   Mesh
+	morph     Mesh
+}
+
+// arenaPool 所有渲染器共享的缓冲池
+var arenaPool = sync.Pool{New: func() any { return new(frameArena) }}
+
+// frameArena 返回渲染器的缓冲区，第一次调用时从池中借用
+func (r *Renderer) frameArena() *frameArena {
+	if r.arena == nil {
+		r.arena = arenaPool.Get().(*frameArena)
+	}
+	return r.arena
+}
+
+// triangleBuffer 借用容量至少为 n 的空三角形切片，用完后调用 releaseTriangles 归还
+// 借用期间再次借用（嵌套绘制）时得到新分配的切片，不会互相覆盖
+func (r *Renderer) triangleBuffer(n int) []triangleWithDepth {
+	arena := r.frameArena()
+	buf := slices.Grow(arena.triangles[:0], n)
+	arena.triangles = nil
+	return buf
+}
+
+// releaseTriangles 归还借用的三角形切片，清除其中对纹理等数据的引用
+func (r *Renderer) releaseTriangles(buf []triangleWithDepth) {
+	clear(buf)
+	arena := r.frameArena()
+	if cap(buf) > cap(arena.triangles) {
+		arena.triangles = buf[:0]
+	}
+}
+
+// releaseArena 把缓冲区归还到共享池，不再引用本帧的网格和纹理
+func (r *Renderer) releaseArena() {
+	arena := r.arena
+	if arena == nil {
+		return
+	}
+	r.arena = nil
+	clear(arena.batch)
+	arena.batch = arena.batch[:0]
+	arena.scratch = Mesh{Vertices: arena.scratch.Vertices[:0], Triangles: arena.scratch.Triangles[:0]}
+	arena.morph = Mesh{Vertices: arena.morph.Vertices[:0], Triangles: arena.morph.Triangles[:0]}
+	arenaPool.Put(arena)
+}
+
+// primitiveKind 共享基本网格的类型
+type primitiveKind int
+
+const (
+	primitiveSphere   primitiveKind = iota // 半径为 1 的球体
+	primitiveCylinder                      // 半径、高度为 1 的圆柱体（沿 Y 轴）
+	primitiveCone                          // 底面半径、高度为 1 的圆锥（沿 Y 轴）
+	primitiveTorus                         // 主半径为 1、管半径为 ratio 的圆环
+	primitiveCube                          // 半边长为 1 的立方体
+)
+
+// primitiveKey 共享基本网格的类型和细分参数
+type primitiveKey struct {
+	kind     primitiveKind
+	segments int
+	rings    int
+	ratio    float64
+}
+
+// primitiveMeshes 按类型和细分参数缓存的单位尺寸基本网格
+var primitiveMeshes sync.Map
+
+// unitPrimitive 返回缓存的单位尺寸基本网格，所有渲染器和线程共享，只读
+// 星星、坐标轴、轨道等每帧重复绘制的基本体配合模型矩阵缩放使用，不再每次绘制都重新生成网格
+func unitPrimitive(kind primitiveKind, segments, rings int, ratio float64) *Mesh {
+	key := primitiveKey{kind: kind, segments: segments, rings: rings, ratio: ratio}
+	if mesh, ok := primitiveMeshes.Load(key); ok {
+		return mesh.(*Mesh)
+	}
+	var mesh *Mesh
+	switch kind {
+	case primitiveSphere:
+		mesh = CreateSphere(1, segments, rings)
+	case primitiveCylinder:
+		mesh = CreateCylinder(1, 1, segments)
+	case primitiveCone:
+		mesh = CreateCone(1, 1, segments)
+	case primitiveTorus:
+		mesh = CreateTorus(1, ratio, segments, rings)
+	case primitiveCube:
+		mesh = CreateCube(2)
+	}
+	actual, _ := primitiveMeshes.LoadOrStore(key, mesh)
+	return actual.(*Mesh)
+}
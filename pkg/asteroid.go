@@ -0,0 +1,121 @@
+package go3d
+
+import "math"
+
+// Asteroid 小行星
+type Asteroid struct {
+	Elements OrbitalElements
+	Phase    float64 // t = 0 时的平近点角
+	Speed    float64 // 轨道角速度（弧度/单位时间）
+	Size     float64
+}
+
+// AsteroidBelt 小行星带，在两个轨道半径之间程序化生成大量小天体
+type AsteroidBelt struct {
+	InnerRadius     float64
+	OuterRadius     float64
+	Count           int
+	Seed            int64   // 随机种子，相同种子生成相同的小行星带
+	MinSize         float64 // 最小尺寸
+	MaxSize         float64 // 最大尺寸
+	MaxInclination  float64 // 最大轨道倾角（弧度）
+	MaxEccentricity float64 // 最大偏心率
+	Speed           float64 // 内缘处的轨道角速度（弧度/单位时间），外侧按开普勒第三定律递减
+	Color           [3]float64
+
+	asteroids []Asteroid
+	mesh      *Mesh
+}
+
+// NewAsteroidBelt 创建小行星带
+func NewAsteroidBelt(innerRadius, outerRadius float64, count int, seed int64) *AsteroidBelt {
+	return &AsteroidBelt{
+		InnerRadius:     innerRadius,
+		OuterRadius:     outerRadius,
+		Count:           count,
+		Seed:            seed,
+		MinSize:         0.02,
+		MaxSize:         0.06,
+		MaxInclination:  0.15,
+		MaxEccentricity: 0.1,
+		Speed:           1.2 * math.Pi,
+		Color:           [3]float64{0.55, 0.5, 0.45},
+	}
+}
+
+// Generate 按当前参数重新生成小行星
+func (ab *AsteroidBelt) Generate() {
+	rng := NewRandom(ab.Seed, 0)
+	ab.asteroids = make([]Asteroid, ab.Count)
+
+	for i := range ab.asteroids {
+		radius := ab.InnerRadius + rng.Float64()*(ab.OuterRadius-ab.InnerRadius)
+		ab.asteroids[i] = Asteroid{
+			Elements: OrbitalElements{
+				SemiMajorAxis: radius,
+				Eccentricity:  rng.Float64() * ab.MaxEccentricity,
+				Inclination:   (rng.Float64()*2 - 1) * ab.MaxInclination,
+				AscendingNode: rng.Float64() * 2 * math.Pi,
+				ArgPeriapsis:  rng.Float64() * 2 * math.Pi,
+			},
+			Phase: rng.Float64() * 2 * math.Pi,
+			// 开普勒第三定律：角速度与半长轴的 1.5 次方成反比
+			Speed: ab.Speed * math.Pow(ab.InnerRadius/radius, 1.5),
+			Size:  ab.MinSize + rng.Float64()*(ab.MaxSize-ab.MinSize),
+		}
+	}
+}
+
+// Asteroids 获取生成的小行星（首次调用时生成）
+func (ab *AsteroidBelt) Asteroids() []Asteroid {
+	if len(ab.asteroids) != ab.Count {
+		ab.Generate()
+	}
+	return ab.asteroids
+}
+
+// Render 渲染小行星带
+func (ab *AsteroidBelt) Render(renderer *Renderer, t float64) {
+	// 所有小行星共享一个低面数的单位球体
+	if ab.mesh == nil {
+		ab.mesh = CreateSphere(1.0, 5, 4)
+	}
+
+	for _, asteroid := range ab.Asteroids() {
+		eccAnomaly := SolveKepler(asteroid.Phase+asteroid.Speed*t, asteroid.Elements.Eccentricity)
+		pos := asteroid.Elements.PositionAtEccentricAnomaly(eccAnomaly)
+
+		transform := Identity()
+		transform = transform.Multiply(Translation(pos.X, pos.Y, pos.Z))
+		transform = transform.Multiply(Scale(asteroid.Size, asteroid.Size, asteroid.Size))
+		renderer.DrawMeshTransformed(ab.mesh, transform, ab.Color)
+	}
+}
+
+// WorldBounds 所有小行星在时间 t 的包围盒
+func (ab *AsteroidBelt) WorldBounds(t float64) AABB {
+	var box AABB
+	for i, asteroid := range ab.Asteroids() {
+		eccAnomaly := SolveKepler(asteroid.Phase+asteroid.Speed*t, asteroid.Elements.Eccentricity)
+		rock := sphereBounds(asteroid.Elements.PositionAtEccentricAnomaly(eccAnomaly), asteroid.Size)
+		if i == 0 {
+			box = rock
+		} else {
+			box = box.union(rock)
+		}
+	}
+	return box
+}
+
+// NewCeres 创建谷神星预设（位于火星与木星之间的小行星带中）
+func NewCeres() *Planet {
+	return NewPlanet("Ceres", "谷神星", 0.1, 6.0, 1.1, 9.0, [3]float64{0.62, 0.6, 0.58}).
+		SetOrbitElements(0.0758, 10.59*math.Pi/180, 80.3*math.Pi/180, 73.6*math.Pi/180)
+}
+
+// NewPluto 创建冥王星预设（高偏心率、高倾角轨道）
+func NewPluto() *Planet {
+	return NewPlanet("Pluto", "冥王星", 0.12, 14.0, 0.2, 6.0, [3]float64{0.85, 0.75, 0.65}).
+		SetOrbitElements(0.2488, 17.14*math.Pi/180, 110.3*math.Pi/180, 113.8*math.Pi/180).
+		SetRotation(122.5*math.Pi/180, 0)
+}
@@ -0,0 +1,123 @@
+package go3d
+
+import (
+	"math"
+	"strconv"
+)
+
+// GridPlane 坐标系统绘制网格的坐标平面
+type GridPlane int
+
+const (
+	GridNone GridPlane = iota // 不绘制网格
+	GridXY                    // XY 平面（z = 0）
+	GridXZ                    // XZ 平面（y = 0，地面）
+	GridYZ                    // YZ 平面（x = 0）
+)
+
+// axisDirection 第 i 条坐标轴（0 为 X，1 为 Y，2 为 Z）的单位方向
+func axisDirection(i int) Vector3 {
+	var v Vector3
+	switch i {
+	case 0:
+		v.X = 1
+	case 1:
+		v.Y = 1
+	default:
+		v.Z = 1
+	}
+	return v
+}
+
+// drawNegativeAxis 绘制从原点到 end 的负半轴：与正半轴同样粗细，颜色变暗，没有箭头和标签
+func (cs *CoordinateSystem) drawNegativeAxis(renderer *Renderer, end Vector3, color [3]float64) {
+	direction := end.Normalize()
+	up := NewVector3(0, 1, 0)
+	rotation := IdentityQuaternion()
+	// 圆柱体关于中心对称，-Y 方向不需要旋转
+	if axis := up.Cross(direction); axis.Length() > 0.001 {
+		rotation = QuaternionFromAxisAngle(axis.Normalize(), math.Acos(up.Dot(direction)))
+	}
+	transform := NewTransform().SetPosition(end.Scale(0.5)).SetRotation(rotation).Matrix()
+	dim := [3]float64{color[0] * 0.5, color[1] * 0.5, color[2] * 0.5}
+	cylinder := unitPrimitive(primitiveCylinder, 8, 0, 0)
+	renderer.DrawMeshTransformed(cylinder, transform.Multiply(Scale(cs.Thickness, end.Length(), cs.Thickness)), dim)
+}
+
+// ticks 沿一条轴的刻度位置（不含原点），负半轴可见时包括负值
+func (cs *CoordinateSystem) ticks(interval float64) []float64 {
+	if interval <= 0 || cs.Length <= 0 {
+		return nil
+	}
+	count := int(math.Floor(cs.Length/interval + 1e-9))
+	values := make([]float64, 0, 2*count)
+	for k := 1; k <= count; k++ {
+		// 消除 0.1 × 3 这类浮点累积误差，刻度标签显示为 0.3
+		v := math.Round(float64(k)*interval*1e9) / 1e9
+		values = append(values, v)
+		if cs.Negative {
+			values = append(values, -v)
+		}
+	}
+	return values
+}
+
+// drawTicks 在第 i 条轴上绘制刻度线和数值标签，刻度线垂直于轴（X、Z 轴的刻度竖直，Y 轴的刻度沿 X 方向）
+func (cs *CoordinateSystem) drawTicks(renderer *Renderer, i int) {
+	values := cs.ticks(cs.TickInterval)
+	if len(values) == 0 {
+		return
+	}
+	direction := axisDirection(i)
+	across := NewVector3(0, 1, 0)
+	anchor := LabelAnchorBelow
+	if i == 1 {
+		across = NewVector3(1, 0, 0)
+		anchor = LabelAnchorLeft
+	}
+	half := across.Scale(math.Max(cs.Thickness*3, cs.Length*0.015))
+	color := axisColors[i]
+
+	for _, v := range values {
+		p := direction.Scale(v)
+		renderer.DrawLine3D(p.Sub(half), p.Add(half), color, 1.5)
+		if cs.TickLabels {
+			label := NewLabel3D(p.Sub(half), strconv.FormatFloat(v, 'g', -1, 64), color).SetAnchor(anchor, 2)
+			label.FontSize = 11
+			label.Bold = false
+			label.Render(renderer, 0)
+		}
+	}
+}
+
+// drawGrid 在选定的坐标平面上绘制网格线，范围与坐标轴相同（负半轴不可见时只覆盖正象限）
+func (cs *CoordinateSystem) drawGrid(renderer *Renderer) {
+	var u, v Vector3
+	switch cs.Grid {
+	case GridXY:
+		u, v = axisDirection(0), axisDirection(1)
+	case GridXZ:
+		u, v = axisDirection(0), axisDirection(2)
+	case GridYZ:
+		u, v = axisDirection(1), axisDirection(2)
+	default:
+		return
+	}
+	interval := cs.GridInterval
+	if interval <= 0 {
+		interval = cs.TickInterval
+	}
+	if interval <= 0 {
+		interval = cs.Length / 10
+	}
+
+	low := 0.0
+	if cs.Negative {
+		low = -cs.Length
+	}
+	lines := append([]float64{0}, cs.ticks(interval)...)
+	for _, c := range lines {
+		renderer.DrawLine3D(u.Scale(c).Add(v.Scale(low)), u.Scale(c).Add(v.Scale(cs.Length)), cs.GridColor, 1)
+		renderer.DrawLine3D(v.Scale(c).Add(u.Scale(low)), v.Scale(c).Add(u.Scale(cs.Length)), cs.GridColor, 1)
+	}
+}
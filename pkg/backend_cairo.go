@@ -0,0 +1,139 @@
+//go:build !purego
+
+// 默认绘图后端：go-cairo
+// 使用 purego 构建标签时改用纯 Go 的软件光栅化后端（见 backend_purego.go）
+
+package go3d
+
+import "github.com/novvoo/go-cairo/pkg/cairo"
+
+// ImageSurface 渲染画布使用的图像表面
+type ImageSurface = cairo.ImageSurface
+
+// Context 渲染画布使用的绘图上下文
+type Context = cairo.Context
+
+const (
+	operatorOver   = cairo.OperatorOver
+	operatorSource = cairo.OperatorSource
+	operatorAdd    = cairo.OperatorAdd
+
+	lineCapRound  = cairo.LineCapRound
+	lineJoinRound = cairo.LineJoinRound
+
+	antialiasGood = cairo.AntialiasGood
+	antialiasNone = cairo.AntialiasNone
+)
+
+// newImageSurface 创建完全透明的 ARGB 图像表面
+func newImageSurface(width, height int) ImageSurface {
+	return cairo.NewImageSurface(cairo.FormatARGB32, width, height).(cairo.ImageSurface)
+}
+
+// newContext 创建绘制到 surface 的绘图上下文
+func newContext(surface ImageSurface) Context {
+	return cairo.NewContext(surface)
+}
+
+// fillMeshTriangle 本后端不支持网格渐变填充，总是返回 false：go-cairo 能创建网格（Coons 面片）图案，
+// 但光栅器不绘制这类图案。Gouraud 三角形因此由 fillSmoothTriangle 填充：
+// 每个三角形分配临时表面并逐像素插值
+func fillMeshTriangle(context Context, patch [3][2]float64, colors [3][3]float64, opacity float64, path [][2]float64) bool {
+	return false
+}
+
+// textLayout 一段单行文字的排版结果（Pango 布局）
+type textLayout struct {
+	context Context
+	layout  *cairo.PangoCairoLayout
+}
+
+// newTextLayout 按字号排版文字，字体为无衬线字体
+func newTextLayout(context Context, text string, bold bool, size float64) (*textLayout, bool) {
+	layout, ok := context.PangoCairoCreateLayout().(*cairo.PangoCairoLayout)
+	if !ok {
+		return nil, false
+	}
+
+	fontDesc := cairo.NewPangoFontDescription()
+	fontDesc.SetFamily("sans-serif")
+	if bold {
+		fontDesc.SetWeight(700)
+	}
+	fontDesc.SetSize(size)
+	layout.SetFontDescription(fontDesc)
+	layout.SetText(text)
+	return &textLayout{context: context, layout: layout}, true
+}
+
+// inkExtents 文字墨迹范围（像素），相对于排版原点（左上角）
+func (tl *textLayout) inkExtents() labelRect {
+	extents := tl.layout.GetPixelExtents()
+	return labelRect{
+		X:      float64(extents.X),
+		Y:      float64(extents.Y),
+		Width:  float64(extents.Width),
+		Height: float64(extents.Height),
+	}
+}
+
+// show 以当前颜色绘制文字，排版原点位于 (x, y)
+func (tl *textLayout) show(x, y float64) {
+	tl.context.MoveTo(x, y)
+	tl.context.PangoCairoShowText(tl.layout)
+}
+
+// destroy 释放排版资源
+func (tl *textLayout) destroy() {
+	tl.layout.Destroy()
+}
+
+// appendGlyphOutlines 把一行文字的字形轮廓加入 b，基线位于 y = baseline（Y 轴向下）
+func appendGlyphOutlines(b *outlineBuilder, text, font string, size, baseline float64) {
+	face := cairo.NewPangoCairoFont(font, cairo.FontSlantNormal, cairo.FontWeightBold)
+	defer face.Destroy()
+
+	fontMatrix := cairo.NewMatrix()
+	fontMatrix.InitScale(size, size)
+	ctm := cairo.NewMatrix()
+	ctm.InitIdentity()
+	scaledFont := cairo.NewPangoCairoScaledFont(face, fontMatrix, ctm, nil)
+	defer scaledFont.Destroy()
+
+	glyphs, _, _, status := scaledFont.TextToGlyphs(0, baseline, text)
+	if status != cairo.StatusSuccess {
+		return
+	}
+
+	for _, glyph := range glyphs {
+		path, err := scaledFont.GlyphPath(glyph.Index)
+		if err != nil || path == nil {
+			continue
+		}
+		point := func(p cairo.Point) Vector2 {
+			return NewVector2(p.X+glyph.X, p.Y+glyph.Y)
+		}
+		for _, data := range path.Data {
+			switch data.Type {
+			case cairo.PathMoveTo:
+				b.moveTo(point(data.Points[0]))
+			case cairo.PathLineTo:
+				b.lineTo(point(data.Points[0]))
+			case cairo.PathCurveTo:
+				if len(data.Points) < 3 {
+					continue
+				}
+				p1, p2, p3 := point(data.Points[0]), point(data.Points[1]), point(data.Points[2])
+				// TrueType 的二次曲线以两个相同控制点的三次曲线给出，按二次曲线求值
+				if p1 == p2 {
+					b.quadTo(p1, p3)
+				} else {
+					b.cubicTo(p1, p2, p3)
+				}
+			case cairo.PathClosePath:
+				b.closePath()
+			}
+		}
+		b.closePath()
+	}
+}
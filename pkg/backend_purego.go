@@ -0,0 +1,251 @@
+//go:build purego
+
+// 纯 Go 绘图后端：软件光栅化（golang.org/x/image/vector）和内置的 Go 字体，
+// 不依赖 go-cairo，用于 go-cairo 无法编译的平台：
+//
+//	go build -tags purego ./...
+//
+// 文字只能使用内置字体（Go Regular/Bold，字体族名包含 "mono" 时使用 Go Mono），
+// 不包含中日韩字形
+
+package go3d
+
+import (
+	"math"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/gomonobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// ImageSurface 渲染画布使用的图像表面
+type ImageSurface = *SoftSurface
+
+// Context 渲染画布使用的绘图上下文
+type Context = *SoftContext
+
+const (
+	operatorOver   = OperatorOver
+	operatorSource = OperatorSource
+	operatorAdd    = OperatorAdd
+
+	lineCapRound  = LineCapRound
+	lineJoinRound = LineJoinRound
+
+	antialiasGood = AntialiasGood
+	antialiasNone = AntialiasNone
+)
+
+// newImageSurface 创建完全透明的 RGBA 图像表面
+func newImageSurface(width, height int) ImageSurface {
+	return NewSoftSurface(width, height)
+}
+
+// newContext 创建绘制到 surface 的绘图上下文
+func newContext(surface ImageSurface) Context {
+	return NewSoftContext(surface)
+}
+
+// fillMeshTriangle 以只有三条边的网格渐变面片为源填充 path：patch 为面片的三个角，
+// colors 为对应的顶点颜色，第四个角与第一个角重合
+func fillMeshTriangle(context Context, patch [3][2]float64, colors [3][3]float64, opacity float64, path [][2]float64) bool {
+	pattern := NewSoftMeshPattern()
+	defer pattern.Destroy()
+	pattern.BeginPatch()
+	pattern.MoveTo(patch[0][0], patch[0][1])
+	pattern.LineTo(patch[1][0], patch[1][1])
+	pattern.LineTo(patch[2][0], patch[2][1])
+	for k, corner := range [4]int{0, 1, 2, 0} {
+		var c [3]float64
+		for j := range 3 {
+			c[j] = math.Max(0, math.Min(1, colors[corner][j]))
+		}
+		pattern.SetCornerColorRGBA(uint(k), c[0], c[1], c[2], opacity)
+	}
+	pattern.EndPatch()
+
+	context.SetSource(pattern)
+	context.MoveTo(path[0][0], path[0][1])
+	for _, p := range path[1:] {
+		context.LineTo(p[0], p[1])
+	}
+	context.ClosePath()
+	context.Fill()
+	return true
+}
+
+// 内置字体，首次使用时解析
+var (
+	fontRegular  = sync.OnceValue(func() *sfnt.Font { return parseFont(goregular.TTF) })
+	fontBold     = sync.OnceValue(func() *sfnt.Font { return parseFont(gobold.TTF) })
+	fontMono     = sync.OnceValue(func() *sfnt.Font { return parseFont(gomono.TTF) })
+	fontMonoBold = sync.OnceValue(func() *sfnt.Font { return parseFont(gomonobold.TTF) })
+)
+
+// parseFont 解析内置字体数据，失败时返回 nil
+func parseFont(data []byte) *sfnt.Font {
+	f, err := sfnt.Parse(data)
+	if err != nil {
+		return nil
+	}
+	return f
+}
+
+// builtinFont 按字体族名和粗细选择内置字体
+func builtinFont(family string, bold bool) *sfnt.Font {
+	mono := strings.Contains(strings.ToLower(family), "mono")
+	switch {
+	case mono && bold:
+		return fontMonoBold()
+	case mono:
+		return fontMono()
+	case bold:
+		return fontBold()
+	default:
+		return fontRegular()
+	}
+}
+
+// glyphRun 逐字符遍历文字的字形及其笔位（考虑字距调整）
+// 字体中没有的字符使用 .notdef 字形
+func glyphRun(f *sfnt.Font, buf *sfnt.Buffer, text string, ppem fixed.Int26_6, fn func(glyph sfnt.GlyphIndex, x fixed.Int26_6)) {
+	var x fixed.Int26_6
+	prev, hasPrev := sfnt.GlyphIndex(0), false
+	for _, r := range text {
+		glyph, err := f.GlyphIndex(buf, r)
+		if err != nil {
+			continue
+		}
+		if hasPrev {
+			if kern, err := f.Kern(buf, prev, glyph, ppem, font.HintingNone); err == nil {
+				x += kern
+			}
+		}
+		fn(glyph, x)
+		if advance, err := f.GlyphAdvance(buf, glyph, ppem, font.HintingNone); err == nil {
+			x += advance
+		}
+		prev, hasPrev = glyph, true
+	}
+}
+
+// appendSfntOutlines 把一行文字的字形轮廓加入 b，笔位起点为 (x, baseline)（Y 轴向下）
+// 字形按 ppem 取得后再乘以 scale
+func appendSfntOutlines(b *outlineBuilder, f *sfnt.Font, text string, ppem fixed.Int26_6, scale, x, baseline float64) {
+	var buf sfnt.Buffer
+	glyphRun(f, &buf, text, ppem, func(glyph sfnt.GlyphIndex, pen fixed.Int26_6) {
+		segments, err := f.LoadGlyph(&buf, glyph, ppem, nil)
+		if err != nil {
+			return
+		}
+		dx := x + fixedToFloat(pen)*scale
+		point := func(p fixed.Point26_6) Vector2 {
+			return NewVector2(fixedToFloat(p.X)*scale+dx, fixedToFloat(p.Y)*scale+baseline)
+		}
+		for _, segment := range segments {
+			switch segment.Op {
+			case sfnt.SegmentOpMoveTo:
+				b.moveTo(point(segment.Args[0]))
+			case sfnt.SegmentOpLineTo:
+				b.lineTo(point(segment.Args[0]))
+			case sfnt.SegmentOpQuadTo:
+				b.quadTo(point(segment.Args[0]), point(segment.Args[1]))
+			case sfnt.SegmentOpCubeTo:
+				b.cubicTo(point(segment.Args[0]), point(segment.Args[1]), point(segment.Args[2]))
+			}
+		}
+		b.closePath()
+	})
+}
+
+// fixedToFloat 26.6 定点数转换为浮点数
+func fixedToFloat(v fixed.Int26_6) float64 {
+	return float64(v) / 64
+}
+
+// textLayout 一段单行文字的排版结果
+type textLayout struct {
+	context Context
+	font    *sfnt.Font
+	text    string
+	ppem    fixed.Int26_6
+	ascent  float64 // 排版原点（行顶部）到基线的距离
+}
+
+// newTextLayout 按字号（像素）排版文字，字体为内置的无衬线字体
+func newTextLayout(context Context, text string, bold bool, size float64) (*textLayout, bool) {
+	f := builtinFont("sans-serif", bold)
+	if f == nil || size <= 0 {
+		return nil, false
+	}
+	ppem := fixed.Int26_6(size * 64)
+	var buf sfnt.Buffer
+	metrics, err := f.Metrics(&buf, ppem, font.HintingNone)
+	if err != nil {
+		return nil, false
+	}
+	return &textLayout{context: context, font: f, text: text, ppem: ppem, ascent: fixedToFloat(metrics.Ascent)}, true
+}
+
+// inkExtents 文字墨迹范围（像素），相对于排版原点（左上角）
+func (tl *textLayout) inkExtents() labelRect {
+	var buf sfnt.Buffer
+	var ink fixed.Rectangle26_6
+	empty := true
+	glyphRun(tl.font, &buf, tl.text, tl.ppem, func(glyph sfnt.GlyphIndex, pen fixed.Int26_6) {
+		bounds, _, err := tl.font.GlyphBounds(&buf, glyph, tl.ppem, font.HintingNone)
+		if err != nil || bounds.Empty() {
+			return
+		}
+		bounds = bounds.Add(fixed.Point26_6{X: pen})
+		if empty {
+			ink, empty = bounds, false
+		} else {
+			ink = ink.Union(bounds)
+		}
+	})
+	if empty {
+		return labelRect{}
+	}
+	return labelRect{
+		X:      fixedToFloat(ink.Min.X),
+		Y:      tl.ascent + fixedToFloat(ink.Min.Y),
+		Width:  fixedToFloat(ink.Max.X - ink.Min.X),
+		Height: fixedToFloat(ink.Max.Y - ink.Min.Y),
+	}
+}
+
+// show 以当前颜色绘制文字，排版原点位于 (x, y)
+func (tl *textLayout) show(x, y float64) {
+	var b outlineBuilder
+	appendSfntOutlines(&b, tl.font, tl.text, tl.ppem, 1, x, y+tl.ascent)
+	contours := b.finish()
+	polygons := make([][][2]float64, len(contours))
+	for i, contour := range contours {
+		polygons[i] = make([][2]float64, len(contour))
+		for j, p := range contour {
+			polygons[i][j] = [2]float64{p.X, p.Y}
+		}
+	}
+	tl.context.fillPolygons(polygons)
+}
+
+// destroy 释放排版资源
+func (tl *textLayout) destroy() {}
+
+// appendGlyphOutlines 把一行文字的字形轮廓加入 b，基线位于 y = baseline（Y 轴向下）
+// 字形使用内置的粗体字体，按字体设计单位取得轮廓再缩放，避免字号很小时定点数精度不足
+func appendGlyphOutlines(b *outlineBuilder, text, family string, size, baseline float64) {
+	f := builtinFont(family, true)
+	if f == nil || size <= 0 {
+		return
+	}
+	unitsPerEm := float64(f.UnitsPerEm())
+	appendSfntOutlines(b, f, text, fixed.Int26_6(unitsPerEm*64), size/unitsPerEm, 0, baseline)
+}
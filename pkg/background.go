@@ -0,0 +1,225 @@
+package go3d
+
+import (
+	"image"
+	"math"
+)
+
+// BackgroundFit 图像背景的缩放方式
+type BackgroundFit int
+
+const (
+	FitStretch BackgroundFit = iota // 拉伸铺满画布，不保持宽高比
+	FitCover                        // 保持宽高比铺满画布，超出部分裁剪
+	FitContain                      // 保持宽高比完整显示，空白处使用 FillColor
+)
+
+// ImageBackground 图像背景
+type ImageBackground struct {
+	Texture   *Texture
+	Fit       BackgroundFit
+	FillColor [3]float64 // FitContain 时图像外的颜色
+
+	cache                   ImageSurface // 按画布尺寸缓存的缩放结果
+	cacheWidth, cacheHeight int
+	cacheFit                BackgroundFit
+	cacheTexture            *Texture
+}
+
+// NewImageBackground 创建图像背景
+func NewImageBackground(texture *Texture, fit BackgroundFit) *ImageBackground {
+	return &ImageBackground{
+		Texture: texture,
+		Fit:     fit,
+	}
+}
+
+// LoadImageBackground 从 PNG/JPEG 文件创建图像背景
+func LoadImageBackground(filename string, fit BackgroundFit) (*ImageBackground, error) {
+	texture, err := LoadTexture(filename)
+	if err != nil {
+		return nil, err
+	}
+	return NewImageBackground(texture, fit), nil
+}
+
+// Render 渲染图像背景
+func (ib *ImageBackground) Render(renderer *Renderer, t float64) {
+	if ib.Texture == nil || ib.Texture.Width == 0 || ib.Texture.Height == 0 {
+		return
+	}
+
+	// 背景不随时间变化，画布尺寸和参数不变时复用缓存
+	if ib.cache == nil || ib.cacheWidth != renderer.Width || ib.cacheHeight != renderer.Height ||
+		ib.cacheFit != ib.Fit || ib.cacheTexture != ib.Texture {
+		if ib.cache != nil {
+			ib.cache.Destroy()
+		}
+		ib.cache = ib.rasterize(renderer.Width, renderer.Height)
+		ib.cacheWidth, ib.cacheHeight = renderer.Width, renderer.Height
+		ib.cacheFit, ib.cacheTexture = ib.Fit, ib.Texture
+	}
+
+	renderer.Context.Save()
+	defer renderer.Context.Restore()
+	renderer.Context.SetSourceSurface(ib.cache, 0, 0)
+	renderer.Context.Paint()
+}
+
+// rasterize 按缩放方式把纹理采样到画布大小的图像表面
+func (ib *ImageBackground) rasterize(width, height int) ImageSurface {
+	// 计算图像在画布上占据的矩形
+	imgW, imgH := float64(ib.Texture.Width), float64(ib.Texture.Height)
+	scaleX, scaleY := float64(width)/imgW, float64(height)/imgH
+	switch ib.Fit {
+	case FitCover:
+		scaleX = math.Max(scaleX, scaleY)
+		scaleY = scaleX
+	case FitContain:
+		scaleX = math.Min(scaleX, scaleY)
+		scaleY = scaleX
+	}
+	drawW, drawH := imgW*scaleX, imgH*scaleY
+	offsetX := (float64(width) - drawW) / 2
+	offsetY := (float64(height) - drawH) / 2
+
+	return paintPixels(width, height, func(x, y float64) [3]float64 {
+		u := (x - offsetX) / drawW
+		v := (y - offsetY) / drawH
+		if u < 0 || u > 1 || v < 0 || v > 1 {
+			return ib.FillColor
+		}
+		return ib.Texture.Sample(u, v)
+	})
+}
+
+// CubeMap 立方体贴图，六个面分别对应 +X、-X、+Y、-Y、+Z、-Z 方向
+type CubeMap struct {
+	PosX, NegX, PosY, NegY, PosZ, NegZ *Texture
+}
+
+// Sample 按方向采样立方体贴图
+func (cm *CubeMap) Sample(dir Vector3) [3]float64 {
+	ax, ay, az := math.Abs(dir.X), math.Abs(dir.Y), math.Abs(dir.Z)
+
+	// 选择主轴所在的面，并计算面内坐标（-1 到 1）
+	var face *Texture
+	var sc, tc, ma float64
+	switch {
+	case ax >= ay && ax >= az:
+		ma = ax
+		if dir.X > 0 {
+			face, sc, tc = cm.PosX, -dir.Y, -dir.Z
+		} else {
+			face, sc, tc = cm.NegX, dir.Y, -dir.Z
+		}
+	case ay >= az:
+		ma = ay
+		if dir.Y > 0 {
+			face, sc, tc = cm.PosY, dir.X, -dir.Z
+		} else {
+			face, sc, tc = cm.NegY, -dir.X, -dir.Z
+		}
+	default:
+		ma = az
+		if dir.Z > 0 {
+			face, sc, tc = cm.PosZ, dir.X, dir.Y
+		} else {
+			face, sc, tc = cm.NegZ, dir.X, -dir.Y
+		}
+	}
+
+	if face == nil || ma == 0 {
+		return [3]float64{0, 0, 0}
+	}
+	return face.Sample((sc/ma+1)/2, (tc/ma+1)/2)
+}
+
+// SkyBackground 天空背景：按相机朝向显示等距圆柱投影全景图或立方体贴图
+// 天顶方向为 +Z（与轨道参考平面一致），全景图的 u 从 +X 方向开始向 +Y 增加
+type SkyBackground struct {
+	Texture    *Texture // 等距圆柱投影全景图
+	CubeMap    *CubeMap // 立方体贴图（Texture 为空时使用）
+	Brightness float64
+}
+
+// NewSkyBackground 创建等距圆柱投影天空背景
+func NewSkyBackground(texture *Texture) *SkyBackground {
+	return &SkyBackground{Texture: texture, Brightness: 1.0}
+}
+
+// NewCubeMapSkyBackground 创建立方体贴图天空背景
+func NewCubeMapSkyBackground(cubeMap *CubeMap) *SkyBackground {
+	return &SkyBackground{CubeMap: cubeMap, Brightness: 1.0}
+}
+
+// sample 按方向采样天空颜色
+func (sb *SkyBackground) sample(dir Vector3) [3]float64 {
+	var c [3]float64
+	switch {
+	case sb.Texture != nil:
+		lon := math.Atan2(dir.Y, dir.X)
+		lat := math.Asin(math.Max(-1, math.Min(1, dir.Z)))
+		u := lon / (2 * math.Pi)
+		if u < 0 {
+			u++
+		}
+		c = sb.Texture.Sample(u, 0.5-lat/math.Pi)
+	case sb.CubeMap != nil:
+		c = sb.CubeMap.Sample(dir)
+	}
+	return [3]float64{c[0] * sb.Brightness, c[1] * sb.Brightness, c[2] * sb.Brightness}
+}
+
+// Render 渲染天空背景：逐像素计算视线方向并采样
+func (sb *SkyBackground) Render(renderer *Renderer, t float64) {
+	if sb.Texture == nil && sb.CubeMap == nil {
+		return
+	}
+
+	surface := paintPixels(renderer.Width, renderer.Height, func(x, y float64) [3]float64 {
+		return sb.sample(renderer.cameraRay(x, y))
+	})
+	defer surface.Destroy()
+
+	renderer.Context.Save()
+	defer renderer.Context.Restore()
+	renderer.Context.SetSourceSurface(surface, 0, 0)
+	renderer.Context.Paint()
+}
+
+// cameraRay 计算穿过屏幕像素 (x, y) 的世界空间视线方向（单位向量）
+func (r *Renderer) cameraRay(x, y float64) Vector3 {
+	forward, right, up := r.Camera.Basis()
+
+	aspect := float64(r.Width) / float64(r.Height)
+	tanHalf := math.Tan(r.Camera.FOV / 2)
+	ndcX := 2*x/float64(r.Width) - 1
+	ndcY := 1 - 2*y/float64(r.Height)
+
+	return forward.
+		Add(right.Scale(ndcX * tanHalf * aspect)).
+		Add(up.Scale(ndcY * tanHalf)).
+		Normalize()
+}
+
+// paintPixels 创建图像表面并按像素中心坐标逐像素着色
+func paintPixels(width, height int, shade func(x, y float64) [3]float64) ImageSurface {
+	surface := newImageSurface(width, height)
+	img, ok := surface.GetGoImage().(*image.RGBA)
+	if !ok {
+		return surface
+	}
+
+	for py := range height {
+		for px := range width {
+			c := shade(float64(px)+0.5, float64(py)+0.5)
+			offset := py*img.Stride + px*4
+			for k := range 3 {
+				img.Pix[offset+k] = uint8(math.Max(0, math.Min(1, c[k]))*255 + 0.5)
+			}
+			img.Pix[offset+3] = 255
+		}
+	}
+	return surface
+}
@@ -0,0 +1,206 @@
+package go3d
+
+import (
+	"math"
+	"sort"
+)
+
+// BarChart3D 三维柱状图：Data[i][j] 画成第 i 行（数据 y 方向）第 j 列（数据 x 方向）的柱子，
+// 柱子按数值着色，负值向下生长。坐标框的 x、y 范围等分为列数、行数个格子，每根柱子占一格；
+// 默认为分类轴（行列编号），直方图则使用数值轴（见 NewHistogram3D）。
+// Progress 控制生长动画，可以直接设置，也可以用 GrowIn 交给场景时间线驱动
+type BarChart3D struct {
+	Axes     *PlotAxes
+	Colorbar *Colorbar // 颜色图例，nil 表示不绘制
+	Data     [][]float64
+
+	BarWidth float64 // 柱子占格子宽度的比例（0-1）
+	Progress float64 // 生长进度（0-1），1 为完整高度
+	Stagger  float64 // 各柱子开始生长的错开程度（0-1），0 表示同时生长
+
+	ramp       ColorRamp
+	colorRange [2]float64 // 着色的数值范围（数据的最小值和最大值）
+}
+
+// NewBarChart3D 创建柱状图，行列的类别名称默认为从 1 开始的编号，可以通过 Axes.Categories 修改；
+// 数值轴范围包含 0 和所有数据，颜色使用 ViridisRamp
+func NewBarChart3D(data [][]float64) *BarChart3D {
+	rows, columns := len(data), 0
+	for _, row := range data {
+		columns = max(columns, len(row))
+	}
+	bc := newBarChart(data, NewVector3(0, 0, 0), NewVector3(float64(max(1, columns)), float64(max(1, rows)), 0))
+	bc.Axes.Categories[0] = numberedCategories(columns)
+	bc.Axes.Categories[1] = numberedCategories(rows)
+	bc.Axes.Labels = [3]string{"", "", ""}
+	return bc
+}
+
+// NewHistogram3D 统计二维样本 (x, y) 在 xRange × yRange 上 xBins × yBins 个等宽格子中的个数，
+// 以柱状图显示，x、y 为数值轴。范围之外的样本不计入
+func NewHistogram3D(samples []Vector2, xRange, yRange [2]float64, xBins, yBins int) *BarChart3D {
+	xBins, yBins = max(1, xBins), max(1, yBins)
+	counts := make([][]float64, yBins)
+	for i := range counts {
+		counts[i] = make([]float64, xBins)
+	}
+	for _, s := range samples {
+		if !(xRange[1] > xRange[0] && yRange[1] > yRange[0]) {
+			break
+		}
+		j := int(math.Floor((s.X - xRange[0]) / (xRange[1] - xRange[0]) * float64(xBins)))
+		i := int(math.Floor((s.Y - yRange[0]) / (yRange[1] - yRange[0]) * float64(yBins)))
+		if s.X == xRange[1] {
+			j = xBins - 1 // 右端点计入最后一格
+		}
+		if s.Y == yRange[1] {
+			i = yBins - 1
+		}
+		if i >= 0 && i < yBins && j >= 0 && j < xBins {
+			counts[i][j]++
+		}
+	}
+	bc := newBarChart(counts, NewVector3(xRange[0], yRange[0], 0), NewVector3(xRange[1], yRange[1], 0))
+	bc.Axes.Labels[2] = "count"
+	bc.Colorbar.Title = "count"
+	return bc
+}
+
+// newBarChart 创建 x、y 范围为 [lo, hi] 的柱状图，数值范围由数据决定
+func newBarChart(data [][]float64, lo, hi Vector3) *BarChart3D {
+	vMin, vMax := math.Inf(1), math.Inf(-1)
+	for _, row := range data {
+		for _, v := range row {
+			if finite(v) {
+				vMin, vMax = math.Min(vMin, v), math.Max(vMax, v)
+			}
+		}
+	}
+	if vMin > vMax {
+		vMin, vMax = 0, 1
+	}
+	zLo, zHi := niceRange(math.Min(0, vMin), math.Max(0, vMax), 5)
+	lo.Z, hi.Z = zLo, zHi
+
+	bc := &BarChart3D{
+		Axes:       NewPlotAxes(lo, hi),
+		Data:       data,
+		BarWidth:   0.7,
+		Progress:   1,
+		colorRange: [2]float64{vMin, vMax},
+	}
+	bc.Colorbar = NewColorbar(nil, vMin, vMax)
+	return bc.SetRamp(ViridisRamp())
+}
+
+// numberedCategories 从 1 开始的编号
+func numberedCategories(n int) []string {
+	names := make([]string, n)
+	for i := range names {
+		names[i] = formatTick(float64(i + 1))
+	}
+	return names
+}
+
+// SetRamp 设置按数值着色的色带（同时用于颜色图例），色带位置 0 对应最小值，1 对应最大值
+func (bc *BarChart3D) SetRamp(ramp ColorRamp) *BarChart3D {
+	bc.ramp = ramp
+	if bc.Colorbar != nil {
+		bc.Colorbar.Ramp = ramp
+	}
+	return bc
+}
+
+// GrowIn 在时间线上添加生长动画：柱子从 start 时刻开始生长，到 end 时刻达到完整高度
+func (bc *BarChart3D) GrowIn(timeline *Timeline, start, end float64, easing func(float64) float64) *BarChart3D {
+	timeline.AddTrack(NewFloatTrack(&bc.Progress).
+		AddKeyframe(start, 0, easing).
+		AddKeyframe(end, 1, nil))
+	return bc
+}
+
+// growth 第 k 根柱子（共 n 根，按行列顺序编号）在当前进度下的高度比例
+func (bc *BarChart3D) growth(k, n int) float64 {
+	stagger := math.Max(0, math.Min(1, bc.Stagger))
+	delay := 0.0
+	if n > 1 {
+		delay = stagger * float64(k) / float64(n-1)
+	}
+	if stagger >= 1 {
+		// 没有生长时间，进度到达时直接出现
+		if bc.Progress >= delay {
+			return 1
+		}
+		return 0
+	}
+	return math.Max(0, math.Min(1, (bc.Progress-delay)/(1-stagger)))
+}
+
+// color 数值 v 在色带上的颜色
+func (bc *BarChart3D) color(v float64) [3]float64 {
+	span := bc.colorRange[1] - bc.colorRange[0]
+	if span <= 0 {
+		return bc.ramp.At(1)
+	}
+	return bc.ramp.At((v - bc.colorRange[0]) / span)
+}
+
+// Render 依次绘制网格线、柱子、坐标轴和颜色图例；柱子按与相机的距离从远到近绘制，彼此正确遮挡
+func (bc *BarChart3D) Render(renderer *Renderer, t float64) {
+	bc.Axes.renderBack(renderer)
+
+	rows, columns := len(bc.Data), 0
+	for _, row := range bc.Data {
+		columns = max(columns, len(row))
+	}
+	type bar struct {
+		center, size Vector3
+		color        [3]float64
+		distance     float64
+	}
+	var bars []bar
+	total := rows * columns
+	width := math.Max(0, math.Min(1, bc.BarWidth))
+	base := math.Max(component(bc.Axes.DataMin, 2), math.Min(component(bc.Axes.DataMax, 2), 0))
+	for i, row := range bc.Data {
+		for j, v := range row {
+			if !finite(v) || v == 0 {
+				continue
+			}
+			height := v * bc.growth(i*columns+j, total)
+			nx := (float64(j)+0.5)/float64(columns) - 0.5
+			ny := (float64(i)+0.5)/float64(rows) - 0.5
+			z0, z1 := bc.Axes.normalize(2, base), bc.Axes.normalize(2, base+height)
+			if math.Abs(z1-z0) < 1e-9 {
+				continue
+			}
+			bottom := bc.Axes.point([3]float64{nx, ny, z0})
+			top := bc.Axes.point([3]float64{nx, ny, z1})
+			center := bottom.Add(top).Scale(0.5)
+			bars = append(bars, bar{
+				center:   center,
+				size:     NewVector3(bc.Axes.Size.X*width/float64(columns), math.Abs(top.Y-bottom.Y), bc.Axes.Size.Y*width/float64(rows)),
+				color:    bc.color(v),
+				distance: center.Sub(renderer.Camera.Position).Length(),
+			})
+		}
+	}
+	sort.SliceStable(bars, func(i, j int) bool {
+		return bars[i].distance > bars[j].distance
+	})
+	cube := unitPrimitive(primitiveCube, 0, 0, 0)
+	for _, b := range bars {
+		model := Translation(b.center.X, b.center.Y, b.center.Z).Multiply(Scale(b.size.X/2, b.size.Y/2, b.size.Z/2))
+		renderer.DrawMeshTransformed(cube, model, b.color)
+	}
+
+	bc.Axes.renderFront(renderer)
+	if bc.Colorbar != nil {
+		bc.Colorbar.Render(renderer, t)
+	}
+}
+
+// WorldBounds 坐标框的范围
+func (bc *BarChart3D) WorldBounds(t float64) AABB {
+	return bc.Axes.WorldBounds(t)
+}
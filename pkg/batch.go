@@ -0,0 +1,143 @@
+package go3d
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// BatchJob 批量渲染中的一张静帧
+type BatchJob struct {
+	Name   string  // 输出文件名，扩展名为 .svg 时输出矢量 SVG（见 RecordSVG），否则输出 PNG（没有扩展名时补上 .png）
+	Scene  *Scene  // 要渲染的场景
+	Camera *Camera // 相机（复制后使用），nil 表示使用渲染器的默认相机
+	Width  int     // 画面宽度，0 表示使用 BatchRenderer 的默认宽度
+	Height int     // 画面高度，0 表示使用 BatchRenderer 的默认高度
+	Time   float64 // 场景时间（时间线和动画对象的求值时间）
+
+	Setup func(renderer *Renderer) // 可选：渲染前配置渲染器（渲染模式、光照、后期处理等）
+}
+
+// BatchRenderer 批量静帧渲染器：把一组命名的（场景、相机、分辨率）任务并行渲染为单独的图片
+// 使用同一个场景的任务按添加顺序依次渲染（场景求值会修改对象状态），不同场景之间并行
+type BatchRenderer struct {
+	Jobs       []BatchJob
+	OutputDir  string     // 输出目录，不存在时自动创建
+	Width      int        // 默认画面宽度
+	Height     int        // 默认画面高度
+	Background [3]float64 // 清屏颜色
+	Workers    int        // 并行渲染的工作线程数（默认为1，单线程）
+}
+
+// NewBatchRenderer 创建批量渲染器
+func NewBatchRenderer(outputDir string) *BatchRenderer {
+	return &BatchRenderer{
+		OutputDir: outputDir,
+		Width:     1920,
+		Height:    1080,
+		Workers:   1,
+	}
+}
+
+// AddJob 添加一个渲染任务
+func (br *BatchRenderer) AddJob(job BatchJob) *BatchRenderer {
+	br.Jobs = append(br.Jobs, job)
+	return br
+}
+
+// SetWorkers 设置并行渲染的工作线程数
+func (br *BatchRenderer) SetWorkers(workers int) *BatchRenderer {
+	br.Workers = workers
+	return br
+}
+
+// Render 渲染所有任务，返回所有失败任务的错误（合并为一个）
+func (br *BatchRenderer) Render() error {
+	if err := os.MkdirAll(br.OutputDir, 0755); err != nil {
+		return fmt.Errorf("创建输出目录失败: %w", err)
+	}
+
+	// 按场景分组，同一场景的任务由同一个工作线程依次渲染
+	var groups [][]int
+	groupOf := make(map[*Scene]int)
+	for i, job := range br.Jobs {
+		g, ok := groupOf[job.Scene]
+		if !ok {
+			g = len(groups)
+			groupOf[job.Scene] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+
+	jobErrors := make([]error, len(br.Jobs))
+	next := make(chan []int, len(groups))
+	for _, group := range groups {
+		next <- group
+	}
+	close(next)
+
+	var wg sync.WaitGroup
+	for range max(1, min(br.Workers, len(groups))) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for group := range next {
+				for _, i := range group {
+					jobErrors[i] = br.renderJob(br.Jobs[i])
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(jobErrors...)
+}
+
+// renderJob 渲染单个任务并保存
+func (br *BatchRenderer) renderJob(job BatchJob) error {
+	if job.Scene == nil {
+		return fmt.Errorf("任务 %s: 没有场景", job.Name)
+	}
+	width, height := job.Width, job.Height
+	if width <= 0 {
+		width = br.Width
+	}
+	if height <= 0 {
+		height = br.Height
+	}
+
+	renderer := NewRenderer(width, height)
+	defer renderer.Destroy()
+	if job.Camera != nil {
+		camera := *job.Camera
+		renderer.Camera = &camera
+	}
+	if job.Setup != nil {
+		job.Setup(renderer)
+	}
+	ext := strings.ToLower(filepath.Ext(job.Name))
+	if ext == ".svg" {
+		renderer.RecordSVG()
+	}
+	renderer.Clear(br.Background[0], br.Background[1], br.Background[2])
+	job.Scene.Render(renderer, job.Time)
+
+	path := filepath.Join(br.OutputDir, job.Name)
+	var err error
+	switch ext {
+	case ".svg":
+		err = renderer.SaveToSVG(path)
+	case "":
+		err = renderer.SaveToPNG(path + ".png")
+	default:
+		err = renderer.SaveToPNG(path)
+	}
+	if err != nil {
+		return fmt.Errorf("任务 %s: %w", job.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package go3d
+
+import "sort"
+
+const (
+	bspEpsilon    = 1e-7 // 判断点是否在平面上的容差
+	bspCandidates = 8    // 选择分割平面时评估的候选三角形数
+	bspMaxDepth   = 32   // 树的最大深度，更深的三角形不再切分，按平均深度排序
+)
+
+// bspNode BSP 树节点
+type bspNode struct {
+	normal   Vector3 // 分割平面法线
+	d        float64 // 分割平面常数（normal·p = d）
+	coplanar []triangleWithDepth
+	front    *bspNode
+	back     *bspNode
+
+	leaf []triangleWithDepth // 超过最大深度后不再切分的三角形（此时没有分割平面）
+}
+
+// sortTrianglesBSP 使用 BSP 树对三角形进行从远到近排序
+// 与分割平面相交的三角形会被切分，从而正确处理相互穿插和循环遮挡
+func sortTrianglesBSP(triangles []triangleWithDepth, eye Vector3) []triangleWithDepth {
+	root := buildBSP(triangles, 0)
+	result := make([]triangleWithDepth, 0, len(triangles))
+	return root.collectBackToFront(eye, result)
+}
+
+// buildBSP 构建 BSP 树：每个节点从候选三角形中选择切分最少、两侧最均衡的分割平面，
+// 超过最大深度时退化为按平均深度排序的叶节点，避免病态输入使切分数量和递归深度失控
+func buildBSP(triangles []triangleWithDepth, depth int) *bspNode {
+	if len(triangles) == 0 {
+		return nil
+	}
+	if depth >= bspMaxDepth {
+		return &bspNode{leaf: triangles}
+	}
+
+	splitter := chooseSplitter(triangles)
+	normal := triangles[splitter].normal()
+	node := &bspNode{
+		normal:   normal,
+		d:        normal.Dot(triangles[splitter].tri.V0),
+		coplanar: []triangleWithDepth{triangles[splitter]},
+	}
+
+	var front, back []triangleWithDepth
+	for i, td := range triangles {
+		if i != splitter {
+			node.split(td, &front, &back)
+		}
+	}
+
+	node.front = buildBSP(front, depth+1)
+	node.back = buildBSP(back, depth+1)
+	return node
+}
+
+// chooseSplitter 从均匀间隔选取的候选三角形中选择分割平面，返回其下标。
+// 代价为切分的三角形数的 8 倍（每次切分都会增加三角形）加上两侧数量之差；退化三角形不作为候选
+func chooseSplitter(triangles []triangleWithDepth) int {
+	if len(triangles) <= 2 {
+		return 0
+	}
+	candidates := min(bspCandidates, len(triangles))
+	best, bestCost := 0, -1
+	for c := range candidates {
+		i := c * len(triangles) / candidates
+		normal := triangles[i].normal()
+		if normal.Length() < 1e-10 {
+			continue
+		}
+		d := normal.Dot(triangles[i].tri.V0)
+
+		splits, front, back := 0, 0, 0
+		for j, td := range triangles {
+			if j == i {
+				continue
+			}
+			switch side := classifyTriangle(td.tri, normal, d); {
+			case side > 0:
+				front++
+			case side < 0:
+				back++
+			case side == 0 && !coplanarTriangle(td.tri, normal, d):
+				splits++
+			}
+		}
+		cost := splits*8 + max(front-back, back-front)
+		if bestCost < 0 || cost < bestCost {
+			best, bestCost = i, cost
+		}
+	}
+	return best
+}
+
+// normal 三角形的单位法线，退化三角形返回零向量
+func (td triangleWithDepth) normal() Vector3 {
+	return td.tri.V1.Sub(td.tri.V0).Cross(td.tri.V2.Sub(td.tri.V0)).Normalize()
+}
+
+// classifyTriangle 三角形相对于平面的位置：1 在前方，-1 在后方，0 跨越平面或共面
+func classifyTriangle(tri Triangle, normal Vector3, d float64) int {
+	frontCount, backCount := 0, 0
+	for _, v := range [3]Vector3{tri.V0, tri.V1, tri.V2} {
+		dist := normal.Dot(v) - d
+		if dist > bspEpsilon {
+			frontCount++
+		} else if dist < -bspEpsilon {
+			backCount++
+		}
+	}
+	switch {
+	case frontCount > 0 && backCount == 0:
+		return 1
+	case backCount > 0 && frontCount == 0:
+		return -1
+	}
+	return 0
+}
+
+// coplanarTriangle 三角形的三个顶点是否都在平面上
+func coplanarTriangle(tri Triangle, normal Vector3, d float64) bool {
+	for _, v := range [3]Vector3{tri.V0, tri.V1, tri.V2} {
+		if dist := normal.Dot(v) - d; dist > bspEpsilon || dist < -bspEpsilon {
+			return false
+		}
+	}
+	return true
+}
+
+// split 将三角形按节点平面分类，必要时切分
+func (n *bspNode) split(td triangleWithDepth, front, back *[]triangleWithDepth) {
+	// 退化三角形（法线为零）直接视为共面
+	if n.normal.Length() < 1e-10 {
+		n.coplanar = append(n.coplanar, td)
+		return
+	}
+
+	verts := [3]Vector3{td.tri.V0, td.tri.V1, td.tri.V2}
+	var dist [3]float64
+	frontCount, backCount := 0, 0
+	for i, v := range verts {
+		dist[i] = n.normal.Dot(v) - n.d
+		if dist[i] > bspEpsilon {
+			frontCount++
+		} else if dist[i] < -bspEpsilon {
+			backCount++
+		}
+	}
+
+	switch {
+	case frontCount == 0 && backCount == 0:
+		n.coplanar = append(n.coplanar, td)
+		return
+	case backCount == 0:
+		*front = append(*front, td)
+		return
+	case frontCount == 0:
+		*back = append(*back, td)
+		return
+	}
+
+	// 跨越平面：切分为前后两个多边形
+	var frontPoly, backPoly []Vector3
+	for i := range 3 {
+		j := (i + 1) % 3
+		vi, vj := verts[i], verts[j]
+		di, dj := dist[i], dist[j]
+
+		if di >= -bspEpsilon {
+			frontPoly = append(frontPoly, vi)
+		}
+		if di <= bspEpsilon {
+			backPoly = append(backPoly, vi)
+		}
+
+		if (di > bspEpsilon && dj < -bspEpsilon) || (di < -bspEpsilon && dj > bspEpsilon) {
+			s := di / (di - dj)
+			p := vi.Add(vj.Sub(vi).Scale(s))
+			frontPoly = append(frontPoly, p)
+			backPoly = append(backPoly, p)
+		}
+	}
+
+	*front = appendFan(*front, frontPoly, td)
+	*back = appendFan(*back, backPoly, td)
+}
+
+// appendFan 将凸多边形按扇形三角化，保留原三角形的颜色和深度
+// 带顶点颜色或纹理的三角形会按重心坐标重新插值新顶点的颜色和纹理坐标
+func appendFan(dst []triangleWithDepth, poly []Vector3, src triangleWithDepth) []triangleWithDepth {
+	for i := 1; i+1 < len(poly); i++ {
+		td := src
+		td.tri = Triangle{V0: poly[0], V1: poly[i], V2: poly[i+1]}
+		if src.smooth {
+			td.vertexColors = [3][3]float64{
+				src.colorAt(td.tri.V0),
+				src.colorAt(td.tri.V1),
+				src.colorAt(td.tri.V2),
+			}
+		}
+		if src.texture != nil {
+			td.uvs = [3][2]float64{
+				src.uvAt(td.tri.V0),
+				src.uvAt(td.tri.V1),
+				src.uvAt(td.tri.V2),
+			}
+		}
+		dst = append(dst, td)
+	}
+	return dst
+}
+
+// collectBackToFront 按相对于视点从远到近的顺序收集三角形
+func (n *bspNode) collectBackToFront(eye Vector3, result []triangleWithDepth) []triangleWithDepth {
+	if n == nil {
+		return result
+	}
+	if n.leaf != nil {
+		start := len(result)
+		result = append(result, n.leaf...)
+		sort.SliceStable(result[start:], func(i, j int) bool {
+			return result[start+i].depth > result[start+j].depth
+		})
+		return result
+	}
+
+	if n.normal.Dot(eye)-n.d >= 0 {
+		// 视点在平面前方：先画背面，再画本平面，最后画前面
+		result = n.back.collectBackToFront(eye, result)
+		result = append(result, n.coplanar...)
+		return n.front.collectBackToFront(eye, result)
+	}
+
+	result = n.front.collectBackToFront(eye, result)
+	result = append(result, n.coplanar...)
+	return n.back.collectBackToFront(eye, result)
+}
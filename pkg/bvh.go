@@ -0,0 +1,222 @@
+package go3d
+
+import (
+	"math"
+	"sort"
+)
+
+// bvhLeafSize 叶节点最多包含的三角形数
+const bvhLeafSize = 4
+
+// BVH 三角形包围体层次结构，用于加速光线求交
+type BVH struct {
+	triangles []Triangle
+	order     []int // 叶节点按 start、count 引用的三角形编号
+	nodes     []bvhNode
+}
+
+// bvhNode BVH 节点：count 大于 0 为叶节点，否则 left、right 为子节点编号
+type bvhNode struct {
+	bounds      AABB
+	left, right int
+	start       int
+	count       int
+}
+
+// RayHit 光线与三角形的交点
+type RayHit struct {
+	Triangle int     // 三角形在构建 BVH 时传入的切片中的编号
+	T        float64 // 光线参数：交点 = origin + dir·T
+	U, V     float64 // 重心坐标：交点 = (1-U-V)·V0 + U·V1 + V·V2
+}
+
+// NewBVH 为三角形构建 BVH，三角形切片在 BVH 使用期间不应修改
+func NewBVH(triangles []Triangle) *BVH {
+	b := &BVH{triangles: triangles, order: make([]int, len(triangles))}
+	for i := range b.order {
+		b.order[i] = i
+	}
+	if len(triangles) > 0 {
+		b.build(0, len(triangles))
+	}
+	return b
+}
+
+// build 递归构建 order[start:end] 的子树，返回节点编号
+// 沿三角形中心分布最广的坐标轴按中位数划分
+func (b *BVH) build(start, end int) int {
+	index := len(b.nodes)
+	b.nodes = append(b.nodes, bvhNode{})
+
+	bounds := triangleBounds(b.triangles[b.order[start]])
+	centroidMin := b.triangles[b.order[start]].Center()
+	centroidMax := centroidMin
+	for _, i := range b.order[start+1 : end] {
+		bounds = bounds.union(triangleBounds(b.triangles[i]))
+		c := b.triangles[i].Center()
+		centroidMin = NewVector3(math.Min(centroidMin.X, c.X), math.Min(centroidMin.Y, c.Y), math.Min(centroidMin.Z, c.Z))
+		centroidMax = NewVector3(math.Max(centroidMax.X, c.X), math.Max(centroidMax.Y, c.Y), math.Max(centroidMax.Z, c.Z))
+	}
+
+	if end-start <= bvhLeafSize {
+		b.nodes[index] = bvhNode{bounds: bounds, start: start, count: end - start}
+		return index
+	}
+
+	extent := centroidMax.Sub(centroidMin)
+	axis := func(v Vector3) float64 { return v.X }
+	if extent.Y > extent.X && extent.Y >= extent.Z {
+		axis = func(v Vector3) float64 { return v.Y }
+	} else if extent.Z > extent.X && extent.Z > extent.Y {
+		axis = func(v Vector3) float64 { return v.Z }
+	}
+	part := b.order[start:end]
+	sort.SliceStable(part, func(i, j int) bool {
+		return axis(b.triangles[part[i]].Center()) < axis(b.triangles[part[j]].Center())
+	})
+
+	mid := (start + end) / 2
+	left := b.build(start, mid)
+	right := b.build(mid, end)
+	b.nodes[index] = bvhNode{bounds: bounds, left: left, right: right}
+	return index
+}
+
+// Bounds 所有三角形的包围盒
+func (b *BVH) Bounds() AABB {
+	if len(b.nodes) == 0 {
+		return AABB{}
+	}
+	return b.nodes[0].bounds
+}
+
+// Intersect 求光线在 (tMin, tMax) 范围内最近的交点，三角形两面都参与求交
+func (b *BVH) Intersect(origin, dir Vector3, tMin, tMax float64) (RayHit, bool) {
+	best := RayHit{Triangle: -1}
+	b.traverse(origin, dir, tMin, &tMax, func(i int, t, u, v float64) bool {
+		best = RayHit{Triangle: i, T: t, U: u, V: v}
+		tMax = t
+		return false
+	})
+	return best, best.Triangle >= 0
+}
+
+// Occluded 光线在 (tMin, tMax) 范围内是否与任何三角形相交，用于阴影测试
+// skip 不为空时跳过返回 true 的三角形
+func (b *BVH) Occluded(origin, dir Vector3, tMin, tMax float64, skip func(i int) bool) bool {
+	occluded := false
+	b.traverse(origin, dir, tMin, &tMax, func(i int, t, u, v float64) bool {
+		if skip != nil && skip(i) {
+			return false
+		}
+		occluded = true
+		return true
+	})
+	return occluded
+}
+
+// traverse 遍历与光线相交的叶节点，对每个交点调用 hit；hit 返回 true 时停止遍历
+// tMax 通过指针传入，hit 中缩小的范围会立即用于剪枝
+func (b *BVH) traverse(origin, dir Vector3, tMin float64, tMax *float64, hit func(i int, t, u, v float64) bool) {
+	if len(b.nodes) == 0 {
+		return
+	}
+	inv := NewVector3(1/dir.X, 1/dir.Y, 1/dir.Z)
+
+	stack := make([]int, 0, 64)
+	stack = append(stack, 0)
+	for len(stack) > 0 {
+		node := &b.nodes[stack[len(stack)-1]]
+		stack = stack[:len(stack)-1]
+		if !node.bounds.intersectsRay(origin, inv, tMin, *tMax) {
+			continue
+		}
+		if node.count == 0 {
+			stack = append(stack, node.left, node.right)
+			continue
+		}
+		for _, i := range b.order[node.start : node.start+node.count] {
+			t, u, v, ok := intersectTriangle(b.triangles[i], origin, dir)
+			if ok && t > tMin && t < *tMax && hit(i, t, u, v) {
+				return
+			}
+		}
+	}
+}
+
+// intersectTriangle Möller–Trumbore 光线三角形求交，返回光线参数和重心坐标
+func intersectTriangle(tri Triangle, origin, dir Vector3) (t, u, v float64, ok bool) {
+	e1 := tri.V1.Sub(tri.V0)
+	e2 := tri.V2.Sub(tri.V0)
+	p := dir.Cross(e2)
+	det := e1.Dot(p)
+	if math.Abs(det) < 1e-14 {
+		return 0, 0, 0, false
+	}
+	invDet := 1 / det
+
+	s := origin.Sub(tri.V0)
+	u = s.Dot(p) * invDet
+	if u < 0 || u > 1 {
+		return 0, 0, 0, false
+	}
+	q := s.Cross(e1)
+	v = dir.Dot(q) * invDet
+	if v < 0 || u+v > 1 {
+		return 0, 0, 0, false
+	}
+	return e2.Dot(q) * invDet, u, v, true
+}
+
+// triangleBounds 三角形的包围盒
+func triangleBounds(tri Triangle) AABB {
+	return AABB{
+		Min: NewVector3(math.Min(tri.V0.X, math.Min(tri.V1.X, tri.V2.X)), math.Min(tri.V0.Y, math.Min(tri.V1.Y, tri.V2.Y)), math.Min(tri.V0.Z, math.Min(tri.V1.Z, tri.V2.Z))),
+		Max: NewVector3(math.Max(tri.V0.X, math.Max(tri.V1.X, tri.V2.X)), math.Max(tri.V0.Y, math.Max(tri.V1.Y, tri.V2.Y)), math.Max(tri.V0.Z, math.Max(tri.V1.Z, tri.V2.Z))),
+	}
+}
+
+// union 两个包围盒的并集
+func (b AABB) union(other AABB) AABB {
+	return AABB{
+		Min: NewVector3(math.Min(b.Min.X, other.Min.X), math.Min(b.Min.Y, other.Min.Y), math.Min(b.Min.Z, other.Min.Z)),
+		Max: NewVector3(math.Max(b.Max.X, other.Max.X), math.Max(b.Max.Y, other.Max.Y), math.Max(b.Max.Z, other.Max.Z)),
+	}
+}
+
+// intersectsRay 光线（方向分量取倒数后传入）在 [tMin, tMax] 内是否穿过包围盒（slab 方法）
+func (b AABB) intersectsRay(origin, inv Vector3, tMin, tMax float64) bool {
+	_, _, hit := b.rayInterval(origin, inv, tMin, tMax)
+	return hit
+}
+
+// rayInterval 光线（方向分量取倒数后传入）在 [tMin, tMax] 内位于包围盒中的参数区间
+func (b AABB) rayInterval(origin, inv Vector3, tMin, tMax float64) (float64, float64, bool) {
+	for axis := range 3 {
+		var o, d, lo, hi float64
+		switch axis {
+		case 0:
+			o, d, lo, hi = origin.X, inv.X, b.Min.X, b.Max.X
+		case 1:
+			o, d, lo, hi = origin.Y, inv.Y, b.Min.Y, b.Max.Y
+		default:
+			o, d, lo, hi = origin.Z, inv.Z, b.Min.Z, b.Max.Z
+		}
+		t0 := (lo - o) * d
+		t1 := (hi - o) * d
+		if t0 > t1 {
+			t0, t1 = t1, t0
+		}
+		// 方向分量为 0 且原点在 slab 上时会得到 NaN，此时不缩小范围
+		if t0 > tMin {
+			tMin = t0
+		}
+		if t1 < tMax {
+			tMax = t1
+		}
+		if tMin > tMax {
+			return tMin, tMax, false
+		}
+	}
+	return tMin, tMax, true
+}
@@ -9,12 +9,20 @@ type CameraPath interface {
 	GetFOV(t float64) float64
 }
 
+// CameraUpPath 同时给出相机上方向的相机路径（可选接口），用于滚转镜头；
+// ApplyCameraPath 遇到它时一并设置 Camera.Up，GetUp 返回零向量时保持原来的上方向
+type CameraUpPath interface {
+	CameraPath
+	GetUp(t float64) Vector3
+}
+
 // CameraKeyframe 相机关键帧
 type CameraKeyframe struct {
 	Time     float64 // 时间点 (0-1)
 	Position Vector3
 	Target   Vector3
 	FOV      float64
+	Up       Vector3 // 相机上方向，零向量表示不设置（保持 Camera.Up 不变）
 }
 
 // InterpolatedCameraPath 插值相机路径
@@ -46,6 +54,11 @@ func (cp *InterpolatedCameraPath) GetFOV(t float64) float64 {
 	return cp.interpolateFloat(t, func(kf CameraKeyframe) float64 { return kf.FOV })
 }
 
+// GetUp 获取指定时间的相机上方向，关键帧没有设置上方向时为零向量
+func (cp *InterpolatedCameraPath) GetUp(t float64) Vector3 {
+	return cp.interpolateVector(t, func(kf CameraKeyframe) Vector3 { return kf.Up })
+}
+
 // interpolateVector 插值向量
 func (cp *InterpolatedCameraPath) interpolateVector(t float64, getter func(CameraKeyframe) Vector3) Vector3 {
 	if len(cp.Keyframes) == 0 {
@@ -195,6 +208,16 @@ func Smootherstep(t float64) float64 {
 	return t * t * t * (t*(t*6-15) + 10)
 }
 
+// EaseIn 缓入函数：开始慢、结束快
+func EaseIn(t float64) float64 {
+	return t * t
+}
+
+// EaseOut 缓出函数：开始快、结束慢
+func EaseOut(t float64) float64 {
+	return 1 - (1-t)*(1-t)
+}
+
 // EaseInOut 缓入缓出函数
 func EaseInOut(t float64) float64 {
 	if t < 0.5 {
@@ -204,8 +227,130 @@ func EaseInOut(t float64) float64 {
 }
 
 // ApplyCameraPath 应用相机路径到渲染器
+// 相机由动画的剪辑表（AnimationConfig.Cuts）控制时不做任何修改
 func ApplyCameraPath(renderer *Renderer, path CameraPath, t float64) {
+	if renderer.cameraLocked {
+		return
+	}
 	renderer.Camera.Position = path.GetPosition(t)
 	renderer.Camera.Target = path.GetTarget(t)
 	renderer.Camera.FOV = path.GetFOV(t)
+	if upPath, ok := path.(CameraUpPath); ok {
+		if up := upPath.GetUp(t); up.Length() > 0 {
+			renderer.Camera.Up = up
+		}
+	}
+}
+
+// OrbitController 轨道相机控制器（turntable 风格）
+// 使用偏航角/俯仰角/距离描述相机，俯仰角在两极附近被钳制，避免上方向退化
+type OrbitController struct {
+	Target      Vector3 // 环绕中心
+	Yaw         float64 // 偏航角（弧度，绕Y轴）
+	Pitch       float64 // 俯仰角（弧度，正值为从上方俯视）
+	Distance    float64 // 相机到目标的距离
+	Pan         Vector3 // 平移偏移（同时作用于相机位置和目标）
+	MinPitch    float64 // 最小俯仰角
+	MaxPitch    float64 // 最大俯仰角
+	MinDistance float64 // 最小距离
+	MaxDistance float64 // 最大距离（0 表示不限制）
+}
+
+// NewOrbitController 创建轨道相机控制器
+func NewOrbitController(target Vector3, distance float64) *OrbitController {
+	// 留出一点余量，避免正好位于极点
+	poleLimit := math.Pi/2 - 0.01
+	return &OrbitController{
+		Target:      target,
+		Distance:    distance,
+		MinPitch:    -poleLimit,
+		MaxPitch:    poleLimit,
+		MinDistance: 0.01,
+	}
+}
+
+// NewOrbitControllerFromCamera 创建与相机当前位置和目标一致的轨道相机控制器
+func NewOrbitControllerFromCamera(camera *Camera) *OrbitController {
+	offset := camera.Position.Sub(camera.Target)
+	oc := NewOrbitController(camera.Target, offset.Length())
+	if distance := offset.Length(); distance > 1e-10 {
+		oc.Yaw = math.Atan2(offset.X, offset.Z)
+		oc.Pitch = math.Asin(math.Max(-1, math.Min(1, offset.Y/distance)))
+	}
+	oc.clamp()
+	return oc
+}
+
+// Rotate 按增量旋转相机
+func (oc *OrbitController) Rotate(deltaYaw, deltaPitch float64) {
+	oc.Yaw += deltaYaw
+	oc.Pitch += deltaPitch
+	oc.clamp()
+}
+
+// Zoom 按比例缩放距离（factor < 1 拉近，> 1 拉远）
+func (oc *OrbitController) Zoom(factor float64) {
+	if factor <= 0 {
+		return
+	}
+	oc.Distance *= factor
+	oc.clamp()
+}
+
+// PanBy 在相机平面内平移（dx 向右，dy 向上）
+func (oc *OrbitController) PanBy(dx, dy float64) {
+	_, right, up := oc.basis()
+	oc.Pan = oc.Pan.Add(right.Scale(dx)).Add(up.Scale(dy))
+}
+
+// clamp 钳制俯仰角和距离
+func (oc *OrbitController) clamp() {
+	oc.Pitch = math.Max(oc.MinPitch, math.Min(oc.MaxPitch, oc.Pitch))
+	if oc.Distance < oc.MinDistance {
+		oc.Distance = oc.MinDistance
+	}
+	if oc.MaxDistance > 0 && oc.Distance > oc.MaxDistance {
+		oc.Distance = oc.MaxDistance
+	}
+}
+
+// basis 计算相机的前、右、上方向
+func (oc *OrbitController) basis() (forward, right, up Vector3) {
+	pitch := math.Max(oc.MinPitch, math.Min(oc.MaxPitch, oc.Pitch))
+	cosPitch := math.Cos(pitch)
+
+	// 从目标指向相机的方向
+	offset := NewVector3(
+		cosPitch*math.Sin(oc.Yaw),
+		math.Sin(pitch),
+		cosPitch*math.Cos(oc.Yaw),
+	)
+	forward = offset.Scale(-1)
+	right = forward.Cross(NewVector3(0, 1, 0)).Normalize()
+	up = right.Cross(forward).Normalize()
+	return forward, right, up
+}
+
+// GetPosition 获取相机位置
+func (oc *OrbitController) GetPosition() Vector3 {
+	forward, _, _ := oc.basis()
+	return oc.GetTarget().Sub(forward.Scale(oc.Distance))
+}
+
+// GetTarget 获取相机目标（包含平移偏移）
+func (oc *OrbitController) GetTarget() Vector3 {
+	return oc.Target.Add(oc.Pan)
+}
+
+// GetUp 获取与视线正交的上方向
+func (oc *OrbitController) GetUp() Vector3 {
+	_, _, up := oc.basis()
+	return up
+}
+
+// Apply 将控制器状态应用到相机
+func (oc *OrbitController) Apply(camera *Camera) {
+	camera.Position = oc.GetPosition()
+	camera.Target = oc.GetTarget()
+	camera.Up = oc.GetUp()
 }
@@ -0,0 +1,115 @@
+package go3d
+
+import (
+	"fmt"
+	"math"
+)
+
+// parallelEpsilon Up 与视线夹角的正弦值小于该值时认为两者平行，相机基向量无法确定
+const parallelEpsilon = 1e-6
+
+// Validate 检查相机参数能否构成正交的相机坐标系：位置和目标不能重合，Up 不能为零或与视线平行，
+// FOV 必须在 (0, π) 之间（弧度），0 < Near < Far。无效的相机会渲染出退化的画面，Scene.Render 会跳过
+// 绘制并通过 Renderer.CameraError 报告错误
+func (c *Camera) Validate() error {
+	for _, v := range []struct {
+		name string
+		v    Vector3
+	}{{"位置", c.Position}, {"目标", c.Target}, {"Up", c.Up}} {
+		if !finite(v.v.X) || !finite(v.v.Y) || !finite(v.v.Z) {
+			return fmt.Errorf("相机%s包含 NaN 或无穷大: %v", v.name, v.v)
+		}
+	}
+
+	view := c.Target.Sub(c.Position)
+	switch {
+	case view.Length() < 1e-10:
+		return fmt.Errorf("相机位置与目标重合: %v", c.Position)
+	case c.Up.Length() < 1e-10:
+		return fmt.Errorf("相机 Up 为零向量")
+	case view.Normalize().Cross(c.Up.Normalize()).Length() < parallelEpsilon:
+		return fmt.Errorf("相机 Up %v 与视线方向平行，无法确定画面朝向（可设置 AutoUp 或调用 Orthonormalize）", c.Up)
+	case !(c.FOV > 0 && c.FOV < math.Pi):
+		return fmt.Errorf("相机 FOV %g 超出 (0, π)，FOV 以弧度为单位", c.FOV)
+	case !(c.Near > 0 && c.Far > c.Near):
+		return fmt.Errorf("相机裁剪面无效: Near = %g, Far = %g（需要 0 < Near < Far）", c.Near, c.Far)
+	}
+	return nil
+}
+
+// Orthonormalize 把 Up 修正为与视线垂直的单位向量；Up 为零或与视线平行时选择稳定的上方向
+// （与 LookAt 相同：优先世界 Y 轴，视线接近竖直时使用 X 轴）。位置与目标重合时无法修正，返回错误
+func (c *Camera) Orthonormalize() error {
+	forward := c.Target.Sub(c.Position)
+	if forward.Length() < 1e-10 {
+		return fmt.Errorf("相机位置与目标重合: %v", c.Position)
+	}
+	_, _, c.Up = c.Basis()
+	return nil
+}
+
+// Basis 返回相机的正交基：视线方向、右方向和上方向（均为单位向量）。
+// Up 与视线平行时按 Orthonormalize 的规则选择上方向，不会返回零向量或 NaN
+func (c *Camera) Basis() (forward, right, up Vector3) {
+	forward = c.Target.Sub(c.Position).Normalize()
+	if forward.Length() < 1e-10 {
+		forward = Vector3{0, 0, 1}
+	}
+	right = forward.Cross(c.Up)
+	if right.Length() < parallelEpsilon*c.Up.Length() || right.Length() < 1e-10 {
+		right = forward.Cross(stableUp(forward))
+	}
+	right = right.Normalize()
+	up = right.Cross(forward).Normalize()
+	return forward, right, up
+}
+
+// stableUp Up 与视线平行时使用的上方向
+func stableUp(forward Vector3) Vector3 {
+	if math.Abs(forward.Y) < 0.9 {
+		return Vector3{0, 1, 0}
+	}
+	return Vector3{1, 0, 0}
+}
+
+// finite 判断浮点数不是 NaN 或无穷大
+func finite(x float64) bool {
+	return !math.IsNaN(x) && !math.IsInf(x, 0)
+}
+
+// checkCamera 在绘制场景前检查相机：设置了 AutoUp 时先修正 Up，仍然无效则记录错误（只保留第一个）
+func (r *Renderer) checkCamera() error {
+	if r.Camera.AutoUp {
+		r.Camera.Orthonormalize()
+	}
+	err := r.Camera.Validate()
+	if err != nil && r.cameraErr == nil {
+		r.cameraErr = err
+	}
+	return err
+}
+
+// CameraError 返回本帧渲染场景时遇到的相机错误，没有错误时返回 nil。
+// AnimationGenerator 在每帧渲染后检查它，相机无效时停止生成并报告帧号
+func (r *Renderer) CameraError() error {
+	if r.cameraErr == nil && r.viewport != nil {
+		return r.viewport.renderer.cameraErr
+	}
+	return r.cameraErr
+}
+
+// ReportError 记录帧渲染函数中遇到的错误（如场景描述无效），只保留第一个。
+// 帧渲染函数没有返回值，通过它让 AnimationGenerator 停止生成并报告帧号
+func (r *Renderer) ReportError(err error) {
+	if err != nil && r.frameErr == nil {
+		r.frameErr = err
+	}
+}
+
+// Err 返回本帧渲染中报告的错误（ReportError 或相机错误），没有错误时返回 nil
+func (r *Renderer) Err() error {
+	if r.frameErr != nil {
+		return r.frameErr
+	}
+	return r.CameraError()
+}
@@ -0,0 +1,242 @@
+package go3d
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SampleCameraPath 在 [0, 1] 内均匀采样 samples+1 次，把任意相机路径转换为线性插值的关键帧路径。
+// 采样点处与原路径完全一致，路径实现 CameraUpPath 时同时记录上方向（滚转）
+func SampleCameraPath(path CameraPath, samples int) *InterpolatedCameraPath {
+	samples = max(1, samples)
+	keyframes := make([]CameraKeyframe, samples+1)
+	for i := range keyframes {
+		keyframes[i] = cameraKeyframeAt(path, float64(i)/float64(samples))
+	}
+	return newLinearCameraPath(keyframes)
+}
+
+// cameraKeyframeAt 路径在时间 t 的关键帧
+func cameraKeyframeAt(path CameraPath, t float64) CameraKeyframe {
+	kf := CameraKeyframe{
+		Time:     t,
+		Position: path.GetPosition(t),
+		Target:   path.GetTarget(t),
+		FOV:      path.GetFOV(t),
+	}
+	if upPath, ok := path.(CameraUpPath); ok {
+		kf.Up = upPath.GetUp(t)
+	}
+	return kf
+}
+
+// newLinearCameraPath 关键帧之间线性插值的路径，密集的关键帧逐段缓入缓出会使运动一顿一顿
+func newLinearCameraPath(keyframes []CameraKeyframe) *InterpolatedCameraPath {
+	path := NewInterpolatedCameraPath(keyframes)
+	path.SmoothFunction = nil
+	return path
+}
+
+// CameraRecorder 相机录制器：按时间记录相机状态，生成可以在离线渲染中原样重放的路径。
+// 交给 InteractiveView.Recorder 后会记录交互预览中每次渲染的视角
+type CameraRecorder struct {
+	Keyframes []CameraKeyframe // 按时间排列
+}
+
+// NewCameraRecorder 创建空的相机录制器
+func NewCameraRecorder() *CameraRecorder {
+	return &CameraRecorder{}
+}
+
+// Record 记录时间 t 的相机状态；已有同一时间的记录时覆盖，因此在暂停时反复调整视角只保留最后一次
+func (rec *CameraRecorder) Record(t float64, camera *Camera) {
+	kf := CameraKeyframe{Time: t, Position: camera.Position, Target: camera.Target, FOV: camera.FOV, Up: camera.Up}
+	i := sort.Search(len(rec.Keyframes), func(i int) bool { return rec.Keyframes[i].Time >= t })
+	if i < len(rec.Keyframes) && math.Abs(rec.Keyframes[i].Time-t) < 1e-9 {
+		rec.Keyframes[i] = kf
+		return
+	}
+	rec.Keyframes = append(rec.Keyframes, CameraKeyframe{})
+	copy(rec.Keyframes[i+1:], rec.Keyframes[i:])
+	rec.Keyframes[i] = kf
+}
+
+// Clear 清空记录
+func (rec *CameraRecorder) Clear() {
+	rec.Keyframes = rec.Keyframes[:0]
+}
+
+// Path 按记录生成线性插值的相机路径
+func (rec *CameraRecorder) Path() *InterpolatedCameraPath {
+	return newLinearCameraPath(append([]CameraKeyframe(nil), rec.Keyframes...))
+}
+
+// Spec 路径对应的相机描述（视场角换算为度）：固定位置取第一个关键帧，Path 为全部关键帧，
+// 可以直接作为场景描述文件中 camera 的值，或交给命令行工具的 -camera 选项
+func (cp *InterpolatedCameraPath) Spec() CameraSpec {
+	path := &CameraPathSpec{Type: "keyframes", Interpolation: "smooth"}
+	if cp.SmoothFunction == nil {
+		path.Interpolation = "linear"
+	}
+	for _, kf := range cp.Keyframes {
+		ks := CameraKeyframeSpec{
+			Time:     kf.Time,
+			Position: [3]float64{kf.Position.X, kf.Position.Y, kf.Position.Z},
+			Target:   [3]float64{kf.Target.X, kf.Target.Y, kf.Target.Z},
+			FOV:      kf.FOV * 180 / math.Pi,
+		}
+		if kf.Up.Length() > 0 {
+			ks.Up = &[3]float64{kf.Up.X, kf.Up.Y, kf.Up.Z}
+		}
+		path.Keyframes = append(path.Keyframes, ks)
+	}
+	spec := CameraSpec{Path: path}
+	if len(path.Keyframes) > 0 {
+		first := path.Keyframes[0]
+		spec.Position, spec.Target, spec.FOV = first.Position, first.Target, first.FOV
+	}
+	return spec
+}
+
+// WriteJSON 以 JSON 写出路径的相机描述（见 Spec）
+func (cp *InterpolatedCameraPath) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(cp.Spec())
+}
+
+// cameraCSVHeader CSV 相机路径的列：时间、位置、目标、视场角（度）、上方向
+var cameraCSVHeader = []string{"time", "x", "y", "z", "targetX", "targetY", "targetZ", "fov", "upX", "upY", "upZ"}
+
+// WriteCSV 以 CSV 写出关键帧，每行一个，便于在表格软件中查看和编辑
+func (cp *InterpolatedCameraPath) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	writer.Write(cameraCSVHeader)
+	for _, kf := range cp.Keyframes {
+		values := []float64{
+			kf.Time,
+			kf.Position.X, kf.Position.Y, kf.Position.Z,
+			kf.Target.X, kf.Target.Y, kf.Target.Z,
+			kf.FOV * 180 / math.Pi,
+			kf.Up.X, kf.Up.Y, kf.Up.Z,
+		}
+		record := make([]string, len(values))
+		for i, v := range values {
+			record[i] = strconv.FormatFloat(v, 'g', -1, 64)
+		}
+		writer.Write(record)
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// SaveCameraPath 把路径写入文件：.csv 按 WriteCSV 写出，其余按 WriteJSON 写出
+func SaveCameraPath(path *InterpolatedCameraPath, filename string) error {
+	var buf bytes.Buffer
+	var err error
+	if strings.EqualFold(filepath.Ext(filename), ".csv") {
+		err = path.WriteCSV(&buf)
+	} else {
+		err = path.WriteJSON(&buf)
+	}
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, buf.Bytes(), 0o644)
+}
+
+// ReadCameraPathJSON 读取 JSON 相机描述（与场景描述文件中 camera 的格式相同）对应的相机路径
+func ReadCameraPathJSON(r io.Reader) (CameraPath, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	sf := &SceneFile{}
+	if err := decoder.Decode(&sf.Camera); err != nil {
+		return nil, fmt.Errorf("解析相机描述失败: %w", err)
+	}
+	if path := sf.Camera.Path; path != nil {
+		if err := path.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return sf.CameraPath(), nil
+}
+
+// ReadCameraPathCSV 读取 WriteCSV 格式的关键帧（按表头的列名查找，up 列可以省略），关键帧之间线性插值
+func ReadCameraPathCSV(r io.Reader) (*InterpolatedCameraPath, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("读取相机路径失败: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range cameraCSVHeader[:8] {
+		if _, ok := columns[strings.ToLower(name)]; !ok {
+			return nil, fmt.Errorf("相机路径缺少 %s 列", name)
+		}
+	}
+
+	var keyframes []CameraKeyframe
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[string]float64, len(cameraCSVHeader))
+		for _, name := range cameraCSVHeader {
+			i, ok := columns[strings.ToLower(name)]
+			if !ok || i >= len(record) || strings.TrimSpace(record[i]) == "" {
+				continue
+			}
+			v, err := strconv.ParseFloat(strings.TrimSpace(record[i]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("相机路径第 %d 行的 %s 无效: %q", line, name, record[i])
+			}
+			values[name] = v
+		}
+		keyframes = append(keyframes, CameraKeyframe{
+			Time:     values["time"],
+			Position: NewVector3(values["x"], values["y"], values["z"]),
+			Target:   NewVector3(values["targetX"], values["targetY"], values["targetZ"]),
+			FOV:      values["fov"] * math.Pi / 180,
+			Up:       NewVector3(values["upX"], values["upY"], values["upZ"]),
+		})
+	}
+	if len(keyframes) == 0 {
+		return nil, fmt.Errorf("相机路径没有关键帧")
+	}
+	sort.SliceStable(keyframes, func(a, b int) bool { return keyframes[a].Time < keyframes[b].Time })
+	return newLinearCameraPath(keyframes), nil
+}
+
+// LoadCameraPath 从文件加载相机路径：.csv 按 ReadCameraPathCSV 解析，其余按 ReadCameraPathJSON 解析
+func LoadCameraPath(filename string) (CameraPath, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	if strings.EqualFold(filepath.Ext(filename), ".csv") {
+		path, err := ReadCameraPathCSV(file)
+		if err != nil {
+			return nil, err
+		}
+		return path, nil
+	}
+	return ReadCameraPathJSON(file)
+}
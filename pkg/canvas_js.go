@@ -0,0 +1,270 @@
+//go:build js && wasm
+
+// 浏览器 canvas 输出，用于把场景编译为 WebAssembly 在网页中运行：
+//
+//	GOOS=js GOARCH=wasm go build -o scene.wasm ./your/program
+//	cp "$(go env GOROOT)/lib/wasm/wasm_exec.js" .
+//
+// 页面中放置 <canvas id="scene"></canvas>，再按 wasm_exec.js 的说明加载 scene.wasm
+
+package go3d
+
+import (
+	"fmt"
+	"image"
+	"sync"
+	"syscall/js"
+)
+
+// CanvasTarget 把渲染结果绘制到网页中的 <canvas> 元素
+// 画面经 ImageData 写入，像素缓冲在尺寸不变时重复使用
+type CanvasTarget struct {
+	Canvas  js.Value // <canvas> 元素
+	Context js.Value // canvas 的 2D 绘图上下文
+
+	pixels    []byte   // 转换为非预乘 alpha 后的像素
+	array     js.Value // 与 pixels 同尺寸的 Uint8ClampedArray
+	imageData js.Value
+	width     int
+	height    int
+}
+
+// NewCanvasTarget 按元素 id 查找 canvas 并创建输出目标
+func NewCanvasTarget(id string) (*CanvasTarget, error) {
+	canvas := js.Global().Get("document").Call("getElementById", id)
+	if canvas.IsNull() || canvas.IsUndefined() {
+		return nil, fmt.Errorf("找不到 canvas 元素: %s", id)
+	}
+	context := canvas.Call("getContext", "2d")
+	if context.IsNull() {
+		return nil, fmt.Errorf("canvas 不支持 2D 绘图上下文: %s", id)
+	}
+	return &CanvasTarget{Canvas: canvas, Context: context}, nil
+}
+
+// Draw 把图像绘制到 canvas 左上角，canvas 尺寸随图像调整
+// 渲染器画布为预乘 alpha，ImageData 为非预乘 alpha，绘制前逐像素转换
+func (c *CanvasTarget) Draw(img *image.RGBA) {
+	width, height := img.Rect.Dx(), img.Rect.Dy()
+	if width <= 0 || height <= 0 {
+		return
+	}
+	if width != c.width || height != c.height {
+		c.width, c.height = width, height
+		c.Canvas.Set("width", width)
+		c.Canvas.Set("height", height)
+		c.pixels = make([]byte, width*height*4)
+		c.array = js.Global().Get("Uint8ClampedArray").New(len(c.pixels))
+		c.imageData = js.Global().Get("ImageData").New(c.array, width, height)
+	}
+
+	for y := range height {
+		src := img.Pix[img.PixOffset(img.Rect.Min.X, img.Rect.Min.Y+y):]
+		dst := c.pixels[y*width*4 : (y+1)*width*4]
+		for i := 0; i < len(dst); i += 4 {
+			a := src[i+3]
+			switch a {
+			case 0:
+				dst[i], dst[i+1], dst[i+2], dst[i+3] = 0, 0, 0, 0
+			case 255:
+				copy(dst[i:i+4], src[i:i+4])
+			default:
+				dst[i] = unpremultiply(src[i], a)
+				dst[i+1] = unpremultiply(src[i+1], a)
+				dst[i+2] = unpremultiply(src[i+2], a)
+				dst[i+3] = a
+			}
+		}
+	}
+	js.CopyBytesToJS(c.array, c.pixels)
+	c.Context.Call("putImageData", c.imageData, 0, 0)
+}
+
+// DrawRenderer 应用后期处理后把渲染器的画面绘制到 canvas
+func (c *CanvasTarget) DrawRenderer(r *Renderer) {
+	r.ApplyPostEffects()
+	if img, ok := r.Surface.GetGoImage().(*image.RGBA); ok {
+		c.Draw(img)
+	}
+}
+
+// unpremultiply 把预乘 alpha 的颜色分量还原为非预乘值
+func unpremultiply(c, a uint8) uint8 {
+	return uint8(min(255, (int(c)*255+int(a)/2)/int(a)))
+}
+
+// ShowCanvas 在 id 指定的 canvas 中显示交互式预览，页面关闭前不会返回
+// 操作方式与 ShowWindow 相同：左键拖动旋转，右键或 Shift+左键拖动平移，滚轮缩放，
+// 空格播放/暂停，← → 调整时间，点击底部时间轴跳转
+func (v *InteractiveView) ShowCanvas(id string) error {
+	target, err := NewCanvasTarget(id)
+	if err != nil {
+		return err
+	}
+
+	// 事件回调中只记录操作，由渲染循环统一执行，避免渲染过程中修改视角
+	var mu sync.Mutex
+	var pending []func()
+	post := func(op func()) {
+		mu.Lock()
+		pending = append(pending, op)
+		mu.Unlock()
+	}
+
+	canvas := target.Canvas
+	canvas.Set("tabIndex", 0) // 允许 canvas 获得键盘焦点
+	// 鼠标位置换算为画布像素（canvas 可能被 CSS 缩放）
+	position := func(event js.Value) (float64, float64) {
+		scaleX := canvas.Get("width").Float() / max(1, canvas.Get("clientWidth").Float())
+		scaleY := canvas.Get("height").Float() / max(1, canvas.Get("clientHeight").Float())
+		return event.Get("offsetX").Float() * scaleX, event.Get("offsetY").Float() * scaleY
+	}
+
+	var lastX, lastY float64
+	button := -1 // 正在按下的鼠标按键，-1 表示没有
+	scrubbing := false
+	listeners := map[string]js.Func{
+		"mousedown": js.FuncOf(func(this js.Value, args []js.Value) any {
+			event := args[0]
+			x, y := position(event)
+			lastX, lastY = x, y
+			button = event.Get("button").Int()
+			_, height := v.Size()
+			scrubbing = button == 0 && y >= float64(height-ScrubberHeight)
+			if scrubbing {
+				post(func() {
+					width, _ := v.Size()
+					v.Seek(x / float64(max(1, width-1)))
+				})
+			}
+			canvas.Call("focus")
+			event.Call("preventDefault")
+			return nil
+		}),
+		"mousemove": js.FuncOf(func(this js.Value, args []js.Value) any {
+			event := args[0]
+			x, y := position(event)
+			dx, dy := x-lastX, y-lastY
+			lastX, lastY = x, y
+			shift := event.Get("shiftKey").Bool()
+			switch {
+			case button < 0:
+			case scrubbing:
+				post(func() {
+					width, _ := v.Size()
+					v.Seek(x / float64(max(1, width-1)))
+				})
+			case button == 2, button == 0 && shift:
+				post(func() { v.PanPixels(dx, dy) })
+			case button == 0:
+				post(func() { v.Drag(dx, dy) })
+			}
+			return nil
+		}),
+		"mouseup": js.FuncOf(func(this js.Value, args []js.Value) any {
+			button = -1
+			scrubbing = false
+			return nil
+		}),
+		"wheel": js.FuncOf(func(this js.Value, args []js.Value) any {
+			event := args[0]
+			// deltaMode 0 按像素（约 100 像素一格），1 按行（约 3 行一格）
+			steps := -event.Get("deltaY").Float() / 100
+			if event.Get("deltaMode").Int() == 1 {
+				steps = -event.Get("deltaY").Float() / 3
+			}
+			post(func() { v.Scroll(steps) })
+			event.Call("preventDefault")
+			return nil
+		}),
+		"keydown": js.FuncOf(func(this js.Value, args []js.Value) any {
+			event := args[0]
+			step := 0.01
+			if event.Get("shiftKey").Bool() {
+				step = 0.1
+			}
+			switch event.Get("key").String() {
+			case " ":
+				post(v.TogglePlay)
+			case "ArrowRight":
+				post(func() { v.Seek(v.Time + step) })
+			case "ArrowLeft":
+				post(func() { v.Seek(v.Time - step) })
+			default:
+				return nil
+			}
+			event.Call("preventDefault")
+			return nil
+		}),
+		"contextmenu": js.FuncOf(func(this js.Value, args []js.Value) any {
+			args[0].Call("preventDefault")
+			return nil
+		}),
+	}
+	for name, listener := range listeners {
+		// 松开按键可能发生在 canvas 之外
+		if name == "mouseup" {
+			js.Global().Call("addEventListener", name, listener)
+		} else {
+			canvas.Call("addEventListener", name, listener, map[string]any{"passive": false})
+		}
+	}
+
+	// requestAnimationFrame 回调只传递时间戳，渲染在当前 goroutine 中进行
+	frames := make(chan float64, 1)
+	onFrame := js.FuncOf(func(this js.Value, args []js.Value) any {
+		select {
+		case frames <- args[0].Float():
+		default:
+		}
+		return nil
+	})
+
+	var frame *image.RGBA
+	lastTime := -1.0
+	for {
+		js.Global().Call("requestAnimationFrame", onFrame)
+		now := <-frames
+
+		mu.Lock()
+		ops := pending
+		pending = nil
+		mu.Unlock()
+		wasPlaying := v.Playing
+		for _, op := range ops {
+			op()
+		}
+		if lastTime >= 0 {
+			v.Advance((now - lastTime) / 1000)
+		}
+		lastTime = now
+
+		if v.NeedsRender() {
+			frame = v.Render()
+		} else if len(ops) == 0 && wasPlaying == v.Playing {
+			continue
+		}
+		if frame != nil {
+			target.Draw(frame)
+			v.drawCanvasOverlay(target.Context)
+		}
+	}
+}
+
+// drawCanvasOverlay 在 canvas 上叠加时间轴和状态文字
+func (v *InteractiveView) drawCanvasOverlay(ctx js.Value) {
+	width, height := v.Size()
+	top := float64(height - ScrubberHeight)
+	ctx.Set("fillStyle", "rgba(0, 0, 0, 0.63)")
+	ctx.Call("fillRect", 0, top, width, ScrubberHeight)
+	ctx.Set("fillStyle", "rgb(90, 160, 255)")
+	ctx.Call("fillRect", 0, top+6, v.Time*float64(width), 4)
+
+	state := "暂停"
+	if v.Playing {
+		state = "播放"
+	}
+	ctx.Set("font", "12px monospace")
+	ctx.Set("fillStyle", "white")
+	ctx.Call("fillText", fmt.Sprintf("t = %.3f  %s  距离 %.2f", v.Time, state, v.Orbit.Distance), 4, 14)
+}
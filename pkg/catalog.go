@@ -0,0 +1,274 @@
+package go3d
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// obliquityJ2000 J2000 历元的黄赤交角（弧度）
+const obliquityJ2000 = 23.4392911 * math.Pi / 180
+
+// CatalogStar 星表中的一颗星
+type CatalogStar struct {
+	ID         int     // 星表编号（HR 或 HIP）
+	Name       string  // 专有名称（可选）
+	RA         float64 // 赤经（弧度）
+	Dec        float64 // 赤纬（弧度）
+	Magnitude  float64 // 视星等
+	ColorIndex float64 // B-V 色指数
+}
+
+// Direction 星星在黄道坐标系中的单位方向（XY 为黄道面，与星历坐标一致）
+func (cs CatalogStar) Direction() Vector3 {
+	equatorial := NewVector3(
+		math.Cos(cs.Dec)*math.Cos(cs.RA),
+		math.Cos(cs.Dec)*math.Sin(cs.RA),
+		math.Sin(cs.Dec),
+	)
+	return RotationX(-obliquityJ2000).TransformVector(equatorial)
+}
+
+// ColorFromBV 根据 B-V 色指数估算星星颜色：蓝白色的热星到橙红色的冷星
+func ColorFromBV(bv float64) [3]float64 {
+	bv = math.Max(-0.4, math.Min(2.0, bv))
+
+	// 在若干参考色之间线性插值
+	stops := []struct {
+		bv    float64
+		color [3]float64
+	}{
+		{-0.4, [3]float64{0.61, 0.70, 1.00}},
+		{0.0, [3]float64{0.79, 0.84, 1.00}},
+		{0.4, [3]float64{0.97, 0.97, 1.00}},
+		{0.8, [3]float64{1.00, 0.93, 0.80}},
+		{1.2, [3]float64{1.00, 0.82, 0.60}},
+		{2.0, [3]float64{1.00, 0.65, 0.40}},
+	}
+	for i := 1; i < len(stops); i++ {
+		if bv <= stops[i].bv {
+			a, b := stops[i-1], stops[i]
+			s := (bv - a.bv) / (b.bv - a.bv)
+			return [3]float64{
+				a.color[0] + (b.color[0]-a.color[0])*s,
+				a.color[1] + (b.color[1]-a.color[1])*s,
+				a.color[2] + (b.color[2]-a.color[2])*s,
+			}
+		}
+	}
+	return stops[len(stops)-1].color
+}
+
+// ReadStarCatalogCSV 读取 CSV 星表（如 HYG 数据库）
+// 需要表头包含 ra（赤经，小时）、dec（赤纬，度）和 mag（视星等）列，
+// 可选 id/hr/hip（编号）、proper/name（名称）和 ci（B-V 色指数）列
+func ReadStarCatalogCSV(r io.Reader) ([]CatalogStar, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	column := func(names ...string) int {
+		for _, name := range names {
+			if i, ok := columns[name]; ok {
+				return i
+			}
+		}
+		return -1
+	}
+
+	raCol, decCol, magCol := column("ra"), column("dec"), column("mag", "vmag")
+	if raCol < 0 || decCol < 0 || magCol < 0 {
+		return nil, errors.New("星表: 缺少 ra、dec 或 mag 列")
+	}
+	idCol := column("id", "hr", "hip")
+	nameCol := column("proper", "name")
+	ciCol := column("ci", "bv", "b-v")
+
+	field := func(record []string, i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var stars []CatalogStar
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		ra, err1 := strconv.ParseFloat(field(record, raCol), 64)
+		dec, err2 := strconv.ParseFloat(field(record, decCol), 64)
+		mag, err3 := strconv.ParseFloat(field(record, magCol), 64)
+		if err1 != nil || err2 != nil || err3 != nil {
+			return nil, fmt.Errorf("星表: 第 %d 行的坐标或星等无效", line)
+		}
+
+		star := CatalogStar{
+			Name:      field(record, nameCol),
+			RA:        ra * math.Pi / 12,
+			Dec:       dec * math.Pi / 180,
+			Magnitude: mag,
+		}
+		if id, err := strconv.Atoi(field(record, idCol)); err == nil {
+			star.ID = id
+		}
+		if ci, err := strconv.ParseFloat(field(record, ciCol), 64); err == nil {
+			star.ColorIndex = ci
+		}
+		stars = append(stars, star)
+	}
+	return stars, nil
+}
+
+// ReadBrightStarCatalog 读取耶鲁亮星星表（BSC5）的定长格式数据
+// 没有 J2000 坐标的条目（新星、星团等）会被跳过
+func ReadBrightStarCatalog(r io.Reader) ([]CatalogStar, error) {
+	// slice 按 BSC5 ReadMe 的字节位置（从 1 开始，含两端）截取字段
+	slice := func(line string, from, to int) string {
+		if len(line) < from {
+			return ""
+		}
+		return strings.TrimSpace(line[from-1 : min(to, len(line))])
+	}
+	number := func(line string, from, to int) (float64, bool) {
+		v, err := strconv.ParseFloat(slice(line, from, to), 64)
+		return v, err == nil
+	}
+
+	var stars []CatalogStar
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		raH, ok1 := number(line, 76, 77)
+		raM, ok2 := number(line, 78, 79)
+		raS, ok3 := number(line, 80, 83)
+		decD, ok4 := number(line, 85, 86)
+		decM, ok5 := number(line, 87, 88)
+		decS, ok6 := number(line, 89, 90)
+		mag, ok7 := number(line, 103, 107)
+		if !(ok1 && ok2 && ok3 && ok4 && ok5 && ok6 && ok7) {
+			continue
+		}
+
+		dec := decD + decM/60 + decS/3600
+		if slice(line, 84, 84) == "-" {
+			dec = -dec
+		}
+
+		star := CatalogStar{
+			Name:      slice(line, 5, 14),
+			RA:        (raH + raM/60 + raS/3600) * math.Pi / 12,
+			Dec:       dec * math.Pi / 180,
+			Magnitude: mag,
+		}
+		if id, err := strconv.Atoi(slice(line, 1, 4)); err == nil {
+			star.ID = id
+		}
+		if bv, ok := number(line, 110, 114); ok {
+			star.ColorIndex = bv
+		}
+		stars = append(stars, star)
+	}
+	return stars, scanner.Err()
+}
+
+// NewCatalogStarField 由星表创建星空场，星星位于半径为 distance 的天球上
+// 亮度由星等决定，颜色由 B-V 色指数决定
+func NewCatalogStarField(stars []CatalogStar, distance float64) *StarField {
+	sf := &StarField{
+		Stars:              make([]Star, len(stars)),
+		PointSize:          2.5,
+		ConstellationColor: [3]float64{0.3, 0.4, 0.6},
+		ConstellationWidth: 0.8,
+	}
+	for i, cs := range stars {
+		star := NewStar(cs.Direction().Scale(distance), 0.05, ColorFromBV(cs.ColorIndex))
+		star.Magnitude = cs.Magnitude
+		star.ID = cs.ID
+		star.Name = cs.Name
+		sf.Stars[i] = *star
+	}
+	return sf
+}
+
+// LoadStarCatalog 从文件加载星表并创建星空场
+// .csv 文件按 ReadStarCatalogCSV 解析，其余按 BSC5 定长格式解析
+func LoadStarCatalog(filename string, distance float64) (*StarField, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var stars []CatalogStar
+	if strings.EqualFold(filepath.Ext(filename), ".csv") {
+		stars, err = ReadStarCatalogCSV(file)
+	} else {
+		stars, err = ReadBrightStarCatalog(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewCatalogStarField(stars, distance), nil
+}
+
+// Constellation 星座连线，按星表编号引用星星
+type Constellation struct {
+	Name  string
+	Lines [][2]int // 每条连线两端星星的星表编号
+}
+
+// AddConstellation 添加星座连线
+func (sf *StarField) AddConstellation(name string, lines [][2]int) *StarField {
+	sf.Constellations = append(sf.Constellations, Constellation{Name: name, Lines: lines})
+	return sf
+}
+
+// renderConstellations 绘制星座连线，找不到编号的连线会被跳过
+func (sf *StarField) renderConstellations(renderer *Renderer) {
+	if len(sf.Constellations) == 0 {
+		return
+	}
+
+	positions := make(map[int]Vector3, len(sf.Stars))
+	for _, star := range sf.Stars {
+		if star.ID != 0 {
+			positions[star.ID] = star.Position
+		}
+	}
+
+	renderer.Context.SetSourceRGB(sf.ConstellationColor[0], sf.ConstellationColor[1], sf.ConstellationColor[2])
+	renderer.Context.SetLineWidth(sf.ConstellationWidth)
+	for _, constellation := range sf.Constellations {
+		for _, line := range constellation.Lines {
+			a, okA := positions[line[0]]
+			b, okB := positions[line[1]]
+			if !okA || !okB {
+				continue
+			}
+			renderer.tracePolyline([]Vector3{a, b})
+		}
+	}
+	renderer.Context.Stroke()
+}
@@ -1,6 +1,10 @@
 package go3d
 
-import "math"
+import (
+	"math"
+	"slices"
+	"sync"
+)
 
 // Planet 行星
 type Planet struct {
@@ -16,6 +20,25 @@ type Planet struct {
 	HasMoon       bool
 	HasRings      bool
 	RingColors    [][3]float64
+
+	Eccentricity  float64 // 轨道偏心率
+	Inclination   float64 // 轨道倾角（弧度）
+	AscendingNode float64 // 升交点经度（弧度）
+	ArgPeriapsis  float64 // 近心点幅角（弧度）
+	OrbitPhase    float64 // t = 0 时的平近点角（弧度）
+
+	AxialTilt   float64 // 自转轴倾角（弧度，相对于轨道平面法线，绕 X 轴倾斜）
+	SiderealDay float64 // 恒星日：自转一周所需的时间（与 t 同单位，负值为逆向自转；0 表示使用 RotationSpeed）
+
+	Texture *Texture // 表面纹理（等距圆柱投影贴图，设置后优先于纯色和渐变）
+
+	NormalMap *Texture // 法线贴图（等距圆柱投影，见 Mesh.NormalMap）
+	BumpMap   *Texture // 凹凸贴图（等距圆柱投影，见 Mesh.BumpMap）
+	BumpScale float64  // 凹凸强度，0 表示 1
+
+	mesh     meshCache // 本体球体（未变换，每帧只更新模型矩阵）
+	moonMesh meshCache
+	ringMesh meshCache
 }
 
 // NewPlanet 创建行星
@@ -40,6 +63,25 @@ func (p *Planet) SetGradient(color1, color2 [3]float64) *Planet {
 	return p
 }
 
+// SetTexture 设置表面纹理
+func (p *Planet) SetTexture(texture *Texture) *Planet {
+	p.Texture = texture
+	return p
+}
+
+// SetNormalMap 设置法线贴图，表面细节随光照方向变化而不增加三角形
+func (p *Planet) SetNormalMap(normalMap *Texture) *Planet {
+	p.NormalMap = normalMap
+	return p
+}
+
+// SetBumpMap 设置凹凸贴图及其强度（陨石坑、山脉等地形起伏）
+func (p *Planet) SetBumpMap(bumpMap *Texture, scale float64) *Planet {
+	p.BumpMap = bumpMap
+	p.BumpScale = scale
+	return p
+}
+
 // AddMoon 添加月球
 func (p *Planet) AddMoon() *Planet {
 	p.HasMoon = true
@@ -53,34 +95,106 @@ func (p *Planet) AddRings(colors [][3]float64) *Planet {
 	return p
 }
 
+// SetOrbitElements 设置椭圆轨道根数（角度均为弧度）
+func (p *Planet) SetOrbitElements(eccentricity, inclination, ascendingNode, argPeriapsis float64) *Planet {
+	p.Eccentricity = eccentricity
+	p.Inclination = inclination
+	p.AscendingNode = ascendingNode
+	p.ArgPeriapsis = argPeriapsis
+	return p
+}
+
+// SetRotation 设置自转轴倾角（弧度）和恒星日
+func (p *Planet) SetRotation(axialTilt, siderealDay float64) *Planet {
+	p.AxialTilt = axialTilt
+	p.SiderealDay = siderealDay
+	return p
+}
+
+// SpinAngle 获取时间 t 的自转角
+func (p *Planet) SpinAngle(t float64) float64 {
+	if p.SiderealDay != 0 {
+		return 2 * math.Pi * t / p.SiderealDay
+	}
+	return t * p.RotationSpeed * math.Pi
+}
+
+// SpinAxis 获取自转轴方向（倾角为 0 时垂直于轨道参考平面）
+func (p *Planet) SpinAxis() Vector3 {
+	return RotationX(p.AxialTilt).TransformVector(NewVector3(0, 0, 1))
+}
+
+// BodyTransform 获取本体变换：平移到轨道位置，倾斜自转轴，再绕自转轴自转
+// 变换后本体坐标系的 Z 轴即为自转轴
+func (p *Planet) BodyTransform(t float64) Matrix4 {
+	tilt := QuaternionFromAxisAngle(NewVector3(1, 0, 0), p.AxialTilt)
+	spin := QuaternionFromAxisAngle(NewVector3(0, 0, 1), p.SpinAngle(t))
+	return NewTransform().
+		SetPosition(p.GetPosition(t)).
+		SetRotation(tilt.Multiply(spin)).
+		Matrix()
+}
+
+// OrbitalElements 获取行星的轨道根数，OrbitRadius 作为半长轴
+func (p *Planet) OrbitalElements() OrbitalElements {
+	return OrbitalElements{
+		SemiMajorAxis: p.OrbitRadius,
+		Eccentricity:  p.Eccentricity,
+		Inclination:   p.Inclination,
+		AscendingNode: p.AscendingNode,
+		ArgPeriapsis:  p.ArgPeriapsis,
+	}
+}
+
+// KeplerOrbit 获取行星的开普勒轨道，OrbitSpeed 为每单位时间转过的半圈数
+func (p *Planet) KeplerOrbit() *KeplerOrbit {
+	period := 0.0
+	if p.OrbitSpeed != 0 {
+		period = 2 / p.OrbitSpeed
+	}
+	orbit := NewKeplerOrbit(p.OrbitalElements(), period)
+	orbit.MeanAnomalyAtEpoch = p.OrbitPhase
+	return orbit
+}
+
 // GetPosition 获取行星在指定时间的位置
+// 按开普勒方程运动：近日点附近更快，远日点附近更慢；偏心率为 0 时为匀速圆周运动
 func (p *Planet) GetPosition(t float64) Vector3 {
-	angle := t * p.OrbitSpeed * math.Pi
-	x := p.OrbitRadius * math.Cos(angle)
-	y := p.OrbitRadius * math.Sin(angle)
-	z := 0.0 // 行星在XY平面上运动
-	return NewVector3(x, y, z)
+	position, _ := p.GetState(t)
+	return position
+}
+
+// GetState 获取行星在指定时间的位置和速度
+func (p *Planet) GetState(t float64) (position, velocity Vector3) {
+	if p.OrbitSpeed == 0 {
+		eccAnomaly := SolveKepler(p.OrbitPhase, p.Eccentricity)
+		return p.OrbitalElements().PositionAtEccentricAnomaly(eccAnomaly), NewVector3(0, 0, 0)
+	}
+	return p.KeplerOrbit().StateAt(t)
 }
 
 // Render 渲染行星
 func (p *Planet) Render(renderer *Renderer, t float64) {
 	pos := p.GetPosition(t)
 
-	// 创建行星球体
-	planetMesh := CreateSphere(p.Radius, 16, 16)
-
-	// 应用变换
-	transform := Identity()
-	transform = transform.Multiply(Translation(pos.X, pos.Y, pos.Z))
-	transform = transform.Multiply(RotationY(t * p.RotationSpeed * math.Pi))
+	planetMesh := p.mesh.sphere(p.Radius, 16)
+	if p.NormalMap != nil || p.BumpMap != nil {
+		// 缓存的球体可能被多个渲染线程共享，在浅拷贝上设置贴图
+		detailed := *planetMesh
+		detailed.NormalMap, detailed.BumpMap, detailed.BumpScale = p.NormalMap, p.BumpMap, p.BumpScale
+		planetMesh = &detailed
+	}
 
-	transformedPlanet := planetMesh.Transform(transform)
+	// 应用变换：球体的两极在 Y 轴上，先转到本体的 Z 轴（自转轴）
+	transform := p.BodyTransform(t).Multiply(RotationX(math.Pi / 2))
 
 	// 渲染行星
-	if p.UseGradient {
-		renderer.DrawMeshWithGradient(transformedPlanet, p.Color, p.GradientColor)
+	if p.Texture != nil {
+		renderer.DrawTexturedMeshTransformed(planetMesh, transform, p.Texture, p.Color)
+	} else if p.UseGradient {
+		renderer.DrawMeshWithGradientTransformed(planetMesh, transform, p.Color, p.GradientColor)
 	} else {
-		renderer.DrawMesh(transformedPlanet, p.Color)
+		renderer.DrawMeshTransformed(planetMesh, transform, p.Color)
 	}
 
 	// 渲染标签
@@ -99,20 +213,53 @@ func (p *Planet) Render(renderer *Renderer, t float64) {
 	}
 }
 
-// renderMoon 渲染月球
-func (p *Planet) renderMoon(renderer *Renderer, planetPos Vector3, t float64) {
+// moonPosition 计算月球位置
+func (p *Planet) moonPosition(planetPos Vector3, t float64) Vector3 {
 	moonOrbitRadius := p.Radius * 2
 	moonAngle := t * 8.0 * math.Pi
 
-	moonX := planetPos.X + moonOrbitRadius*math.Cos(moonAngle)
-	moonY := planetPos.Y + moonOrbitRadius*math.Sin(moonAngle)
-	moonZ := planetPos.Z + math.Sin(moonAngle)*0.1
+	return NewVector3(
+		planetPos.X+moonOrbitRadius*math.Cos(moonAngle),
+		planetPos.Y+moonOrbitRadius*math.Sin(moonAngle),
+		planetPos.Z+math.Sin(moonAngle)*0.1,
+	)
+}
+
+// moonRadius 月球半径
+func (p *Planet) moonRadius() float64 {
+	return p.Radius * 0.3
+}
+
+// ShadowSpheres 行星及其月球的阴影遮挡体
+func (p *Planet) ShadowSpheres(t float64) []SphereOccluder {
+	pos := p.GetPosition(t)
+	spheres := []SphereOccluder{{Center: pos, Radius: p.Radius}}
+	if p.HasMoon {
+		spheres = append(spheres, SphereOccluder{Center: p.moonPosition(pos, t), Radius: p.moonRadius()})
+	}
+	return spheres
+}
+
+// WorldBounds 行星（包括光环和月球）在时间 t 的包围盒
+func (p *Planet) WorldBounds(t float64) AABB {
+	pos := p.GetPosition(t)
+	radius := p.Radius
+	if p.HasRings && len(p.RingColors) > 0 {
+		radius = p.Radius*1.4 + float64(len(p.RingColors))*p.Radius*0.3
+	}
+	box := sphereBounds(pos, radius)
+	if p.HasMoon {
+		box = box.union(sphereBounds(p.moonPosition(pos, t), p.moonRadius()))
+	}
+	return box
+}
+
+// renderMoon 渲染月球
+func (p *Planet) renderMoon(renderer *Renderer, planetPos Vector3, t float64) {
+	moonPos := p.moonPosition(planetPos, t)
 
-	moon := CreateSphere(p.Radius*0.3, 10, 10)
-	transform := Identity()
-	transform = transform.Multiply(Translation(moonX, moonY, moonZ))
-	transformedMoon := moon.Transform(transform)
-	renderer.DrawMesh(transformedMoon, [3]float64{0.95, 0.95, 0.95})
+	moon := p.moonMesh.sphere(p.moonRadius(), 10)
+	renderer.DrawMeshTransformed(moon, NewTransform().SetPosition(moonPos).Matrix(), [3]float64{0.95, 0.95, 0.95})
 }
 
 // renderRings 渲染光环
@@ -121,17 +268,54 @@ func (p *Planet) renderRings(renderer *Renderer, planetPos Vector3, t float64) {
 		return
 	}
 
-	baseRadius := p.Radius * 1.4
-	for i, color := range p.RingColors {
-		radius := baseRadius + float64(i)*p.Radius*0.3
-		ring := CreateTorus(radius, 0.02, 48, 6)
+	var transform Matrix4
+	if p.AxialTilt != 0 {
+		// 光环位于赤道面：圆环默认在 XY 平面，与本体坐标系的赤道面一致
+		transform = p.BodyTransform(t)
+	} else {
+		transform = NewTransform().
+			SetPosition(planetPos).
+			SetRotation(QuaternionFromAxisAngle(NewVector3(1, 0, 0), math.Pi/2+0.3)).
+			Rotate(QuaternionFromAxisAngle(NewVector3(0, 1, 0), t*math.Pi)).
+			Matrix()
+	}
+
+	renderer.DrawMeshTransformed(p.ringMesh.rings(p.Radius, p.RingColors), transform, p.RingColors[0])
+}
 
-		transform := Identity()
-		transform = transform.Multiply(Translation(planetPos.X, planetPos.Y, planetPos.Z))
-		transform = transform.Multiply(RotationX(math.Pi/2 + 0.3))
-		transform = transform.Multiply(RotationY(t * math.Pi))
+// meshCache 缓存天体未变换的网格，半径、细分数或光环颜色改变时才重新生成。
+// 多个渲染线程共享同一个场景时由互斥锁保护，生成后的网格只读
+type meshCache struct {
+	mu       sync.Mutex
+	mesh     *Mesh
+	radius   float64
+	segments int
+	colors   [][3]float64
+}
+
+// sphere 返回半径 radius、经纬方向各 segments 段的球体
+func (c *meshCache) sphere(radius float64, segments int) *Mesh {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.mesh == nil || c.radius != radius || c.segments != segments {
+		c.mesh = CreateSphere(radius, segments, segments)
+		c.radius, c.segments = radius, segments
+	}
+	return c.mesh
+}
 
-		transformedRing := ring.Transform(transform)
-		renderer.DrawMesh(transformedRing, color)
+// rings 返回半径 radius 的行星的光环：环带从内到外依次使用 colors，外缘逐渐变淡
+func (c *meshCache) rings(radius float64, colors [][3]float64) *Mesh {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.mesh == nil || c.radius != radius || !slices.Equal(c.colors, colors) {
+		innerRadius := radius * 1.4
+		outerRadius := innerRadius + float64(len(colors))*radius*0.3
+		c.mesh = NewRingDisc(innerRadius, outerRadius).
+			SetColors(colors).
+			SetAlphaGradient(0.9, 0.5).
+			Mesh()
+		c.radius, c.colors = radius, slices.Clone(colors)
 	}
+	return c.mesh
 }
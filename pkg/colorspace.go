@@ -0,0 +1,72 @@
+package go3d
+
+import "math"
+
+// ToneMapping 色调映射方式，把超过 1 的高动态范围光照压缩到可显示范围
+type ToneMapping int
+
+const (
+	ToneMapNone     ToneMapping = iota // 不做映射，直接截断到 1
+	ToneMapReinhard                    // Reinhard：x / (1 + x)
+	ToneMapACES                        // ACES 电影曲线（Narkowicz 近似）
+)
+
+// SRGBToLinear 将 sRGB 颜色分量转换为线性空间
+func SRGBToLinear(c float64) float64 {
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+// LinearToSRGB 将线性空间颜色分量转换为 sRGB
+func LinearToSRGB(c float64) float64 {
+	c = math.Max(0, math.Min(1, c))
+	if c <= 0.0031308 {
+		return c * 12.92
+	}
+	return 1.055*math.Pow(c, 1/2.4) - 0.055
+}
+
+// Apply 对单个颜色分量做色调映射，结果在 0-1 之间
+func (tm ToneMapping) Apply(x float64) float64 {
+	x = math.Max(0, x)
+	switch tm {
+	case ToneMapReinhard:
+		return x / (1 + x)
+	case ToneMapACES:
+		return math.Min(1, (x*(2.51*x+0.03))/(x*(2.43*x+0.59)+0.14))
+	default:
+		return math.Min(1, x)
+	}
+}
+
+// SetLinearLighting 设置是否在线性空间计算光照
+// 启用后基础色和光源颜色先从 sRGB 解码，光照结果经色调映射后再编码回 sRGB
+func (r *Renderer) SetLinearLighting(enabled bool) {
+	r.LinearLighting = enabled
+}
+
+// SetToneMapping 设置光照结果的色调映射方式
+func (r *Renderer) SetToneMapping(mapping ToneMapping) {
+	r.ToneMapping = mapping
+}
+
+// decodeColor 按光照空间设置把 sRGB 颜色转换为参与光照计算的颜色
+func (r *Renderer) decodeColor(c [3]float64) [3]float64 {
+	if !r.LinearLighting {
+		return c
+	}
+	return [3]float64{SRGBToLinear(c[0]), SRGBToLinear(c[1]), SRGBToLinear(c[2])}
+}
+
+// encodeColor 对光照结果做色调映射，并按光照空间设置转换回 sRGB
+func (r *Renderer) encodeColor(c [3]float64) [3]float64 {
+	for k := range 3 {
+		c[k] = r.ToneMapping.Apply(c[k])
+		if r.LinearLighting {
+			c[k] = LinearToSRGB(c[k])
+		}
+	}
+	return c
+}
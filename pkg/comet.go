@@ -0,0 +1,150 @@
+package go3d
+
+import "math"
+
+// Comet 彗星：高偏心率轨道上的彗核，彗尾始终背向太阳（原点），在近日点附近变长变亮
+type Comet struct {
+	Name          string
+	NameCN        string
+	NucleusRadius float64
+	Color         [3]float64 // 彗核颜色
+	TailColor     [3]float64 // 彗尾颜色
+	Orbit         *KeplerOrbit
+
+	TailLength    float64 // 近日点处的彗尾长度
+	TailParticles int     // 彗尾粒子数
+	TailSpread    float64 // 彗尾末端的横向扩散半径（相对于彗尾长度）
+	TailFlow      float64 // 粒子沿彗尾流动的速度（每单位时间流过的彗尾长度比例）
+	ParticleSize  float64 // 彗尾头部的粒子半径（像素）
+	Seed          int64   // 粒子分布的随机种子
+
+	nucleus meshCache
+}
+
+// NewComet 创建彗星
+func NewComet(name, nameCN string, elements OrbitalElements, period float64) *Comet {
+	return &Comet{
+		Name:          name,
+		NameCN:        nameCN,
+		NucleusRadius: 0.08,
+		Color:         [3]float64{0.9, 0.95, 1.0},
+		TailColor:     [3]float64{0.55, 0.8, 1.0},
+		Orbit:         NewKeplerOrbit(elements, period),
+		TailLength:    2.5,
+		TailParticles: 120,
+		TailSpread:    0.12,
+		TailFlow:      1.5,
+		ParticleSize:  3.0,
+		Seed:          1,
+	}
+}
+
+// NewHalleyComet 创建哈雷彗星预设（逆行轨道）
+// 真实偏心率为 0.967，按默认太阳系的显示比例近日点会落入太阳内部，这里适当减小
+func NewHalleyComet() *Comet {
+	deg := math.Pi / 180
+	elements := OrbitalElements{
+		SemiMajorAxis: 10.0,
+		Eccentricity:  0.85,
+		Inclination:   162.26 * deg,
+		AscendingNode: 58.42 * deg,
+		ArgPeriapsis:  111.33 * deg,
+	}
+	return NewComet("Halley", "哈雷彗星", elements, 4.0)
+}
+
+// GetPosition 获取彗核在指定时间的位置
+func (c *Comet) GetPosition(t float64) Vector3 {
+	position, _ := c.Orbit.StateAt(t)
+	return position
+}
+
+// TailDirection 彗尾方向：从太阳指向彗核
+func (c *Comet) TailDirection(t float64) Vector3 {
+	pos := c.GetPosition(t)
+	if pos.Length() == 0 {
+		return NewVector3(1, 0, 0)
+	}
+	return pos.Normalize()
+}
+
+// TailScale 彗尾强度：近日点为 1，按与太阳距离的平方反比衰减
+func (c *Comet) TailScale(t float64) float64 {
+	e := c.Orbit.Elements.clampedEccentricity()
+	perihelion := c.Orbit.Elements.SemiMajorAxis * (1 - e)
+	distance := c.GetPosition(t).Length()
+	if distance <= perihelion || distance == 0 {
+		return 1
+	}
+	ratio := perihelion / distance
+	return ratio * ratio
+}
+
+// WorldBounds 彗核在时间 t 的包围盒，按近日点处的彗尾长度向各方向扩展
+func (c *Comet) WorldBounds(t float64) AABB {
+	return sphereBounds(c.GetPosition(t), math.Max(c.NucleusRadius, c.TailLength))
+}
+
+// Render 渲染彗星
+func (c *Comet) Render(renderer *Renderer, t float64) {
+	pos := c.GetPosition(t)
+
+	c.renderTail(renderer, pos, t)
+
+	nucleus := c.nucleus.sphere(c.NucleusRadius, 10)
+	renderer.DrawMeshTransformed(nucleus, Translation(pos.X, pos.Y, pos.Z), c.Color)
+
+	if c.NameCN != "" {
+		labelPos := NewVector3(pos.X, pos.Y+c.NucleusRadius+0.3, pos.Z)
+		NewLabel3D(labelPos, c.NameCN, [3]float64{1, 1, 1}).Render(renderer, t)
+	}
+}
+
+// renderTail 以半透明粒子渲染彗尾
+// 粒子的横向偏移由种子决定，沿彗尾的位置随时间流动，因此每一帧的结果都是确定的
+func (c *Comet) renderTail(renderer *Renderer, pos Vector3, t float64) {
+	scale := c.TailScale(t)
+	length := c.TailLength * scale
+	if length <= 0 || c.TailParticles <= 0 {
+		return
+	}
+
+	// 构建垂直于彗尾方向的正交基
+	dir := c.TailDirection(t)
+	side := dir.Cross(NewVector3(0, 0, 1))
+	if side.Length() < 1e-6 {
+		side = dir.Cross(NewVector3(1, 0, 0))
+	}
+	side = side.Normalize()
+	up := dir.Cross(side)
+
+	rng := NewRandom(c.Seed, 0)
+	renderer.DrawOverlay(func() {
+		renderer.Context.Save()
+		defer renderer.Context.Restore()
+
+		for range c.TailParticles {
+			offset := rng.Float64()
+			angle := rng.Float64() * 2 * math.Pi
+			radius := math.Sqrt(rng.Float64())
+
+			// 0 为彗核，1 为彗尾末端
+			u := math.Mod(offset+t*c.TailFlow, 1)
+			spread := c.TailSpread * length * u * radius
+			p := pos.Add(dir.Scale(u * length)).
+				Add(side.Scale(math.Cos(angle) * spread)).
+				Add(up.Scale(math.Sin(angle) * spread))
+
+			x, y, z := renderer.ProjectToScreen(p)
+			if z < -1 || z > 1 {
+				continue
+			}
+
+			alpha := (1 - u) * math.Min(1, 0.3+scale)
+			size := c.ParticleSize * (1 - 0.7*u)
+			renderer.Context.SetSourceRGBA(c.TailColor[0], c.TailColor[1], c.TailColor[2], alpha*0.6)
+			renderer.Context.Arc(x, y, size, 0, 2*math.Pi)
+			renderer.Context.Fill()
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package go3d
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// contactSheetGap 联系表中缩略图之间和四周的间距（像素）
+const contactSheetGap = 4
+
+// ContactSheet 把一组图片按 columns 列排成缩略图网格（联系表），缩略图宽度为 thumbWidth，
+// 高度按第一张图片的宽高比计算；背景为深灰色
+func ContactSheet(images []image.Image, columns, thumbWidth int) *image.RGBA {
+	if len(images) == 0 || columns <= 0 || thumbWidth <= 0 {
+		return image.NewRGBA(image.Rect(0, 0, 0, 0))
+	}
+	first := images[0].Bounds()
+	thumbHeight := max(1, thumbWidth*first.Dy()/max(1, first.Dx()))
+	rows := (len(images) + columns - 1) / columns
+
+	sheet := image.NewRGBA(image.Rect(0, 0,
+		columns*(thumbWidth+contactSheetGap)+contactSheetGap,
+		rows*(thumbHeight+contactSheetGap)+contactSheetGap))
+	draw.Draw(sheet, sheet.Bounds(), image.NewUniform(color.RGBA{32, 32, 32, 255}), image.Point{}, draw.Src)
+
+	for i, img := range images {
+		if img == nil {
+			continue
+		}
+		x := contactSheetGap + i%columns*(thumbWidth+contactSheetGap)
+		y := contactSheetGap + i/columns*(thumbHeight+contactSheetGap)
+		thumb := resizeImage(img, thumbWidth, thumbHeight)
+		draw.Draw(sheet, image.Rect(x, y, x+thumbWidth, y+thumbHeight), thumb, image.Point{}, draw.Over)
+	}
+	return sheet
+}
+
+// resizeImage 按区域平均缩放图片（缩小时每个目标像素取覆盖的源像素平均值）
+func resizeImage(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*bounds.Dy()/height)
+		for x := range width {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := max(x0+1, bounds.Min.X+(x+1)*bounds.Dx()/width)
+
+			var sum [4]uint32
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					r, g, b, a := src.At(sx, sy).RGBA()
+					sum[0] += r >> 8
+					sum[1] += g >> 8
+					sum[2] += b >> 8
+					sum[3] += a >> 8
+				}
+			}
+			n := uint32((y1 - y0) * (x1 - x0))
+			offset := y*dst.Stride + x*4
+			for k := range 4 {
+				dst.Pix[offset+k] = uint8((sum[k] + n/2) / n)
+			}
+		}
+	}
+	return dst
+}
+
+// contactSheetSlots 从待渲染的帧中均匀选出 count 帧（包含首尾），返回帧号到缩略图位置的映射
+func contactSheetSlots(frames []int, count int) map[int]int {
+	slots := make(map[int]int)
+	if len(frames) == 0 || count <= 0 {
+		return slots
+	}
+	count = min(count, len(frames))
+	for i := range count {
+		index := 0
+		if count > 1 {
+			index = i * (len(frames) - 1) / (count - 1)
+		}
+		slots[frames[index]] = i
+	}
+	return slots
+}
@@ -0,0 +1,138 @@
+package go3d
+
+import "math"
+
+// CrossSection 剖切动画：平面 Normal·p = Offset 正侧（Normal 指向的一侧）的几何被隐藏，
+// 切口用端面填充并描出截面轮廓。用 Sweep 让平面随时间扫过整个网格，得到类似 CT 扫描的逐层剖切效果
+type CrossSection struct {
+	Mesh   *Mesh
+	Color  [3]float64
+	Normal Vector3 // 剖切平面的法线（扫描方向），不必是单位向量
+	Offset float64 // 平面沿单位法线到原点的有向距离
+
+	Caps         bool       // 是否填充切口
+	CapColor     [3]float64 // 端面颜色
+	ContourColor [3]float64 // 截面轮廓颜色
+	ContourWidth float64    // 截面轮廓线宽（像素），0 不描轮廓
+
+	ShowPlane    bool // 是否以半透明矩形显示剖切平面
+	PlaneColor   [3]float64
+	PlaneOpacity float64
+}
+
+// NewCrossSection 创建沿 normal 方向剖切的网格，平面初始位于网格最前端（整个网格可见）
+func NewCrossSection(mesh *Mesh, color [3]float64, normal Vector3) *CrossSection {
+	cs := &CrossSection{
+		Mesh:         mesh,
+		Color:        color,
+		Normal:       normal,
+		Caps:         true,
+		CapColor:     [3]float64{0.95, 0.35, 0.25},
+		ContourColor: [3]float64{1, 1, 0.6},
+		ContourWidth: 2,
+		PlaneColor:   [3]float64{0.4, 0.7, 1},
+		PlaneOpacity: 0.2,
+	}
+	_, cs.Offset = cs.Range()
+	return cs
+}
+
+// SetOffset 设置剖切平面的位置
+func (cs *CrossSection) SetOffset(offset float64) *CrossSection {
+	cs.Offset = offset
+	return cs
+}
+
+// SetShowPlane 设置是否显示剖切平面
+func (cs *CrossSection) SetShowPlane(show bool) *CrossSection {
+	cs.ShowPlane = show
+	return cs
+}
+
+// Plane 当前的剖切平面，法线为零向量时取 +Y
+func (cs *CrossSection) Plane() Plane {
+	normal := cs.Normal
+	if normal.Length() == 0 {
+		normal = NewVector3(0, 1, 0)
+	}
+	return Plane{Normal: normal.Normalize(), D: cs.Offset}
+}
+
+// Range 网格沿法线方向的范围，Offset 取 lo 时整个网格被隐藏，取 hi 时完全可见
+func (cs *CrossSection) Range() (lo, hi float64) {
+	if cs.Mesh == nil || len(cs.Mesh.Triangles) == 0 {
+		return 0, 0
+	}
+	normal := cs.Plane().Normal
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, tri := range cs.Mesh.Triangles {
+		for _, v := range [3]Vector3{tri.V0, tri.V1, tri.V2} {
+			d := normal.Dot(v)
+			lo, hi = math.Min(lo, d), math.Max(hi, d)
+		}
+	}
+	return lo, hi
+}
+
+// Sweep 在时间线上添加轨道，使平面在 [start, end] 内从网格最后端扫到最前端（网格逐层出现）；
+// reverse 为 true 时反向扫描（网格逐层消失）
+func (cs *CrossSection) Sweep(timeline *Timeline, start, end float64, reverse bool, easing func(float64) float64) *CrossSection {
+	lo, hi := cs.Range()
+	if reverse {
+		lo, hi = hi, lo
+	}
+	timeline.AddTrack(NewFloatTrack(&cs.Offset).AddKeyframe(start, lo, easing).AddKeyframe(end, hi, nil))
+	return cs
+}
+
+// Render 绘制平面负侧的部分、端面、截面轮廓和剖切平面
+func (cs *CrossSection) Render(renderer *Renderer, t float64) {
+	if cs.Mesh == nil || len(cs.Mesh.Triangles) == 0 {
+		return
+	}
+	plane := cs.Plane()
+	_, kept, sections := cs.Mesh.SliceByPlane(plane, false)
+	renderer.DrawMesh(kept, cs.Color)
+
+	if cs.Caps {
+		front, caps := NewMesh(), NewMesh()
+		(&Mesh{}).addSliceCaps(front, caps, plane, sections)
+		renderer.DrawMesh(caps, cs.CapColor)
+	}
+	if cs.ContourWidth > 0 {
+		for _, section := range sections {
+			renderer.DrawPolyline3D(section, cs.ContourColor, cs.ContourWidth)
+		}
+	}
+	if cs.ShowPlane {
+		renderer.DrawMesh(cs.planeMesh(plane), cs.PlaneColor)
+	}
+}
+
+// planeMesh 覆盖网格范围的半透明平面矩形，两面都可见
+func (cs *CrossSection) planeMesh(plane Plane) *Mesh {
+	box := cs.Mesh.BoundingBox()
+	center := plane.Project(box.Min.Add(box.Max).Scale(0.5))
+	half := box.Max.Sub(box.Min).Length() * 0.55
+	u, v := plane.basis()
+	corners := [4]Vector3{
+		center.Add(u.Scale(-half)).Add(v.Scale(-half)),
+		center.Add(u.Scale(half)).Add(v.Scale(-half)),
+		center.Add(u.Scale(half)).Add(v.Scale(half)),
+		center.Add(u.Scale(-half)).Add(v.Scale(half)),
+	}
+	mesh := NewMesh()
+	for _, tri := range [][3]int{{0, 1, 2}, {0, 2, 3}, {0, 2, 1}, {0, 3, 2}} {
+		mesh.AddTriangle(Triangle{V0: corners[tri[0]], V1: corners[tri[1]], V2: corners[tri[2]]})
+		mesh.FaceAlpha = append(mesh.FaceAlpha, cs.PlaneOpacity)
+	}
+	return mesh
+}
+
+// WorldBounds 整个网格的包围盒（与平面位置无关，扫描过程中取景保持不变）
+func (cs *CrossSection) WorldBounds(t float64) AABB {
+	if cs.Mesh == nil {
+		return AABB{}
+	}
+	return cs.Mesh.BoundingBox()
+}
@@ -0,0 +1,188 @@
+package go3d
+
+import "math"
+
+// Curve3D 3D参数曲线接口，t 取值范围为 [0, 1]
+type Curve3D interface {
+	Point(t float64) Vector3
+}
+
+// CubicBezier 三次贝塞尔曲线
+type CubicBezier struct {
+	P0, P1, P2, P3 Vector3
+}
+
+// NewCubicBezier 创建三次贝塞尔曲线
+func NewCubicBezier(p0, p1, p2, p3 Vector3) *CubicBezier {
+	return &CubicBezier{P0: p0, P1: p1, P2: p2, P3: p3}
+}
+
+// Point 计算曲线上的点
+func (b *CubicBezier) Point(t float64) Vector3 {
+	u := 1 - t
+	return b.P0.Scale(u * u * u).
+		Add(b.P1.Scale(3 * u * u * t)).
+		Add(b.P2.Scale(3 * u * t * t)).
+		Add(b.P3.Scale(t * t * t))
+}
+
+// CatmullRomSpline Catmull-Rom 样条，曲线经过所有控制点
+type CatmullRomSpline struct {
+	Points []Vector3
+	Closed bool // 是否首尾相连
+}
+
+// NewCatmullRomSpline 创建 Catmull-Rom 样条
+func NewCatmullRomSpline(points []Vector3, closed bool) *CatmullRomSpline {
+	return &CatmullRomSpline{Points: points, Closed: closed}
+}
+
+// Point 计算曲线上的点
+func (c *CatmullRomSpline) Point(t float64) Vector3 {
+	i, localT, ok := splineSegment(len(c.Points), c.Closed, t)
+	if !ok {
+		return singlePoint(c.Points)
+	}
+
+	p0 := splinePoint(c.Points, i-1, c.Closed)
+	p1 := splinePoint(c.Points, i, c.Closed)
+	p2 := splinePoint(c.Points, i+1, c.Closed)
+	p3 := splinePoint(c.Points, i+2, c.Closed)
+
+	t2 := localT * localT
+	t3 := t2 * localT
+	return p1.Scale(2).
+		Add(p2.Sub(p0).Scale(localT)).
+		Add(p0.Scale(2).Sub(p1.Scale(5)).Add(p2.Scale(4)).Sub(p3).Scale(t2)).
+		Add(p1.Scale(3).Sub(p0).Sub(p2.Scale(3)).Add(p3).Scale(t3)).
+		Scale(0.5)
+}
+
+// BSpline 均匀三次 B 样条，曲线逼近（不一定经过）控制点
+type BSpline struct {
+	Points []Vector3
+	Closed bool
+}
+
+// NewBSpline 创建均匀三次 B 样条
+func NewBSpline(points []Vector3, closed bool) *BSpline {
+	return &BSpline{Points: points, Closed: closed}
+}
+
+// Point 计算曲线上的点
+func (b *BSpline) Point(t float64) Vector3 {
+	i, localT, ok := splineSegment(len(b.Points), b.Closed, t)
+	if !ok {
+		return singlePoint(b.Points)
+	}
+
+	p0 := splinePoint(b.Points, i-1, b.Closed)
+	p1 := splinePoint(b.Points, i, b.Closed)
+	p2 := splinePoint(b.Points, i+1, b.Closed)
+	p3 := splinePoint(b.Points, i+2, b.Closed)
+
+	u := 1 - localT
+	t2 := localT * localT
+	t3 := t2 * localT
+	return p0.Scale(u * u * u).
+		Add(p1.Scale(3*t3 - 6*t2 + 4)).
+		Add(p2.Scale(-3*t3 + 3*t2 + 3*localT + 1)).
+		Add(p3.Scale(t3)).
+		Scale(1.0 / 6.0)
+}
+
+// splineSegment 将全局参数 t 映射到分段索引和段内参数
+func splineSegment(count int, closed bool, t float64) (int, float64, bool) {
+	if count < 2 {
+		return 0, 0, false
+	}
+
+	segments := count - 1
+	if closed {
+		segments = count
+	}
+
+	t = math.Max(0, math.Min(1, t))
+	scaled := t * float64(segments)
+	i := int(math.Floor(scaled))
+	if i >= segments {
+		i = segments - 1
+	}
+	return i, scaled - float64(i), true
+}
+
+// splinePoint 获取控制点，开放曲线在两端重复端点，闭合曲线循环取点
+func splinePoint(points []Vector3, i int, closed bool) Vector3 {
+	n := len(points)
+	if closed {
+		return points[((i%n)+n)%n]
+	}
+	if i < 0 {
+		i = 0
+	}
+	if i >= n {
+		i = n - 1
+	}
+	return points[i]
+}
+
+// singlePoint 控制点不足时的退化结果
+func singlePoint(points []Vector3) Vector3 {
+	if len(points) == 0 {
+		return NewVector3(0, 0, 0)
+	}
+	return points[0]
+}
+
+// TessellateCurve 按世界空间容差自适应细分曲线，返回折线顶点
+func TessellateCurve(curve Curve3D, tolerance float64) []Vector3 {
+	return tessellateAdaptive(curve, func(a, b, mid Vector3) float64 {
+		return mid.Sub(a.Add(b).Scale(0.5)).Length()
+	}, tolerance)
+}
+
+// tessellateAdaptive 自适应细分：若中点偏离弦的误差超过容差则继续二分
+func tessellateAdaptive(curve Curve3D, errorFunc func(a, b, mid Vector3) float64, tolerance float64) []Vector3 {
+	const (
+		initialSegments = 8  // 初始均匀分段，避免对称曲线的中点恰好落在弦上
+		maxDepth        = 10 // 每段最大细分深度
+	)
+
+	if tolerance <= 0 {
+		tolerance = 1e-3
+	}
+
+	points := []Vector3{curve.Point(0)}
+	var subdivide func(t0, t1 float64, p0, p1 Vector3, depth int)
+	subdivide = func(t0, t1 float64, p0, p1 Vector3, depth int) {
+		tm := (t0 + t1) / 2
+		pm := curve.Point(tm)
+		if depth < maxDepth && errorFunc(p0, p1, pm) > tolerance {
+			subdivide(t0, tm, p0, pm, depth+1)
+			subdivide(tm, t1, pm, p1, depth+1)
+			return
+		}
+		points = append(points, p1)
+	}
+
+	prev := points[0]
+	for i := 1; i <= initialSegments; i++ {
+		t0 := float64(i-1) / initialSegments
+		t1 := float64(i) / initialSegments
+		next := curve.Point(t1)
+		subdivide(t0, t1, prev, next, 0)
+		prev = next
+	}
+	return points
+}
+
+// DrawCurve3D 绘制3D曲线，按投影后的屏幕误差（约半个像素）自适应细分
+func (r *Renderer) DrawCurve3D(curve Curve3D, color [3]float64, width float64) {
+	points := tessellateAdaptive(curve, func(a, b, mid Vector3) float64 {
+		ax, ay, _ := r.ProjectToScreen(a)
+		bx, by, _ := r.ProjectToScreen(b)
+		mx, my, _ := r.ProjectToScreen(mid)
+		return math.Hypot(mx-(ax+bx)/2, my-(ay+by)/2)
+	}, 0.5)
+	r.DrawPolyline3D(points, color, width)
+}
@@ -0,0 +1,185 @@
+package go3d
+
+import (
+	"math"
+	"sort"
+)
+
+// curveCameraSamples 弧长表的采样数
+const curveCameraSamples = 1024
+
+// CurveCameraPath 沿曲线飞行的相机路径：相机按弧长匀速沿曲线前进，看向前方 LookAhead 处的曲线点，
+// 转弯时按曲率向弯道内侧倾斜（滚转），得到飞行的感觉而不是沿轨道推拉的镜头。
+// 首尾重合的曲线（如闭合样条）视为环路，前视点越过终点后从起点继续；开放曲线越过终点后沿末端切线延伸
+type CurveCameraPath struct {
+	Curve Curve3D // 相机经过的曲线，修改后调用 SetCurve 重新计算弧长
+	FOV   float64
+
+	LookAhead float64 // 目标点在相机前方的弧长距离（世界单位）
+	Banking   float64 // 倾斜系数：滚转角 = atan(Banking × 曲率)，相当于飞行速度² / 重力加速度，0 不倾斜
+	MaxBank   float64 // 最大滚转角（弧度）
+	Up        Vector3 // 不倾斜时的上方向
+
+	Easing func(t float64) float64 // 沿曲线前进的缓动函数，nil 为匀速
+
+	lengths []float64 // 曲线参数 i/curveCameraSamples 处的累计弧长
+	closed  bool
+}
+
+// NewCurveCameraPath 创建沿曲线飞行的相机路径：前视距离为曲线长度的 5%，
+// 倾斜系数为曲线长度的 5%（半径约为长度 1/6 的弯道倾斜约 17°），最大倾斜 45°
+func NewCurveCameraPath(curve Curve3D, fov float64) *CurveCameraPath {
+	cp := &CurveCameraPath{
+		FOV:     fov,
+		MaxBank: math.Pi / 4,
+		Up:      NewVector3(0, 1, 0),
+	}
+	cp.SetCurve(curve)
+	cp.LookAhead = cp.Length() * 0.05
+	cp.Banking = cp.Length() * 0.05
+	return cp
+}
+
+// SetCurve 设置曲线并重新计算弧长表
+func (cp *CurveCameraPath) SetCurve(curve Curve3D) *CurveCameraPath {
+	cp.Curve = curve
+	cp.lengths = make([]float64, curveCameraSamples+1)
+	previous := curve.Point(0)
+	for i := 1; i <= curveCameraSamples; i++ {
+		p := curve.Point(float64(i) / curveCameraSamples)
+		cp.lengths[i] = cp.lengths[i-1] + p.Sub(previous).Length()
+		previous = p
+	}
+	length := cp.Length()
+	cp.closed = length > 0 && curve.Point(1).Sub(curve.Point(0)).Length() < length*1e-9
+	return cp
+}
+
+// SetLookAhead 设置前视距离
+func (cp *CurveCameraPath) SetLookAhead(distance float64) *CurveCameraPath {
+	cp.LookAhead = distance
+	return cp
+}
+
+// SetBanking 设置倾斜系数和最大滚转角（弧度）
+func (cp *CurveCameraPath) SetBanking(banking, maxBank float64) *CurveCameraPath {
+	cp.Banking = banking
+	cp.MaxBank = maxBank
+	return cp
+}
+
+// SetEasing 设置沿曲线前进的缓动函数
+func (cp *CurveCameraPath) SetEasing(easing func(t float64) float64) *CurveCameraPath {
+	cp.Easing = easing
+	return cp
+}
+
+// Length 曲线长度
+func (cp *CurveCameraPath) Length() float64 {
+	return cp.lengths[len(cp.lengths)-1]
+}
+
+// distance 时间 t（0-1）时相机已经走过的弧长
+func (cp *CurveCameraPath) distance(t float64) float64 {
+	t = math.Max(0, math.Min(1, t))
+	if cp.Easing != nil {
+		t = cp.Easing(t)
+	}
+	return t * cp.Length()
+}
+
+// pointAt 弧长 s 处的曲线点：环路按周长取模，开放曲线超出两端时沿端点切线延伸
+func (cp *CurveCameraPath) pointAt(s float64) Vector3 {
+	length := cp.Length()
+	if length == 0 {
+		return cp.Curve.Point(0)
+	}
+	if cp.closed {
+		s = math.Mod(s, length)
+		if s < 0 {
+			s += length
+		}
+	} else if s < 0 || s > length {
+		end := math.Max(0, math.Min(length, s))
+		return cp.pointAt(end).Add(cp.tangentAt(end).Scale(s - end))
+	}
+
+	i := sort.SearchFloat64s(cp.lengths, s)
+	if i == 0 {
+		return cp.Curve.Point(0)
+	}
+	a, b := cp.lengths[i-1], cp.lengths[min(i, curveCameraSamples)]
+	local := 0.0
+	if b > a {
+		local = (s - a) / (b - a)
+	}
+	return cp.Curve.Point((float64(i-1) + local) / curveCameraSamples)
+}
+
+// tangentAt 弧长 s 处的单位切线（按前后一个小步长的差分）
+func (cp *CurveCameraPath) tangentAt(s float64) Vector3 {
+	h := cp.Length() / curveCameraSamples
+	if !cp.closed {
+		s = math.Max(0, math.Min(cp.Length(), s))
+	}
+	lo, hi := s-h, s+h
+	if !cp.closed {
+		lo, hi = math.Max(0, lo), math.Min(cp.Length(), hi)
+	}
+	d := cp.pointAt(hi).Sub(cp.pointAt(lo))
+	if d.Length() < 1e-12 {
+		return NewVector3(0, 0, 1)
+	}
+	return d.Normalize()
+}
+
+// GetPosition 获取相机位置
+func (cp *CurveCameraPath) GetPosition(t float64) Vector3 {
+	return cp.pointAt(cp.distance(t))
+}
+
+// GetTarget 前方 LookAhead 处的曲线点，前视距离为 0 时沿切线方向看
+func (cp *CurveCameraPath) GetTarget(t float64) Vector3 {
+	s := cp.distance(t)
+	if cp.LookAhead <= 0 {
+		return cp.pointAt(s).Add(cp.tangentAt(s))
+	}
+	return cp.pointAt(s + cp.LookAhead)
+}
+
+// GetFOV 获取视场角
+func (cp *CurveCameraPath) GetFOV(t float64) float64 {
+	return cp.FOV
+}
+
+// GetUp 按曲率滚转后的上方向。曲率取前后各半个前视距离内切线的变化率，
+// 使倾斜随弯道平滑地开始和结束，并在进入弯道前略微提前
+func (cp *CurveCameraPath) GetUp(t float64) Vector3 {
+	up := cp.Up
+	if up.Length() == 0 {
+		up = NewVector3(0, 1, 0)
+	}
+	if cp.Banking == 0 || cp.Length() == 0 {
+		return up
+	}
+	s := cp.distance(t)
+	forward := cp.GetTarget(t).Sub(cp.GetPosition(t))
+	if forward.Length() < 1e-12 {
+		return up
+	}
+	forward = forward.Normalize()
+	right := forward.Cross(up)
+	if right.Length() < 1e-10 {
+		return up
+	}
+	right = right.Normalize()
+
+	half := math.Max(cp.LookAhead/2, cp.Length()/curveCameraSamples*4)
+	curvature := cp.tangentAt(s + half).Sub(cp.tangentAt(s - half)).Scale(1 / (2 * half))
+	bank := math.Atan(cp.Banking * curvature.Dot(right))
+	if cp.MaxBank > 0 {
+		bank = math.Max(-cp.MaxBank, math.Min(cp.MaxBank, bank))
+	}
+	// 绕视线转动 bank：正值向右转弯时上方向倒向右侧
+	return QuaternionFromAxisAngle(forward, bank).Rotate(up)
+}
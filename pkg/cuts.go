@@ -0,0 +1,99 @@
+package go3d
+
+import (
+	"fmt"
+	"image"
+)
+
+// CameraCut 剪辑表中的一个镜头：在视频进度 [Start, End) 内使用相机路径 Path，
+// 路径时间在镜头内从 0 走到 1，因此每个镜头都完整地走完自己的运镜
+type CameraCut struct {
+	Path  CameraPath
+	Start float64 // 镜头开始的视频进度（0-1，不受 TimeCurve 影响）
+	End   float64 // 镜头结束的视频进度
+
+	// Crossfade 从上一个镜头交叉淡化过渡的时长（视频进度），从 Start 开始计算，
+	// 过渡期间上一个镜头的运镜继续进行；0 表示硬切
+	Crossfade float64
+}
+
+// validateCuts 检查剪辑表：镜头按开始时间排列、时间范围有效、过渡不超过镜头长度
+func validateCuts(cuts []CameraCut) error {
+	for i, cut := range cuts {
+		switch {
+		case cut.Path == nil:
+			return fmt.Errorf("剪辑表第 %d 个镜头没有相机路径", i+1)
+		case !(cut.Start < cut.End):
+			return fmt.Errorf("剪辑表第 %d 个镜头的时间范围无效: [%g, %g)", i+1, cut.Start, cut.End)
+		case cut.Crossfade < 0 || cut.Crossfade > cut.End-cut.Start:
+			return fmt.Errorf("剪辑表第 %d 个镜头的过渡时长 %g 超出镜头长度", i+1, cut.Crossfade)
+		case i > 0 && cut.Start < cuts[i-1].Start:
+			return fmt.Errorf("剪辑表第 %d 个镜头早于上一个镜头开始", i+1)
+		}
+	}
+	return nil
+}
+
+// activeCut 视频进度 progress 所在的镜头：最后一个已经开始的镜头，第一个镜头之前使用第一个镜头
+func activeCut(cuts []CameraCut, progress float64) int {
+	index := 0
+	for i, cut := range cuts {
+		if cut.Start <= progress {
+			index = i
+		}
+	}
+	return index
+}
+
+// apply 按镜头内的时间设置相机，并锁定相机使帧渲染函数中的 ApplyCameraPath 不再修改它
+func (cut CameraCut) apply(renderer *Renderer, progress float64) {
+	local := (progress - cut.Start) / (cut.End - cut.Start)
+	ApplyCameraPath(renderer, cut.Path, local)
+	renderer.cameraLocked = true
+}
+
+// renderFrame 调用帧渲染函数绘制一帧；设置了剪辑表时先应用当前镜头的相机，
+// 交叉淡化期间再以上一个镜头渲染一次并按过渡进度混合
+func (ag *AnimationGenerator) renderFrame(renderer *Renderer, frame, totalFrames int, t float64) {
+	cuts := ag.Config.Cuts
+	if len(cuts) == 0 {
+		ag.Renderer(renderer, frame, t)
+		return
+	}
+
+	progress := float64(frame-1) / float64(totalFrames)
+	index := activeCut(cuts, progress)
+	cut := cuts[index]
+	cut.apply(renderer, progress)
+	ag.Renderer(renderer, frame, t)
+
+	if index == 0 || cut.Crossfade <= 0 || progress >= cut.Start+cut.Crossfade {
+		return
+	}
+	previous := ag.newFrameRenderer(frame)
+	defer previous.Destroy()
+	cuts[index-1].apply(previous, progress)
+	ag.Renderer(previous, frame, t)
+
+	// 两个画面都应用各自的后期处理后再混合
+	img, ok := renderer.Surface.GetGoImage().(*image.RGBA)
+	from, fromOK := previous.Surface.GetGoImage().(*image.RGBA)
+	if !ok || !fromOK {
+		return
+	}
+	renderer.ApplyPostEffects()
+	previous.ApplyPostEffects()
+	blendImages(img, from, 1-(progress-cut.Start)/cut.Crossfade)
+	if renderer.cameraErr == nil {
+		renderer.cameraErr = previous.cameraErr
+	}
+	renderer.ReportError(previous.frameErr)
+}
+
+// blendImages 把 from 按权重 weight（0-1）混合到 dst 上（逐通道线性插值，预乘格式保持有效）
+func blendImages(dst, from *image.RGBA, weight float64) {
+	w := int(weight*256 + 0.5)
+	for i := range dst.Pix {
+		dst.Pix[i] = uint8((int(dst.Pix[i])*(256-w) + int(from.Pix[i])*w + 128) >> 8)
+	}
+}
@@ -0,0 +1,261 @@
+package go3d
+
+import "math"
+
+// DebugOptions 调试可视化开关，用于排查法线方向（绕序）错误、尺寸错误等问题
+type DebugOptions struct {
+	Normals         bool    // 在每个面的中心绘制法线
+	NormalLength    float64 // 法线长度（世界单位），0 表示按网格包围球半径自动选择
+	Bounds          bool    // 绘制网格的轴对齐包围盒
+	BoundingSpheres bool    // 绘制网格的包围球（三个坐标平面上的大圆）
+	Lights          bool    // 在光源位置绘制标记
+	Frustum         *Camera // 非空时绘制该相机的视锥
+	FrustumDepth    float64 // 视锥远端距离，0 表示到相机目标点的距离
+
+	NormalColor     [3]float64 // 朝向相机的法线颜色
+	BackNormalColor [3]float64 // 背向相机的法线颜色
+	BoundsColor     [3]float64
+	FrustumColor    [3]float64
+}
+
+// NewDebugOptions 创建默认配色、全部关闭的调试选项
+func NewDebugOptions() *DebugOptions {
+	return &DebugOptions{
+		NormalColor:     [3]float64{0.2, 0.9, 0.3},
+		BackNormalColor: [3]float64{0.9, 0.2, 0.2},
+		BoundsColor:     [3]float64{1.0, 0.8, 0.2},
+		FrustumColor:    [3]float64{0.4, 0.7, 1.0},
+	}
+}
+
+// SetDebug 设置调试可视化选项，nil 表示关闭
+func (r *Renderer) SetDebug(options *DebugOptions) {
+	r.Debug = options
+}
+
+// Bounds 计算网格的轴对齐包围盒
+func (m *Mesh) Bounds() (Vector3, Vector3) {
+	if len(m.Triangles) == 0 {
+		return Vector3{}, Vector3{}
+	}
+
+	minV := m.Triangles[0].V0
+	maxV := minV
+	for _, tri := range m.Triangles {
+		for _, v := range [3]Vector3{tri.V0, tri.V1, tri.V2} {
+			minV = NewVector3(math.Min(minV.X, v.X), math.Min(minV.Y, v.Y), math.Min(minV.Z, v.Z))
+			maxV = NewVector3(math.Max(maxV.X, v.X), math.Max(maxV.Y, v.Y), math.Max(maxV.Z, v.Z))
+		}
+	}
+	return minV, maxV
+}
+
+// BoundingSphere 计算网格的包围球（以包围盒中心为球心）
+func (m *Mesh) BoundingSphere() (Vector3, float64) {
+	minV, maxV := m.Bounds()
+	center := minV.Add(maxV).Scale(0.5)
+
+	radius := 0.0
+	for _, tri := range m.Triangles {
+		for _, v := range [3]Vector3{tri.V0, tri.V1, tri.V2} {
+			radius = math.Max(radius, v.Sub(center).Length())
+		}
+	}
+	return center, radius
+}
+
+// drawMeshDebug 按调试选项绘制网格的法线和包围体
+func (r *Renderer) drawMeshDebug(mesh *Mesh) {
+	d := r.Debug
+	if d == nil || len(mesh.Triangles) == 0 || !(d.Normals || d.Bounds || d.BoundingSpheres) {
+		return
+	}
+
+	if d.Bounds {
+		minV, maxV := mesh.Bounds()
+		r.drawDebugSegments(boxEdges(minV, maxV), d.BoundsColor)
+	}
+
+	if d.BoundingSpheres {
+		center, radius := mesh.BoundingSphere()
+		r.drawDebugSegments(sphereCircles(center, radius, 32), d.BoundsColor)
+	}
+
+	if d.Normals {
+		length := d.NormalLength
+		if length <= 0 {
+			_, radius := mesh.BoundingSphere()
+			length = radius * 0.15
+		}
+
+		var front, back [][2]Vector3
+		for _, tri := range mesh.Triangles {
+			center := tri.Center()
+			normal := tri.Normal()
+			segment := [2]Vector3{center, center.Add(normal.Scale(length))}
+			if normal.Dot(r.Camera.Position.Sub(center)) < 0 {
+				back = append(back, segment)
+			} else {
+				front = append(front, segment)
+			}
+		}
+		r.drawDebugSegments(back, d.BackNormalColor)
+		r.drawDebugSegments(front, d.NormalColor)
+	}
+}
+
+// drawSceneDebug 按调试选项绘制光源标记和辅助相机视锥
+func (r *Renderer) drawSceneDebug() {
+	d := r.Debug
+	if d == nil {
+		return
+	}
+
+	if d.Lights {
+		for _, light := range r.Lights {
+			r.drawLightGizmo(light)
+		}
+	}
+
+	if d.Frustum != nil {
+		r.drawDebugSegments(r.frustumEdges(d.Frustum, d.FrustumDepth), d.FrustumColor)
+	}
+}
+
+// drawDebugSegments 以细线绘制一组线段，批量绘制期间推迟到几何体之后
+func (r *Renderer) drawDebugSegments(segments [][2]Vector3, color [3]float64) {
+	if len(segments) == 0 {
+		return
+	}
+
+	r.DrawOverlay(func() {
+		r.Context.Save()
+		defer r.Context.Restore()
+
+		r.Context.SetSourceRGB(color[0], color[1], color[2])
+		r.Context.SetLineWidth(1.0)
+		r.Context.SetLineCap(lineCapRound)
+		for _, s := range segments {
+			r.tracePolyline(s[:])
+		}
+		r.Context.Stroke()
+	})
+}
+
+// drawLightGizmo 在光源位置绘制带光芒的圆圈标记
+func (r *Renderer) drawLightGizmo(light *Light) {
+	x, y, z := r.ProjectToScreen(light.Position)
+	if z < -1 || z > 1 {
+		return
+	}
+
+	color := light.Color
+	r.DrawOverlay(func() {
+		r.Context.Save()
+		defer r.Context.Restore()
+
+		r.Context.SetSourceRGB(color[0], color[1], color[2])
+		r.Context.SetLineWidth(1.5)
+		r.Context.Arc(x, y, 6, 0, 2*math.Pi)
+		r.Context.Stroke()
+
+		// 光芒
+		for i := range 8 {
+			angle := float64(i) * math.Pi / 4
+			c, s := math.Cos(angle), math.Sin(angle)
+			r.Context.MoveTo(x+c*8, y+s*8)
+			r.Context.LineTo(x+c*12, y+s*12)
+		}
+		r.Context.Stroke()
+	})
+}
+
+// boxEdges 轴对齐长方体的 12 条棱
+func boxEdges(minV, maxV Vector3) [][2]Vector3 {
+	corner := func(i int) Vector3 {
+		v := minV
+		if i&1 != 0 {
+			v.X = maxV.X
+		}
+		if i&2 != 0 {
+			v.Y = maxV.Y
+		}
+		if i&4 != 0 {
+			v.Z = maxV.Z
+		}
+		return v
+	}
+
+	var edges [][2]Vector3
+	for i := range 8 {
+		for _, bit := range []int{1, 2, 4} {
+			if i&bit == 0 {
+				edges = append(edges, [2]Vector3{corner(i), corner(i | bit)})
+			}
+		}
+	}
+	return edges
+}
+
+// sphereCircles 球体在三个坐标平面上的大圆
+func sphereCircles(center Vector3, radius float64, segments int) [][2]Vector3 {
+	point := func(axis int, angle float64) Vector3 {
+		c, s := radius*math.Cos(angle), radius*math.Sin(angle)
+		switch axis {
+		case 0:
+			return center.Add(NewVector3(c, s, 0))
+		case 1:
+			return center.Add(NewVector3(c, 0, s))
+		default:
+			return center.Add(NewVector3(0, c, s))
+		}
+	}
+
+	var edges [][2]Vector3
+	for axis := range 3 {
+		for i := range segments {
+			a0 := float64(i) / float64(segments) * 2 * math.Pi
+			a1 := float64(i+1) / float64(segments) * 2 * math.Pi
+			edges = append(edges, [2]Vector3{point(axis, a0), point(axis, a1)})
+		}
+	}
+	return edges
+}
+
+// frustumEdges 相机视锥（近裁剪面到 depth 处）的棱，宽高比与当前画布一致
+func (r *Renderer) frustumEdges(camera *Camera, depth float64) [][2]Vector3 {
+	forward := camera.Target.Sub(camera.Position)
+	if depth <= 0 {
+		depth = forward.Length()
+	}
+	forward, right, up := camera.Basis()
+
+	aspect := float64(r.Width) / float64(r.Height)
+	tanHalf := math.Tan(camera.FOV / 2)
+	plane := func(distance float64) [4]Vector3 {
+		center := camera.Position.Add(forward.Scale(distance))
+		h := up.Scale(distance * tanHalf)
+		w := right.Scale(distance * tanHalf * aspect)
+		return [4]Vector3{
+			center.Sub(w).Sub(h), center.Add(w).Sub(h),
+			center.Add(w).Add(h), center.Sub(w).Add(h),
+		}
+	}
+
+	near := plane(camera.Near)
+	far := plane(depth)
+	var edges [][2]Vector3
+	for i := range 4 {
+		j := (i + 1) % 4
+		edges = append(edges,
+			[2]Vector3{near[i], near[j]},
+			[2]Vector3{far[i], far[j]},
+			[2]Vector3{near[i], far[i]},
+			[2]Vector3{camera.Position, near[i]},
+		)
+	}
+	// 远端上边中点处的上方向标记
+	top := far[2].Add(far[3]).Scale(0.5)
+	edges = append(edges, [2]Vector3{top, top.Add(up.Scale(depth * tanHalf * 0.3))})
+	return edges
+}
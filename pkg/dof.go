@@ -0,0 +1,144 @@
+package go3d
+
+import (
+	"image"
+	"math"
+)
+
+// dofLevels 景深模糊的各级模糊半径（像素），每个像素按弥散圆半径在相邻两级之间插值
+var dofLevels = []int{0, 1, 2, 4, 8, 16, 32}
+
+// SetDepthOfField 设置景深：对焦距离（沿视线方向，0 表示对焦在 Target）和光圈直径（世界单位，0 表示关闭）
+func (c *Camera) SetDepthOfField(focusDistance, aperture float64) *Camera {
+	c.FocusDistance = focusDistance
+	c.Aperture = aperture
+	return c
+}
+
+// focus 实际使用的对焦距离
+func (c *Camera) focus() float64 {
+	if c.FocusDistance > 0 {
+		return c.FocusDistance
+	}
+	return c.Target.Sub(c.Position).Length()
+}
+
+// depthOfField 是否启用景深（需要深度通道，绘制时自动收集）
+func (r *Renderer) depthOfField() bool {
+	return r.Camera != nil && r.Camera.Aperture > 0
+}
+
+// circleOfConfusion 薄透镜模型下深度为 depth 的点在画面上的弥散圆半径（像素）
+// 没有表面的像素（深度为无穷大）按无穷远处理
+func (r *Renderer) circleOfConfusion(depth float64) float64 {
+	focus := r.Camera.focus()
+	blur := 1.0
+	if !math.IsInf(depth, 1) {
+		blur = math.Abs(depth-focus) / math.Max(depth, 1e-9)
+	}
+	// 对焦平面上一个世界单位对应的像素数
+	pixelsPerUnit := float64(r.Height) / 2 / (focus * math.Tan(r.Camera.FOV/2))
+	return 0.5 * r.Camera.Aperture * blur * pixelsPerUnit
+}
+
+// applyDepthOfField 按深度通道对画面做景深模糊：先生成几级不同半径的模糊图，
+// 再按每个像素的弥散圆半径在相邻两级之间混合；没有深度通道时不处理
+func (r *Renderer) applyDepthOfField(img *image.RGBA) {
+	if len(r.gbTriangles) > 0 {
+		r.ResolveGBuffer()
+	}
+	g := r.GBuffer
+	if g == nil || g.Width != r.Width || g.Height != r.Height {
+		return
+	}
+
+	w, h := r.Width, r.Height
+	radius := make([]float64, w*h)
+	maxRadius := 0.0
+	for i, d := range g.Depth {
+		radius[i] = math.Min(float64(dofLevels[len(dofLevels)-1]), r.circleOfConfusion(d))
+		maxRadius = math.Max(maxRadius, radius[i])
+	}
+
+	// 透明背景时 alpha 通道单独模糊（预乘颜色与 alpha 使用相同的权重）
+	transparent := r.TransparentBackground
+	src := make([][3]float64, w*h)
+	var alpha [][3]float64
+	if transparent {
+		alpha = make([][3]float64, w*h)
+	}
+	for y := range h {
+		for x := range w {
+			offset := y*img.Stride + x*4
+			src[y*w+x] = [3]float64{
+				float64(img.Pix[offset]) / 255,
+				float64(img.Pix[offset+1]) / 255,
+				float64(img.Pix[offset+2]) / 255,
+			}
+			if transparent {
+				alpha[y*w+x][0] = float64(img.Pix[offset+3]) / 255
+			}
+		}
+	}
+
+	result := make([][3]float64, w*h)
+	resultAlpha := make([]float64, w*h)
+	for level, levelRadius := range dofLevels {
+		if level > 0 && float64(dofLevels[level-1]) >= maxRadius {
+			break
+		}
+		// 两次盒式模糊近似高斯模糊，与 Bloom 一致
+		blurred, blurredAlpha := src, alpha
+		for range 2 {
+			blurred = boxBlur(blurred, w, h, (levelRadius+1)/2)
+			if transparent {
+				blurredAlpha = boxBlur(blurredAlpha, w, h, (levelRadius+1)/2)
+			}
+		}
+		for i, c := range blurred {
+			weight := dofLevelWeight(radius[i], level)
+			if weight == 0 {
+				continue
+			}
+			result[i][0] += c[0] * weight
+			result[i][1] += c[1] * weight
+			result[i][2] += c[2] * weight
+			if transparent {
+				resultAlpha[i] += blurredAlpha[i][0] * weight
+			}
+		}
+	}
+
+	for y := range h {
+		for x := range w {
+			offset := y*img.Stride + x*4
+			c := result[y*w+x]
+			for k := range 3 {
+				img.Pix[offset+k] = clampByte(c[k])
+			}
+			if transparent {
+				img.Pix[offset+3] = clampByte(resultAlpha[y*w+x])
+			}
+		}
+	}
+}
+
+// dofLevelWeight 弥散圆半径为 radius 的像素在第 level 级模糊图上的权重（相邻两级线性插值）
+func dofLevelWeight(radius float64, level int) float64 {
+	at := float64(dofLevels[level])
+	if level > 0 {
+		below := float64(dofLevels[level-1])
+		if radius > below && radius <= at {
+			return (radius - below) / (at - below)
+		}
+	}
+	if level+1 < len(dofLevels) {
+		above := float64(dofLevels[level+1])
+		if radius >= at && radius < above {
+			return (above - radius) / (above - at)
+		}
+	} else if radius >= at {
+		return 1
+	}
+	return 0
+}
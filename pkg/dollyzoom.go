@@ -0,0 +1,66 @@
+package go3d
+
+import "math"
+
+// maxDollyZoomFOV 滑动变焦的视场角上限（170°），相机贴近目标时不再继续张开
+const maxDollyZoomFOV = 170 * math.Pi / 180
+
+// DollyZoomPath 滑动变焦（希区柯克变焦）相机路径：相机从 Start 移动到 End 的同时调整视场角，
+// 使经过 Target、垂直于视线的平面上的可见范围保持不变——主体在画面中大小不变，背景透视被拉伸或压缩
+type DollyZoomPath struct {
+	Target Vector3 // 保持大小不变的主体位置，相机始终看向它
+	Start  Vector3 // 起点相机位置
+	End    Vector3 // 终点相机位置
+	FOV    float64 // 起点的视场角（弧度），其余时刻由距离换算
+
+	Easing func(t float64) float64 // 移动的缓动函数，nil 为匀速
+}
+
+// NewDollyZoomPath 创建沿目标与 start 的连线移动的滑动变焦：相机从 start 移动到距目标 endDistance 处，
+// endDistance 小于起点距离时推近（背景张开），大于时拉远（背景压缩）
+func NewDollyZoomPath(target, start Vector3, endDistance, fov float64) *DollyZoomPath {
+	direction := start.Sub(target)
+	if direction.Length() < 1e-12 {
+		direction = NewVector3(0, 0, 1)
+	}
+	return &DollyZoomPath{
+		Target: target,
+		Start:  start,
+		End:    target.Add(direction.Normalize().Scale(endDistance)),
+		FOV:    fov,
+	}
+}
+
+// SetEasing 设置移动的缓动函数
+func (dz *DollyZoomPath) SetEasing(easing func(t float64) float64) *DollyZoomPath {
+	dz.Easing = easing
+	return dz
+}
+
+// FrameHeight 目标平面上始终可见的高度（世界单位）
+func (dz *DollyZoomPath) FrameHeight() float64 {
+	return 2 * dz.Start.Sub(dz.Target).Length() * math.Tan(dz.FOV/2)
+}
+
+// GetPosition 在起点和终点之间按（缓动后的）进度插值，t 超出 [0, 1] 时停在端点
+func (dz *DollyZoomPath) GetPosition(t float64) Vector3 {
+	t = math.Max(0, math.Min(1, t))
+	if dz.Easing != nil {
+		t = dz.Easing(t)
+	}
+	return dz.Start.Add(dz.End.Sub(dz.Start).Scale(t))
+}
+
+// GetTarget 获取相机目标
+func (dz *DollyZoomPath) GetTarget(t float64) Vector3 {
+	return dz.Target
+}
+
+// GetFOV 使目标平面上的可见高度等于 FrameHeight 的视场角，相机贴近目标时以 170° 为上限
+func (dz *DollyZoomPath) GetFOV(t float64) float64 {
+	distance := dz.GetPosition(t).Sub(dz.Target).Length()
+	if distance < 1e-12 {
+		return maxDollyZoomFOV
+	}
+	return math.Min(maxDollyZoomFOV, 2*math.Atan(dz.FrameHeight()/2/distance))
+}
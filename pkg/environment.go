@@ -0,0 +1,350 @@
+package go3d
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// 辐照度贴图的分辨率：漫反射环境光变化平缓，低分辨率即可
+const (
+	irradianceWidth  = 32
+	irradianceHeight = 16
+)
+
+// Environment 环境贴图：等距圆柱投影全景图（HDR 或普通图像），用作随法线方向变化的环境光和背景
+// 方向约定与 SkyBackground 相同：天顶为 +Z，u 从 +X 方向开始向 +Y 增加
+// 像素保存线性空间的辐射亮度，普通图像加载时从 sRGB 转换
+type Environment struct {
+	Width     int
+	Height    int
+	Pixels    [][3]float64 // 按行存储的线性辐射亮度
+	Intensity float64      // 亮度倍数，同时作用于环境光和背景
+
+	irradianceOnce sync.Once
+	irradiance     [][3]float64 // 余弦卷积后的辐照度贴图，首次使用时计算
+}
+
+// NewEnvironment 从普通（sRGB）图像创建环境贴图
+func NewEnvironment(img image.Image) *Environment {
+	bounds := img.Bounds()
+	env := newEnvironment(bounds.Dx(), bounds.Dy())
+	for y := range env.Height {
+		for x := range env.Width {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			env.Pixels[y*env.Width+x] = [3]float64{
+				SRGBToLinear(float64(r) / 65535),
+				SRGBToLinear(float64(g) / 65535),
+				SRGBToLinear(float64(b) / 65535),
+			}
+		}
+	}
+	return env
+}
+
+// NewUniformEnvironment 创建各方向亮度相同的环境（颜色为 sRGB），
+// 颜色为 {0.2, 0.2, 0.2} 时与没有环境贴图时的常量环境光一致
+func NewUniformEnvironment(color [3]float64) *Environment {
+	env := newEnvironment(1, 1)
+	env.Pixels[0] = [3]float64{SRGBToLinear(color[0]), SRGBToLinear(color[1]), SRGBToLinear(color[2])}
+	return env
+}
+
+// LoadEnvironment 从文件加载环境贴图：.hdr 按 Radiance RGBE 格式读取，其余按 PNG/JPEG 读取
+func LoadEnvironment(filename string) (*Environment, error) {
+	if strings.EqualFold(filepath.Ext(filename), ".hdr") {
+		return loadRadianceHDR(filename)
+	}
+	texture, err := LoadTexture(filename)
+	if err != nil {
+		return nil, err
+	}
+	return NewEnvironment(texture.Image), nil
+}
+
+// newEnvironment 创建指定尺寸的空环境贴图
+func newEnvironment(width, height int) *Environment {
+	return &Environment{
+		Width:     width,
+		Height:    height,
+		Pixels:    make([][3]float64, width*height),
+		Intensity: 1.0,
+	}
+}
+
+// SetIntensity 设置亮度倍数
+func (e *Environment) SetIntensity(intensity float64) *Environment {
+	e.Intensity = intensity
+	return e
+}
+
+// Sample 按方向采样环境的线性辐射亮度（双线性插值，已乘亮度倍数）
+func (e *Environment) Sample(dir Vector3) [3]float64 {
+	c := sampleEquirect(e.Pixels, e.Width, e.Height, dir)
+	return [3]float64{c[0] * e.Intensity, c[1] * e.Intensity, c[2] * e.Intensity}
+}
+
+// Irradiance 法线方向的漫反射辐照度（线性空间，已除以 π 并乘亮度倍数）
+// 各方向亮度相同的环境返回该亮度本身，因此可直接代替常量环境光
+func (e *Environment) Irradiance(normal Vector3) [3]float64 {
+	e.irradianceOnce.Do(e.computeIrradiance)
+	c := sampleEquirect(e.irradiance, irradianceWidth, irradianceHeight, normal)
+	return [3]float64{c[0] * e.Intensity, c[1] * e.Intensity, c[2] * e.Intensity}
+}
+
+// computeIrradiance 先把全景图缩小到辐照度贴图的分辨率，再对每个方向做余弦加权积分
+func (e *Environment) computeIrradiance() {
+	w, h := irradianceWidth, irradianceHeight
+	small := make([][3]float64, w*h)
+	counts := make([]float64, w*h)
+	if e.Width > 0 && e.Height > 0 {
+		for y := range e.Height {
+			sy := y * h / e.Height
+			for x := range e.Width {
+				i := sy*w + x*w/e.Width
+				p := e.Pixels[y*e.Width+x]
+				small[i][0] += p[0]
+				small[i][1] += p[1]
+				small[i][2] += p[2]
+				counts[i]++
+			}
+		}
+	}
+	// 源图比辐照度贴图小时部分格子没有像素，改为按方向采样
+	dirs := make([]Vector3, w*h)
+	weights := make([]float64, w*h)
+	for y := range h {
+		for x := range w {
+			i := y*w + x
+			dirs[i] = equirectDirection((float64(x)+0.5)/float64(w), (float64(y)+0.5)/float64(h))
+			if counts[i] > 0 {
+				small[i] = [3]float64{small[i][0] / counts[i], small[i][1] / counts[i], small[i][2] / counts[i]}
+			} else {
+				small[i] = sampleEquirect(e.Pixels, e.Width, e.Height, dirs[i])
+			}
+			// 每个格子的立体角正比于纬度的余弦
+			weights[i] = math.Cos(math.Pi * (0.5 - (float64(y)+0.5)/float64(h)))
+		}
+	}
+	solidAngle := 2 * math.Pi / float64(w) * math.Pi / float64(h)
+
+	e.irradiance = make([][3]float64, w*h)
+	for i, normal := range dirs {
+		var sum [3]float64
+		for j, dir := range dirs {
+			cos := normal.Dot(dir)
+			if cos <= 0 {
+				continue
+			}
+			f := cos * weights[j] * solidAngle / math.Pi
+			sum[0] += small[j][0] * f
+			sum[1] += small[j][1] * f
+			sum[2] += small[j][2] * f
+		}
+		e.irradiance[i] = sum
+	}
+}
+
+// Render 把环境贴图作为背景绘制，可直接传给 Scene.SetBackground
+func (e *Environment) Render(renderer *Renderer, t float64) {
+	if e.Width == 0 || e.Height == 0 {
+		return
+	}
+
+	surface := paintPixels(renderer.Width, renderer.Height, func(x, y float64) [3]float64 {
+		return renderer.encodeColor(renderer.environmentColor(e.Sample(renderer.cameraRay(x, y))))
+	})
+	defer surface.Destroy()
+
+	renderer.Context.Save()
+	defer renderer.Context.Restore()
+	renderer.Context.SetSourceSurface(surface, 0, 0)
+	renderer.Context.Paint()
+}
+
+// SetEnvironment 设置环境贴图，nil 表示使用常量环境光
+func (r *Renderer) SetEnvironment(env *Environment) {
+	r.Environment = env
+}
+
+// ambientLight 法线方向的环境光（光照空间）：设置了环境贴图时按法线采样辐照度，否则为常量
+func (r *Renderer) ambientLight(normal Vector3) [3]float64 {
+	if r.Environment == nil {
+		return r.decodeColor([3]float64{0.2, 0.2, 0.2})
+	}
+	return r.environmentColor(r.Environment.Irradiance(normal))
+}
+
+// environmentColor 把环境贴图的线性颜色转换到光照空间
+// 非线性光照时按 sRGB 曲线编码但不截断，保留 HDR 环境中超过 1 的亮度
+func (r *Renderer) environmentColor(c [3]float64) [3]float64 {
+	if r.LinearLighting {
+		return c
+	}
+	for k := range 3 {
+		if c[k] > 0.0031308 {
+			c[k] = 1.055*math.Pow(c[k], 1/2.4) - 0.055
+		} else {
+			c[k] *= 12.92
+		}
+	}
+	return c
+}
+
+// equirectDirection 等距圆柱投影纹理坐标对应的单位方向
+func equirectDirection(u, v float64) Vector3 {
+	lon := 2 * math.Pi * u
+	lat := math.Pi * (0.5 - v)
+	return NewVector3(math.Cos(lat)*math.Cos(lon), math.Cos(lat)*math.Sin(lon), math.Sin(lat))
+}
+
+// sampleEquirect 按方向对等距圆柱投影像素做双线性插值，经度方向环绕
+func sampleEquirect(pixels [][3]float64, width, height int, dir Vector3) [3]float64 {
+	if width == 0 || height == 0 {
+		return [3]float64{}
+	}
+	dir = dir.Normalize()
+	u := math.Atan2(dir.Y, dir.X) / (2 * math.Pi)
+	if u < 0 {
+		u++
+	}
+	v := 0.5 - math.Asin(math.Max(-1, math.Min(1, dir.Z)))/math.Pi
+
+	fx := u*float64(width) - 0.5
+	fy := math.Max(0, math.Min(float64(height-1), v*float64(height)-0.5))
+	x0 := int(math.Floor(fx))
+	y0 := int(fy)
+	tx, ty := fx-float64(x0), fy-float64(y0)
+	y1 := min(y0+1, height-1)
+	x0 = (x0%width + width) % width
+	x1 := (x0 + 1) % width
+
+	var c [3]float64
+	for k := range 3 {
+		top := pixels[y0*width+x0][k]*(1-tx) + pixels[y0*width+x1][k]*tx
+		bottom := pixels[y1*width+x0][k]*(1-tx) + pixels[y1*width+x1][k]*tx
+		c[k] = top*(1-ty) + bottom*ty
+	}
+	return c
+}
+
+// loadRadianceHDR 读取 Radiance RGBE（.hdr）文件，支持未压缩和新式行程编码扫描线
+// 仅支持常见的 -Y height +X width 方向
+func loadRadianceHDR(filename string) (*Environment, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	reader := bufio.NewReader(file)
+
+	// 文件头以空行结束，随后一行是分辨率
+	line, err := reader.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "#?") {
+		return nil, errors.New("HDR: 不是 Radiance 文件")
+	}
+	for {
+		line, err = reader.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("HDR: 读取文件头失败: %w", err)
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(line, "FORMAT=") && line != "FORMAT=32-bit_rle_rgbe" {
+			return nil, fmt.Errorf("HDR: 不支持的像素格式 %s", line[len("FORMAT="):])
+		}
+	}
+	line, err = reader.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("HDR: 读取分辨率失败: %w", err)
+	}
+	var width, height int
+	if _, err := fmt.Sscanf(line, "-Y %d +X %d", &height, &width); err != nil || width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("HDR: 不支持的分辨率行 %q", strings.TrimSpace(line))
+	}
+
+	env := newEnvironment(width, height)
+	scanline := make([][4]byte, width)
+	for y := range height {
+		if err := readRGBEScanline(reader, scanline); err != nil {
+			return nil, fmt.Errorf("HDR: 第 %d 行: %w", y, err)
+		}
+		for x, p := range scanline {
+			if p[3] == 0 {
+				continue
+			}
+			f := math.Ldexp(1, int(p[3])-136)
+			env.Pixels[y*width+x] = [3]float64{float64(p[0]) * f, float64(p[1]) * f, float64(p[2]) * f}
+		}
+	}
+	return env, nil
+}
+
+// readRGBEScanline 读取一行 RGBE 像素
+func readRGBEScanline(reader *bufio.Reader, scanline [][4]byte) error {
+	width := len(scanline)
+	var head [4]byte
+	if _, err := io.ReadFull(reader, head[:]); err != nil {
+		return err
+	}
+	// 新式行程编码以 2, 2 开头，随后是行宽；否则整行未压缩
+	if width < 8 || width > 0x7fff || head[0] != 2 || head[1] != 2 || head[2]&0x80 != 0 {
+		scanline[0] = head
+		for x := 1; x < width; x++ {
+			if _, err := io.ReadFull(reader, scanline[x][:]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if int(head[2])<<8|int(head[3]) != width {
+		return errors.New("行宽不一致")
+	}
+
+	// 四个通道依次编码
+	for channel := range 4 {
+		for x := 0; x < width; {
+			count, err := reader.ReadByte()
+			if err != nil {
+				return err
+			}
+			if count > 128 {
+				n := int(count - 128)
+				value, err := reader.ReadByte()
+				if err != nil {
+					return err
+				}
+				if x+n > width {
+					return errors.New("行程超出行宽")
+				}
+				for ; n > 0; n-- {
+					scanline[x][channel] = value
+					x++
+				}
+				continue
+			}
+			n := int(count)
+			if n == 0 || x+n > width {
+				return errors.New("行程长度无效")
+			}
+			for ; n > 0; n-- {
+				value, err := reader.ReadByte()
+				if err != nil {
+					return err
+				}
+				scanline[x][channel] = value
+				x++
+			}
+		}
+	}
+	return nil
+}
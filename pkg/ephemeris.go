@@ -0,0 +1,114 @@
+package go3d
+
+import (
+	"math"
+	"time"
+)
+
+// PlanetEphemeris 行星平均轨道根数（J2000 历元及每儒略世纪的变化率）
+// 数据来自 JPL "Keplerian Elements for Approximate Positions of the Major Planets"
+// （适用于 1800-2050 年），角度单位为度，距离单位为天文单位
+type PlanetEphemeris struct {
+	Name string
+
+	SemiMajorAxis, SemiMajorAxisRate float64 // a, da/dT
+	Eccentricity, EccentricityRate   float64 // e, de/dT
+	Inclination, InclinationRate     float64 // I, dI/dT
+	MeanLongitude, MeanLongitudeRate float64 // L, dL/dT
+	PeriLongitude, PeriLongitudeRate float64 // ϖ, dϖ/dT（近日点经度）
+	NodeLongitude, NodeLongitudeRate float64 // Ω, dΩ/dT（升交点经度）
+}
+
+// MeanOrbitalElements 八大行星及冥王星的平均轨道根数（地球为地月质心）
+var MeanOrbitalElements = []PlanetEphemeris{
+	{"Mercury", 0.38709927, 0.00000037, 0.20563593, 0.00001906, 7.00497902, -0.00594749, 252.25032350, 149472.67411175, 77.45779628, 0.16047689, 48.33076593, -0.12534081},
+	{"Venus", 0.72333566, 0.00000390, 0.00677672, -0.00004107, 3.39467605, -0.00078890, 181.97909950, 58517.81538729, 131.60246718, 0.00268329, 76.67984255, -0.27769418},
+	{"Earth", 1.00000261, 0.00000562, 0.01671123, -0.00004392, -0.00001531, -0.01294668, 100.46457166, 35999.37244981, 102.93768193, 0.32327364, 0.0, 0.0},
+	{"Mars", 1.52371034, 0.00001847, 0.09339410, 0.00007882, 1.84969142, -0.00813131, -4.55343205, 19140.30268499, -23.94362959, 0.44441088, 49.55953891, -0.29257343},
+	{"Jupiter", 5.20288700, -0.00011607, 0.04838624, -0.00013253, 1.30439695, -0.00183714, 34.39644051, 3034.74612775, 14.72847983, 0.21252668, 100.47390909, 0.20469106},
+	{"Saturn", 9.53667594, -0.00125060, 0.05386179, -0.00050991, 2.48599187, 0.00193609, 49.95424423, 1222.49362201, 92.59887831, -0.41897216, 113.66242448, -0.28867794},
+	{"Uranus", 19.18916464, -0.00196176, 0.04725744, -0.00004397, 0.77263783, -0.00242939, 313.23810451, 428.48202785, 170.95427630, 0.40805281, 74.01692503, 0.04240589},
+	{"Neptune", 30.06992276, 0.00026291, 0.00859048, 0.00005105, 1.77004347, 0.00035372, -55.12002969, 218.45945325, 44.96476227, -0.32241464, 131.78422574, -0.00508664},
+	{"Pluto", 39.48211675, -0.00031596, 0.24882730, 0.00005170, 17.14001206, 0.00004818, 238.92903833, 145.20780515, 224.06891629, -0.04062942, 110.30393684, -0.01183482},
+}
+
+// FindEphemeris 按英文名查找行星平均轨道根数
+func FindEphemeris(name string) (PlanetEphemeris, bool) {
+	for _, pe := range MeanOrbitalElements {
+		if pe.Name == name {
+			return pe, true
+		}
+	}
+	return PlanetEphemeris{}, false
+}
+
+// JulianDate 计算儒略日
+func JulianDate(date time.Time) float64 {
+	return float64(date.UTC().UnixNano())/86400e9 + 2440587.5
+}
+
+// JulianCenturiesSinceJ2000 计算自 J2000.0 起的儒略世纪数
+func JulianCenturiesSinceJ2000(date time.Time) float64 {
+	return (JulianDate(date) - 2451545.0) / 36525.0
+}
+
+// ElementsAt 计算指定日期的轨道根数（角度转换为弧度）和平近点角
+func (pe PlanetEphemeris) ElementsAt(date time.Time) (OrbitalElements, float64) {
+	centuries := JulianCenturiesSinceJ2000(date)
+	deg := math.Pi / 180
+
+	a := pe.SemiMajorAxis + pe.SemiMajorAxisRate*centuries
+	e := pe.Eccentricity + pe.EccentricityRate*centuries
+	inclination := pe.Inclination + pe.InclinationRate*centuries
+	meanLongitude := pe.MeanLongitude + pe.MeanLongitudeRate*centuries
+	periLongitude := pe.PeriLongitude + pe.PeriLongitudeRate*centuries
+	nodeLongitude := pe.NodeLongitude + pe.NodeLongitudeRate*centuries
+
+	elements := OrbitalElements{
+		SemiMajorAxis: a,
+		Eccentricity:  e,
+		Inclination:   inclination * deg,
+		AscendingNode: nodeLongitude * deg,
+		ArgPeriapsis:  (periLongitude - nodeLongitude) * deg,
+	}
+	meanAnomaly := math.Remainder((meanLongitude-periLongitude)*deg, 2*math.Pi)
+	return elements, meanAnomaly
+}
+
+// HeliocentricPosition 计算指定日期的日心黄道坐标（天文单位，XY 为黄道面）
+func (pe PlanetEphemeris) HeliocentricPosition(date time.Time) Vector3 {
+	elements, meanAnomaly := pe.ElementsAt(date)
+	return elements.PositionAtEccentricAnomaly(SolveKepler(meanAnomaly, elements.Eccentricity))
+}
+
+// SetDate 按真实日期设置行星的轨道形状、朝向和初始相位
+// 行星保持原有的显示轨道半径，t = 0 时的位置与该日期的真实方位一致
+func (ss *SolarSystem) SetDate(date time.Time) {
+	for _, planet := range ss.Planets {
+		pe, ok := FindEphemeris(planet.Name)
+		if !ok {
+			continue
+		}
+
+		elements, meanAnomaly := pe.ElementsAt(date)
+		planet.SetOrbitElements(elements.Eccentricity, elements.Inclination, elements.AscendingNode, elements.ArgPeriapsis)
+		planet.OrbitPhase = meanAnomaly
+
+		// 同步对应轨道的形状
+		for _, orbit := range ss.Orbits {
+			if orbit.Radius == planet.OrbitRadius {
+				orbit.Eccentricity = planet.Eccentricity
+				orbit.Inclination = planet.Inclination
+				orbit.AscendingNode = planet.AscendingNode
+				orbit.ArgPeriapsis = planet.ArgPeriapsis
+			}
+		}
+	}
+}
+
+// CreateSolarSystemAt 创建与指定日期行星方位一致的默认太阳系
+func CreateSolarSystemAt(date time.Time) *SolarSystem {
+	ss := CreateDefaultSolarSystem()
+	ss.SetDate(date)
+	return ss
+}
@@ -0,0 +1,313 @@
+package go3d
+
+import (
+	"math"
+	"sort"
+)
+
+// polygonArea 多边形的有向面积，逆时针为正
+func polygonArea(points []Vector2) float64 {
+	area := 0.0
+	for i := range points {
+		area += points[i].Cross(points[(i+1)%len(points)])
+	}
+	return area / 2
+}
+
+// orientPolygon 返回指定方向（逆时针或顺时针）的多边形副本
+func orientPolygon(points []Vector2, counterClockwise bool) []Vector2 {
+	result := append([]Vector2(nil), points...)
+	if (polygonArea(result) > 0) != counterClockwise {
+		for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+			result[i], result[j] = result[j], result[i]
+		}
+	}
+	return result
+}
+
+// pointInTriangle2D 点 p 是否在三角形 abc（逆时针）内部或边上
+func pointInTriangle2D(p, a, b, c Vector2) bool {
+	return b.Sub(a).Cross(p.Sub(a)) >= 0 &&
+		c.Sub(b).Cross(p.Sub(b)) >= 0 &&
+		a.Sub(c).Cross(p.Sub(c)) >= 0
+}
+
+// bridgeHole 用一对重合的桥接边把孔洞（顺时针）并入外轮廓（逆时针），得到单个简单多边形
+// 做法：从孔洞最右侧顶点向 +X 方向发射射线，连接到外轮廓上可见的顶点
+func bridgeHole(outer, hole []Vector2) []Vector2 {
+	// 孔洞最右侧的顶点
+	m := 0
+	for i, p := range hole {
+		if p.X > hole[m].X {
+			m = i
+		}
+	}
+	mp := hole[m]
+
+	// 射线与外轮廓最近的交点所在边
+	best := -1
+	bestX := math.Inf(1)
+	for i := range outer {
+		a, b := outer[i], outer[(i+1)%len(outer)]
+		if (a.Y > mp.Y) == (b.Y > mp.Y) {
+			continue
+		}
+		x := a.X + (mp.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+		if x >= mp.X && x < bestX {
+			bestX = x
+			best = i
+		}
+	}
+	if best < 0 {
+		return outer
+	}
+
+	// 取交点所在边上 X 较大的端点作为候选
+	bridge := best
+	if outer[(best+1)%len(outer)].X > outer[best].X {
+		bridge = (best + 1) % len(outer)
+	}
+
+	// 若三角形（孔洞顶点、交点、候选点）内有外轮廓的凹顶点，改为连接与射线夹角最小的那个
+	hit := NewVector2(bestX, mp.Y)
+	tri := orientPolygon([]Vector2{mp, hit, outer[bridge]}, true)
+	bestAngle := math.Inf(1)
+	for i, p := range outer {
+		if i == bridge || !pointInTriangle2D(p, tri[0], tri[1], tri[2]) {
+			continue
+		}
+		prev, next := outer[(i+len(outer)-1)%len(outer)], outer[(i+1)%len(outer)]
+		if p.Sub(prev).Cross(next.Sub(p)) >= 0 {
+			continue // 凸顶点
+		}
+		angle := math.Abs(math.Atan2(p.Y-mp.Y, p.X-mp.X))
+		if angle < bestAngle {
+			bestAngle = angle
+			bridge = i
+		}
+	}
+
+	// 外轮廓 → 桥接点 → 整个孔洞 → 回到孔洞起点 → 回到桥接点 → 外轮廓其余部分
+	merged := make([]Vector2, 0, len(outer)+len(hole)+2)
+	merged = append(merged, outer[:bridge+1]...)
+	for k := 0; k <= len(hole); k++ {
+		merged = append(merged, hole[(m+k)%len(hole)])
+	}
+	merged = append(merged, outer[bridge:]...)
+	return merged
+}
+
+// TriangulatePolygon 用耳切法对带孔洞的简单多边形做三角剖分
+// 外轮廓和孔洞的方向不限，返回的三角形均为逆时针
+func TriangulatePolygon(outer []Vector2, holes [][]Vector2) [][3]Vector2 {
+	if len(outer) < 3 {
+		return nil
+	}
+
+	polygon := orientPolygon(outer, true)
+
+	// 按最右侧顶点从右到左依次并入孔洞，保证桥接边不与尚未并入的孔洞相交
+	sorted := make([][]Vector2, 0, len(holes))
+	for _, hole := range holes {
+		if len(hole) >= 3 {
+			sorted = append(sorted, orientPolygon(hole, false))
+		}
+	}
+	maxX := func(points []Vector2) float64 {
+		x := math.Inf(-1)
+		for _, p := range points {
+			x = math.Max(x, p.X)
+		}
+		return x
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return maxX(sorted[i]) > maxX(sorted[j])
+	})
+	for _, hole := range sorted {
+		polygon = bridgeHole(polygon, hole)
+	}
+
+	// 耳切
+	indices := make([]int, len(polygon))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	var triangles [][3]Vector2
+	for len(indices) > 3 {
+		n := len(indices)
+		clipped := false
+		for i := range n {
+			a := polygon[indices[(i+n-1)%n]]
+			b := polygon[indices[i]]
+			c := polygon[indices[(i+1)%n]]
+
+			// 凹顶点或退化顶点不是耳朵
+			if b.Sub(a).Cross(c.Sub(b)) <= 0 {
+				continue
+			}
+
+			// 其他顶点（与三角形顶点重合的桥接点除外）不能落在三角形内
+			ear := true
+			for k := range n {
+				p := polygon[indices[k]]
+				if k == i || k == (i+n-1)%n || k == (i+1)%n || p == a || p == b || p == c {
+					continue
+				}
+				if pointInTriangle2D(p, a, b, c) {
+					ear = false
+					break
+				}
+			}
+			if !ear {
+				continue
+			}
+
+			triangles = append(triangles, [3]Vector2{a, b, c})
+			indices = append(indices[:i], indices[i+1:]...)
+			clipped = true
+			break
+		}
+
+		// 自相交等无效输入找不到耳朵时，丢弃一个顶点以保证结束
+		if !clipped {
+			indices = indices[1:]
+		}
+	}
+
+	a, b, c := polygon[indices[0]], polygon[indices[1]], polygon[indices[2]]
+	if b.Sub(a).Cross(c.Sub(b)) > 0 {
+		triangles = append(triangles, [3]Vector2{a, b, c})
+	}
+	return triangles
+}
+
+// CreateExtrusion 将 XY 平面上的二维轮廓沿 Z 轴拉伸为厚度 depth 的网格（Z 方向居中）
+// caps 为 true 时生成前后端面
+func CreateExtrusion(profile []Vector2, depth float64, caps bool) *Mesh {
+	return CreateExtrusionWithHoles(profile, nil, depth, caps)
+}
+
+// CreateExtrusionWithHoles 拉伸带孔洞的二维轮廓，孔洞内壁同样生成侧面
+func CreateExtrusionWithHoles(profile []Vector2, holes [][]Vector2, depth float64, caps bool) *Mesh {
+	mesh := NewMesh()
+	if len(profile) < 3 {
+		return mesh
+	}
+	front := depth / 2
+	back := -depth / 2
+
+	for _, loop := range extrusionLoops(profile, holes) {
+		addLoftRing(mesh, loop, loop, back, front)
+	}
+	if caps {
+		addExtrusionCaps(mesh, profile, holes, back, front)
+	}
+	return mesh
+}
+
+// CreateBeveledExtrusion 拉伸带孔洞的二维轮廓，前后端面边缘做宽度为 bevel 的 45° 倒角
+// bevel 超过厚度一半时取厚度一半；倒角过大导致内缩轮廓自交时结果无效
+func CreateBeveledExtrusion(profile []Vector2, holes [][]Vector2, depth, bevel float64) *Mesh {
+	bevel = math.Max(0, math.Min(bevel, depth/2))
+	if bevel == 0 {
+		return CreateExtrusionWithHoles(profile, holes, depth, true)
+	}
+
+	mesh := NewMesh()
+	if len(profile) < 3 {
+		return mesh
+	}
+	front := depth / 2
+	back := -depth / 2
+
+	// 端面使用向实体内部收缩 bevel 的轮廓，与侧面之间以倒角环连接
+	loops := extrusionLoops(profile, holes)
+	insetLoops := make([][]Vector2, len(loops))
+	for i, loop := range loops {
+		insetLoops[i] = insetPolygon(loop, bevel)
+		addLoftRing(mesh, insetLoops[i], loop, back, back+bevel)
+		if front-bevel > back+bevel {
+			addLoftRing(mesh, loop, loop, back+bevel, front-bevel)
+		}
+		addLoftRing(mesh, loop, insetLoops[i], front-bevel, front)
+	}
+
+	addExtrusionCaps(mesh, insetLoops[0], insetLoops[1:], back, front)
+	return mesh
+}
+
+// extrusionLoops 返回统一方向的轮廓：外轮廓逆时针在前，孔洞顺时针在后
+// 这样每条边的左侧都是实体内部
+func extrusionLoops(profile []Vector2, holes [][]Vector2) [][]Vector2 {
+	loops := [][]Vector2{orientPolygon(profile, true)}
+	for _, hole := range holes {
+		if len(hole) >= 3 {
+			loops = append(loops, orientPolygon(hole, false))
+		}
+	}
+	return loops
+}
+
+// insetPolygon 将轮廓向每条边的左侧（实体内部）平移 distance，顶点沿角平分线移动
+func insetPolygon(loop []Vector2, distance float64) []Vector2 {
+	n := len(loop)
+	leftNormal := func(a, b Vector2) Vector2 {
+		d := b.Sub(a)
+		length := d.Length()
+		if length < 1e-12 {
+			return Vector2{}
+		}
+		return NewVector2(-d.Y/length, d.X/length)
+	}
+
+	result := make([]Vector2, n)
+	for i := range loop {
+		prev, cur, next := loop[(i+n-1)%n], loop[i], loop[(i+1)%n]
+		n1 := leftNormal(prev, cur)
+		n2 := leftNormal(cur, next)
+		bisector := n1.Add(n2)
+		length := bisector.Length()
+		if length < 1e-9 {
+			result[i] = cur.Add(n1.Scale(distance))
+			continue
+		}
+		bisector = bisector.Scale(1 / length)
+
+		// 尖角处斜接长度限制为 3 倍，避免尖刺
+		miter := distance / math.Max(1.0/3.0, bisector.Dot(n1))
+		result[i] = cur.Add(bisector.Scale(miter))
+	}
+	return result
+}
+
+// addLoftRing 连接高度 zLow 处的轮廓 low 与高度 zHigh 处的轮廓 high（顶点一一对应）
+// 轮廓每条边的左侧为实体内部时，生成的面法线朝外
+func addLoftRing(mesh *Mesh, low, high []Vector2, zLow, zHigh float64) {
+	n := len(low)
+	for i := range low {
+		j := (i + 1) % n
+		a0 := NewVector3(low[i].X, low[i].Y, zLow)
+		b0 := NewVector3(low[j].X, low[j].Y, zLow)
+		a1 := NewVector3(high[i].X, high[i].Y, zHigh)
+		b1 := NewVector3(high[j].X, high[j].Y, zHigh)
+		mesh.AddTriangle(Triangle{V0: a0, V1: b0, V2: b1})
+		mesh.AddTriangle(Triangle{V0: a0, V1: b1, V2: a1})
+	}
+}
+
+// addExtrusionCaps 添加前后端面：前端面法线 +Z，后端面反向
+func addExtrusionCaps(mesh *Mesh, profile []Vector2, holes [][]Vector2, back, front float64) {
+	for _, tri := range TriangulatePolygon(profile, holes) {
+		mesh.AddTriangle(Triangle{
+			V0: NewVector3(tri[0].X, tri[0].Y, front),
+			V1: NewVector3(tri[1].X, tri[1].Y, front),
+			V2: NewVector3(tri[2].X, tri[2].Y, front),
+		})
+		mesh.AddTriangle(Triangle{
+			V0: NewVector3(tri[0].X, tri[0].Y, back),
+			V1: NewVector3(tri[2].X, tri[2].Y, back),
+			V2: NewVector3(tri[1].X, tri[1].Y, back),
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package go3d
+
+import (
+	"fmt"
+	"math"
+)
+
+// Bounded 能给出世界空间范围的场景对象，用于 Scene.Bounds 和 Scene.FrameAll
+// 没有实现该接口的对象退而使用阴影遮挡体（ShadowCaster）的范围，都没有时不参与取景
+type Bounded interface {
+	WorldBounds(t float64) AABB
+}
+
+// ObjectBounds 场景对象在时间 t 的包围盒，ok 为 false 表示无法确定对象的范围
+func ObjectBounds(obj SceneObject, t float64) (box AABB, ok bool) {
+	switch o := obj.(type) {
+	case *LayeredObject:
+		return ObjectBounds(o.Object, t)
+	case Bounded:
+		return o.WorldBounds(t), true
+	case ShadowCaster:
+		spheres := o.ShadowSpheres(t)
+		for i, sphere := range spheres {
+			if i == 0 {
+				box = sphereBounds(sphere.Center, sphere.Radius)
+			} else {
+				box = box.union(sphereBounds(sphere.Center, sphere.Radius))
+			}
+		}
+		return box, len(spheres) > 0
+	}
+	return AABB{}, false
+}
+
+// Bounds 场景中所有能确定范围的对象在时间 t 的包围盒（不包括背景），ok 为 false 表示没有这样的对象
+func (s *Scene) Bounds(t float64) (box AABB, ok bool) {
+	for _, obj := range s.Objects {
+		objBox, objOK := ObjectBounds(obj, t)
+		switch {
+		case !objOK:
+		case !ok:
+			box, ok = objBox, true
+		default:
+			box = box.union(objBox)
+		}
+	}
+	return box, ok
+}
+
+// FrameAll 调整相机使时间 0 的整个场景充满画面，保持当前的视线方向和 FOV，四周留出 10% 的边距
+func (s *Scene) FrameAll(camera *Camera) error {
+	box, ok := s.Bounds(0)
+	if !ok {
+		return fmt.Errorf("场景中没有能确定范围的对象，无法自动取景")
+	}
+	camera.FitToBounds(box, 0.1)
+	return nil
+}
+
+// FitToBounds 沿当前视线方向移动相机，使包围盒恰好充满画面，margin 为四周的相对边距（0.1 为 10%）
+// 目标点移到包围盒中心；FOV 无效时使用 45°；近远裁剪面按需要放宽，保证整个包围盒都不被裁剪。
+// 水平方向按与垂直 FOV 相同的视场计算，横向画面总能容纳整个包围盒，竖向画面左右可能被裁掉
+func (c *Camera) FitToBounds(box AABB, margin float64) {
+	if !(c.FOV > 0 && c.FOV < math.Pi) {
+		c.FOV = math.Pi / 4
+	}
+	forward, right, up := c.Basis()
+	tanHalf := math.Tan(c.FOV / 2)
+
+	// 每个角点都要落在视锥内：横向偏移 ≤ 深度 × tanHalf，深度 = 距离 + 角点沿视线的偏移
+	center := box.Center()
+	distance, radius := 0.0, 0.0
+	for i := range 8 {
+		offset := box.corner(i).Sub(center)
+		lateral := math.Max(math.Abs(offset.Dot(right)), math.Abs(offset.Dot(up))) * (1 + margin)
+		distance = math.Max(distance, lateral/tanHalf-offset.Dot(forward))
+		radius = math.Max(radius, offset.Length())
+	}
+	if distance < 1e-6 {
+		distance = 1
+	}
+
+	c.Target = center
+	c.Position = center.Sub(forward.Scale(distance))
+	if near := distance - radius; near > 0 && near < c.Near {
+		c.Near = near
+	} else if near <= 0 {
+		c.Near = math.Min(c.Near, distance*0.01)
+	}
+	if far := distance + radius; far > c.Far {
+		c.Far = far
+	}
+}
+
+// sphereBounds 球体的包围盒
+func sphereBounds(center Vector3, radius float64) AABB {
+	return AABB{Min: center, Max: center}.grow(radius)
+}
+
+// grow 各方向向外扩展 margin 后的包围盒
+func (b AABB) grow(margin float64) AABB {
+	extent := NewVector3(margin, margin, margin)
+	return AABB{Min: b.Min.Sub(extent), Max: b.Max.Add(extent)}
+}
+
+// corner 包围盒的第 i 个角点（i 的三个二进制位分别选择 X、Y、Z 的最大值）
+func (b AABB) corner(i int) Vector3 {
+	corner := b.Min
+	if i&1 != 0 {
+		corner.X = b.Max.X
+	}
+	if i&2 != 0 {
+		corner.Y = b.Max.Y
+	}
+	if i&4 != 0 {
+		corner.Z = b.Max.Z
+	}
+	return corner
+}
+
+// transformBounds 包围盒经过矩阵变换后的包围盒（变换八个角点）
+func transformBounds(box AABB, m Matrix4) AABB {
+	var result AABB
+	for i := range 8 {
+		p := m.TransformVector(box.corner(i))
+		if i == 0 {
+			result = AABB{Min: p, Max: p}
+		} else {
+			result = result.union(AABB{Min: p, Max: p})
+		}
+	}
+	return result
+}
@@ -0,0 +1,132 @@
+package go3d
+
+import "math"
+
+// AABB 轴对齐包围盒
+type AABB struct {
+	Min, Max Vector3
+}
+
+// Center 包围盒中心
+func (b AABB) Center() Vector3 {
+	return b.Min.Add(b.Max).Scale(0.5)
+}
+
+// Size 包围盒各轴尺寸
+func (b AABB) Size() Vector3 {
+	return b.Max.Sub(b.Min)
+}
+
+// Contains 点是否在包围盒内（含边界）
+func (b AABB) Contains(p Vector3) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X &&
+		p.Y >= b.Min.Y && p.Y <= b.Max.Y &&
+		p.Z >= b.Min.Z && p.Z <= b.Max.Z
+}
+
+// BoundingBox 网格的轴对齐包围盒
+func (m *Mesh) BoundingBox() AABB {
+	minV, maxV := m.Bounds()
+	return AABB{Min: minV, Max: maxV}
+}
+
+// Frustum 视锥体：六个平面的法线都指向视锥内部，内部点到各平面的有向距离均不小于 0
+// 平面顺序为左、右、下、上、近、远
+type Frustum struct {
+	Planes [6]Plane
+}
+
+// NewFrustumFromMatrix 从视图投影矩阵（Perspective(...).Multiply(LookAt(...))）提取视锥平面
+func NewFrustumFromMatrix(viewProjection Matrix4) Frustum {
+	row := func(i int) [4]float64 {
+		return [4]float64{viewProjection[i*4], viewProjection[i*4+1], viewProjection[i*4+2], viewProjection[i*4+3]}
+	}
+	r0, r1, r2, r3 := row(0), row(1), row(2), row(3)
+
+	// 裁剪空间中 -w ≤ x, y, z ≤ w，每个不等式对应一个平面 a·x + b·y + c·z + d ≥ 0
+	combine := func(a [4]float64, sign float64, b [4]float64) Plane {
+		normal := NewVector3(a[0]+sign*b[0], a[1]+sign*b[1], a[2]+sign*b[2])
+		d := a[3] + sign*b[3]
+		length := normal.Length()
+		if length < 1e-12 {
+			return Plane{}
+		}
+		return Plane{Normal: normal.Scale(1 / length), D: -d / length}
+	}
+
+	return Frustum{Planes: [6]Plane{
+		combine(r3, 1, r0),
+		combine(r3, -1, r0),
+		combine(r3, 1, r1),
+		combine(r3, -1, r1),
+		combine(r3, 1, r2),
+		combine(r3, -1, r2),
+	}}
+}
+
+// ViewProjection 相机的视图投影矩阵，aspect 为画面宽高比
+func (c *Camera) ViewProjection(aspect float64) Matrix4 {
+	view := LookAt(c.Position, c.Target, c.Up)
+	return Perspective(c.FOV, aspect, c.Near, c.Far).Multiply(view)
+}
+
+// Frustum 相机的视锥体，aspect 为画面宽高比
+func (c *Camera) Frustum(aspect float64) Frustum {
+	return NewFrustumFromMatrix(c.ViewProjection(aspect))
+}
+
+// ViewFrustum 渲染器当前相机的视锥体，宽高比与画布一致
+func (r *Renderer) ViewFrustum() Frustum {
+	return r.Camera.Frustum(float64(r.Width) / float64(r.Height))
+}
+
+// ContainsPoint 点是否在视锥内
+func (f Frustum) ContainsPoint(p Vector3) bool {
+	for _, plane := range f.Planes {
+		if plane.Distance(p) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IntersectsSphere 球体是否与视锥相交或位于其中
+// 对每个平面单独判断，靠近视锥角落的球可能被误判为相交，适合用于保守剔除
+func (f Frustum) IntersectsSphere(center Vector3, radius float64) bool {
+	for _, plane := range f.Planes {
+		if plane.Distance(center) < -radius {
+			return false
+		}
+	}
+	return true
+}
+
+// IntersectsAABB 包围盒是否与视锥相交或位于其中（保守判断，同 IntersectsSphere）
+func (f Frustum) IntersectsAABB(box AABB) bool {
+	for _, plane := range f.Planes {
+		// 沿法线方向最远的角点都在平面外侧时，整个包围盒在视锥外
+		corner := NewVector3(
+			math.Max(box.Min.X*plane.Normal.X, box.Max.X*plane.Normal.X),
+			math.Max(box.Min.Y*plane.Normal.Y, box.Max.Y*plane.Normal.Y),
+			math.Max(box.Min.Z*plane.Normal.Z, box.Max.Z*plane.Normal.Z),
+		)
+		if corner.X+corner.Y+corner.Z-plane.D < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// SetFrustumCulling 设置是否启用视锥剔除
+func (r *Renderer) SetFrustumCulling(enabled bool) {
+	r.FrustumCulling = enabled
+}
+
+// culled 启用视锥剔除时，网格的包围球是否完全位于视锥外
+func (r *Renderer) culled(mesh *Mesh) bool {
+	if !r.FrustumCulling || len(mesh.Triangles) == 0 {
+		return false
+	}
+	center, radius := mesh.BoundingSphere()
+	return !r.ViewFrustum().IntersectsSphere(center, radius)
+}
@@ -0,0 +1,197 @@
+package go3d
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+)
+
+// GBuffer 渲染通道：与主画面同尺寸的逐像素深度、法线、物体编号和无光照基础色
+// 通道按网格表面生成，与渲染模式无关；每个像素取像素中心处最近的表面（不做抗锯齿）
+type GBuffer struct {
+	Width    int
+	Height   int
+	Depth    []float64    // 沿相机视线方向的距离，没有表面的像素为 +Inf
+	Normal   []Vector3    // 世界空间单位法线，朝向相机一侧
+	ObjectID []int        // 物体编号（见 SetObjectID），没有表面的像素为 0
+	Albedo   [][3]float64 // 无光照的基础色（sRGB，已乘纹理颜色）
+}
+
+// SetGBuffer 设置是否生成渲染通道
+// 启用后绘制的网格会额外被收集，Scene.Render 结束时自动生成 Renderer.GBuffer；
+// 不使用 Scene 时在 Clear 之后绘制，再调用 ResolveGBuffer
+func (r *Renderer) SetGBuffer(enabled bool) {
+	r.gbufferEnabled = enabled
+	r.gbTriangles = r.gbTriangles[:0]
+	r.gbInfo = r.gbInfo[:0]
+}
+
+// SetObjectID 设置之后绘制的网格所属的物体编号，用于物体编号通道
+// Scene.Render 按对象在场景中的顺序自动设置为 1、2、3……，0 表示不属于任何物体
+func (r *Renderer) SetObjectID(id int) {
+	r.objectID = id
+}
+
+// collectingGBuffer 是否需要收集网格生成渲染通道（启用了渲染通道或景深）
+func (r *Renderer) collectingGBuffer() bool {
+	return r.gbufferEnabled || r.depthOfField()
+}
+
+// collectGBuffer 需要渲染通道时收集网格的三角形
+func (r *Renderer) collectGBuffer(mesh *Mesh, color [3]float64, texture *Texture) {
+	if !r.collectingGBuffer() || len(mesh.Triangles) == 0 {
+		return
+	}
+	r.gbTriangles, r.gbInfo = r.appendRaytraced(r.gbTriangles, r.gbInfo, mesh, color, texture, true, -1)
+}
+
+// ResolveGBuffer 由 Clear 之后收集的网格生成渲染通道，保存到 Renderer.GBuffer 并返回
+// 未启用渲染通道（也未启用景深）时返回 nil
+func (r *Renderer) ResolveGBuffer() *GBuffer {
+	if !r.collectingGBuffer() {
+		return nil
+	}
+	defer func() {
+		r.gbTriangles = r.gbTriangles[:0]
+		r.gbInfo = r.gbInfo[:0]
+	}()
+
+	size := r.Width * r.Height
+	g := &GBuffer{
+		Width:    r.Width,
+		Height:   r.Height,
+		Depth:    make([]float64, size),
+		Normal:   make([]Vector3, size),
+		ObjectID: make([]int, size),
+		Albedo:   make([][3]float64, size),
+	}
+	for i := range g.Depth {
+		g.Depth[i] = math.Inf(1)
+	}
+	r.GBuffer = g
+	if len(r.gbTriangles) == 0 {
+		return g
+	}
+
+	rt := r.newRayCaster(r.gbTriangles, r.gbInfo)
+	rt.parallelRows(func(y int) {
+		for x := range r.Width {
+			dir, tMin, tMax := rt.cameraRay(float64(x)+0.5, float64(y)+0.5)
+			hit, ok := rt.bvh.Intersect(r.Camera.Position, dir, tMin, tMax)
+			if !ok {
+				continue
+			}
+			surface := rt.surfaceAt(hit, r.Camera.Position, dir)
+			i := y*r.Width + x
+			g.Depth[i] = hit.T * dir.Dot(rt.forward)
+			g.Normal[i] = surface.normal
+			g.ObjectID[i] = surface.info.object
+			for k := range 3 {
+				c := surface.base[k]
+				if r.LinearLighting {
+					c = LinearToSRGB(c)
+				}
+				g.Albedo[i][k] = math.Max(0, math.Min(1, c))
+			}
+		}
+	})
+	return g
+}
+
+// DepthImage 深度通道图像：near 处为黑色，far 处及没有表面的像素为白色
+// near 不小于 far 时使用画面中实际的最小和最大深度
+func (g *GBuffer) DepthImage(near, far float64) *image.Gray16 {
+	if near >= far {
+		near, far = math.Inf(1), math.Inf(-1)
+		for _, d := range g.Depth {
+			if !math.IsInf(d, 1) {
+				near = math.Min(near, d)
+				far = math.Max(far, d)
+			}
+		}
+		if far <= near {
+			far = near + 1
+		}
+	}
+
+	img := image.NewGray16(image.Rect(0, 0, g.Width, g.Height))
+	for i, d := range g.Depth {
+		t := math.Max(0, math.Min(1, (d-near)/(far-near)))
+		img.SetGray16(i%g.Width, i/g.Width, color.Gray16{Y: uint16(t*65535 + 0.5)})
+	}
+	return img
+}
+
+// NormalImage 法线通道图像：分量从 [-1, 1] 映射到 [0, 255]，没有表面的像素透明
+func (g *GBuffer) NormalImage() *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, g.Width, g.Height))
+	for i, n := range g.Normal {
+		if math.IsInf(g.Depth[i], 1) {
+			continue
+		}
+		img.Pix[i*4] = clampByte(n.X*0.5 + 0.5)
+		img.Pix[i*4+1] = clampByte(n.Y*0.5 + 0.5)
+		img.Pix[i*4+2] = clampByte(n.Z*0.5 + 0.5)
+		img.Pix[i*4+3] = 255
+	}
+	return img
+}
+
+// ObjectIDImage 物体编号通道图像：灰度值即物体编号，0 为没有物体
+func (g *GBuffer) ObjectIDImage() *image.Gray16 {
+	img := image.NewGray16(image.Rect(0, 0, g.Width, g.Height))
+	for i, id := range g.ObjectID {
+		img.SetGray16(i%g.Width, i/g.Width, color.Gray16{Y: uint16(max(0, min(id, 65535)))})
+	}
+	return img
+}
+
+// AlbedoImage 无光照基础色通道图像，没有表面的像素透明
+func (g *GBuffer) AlbedoImage() *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, g.Width, g.Height))
+	for i, c := range g.Albedo {
+		if math.IsInf(g.Depth[i], 1) {
+			continue
+		}
+		img.Pix[i*4] = clampByte(c[0])
+		img.Pix[i*4+1] = clampByte(c[1])
+		img.Pix[i*4+2] = clampByte(c[2])
+		img.Pix[i*4+3] = 255
+	}
+	return img
+}
+
+// SavePNGs 把所有通道保存为 PNG：prefix_depth.png、prefix_normal.png、prefix_id.png、prefix_albedo.png
+// 深度按画面中实际的深度范围归一化
+func (g *GBuffer) SavePNGs(prefix string) error {
+	passes := []struct {
+		suffix string
+		img    image.Image
+	}{
+		{"_depth.png", g.DepthImage(0, 0)},
+		{"_normal.png", g.NormalImage()},
+		{"_id.png", g.ObjectIDImage()},
+		{"_albedo.png", g.AlbedoImage()},
+	}
+	for _, pass := range passes {
+		if err := savePNG(prefix+pass.suffix, pass.img); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// savePNG 把图像编码为 PNG 文件
+func savePNG(filename string, img image.Image) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(file, img); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
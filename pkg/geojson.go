@@ -0,0 +1,183 @@
+package go3d
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// GeoFeature GeoJSON 中的一个要素：线（LineString）和多边形的各条边界（Polygon 的外环和内环）都转换为折线，
+// 点（Point）保留为坐标；Multi* 和 GeometryCollection 展开为多条折线或多个点
+type GeoFeature struct {
+	Properties map[string]any
+	Lines      [][]GeoCoord
+	Points     []GeoCoord
+}
+
+// Name 要素的名称：依次查找 name、NAME、ADMIN 和 admin 属性，都没有时返回空字符串
+func (f GeoFeature) Name() string {
+	for _, key := range []string{"name", "NAME", "ADMIN", "admin"} {
+		if name, ok := f.Properties[key].(string); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// geoJSONObject GeoJSON 对象的通用结构，按 Type 使用其中的字段
+type geoJSONObject struct {
+	Type        string          `json:"type"`
+	Features    []geoJSONObject `json:"features"`
+	Geometry    *geoJSONObject  `json:"geometry"`
+	Geometries  []geoJSONObject `json:"geometries"`
+	Properties  map[string]any  `json:"properties"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// ReadGeoJSON 读取 GeoJSON（FeatureCollection、Feature 或单独的几何对象），返回所有要素。
+// 坐标按 GeoJSON 规范为 [经度, 纬度]，高度分量被忽略
+func ReadGeoJSON(r io.Reader) ([]GeoFeature, error) {
+	var root geoJSONObject
+	if err := json.NewDecoder(r).Decode(&root); err != nil {
+		return nil, fmt.Errorf("GeoJSON: %w", err)
+	}
+	switch root.Type {
+	case "FeatureCollection":
+		features := make([]GeoFeature, 0, len(root.Features))
+		for i, object := range root.Features {
+			feature, err := readGeoJSONFeature(object)
+			if err != nil {
+				return nil, fmt.Errorf("GeoJSON: 第 %d 个要素: %w", i+1, err)
+			}
+			features = append(features, feature)
+		}
+		return features, nil
+	case "Feature":
+		feature, err := readGeoJSONFeature(root)
+		if err != nil {
+			return nil, fmt.Errorf("GeoJSON: %w", err)
+		}
+		return []GeoFeature{feature}, nil
+	case "":
+		return nil, errors.New("GeoJSON: 缺少 type")
+	}
+	var feature GeoFeature
+	if err := feature.addGeometry(root); err != nil {
+		return nil, fmt.Errorf("GeoJSON: %w", err)
+	}
+	return []GeoFeature{feature}, nil
+}
+
+// LoadGeoJSON 从文件读取 GeoJSON 要素
+func LoadGeoJSON(filename string) ([]GeoFeature, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadGeoJSON(file)
+}
+
+// readGeoJSONFeature 转换一个 Feature 对象，几何为 null 的要素只保留属性
+func readGeoJSONFeature(object geoJSONObject) (GeoFeature, error) {
+	if object.Type != "Feature" {
+		return GeoFeature{}, fmt.Errorf("应为 Feature，实际为 %q", object.Type)
+	}
+	feature := GeoFeature{Properties: object.Properties}
+	if object.Geometry == nil {
+		return feature, nil
+	}
+	return feature, feature.addGeometry(*object.Geometry)
+}
+
+// addGeometry 把几何对象中的线和点加入要素
+func (f *GeoFeature) addGeometry(geometry geoJSONObject) error {
+	if geometry.Type == "GeometryCollection" {
+		for _, g := range geometry.Geometries {
+			if err := f.addGeometry(g); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var err error
+	switch geometry.Type {
+	case "Point":
+		var position []float64
+		if err = json.Unmarshal(geometry.Coordinates, &position); err == nil {
+			var coord GeoCoord
+			if coord, err = geoPosition(position); err == nil {
+				f.Points = append(f.Points, coord)
+			}
+		}
+	case "MultiPoint":
+		var positions [][]float64
+		if err = json.Unmarshal(geometry.Coordinates, &positions); err == nil {
+			var coords []GeoCoord
+			if coords, err = geoPositions(positions); err == nil {
+				f.Points = append(f.Points, coords...)
+			}
+		}
+	case "LineString":
+		var positions [][]float64
+		if err = json.Unmarshal(geometry.Coordinates, &positions); err == nil {
+			err = f.addLines([][][]float64{positions})
+		}
+	case "MultiLineString", "Polygon":
+		var lines [][][]float64
+		if err = json.Unmarshal(geometry.Coordinates, &lines); err == nil {
+			err = f.addLines(lines)
+		}
+	case "MultiPolygon":
+		var polygons [][][][]float64
+		if err = json.Unmarshal(geometry.Coordinates, &polygons); err == nil {
+			for _, rings := range polygons {
+				if err = f.addLines(rings); err != nil {
+					break
+				}
+			}
+		}
+	default:
+		return fmt.Errorf("不支持的几何类型 %q", geometry.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", geometry.Type, err)
+	}
+	return nil
+}
+
+// addLines 把坐标数组转换为折线加入要素
+func (f *GeoFeature) addLines(lines [][][]float64) error {
+	for _, positions := range lines {
+		coords, err := geoPositions(positions)
+		if err != nil {
+			return err
+		}
+		f.Lines = append(f.Lines, coords)
+	}
+	return nil
+}
+
+// geoPositions 转换 GeoJSON 坐标数组
+func geoPositions(positions [][]float64) ([]GeoCoord, error) {
+	coords := make([]GeoCoord, len(positions))
+	for i, position := range positions {
+		coord, err := geoPosition(position)
+		if err != nil {
+			return nil, err
+		}
+		coords[i] = coord
+	}
+	return coords, nil
+}
+
+// geoPosition 转换一个 [经度, 纬度, 高度] 坐标
+func geoPosition(position []float64) (GeoCoord, error) {
+	if len(position) < 2 {
+		return GeoCoord{}, errors.New("坐标需要包含经度和纬度")
+	}
+	return GeoCoord{Lat: position[1], Lon: position[0]}, nil
+}
@@ -0,0 +1,99 @@
+package go3d
+
+import "sort"
+
+// GizmoCorner 方向指示器所在的画面角落
+type GizmoCorner int
+
+const (
+	GizmoBottomLeft  GizmoCorner = iota // 左下角（默认）
+	GizmoBottomRight                    // 右下角
+	GizmoTopLeft                        // 左上角
+	GizmoTopRight                       // 右上角
+)
+
+// AxisGizmo 固定在画面角落的方向指示器：三条世界坐标轴随相机旋转，但长度以像素为单位，
+// 不随相机距离缩放，与世界空间中的 CoordinateSystem 无关。设置 Renderer.AxisGizmo 后由 Scene.Render 绘制
+type AxisGizmo struct {
+	Corner    GizmoCorner
+	Size      float64       // 轴长（像素）
+	Margin    float64       // 指示器中心与画面边缘的距离（像素，不含轴长）
+	LineWidth float64       // 轴线宽度（像素）
+	FontSize  float64       // 轴标签字号
+	Colors    [3][3]float64 // X、Y、Z 轴的颜色
+	Labels    [3]string     // X、Y、Z 轴的标签，空字符串表示不绘制
+}
+
+// NewAxisGizmo 创建左下角的方向指示器，配色与 CoordinateSystem 相同
+func NewAxisGizmo() *AxisGizmo {
+	return &AxisGizmo{
+		Corner:    GizmoBottomLeft,
+		Size:      36,
+		Margin:    16,
+		LineWidth: 2,
+		FontSize:  12,
+		Colors:    [3][3]float64{{1.0, 0.3, 0.3}, {0.3, 1.0, 0.3}, {0.3, 0.3, 1.0}},
+		Labels:    [3]string{"X", "Y", "Z"},
+	}
+}
+
+// center 指示器中心在画面上的位置
+func (g *AxisGizmo) center(width, height int) (float64, float64) {
+	offset := g.Margin + g.Size + g.FontSize
+	x, y := offset, float64(height)-offset
+	if g.Corner == GizmoBottomRight || g.Corner == GizmoTopRight {
+		x = float64(width) - offset
+	}
+	if g.Corner == GizmoTopLeft || g.Corner == GizmoTopRight {
+		y = offset
+	}
+	return x, y
+}
+
+// drawAxisGizmo 在几何体之后绘制方向指示器
+func (r *Renderer) drawAxisGizmo() {
+	if g := r.AxisGizmo; g != nil {
+		r.DrawOverlay(func() {
+			g.draw(r)
+		})
+	}
+}
+
+// draw 按相机朝向绘制三条轴：指向画面里面的轴先画，被指向观察者的轴覆盖
+func (g *AxisGizmo) draw(r *Renderer) {
+	forward, right, up := r.Camera.Basis()
+	cx, cy := g.center(r.Width, r.Height)
+
+	axes := []int{0, 1, 2}
+	dirs := [3]Vector3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	sort.SliceStable(axes, func(i, j int) bool {
+		return dirs[axes[i]].Dot(forward) > dirs[axes[j]].Dot(forward)
+	})
+
+	r.Context.Save()
+	defer r.Context.Restore()
+	r.Context.SetLineWidth(g.LineWidth)
+	r.Context.SetLineCap(lineCapRound)
+
+	for _, i := range axes {
+		dx, dy := dirs[i].Dot(right), -dirs[i].Dot(up)
+		color := g.Colors[i]
+		r.Context.SetSourceRGB(color[0], color[1], color[2])
+		r.Context.MoveTo(cx, cy)
+		r.Context.LineTo(cx+dx*g.Size, cy+dy*g.Size)
+		r.Context.Stroke()
+
+		if g.Labels[i] == "" || g.FontSize <= 0 {
+			continue
+		}
+		layout, ok := newTextLayout(r.Context, g.Labels[i], true, g.FontSize)
+		if !ok {
+			continue
+		}
+		// 标签中心放在轴的延长线上，比轴端多出约一个字宽
+		extents := layout.inkExtents()
+		reach := g.Size + g.FontSize*0.7
+		layout.show(cx+dx*reach-extents.X-extents.Width/2, cy+dy*reach-extents.Y-extents.Height/2)
+		layout.destroy()
+	}
+}
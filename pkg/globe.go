@@ -0,0 +1,333 @@
+package go3d
+
+import (
+	"math"
+)
+
+// GeoCoord 地理坐标（度），北纬和东经为正
+type GeoCoord struct {
+	Lat, Lon float64
+}
+
+// LatLonToVector3 经纬度（度）在半径为 radius、球心在原点的球面上的位置。
+// 北极为 +Y，经度 0 位于 -X，东经 90° 位于 +Z，与 CreateSphere 的等距圆柱投影纹理坐标一致
+func LatLonToVector3(lat, lon, radius float64) Vector3 {
+	phi, lambda := lat*math.Pi/180, lon*math.Pi/180
+	return NewVector3(
+		-radius*math.Cos(phi)*math.Cos(lambda),
+		radius*math.Sin(phi),
+		radius*math.Cos(phi)*math.Sin(lambda),
+	)
+}
+
+// Vector3ToLatLon 方向 v（相对于球心）对应的经纬度（度），是 LatLonToVector3 的逆变换
+func Vector3ToLatLon(v Vector3) GeoCoord {
+	horizontal := math.Hypot(v.X, v.Z)
+	return GeoCoord{
+		Lat: math.Atan2(v.Y, horizontal) * 180 / math.Pi,
+		Lon: math.Atan2(v.Z, -v.X) * 180 / math.Pi,
+	}
+}
+
+// unit 坐标在单位球面上的方向
+func (c GeoCoord) unit() Vector3 {
+	return LatLonToVector3(c.Lat, c.Lon, 1)
+}
+
+// GreatCircleDistance 两点之间的大圆角距离（弧度），乘以半径即为球面距离
+func GreatCircleDistance(a, b GeoCoord) float64 {
+	u, v := a.unit(), b.unit()
+	return math.Atan2(u.Cross(v).Length(), u.Dot(v))
+}
+
+// greatCircle 单位向量 u 到 v 的大圆插值（球面线性插值），s 为 0 到 1
+func greatCircle(u, v Vector3, s float64) Vector3 {
+	angle := math.Atan2(u.Cross(v).Length(), u.Dot(v))
+	if angle < 1e-9 {
+		return u
+	}
+	sin := math.Sin(angle)
+	return u.Scale(math.Sin((1-s)*angle) / sin).Add(v.Scale(math.Sin(s*angle) / sin))
+}
+
+// GeoPath 贴在球面上的折线（国界、海岸线、航迹），相邻两点之间沿大圆连接
+type GeoPath struct {
+	Coords []GeoCoord
+	Color  [3]float64
+	Width  float64 // 线宽（像素），0 表示使用 Globe.LineWidth
+}
+
+// GeoPoint 球面上的标记点，Label 不为空时在点的右侧显示文字
+type GeoPoint struct {
+	Coord  GeoCoord
+	Color  [3]float64
+	Size   float64 // 圆点半径（像素）
+	Label  string
+	Height float64 // 离地高度（世界单位）
+}
+
+// GeoArc 两点之间拱起的大圆弧（航线），Progress 控制从起点画到终点的进度，适合航线动画
+type GeoArc struct {
+	From, To GeoCoord
+	Color    [3]float64
+	Width    float64 // 线宽（像素），0 表示使用 Globe.LineWidth
+	Height   float64 // 弧线中点的离地高度与半径之比，按两点距离缩放：相距半个地球时为 Height
+	Progress float64 // 绘制进度（0-1），未画完时在弧线前端绘制一个圆点
+}
+
+// Animate 在时间线上添加航线动画：从 start 时刻开始从起点画出弧线，到 end 时刻到达终点
+func (a *GeoArc) Animate(timeline *Timeline, start, end float64, easing func(float64) float64) *GeoArc {
+	timeline.AddTrack(NewFloatTrack(&a.Progress).
+		AddKeyframe(start, 0, easing).
+		AddKeyframe(end, 1, nil))
+	return a
+}
+
+// Globe 地球仪：纹理或纯色球体，加上贴在球面上的经纬网、折线（可以从 GeoJSON 导入国界）、标记点和航线弧线。
+// 线和点在几何体之后绘制，被球体挡住的部分（背面）不绘制
+type Globe struct {
+	Center   Vector3
+	Radius   float64
+	Color    [3]float64
+	Texture  *Texture // 等距圆柱投影贴图（经度 -180° 到 180°，北极在上），设置后优先于纯色
+	Segments int      // 球体的经线分段数
+
+	Graticule      float64 // 经纬网间隔（度），0 表示不绘制
+	GraticuleColor [3]float64
+	LineWidth      float64 // 默认线宽（像素）
+
+	Paths  []*GeoPath
+	Points []*GeoPoint
+	Arcs   []*GeoArc
+}
+
+// NewGlobe 创建球心在原点、半径为 radius 的蓝色地球仪，带 30° 间隔的经纬网
+func NewGlobe(radius float64) *Globe {
+	return &Globe{
+		Radius:         radius,
+		Color:          [3]float64{0.15, 0.3, 0.6},
+		Segments:       64,
+		Graticule:      30,
+		GraticuleColor: [3]float64{0.4, 0.5, 0.7},
+		LineWidth:      1,
+	}
+}
+
+// SetTexture 设置等距圆柱投影的地表贴图
+func (g *Globe) SetTexture(texture *Texture) *Globe {
+	g.Texture = texture
+	return g
+}
+
+// Position 经纬度处离地 altitude（世界单位）的世界坐标
+func (g *Globe) Position(coord GeoCoord, altitude float64) Vector3 {
+	return g.Center.Add(LatLonToVector3(coord.Lat, coord.Lon, g.Radius+altitude))
+}
+
+// AddPath 添加贴在球面上的折线
+func (g *Globe) AddPath(coords []GeoCoord, color [3]float64) *GeoPath {
+	path := &GeoPath{Coords: coords, Color: color}
+	g.Paths = append(g.Paths, path)
+	return path
+}
+
+// AddPoint 添加标记点
+func (g *Globe) AddPoint(coord GeoCoord, color [3]float64, label string) *GeoPoint {
+	point := &GeoPoint{Coord: coord, Color: color, Size: 3, Label: label}
+	g.Points = append(g.Points, point)
+	return point
+}
+
+// AddArc 添加两点之间的航线弧线，默认已完整画出
+func (g *Globe) AddArc(from, to GeoCoord, color [3]float64) *GeoArc {
+	arc := &GeoArc{From: from, To: to, Color: color, Height: 0.25, Progress: 1}
+	g.Arcs = append(g.Arcs, arc)
+	return arc
+}
+
+// AddGeoJSON 把 GeoJSON 要素中的线和多边形边界添加为折线，点添加为标记点（以要素名称为标签）
+func (g *Globe) AddGeoJSON(features []GeoFeature, color [3]float64) *Globe {
+	for _, f := range features {
+		for _, line := range f.Lines {
+			g.AddPath(line, color)
+		}
+		for _, p := range f.Points {
+			g.AddPoint(p, color, f.Name())
+		}
+	}
+	return g
+}
+
+// Render 依次绘制球体、经纬网、折线、航线和标记点
+func (g *Globe) Render(renderer *Renderer, t float64) {
+	segments := max(8, g.Segments)
+	sphere := unitPrimitive(primitiveSphere, segments, segments/2, 0)
+	model := Translation(g.Center.X, g.Center.Y, g.Center.Z).Multiply(Scale(g.Radius, g.Radius, g.Radius))
+	if g.Texture != nil {
+		renderer.DrawTexturedMeshTransformed(sphere, model, g.Texture, g.Color)
+	} else {
+		renderer.DrawMeshTransformed(sphere, model, g.Color)
+	}
+
+	if g.Graticule > 0 {
+		for _, line := range graticule(g.Graticule) {
+			g.drawPath(renderer, line, g.GraticuleColor, g.LineWidth)
+		}
+	}
+	for _, path := range g.Paths {
+		g.drawPath(renderer, path.Coords, path.Color, g.width(path.Width))
+	}
+	for _, arc := range g.Arcs {
+		g.drawArc(renderer, arc)
+	}
+	for _, point := range g.Points {
+		g.drawPoint(renderer, point)
+	}
+}
+
+// width 线宽，0 表示默认线宽
+func (g *Globe) width(w float64) float64 {
+	if w > 0 {
+		return w
+	}
+	return g.LineWidth
+}
+
+// graticule 间隔为 step 度的纬线（不含两极）和经线；纬线不是大圆，每 2° 取一个点
+func graticule(step float64) [][]GeoCoord {
+	var lines [][]GeoCoord
+	for lat := -90 + step; lat < 90-1e-9; lat += step {
+		line := make([]GeoCoord, 0, 181)
+		for lon := -180.0; lon <= 180; lon += 2 {
+			line = append(line, GeoCoord{lat, lon})
+		}
+		lines = append(lines, line)
+	}
+	for lon := -180.0; lon < 180-1e-9; lon += step {
+		lines = append(lines, []GeoCoord{{-90, lon}, {0, lon}, {90, lon}})
+	}
+	return lines
+}
+
+// visible 世界坐标 p 是否没有被球体挡住：从相机到 p 的线段在到达 p 之前不与球面相交
+func (g *Globe) visible(eye, p Vector3) bool {
+	d := p.Sub(eye)
+	oc := eye.Sub(g.Center)
+	a := d.Dot(d)
+	b := 2 * oc.Dot(d)
+	c := oc.Dot(oc) - g.Radius*g.Radius
+	discriminant := b*b - 4*a*c
+	if a == 0 || discriminant <= 0 {
+		return true
+	}
+	s := (-b - math.Sqrt(discriminant)) / (2 * a)
+	return s <= 0 || s >= 1-1e-6
+}
+
+// drawVisible 把折线中没有被球体挡住的连续部分分段绘制
+func (g *Globe) drawVisible(renderer *Renderer, points []Vector3, color [3]float64, width float64) {
+	eye := renderer.Camera.Position
+	var run []Vector3
+	for _, p := range points {
+		if g.visible(eye, p) {
+			run = append(run, p)
+			continue
+		}
+		if len(run) > 1 {
+			renderer.DrawPolyline3D(run, color, width)
+		}
+		run = nil
+	}
+	if len(run) > 1 {
+		renderer.DrawPolyline3D(run, color, width)
+	}
+}
+
+// drawPath 沿大圆加密折线（每段不超过 2°）后抬高一点绘制，避免与球面三角形穿插
+func (g *Globe) drawPath(renderer *Renderer, coords []GeoCoord, color [3]float64, width float64) {
+	if len(coords) < 2 {
+		return
+	}
+	lift := g.Radius * 1.002
+	var points []Vector3
+	for i := 0; i+1 < len(coords); i++ {
+		u, v := coords[i].unit(), coords[i+1].unit()
+		steps := max(1, int(math.Ceil(math.Atan2(u.Cross(v).Length(), u.Dot(v))/(2*math.Pi/180))))
+		for s := range steps {
+			points = append(points, g.Center.Add(greatCircle(u, v, float64(s)/float64(steps)).Scale(lift)))
+		}
+	}
+	points = append(points, g.Center.Add(coords[len(coords)-1].unit().Scale(lift)))
+	g.drawVisible(renderer, points, color, width)
+}
+
+// drawArc 绘制拱起的航线：高度沿弧线按正弦变化，只画到 Progress 处
+func (g *Globe) drawArc(renderer *Renderer, arc *GeoArc) {
+	progress := math.Max(0, math.Min(1, arc.Progress))
+	if progress <= 0 {
+		return
+	}
+	u, v := arc.From.unit(), arc.To.unit()
+	angle := math.Atan2(u.Cross(v).Length(), u.Dot(v))
+	peak := arc.Height * g.Radius * angle / math.Pi
+	steps := max(2, int(math.Ceil(angle/(math.Pi/180)*progress)))
+	points := make([]Vector3, 0, steps+1)
+	for i := 0; i <= steps; i++ {
+		s := progress * float64(i) / float64(steps)
+		radius := g.Radius*1.002 + peak*math.Sin(math.Pi*s)
+		points = append(points, g.Center.Add(greatCircle(u, v, s).Scale(radius)))
+	}
+	width := g.width(arc.Width)
+	g.drawVisible(renderer, points, arc.Color, width)
+
+	head := points[len(points)-1]
+	if progress < 1 && g.visible(renderer.Camera.Position, head) {
+		renderer.DrawOverlay(func() {
+			x, y, z := renderer.ProjectToScreen(head)
+			if z < -1 || z > 1 {
+				return
+			}
+			renderer.Context.Save()
+			defer renderer.Context.Restore()
+			renderer.Context.SetSourceRGB(arc.Color[0], arc.Color[1], arc.Color[2])
+			renderer.Context.NewSubPath()
+			renderer.Context.Arc(x, y, width*2+1, 0, 2*math.Pi)
+			renderer.Context.Fill()
+		})
+	}
+}
+
+// drawPoint 绘制没有被球体挡住的标记点和标签
+func (g *Globe) drawPoint(renderer *Renderer, point *GeoPoint) {
+	position := g.Position(point.Coord, point.Height+g.Radius*0.002)
+	if !g.visible(renderer.Camera.Position, position) {
+		return
+	}
+	renderer.DrawOverlay(func() {
+		x, y, z := renderer.ProjectToScreen(position)
+		if z < -1 || z > 1 {
+			return
+		}
+		renderer.Context.Save()
+		defer renderer.Context.Restore()
+		renderer.Context.SetSourceRGB(point.Color[0], point.Color[1], point.Color[2])
+		renderer.Context.NewSubPath()
+		renderer.Context.Arc(x, y, point.Size, 0, 2*math.Pi)
+		renderer.Context.Fill()
+		if point.Label != "" {
+			showTextLeft(renderer, point.Label, x+point.Size+4, y, 12)
+		}
+	})
+}
+
+// WorldBounds 球体及航线最高点的范围
+func (g *Globe) WorldBounds(t float64) AABB {
+	height := 0.0
+	for _, arc := range g.Arcs {
+		height = math.Max(height, arc.Height*g.Radius)
+	}
+	for _, point := range g.Points {
+		height = math.Max(height, point.Height)
+	}
+	return sphereBounds(g.Center, g.Radius+height)
+}
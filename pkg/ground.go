@@ -0,0 +1,171 @@
+package go3d
+
+import (
+	"image"
+	"math"
+)
+
+// GroundPlane 水平的无限地面：逐像素计算视线与平面 y = Height 的交点并按光源着色，
+// 场景中每个能确定范围的对象（见 ObjectBounds）在地面上投下柔和的圆形接触阴影，
+// 让单个物体的转台展示不再悬浮在空中。地面远处逐渐淡出，与背景自然衔接。
+// 地面不参与深度排序，需要在其它对象之前绘制（Scene.AddGroundPlane 会把它放在对象列表最前面）
+type GroundPlane struct {
+	Scene  *Scene // 投射接触阴影的对象所在的场景
+	Height float64
+	Color  [3]float64
+
+	ShadowOpacity  float64 // 贴地物体正下方阴影的不透明度（0-1）
+	ShadowSoftness float64 // 阴影边缘的柔和程度（阴影半径的比例，0-1）
+	Fade           float64 // 地面完全淡出的水平距离（从相机算起），0 表示不淡出
+}
+
+// NewGroundPlane 创建位于 y = height 的地面，scene 中的对象在地面上投下阴影
+func NewGroundPlane(scene *Scene, height float64) *GroundPlane {
+	return &GroundPlane{
+		Scene:          scene,
+		Height:         height,
+		Color:          [3]float64{0.5, 0.5, 0.5},
+		ShadowOpacity:  0.6,
+		ShadowSoftness: 0.5,
+		Fade:           50,
+	}
+}
+
+// AddGroundPlane 在 y = height 处添加地面并返回它，地面放在对象列表的最前面，先于其它对象绘制
+// （其它对象的物体编号因此各加 1）
+func (s *Scene) AddGroundPlane(height float64) *GroundPlane {
+	ground := NewGroundPlane(s, height)
+	s.Objects = append([]SceneObject{ground}, s.Objects...)
+	return ground
+}
+
+// groundShadow 一个对象在地面上的圆形阴影
+type groundShadow struct {
+	x, z    float64
+	inner   float64 // 完全遮挡的半径
+	outer   float64 // 阴影消失的半径
+	opacity float64
+}
+
+// shadows 时间 t 时场景中各对象投下的阴影：阴影中心是包围盒中心沿主光源方向在地面上的投影
+// （没有光源时在正下方），对象离地越高，阴影越大越淡
+func (g *GroundPlane) shadows(renderer *Renderer, t float64) []groundShadow {
+	if g.Scene == nil || g.ShadowOpacity <= 0 {
+		return nil
+	}
+	var light *Light
+	for _, l := range renderer.Lights {
+		if l.affects(LayerDefault) && l.Position.Y > g.Height {
+			light = l
+			break
+		}
+	}
+
+	var shadows []groundShadow
+	for _, obj := range g.Scene.Objects {
+		if _, ok := obj.(*GroundPlane); ok {
+			continue
+		}
+		box, ok := ObjectBounds(obj, t)
+		if !ok || box.Max.Y <= g.Height {
+			continue
+		}
+		center, size := box.Center(), box.Max.Sub(box.Min)
+		radius := math.Max(size.X, size.Z) / 2
+		elevation := math.Max(0, box.Min.Y-g.Height)
+		if radius <= 0 {
+			continue
+		}
+
+		x, z := center.X, center.Z
+		if light != nil && light.Position.Y-center.Y > 1e-6 {
+			s := (light.Position.Y - g.Height) / (light.Position.Y - center.Y)
+			x = light.Position.X + (center.X-light.Position.X)*s
+			z = light.Position.Z + (center.Z-light.Position.Z)*s
+		}
+
+		spread := radius + elevation/2
+		softness := math.Max(0, math.Min(1, g.ShadowSoftness))
+		shadows = append(shadows, groundShadow{
+			x:       x,
+			z:       z,
+			inner:   spread * (1 - softness),
+			outer:   spread * (1 + softness),
+			opacity: g.ShadowOpacity * radius / (radius + elevation),
+		})
+	}
+	return shadows
+}
+
+// Render 逐像素绘制地面和接触阴影，视线不与地面相交的像素保持原样
+func (g *GroundPlane) Render(renderer *Renderer, t float64) {
+	if renderer.RenderMode == RenderObjectID {
+		return
+	}
+	shadows := g.shadows(renderer, t)
+	origin := renderer.Camera.Position
+	up := NewVector3(0, 1, 0)
+
+	surface := newImageSurface(renderer.Width, renderer.Height)
+	defer surface.Destroy()
+	img, ok := surface.GetGoImage().(*image.RGBA)
+	if !ok {
+		return
+	}
+	for py := range renderer.Height {
+		for px := range renderer.Width {
+			dir := renderer.cameraRay(float64(px)+0.5, float64(py)+0.5)
+			if math.Abs(dir.Y) < 1e-9 {
+				continue
+			}
+			distance := (g.Height - origin.Y) / dir.Y
+			if distance <= 0 {
+				continue
+			}
+			point := origin.Add(dir.Scale(distance))
+
+			alpha := 1.0
+			if g.Fade > 0 {
+				horizontal := math.Hypot(point.X-origin.X, point.Z-origin.Z)
+				alpha = 1 - smoothstep(g.Fade/2, g.Fade, horizontal)
+				if alpha <= 0 {
+					continue
+				}
+			}
+
+			normal := up
+			if origin.Y < g.Height {
+				normal = up.Scale(-1) // 从地面下方看
+			}
+			c := renderer.CalculateLighting(point, normal, g.Color)
+			lit := 1.0
+			for _, s := range shadows {
+				d := math.Hypot(point.X-s.x, point.Z-s.z)
+				lit *= 1 - s.opacity*(1-smoothstep(s.inner, s.outer, d))
+			}
+
+			offset := py*img.Stride + px*4
+			for k := range 3 {
+				img.Pix[offset+k] = uint8(math.Max(0, math.Min(1, c[k]*lit))*alpha*255 + 0.5)
+			}
+			img.Pix[offset+3] = uint8(alpha*255 + 0.5)
+		}
+	}
+
+	renderer.Context.Save()
+	defer renderer.Context.Restore()
+	renderer.Context.SetSourceSurface(surface, 0, 0)
+	renderer.Context.Paint()
+}
+
+// smoothstep 在 edge0 和 edge1 之间从 0 平滑过渡到 1
+func smoothstep(edge0, edge1, x float64) float64 {
+	if edge1 <= edge0 {
+		if x < edge0 {
+			return 0
+		}
+		return 1
+	}
+	f := math.Max(0, math.Min(1, (x-edge0)/(edge1-edge0)))
+	return f * f * (3 - 2*f)
+}
@@ -0,0 +1,203 @@
+package go3d
+
+import (
+	"errors"
+	"math"
+)
+
+// hullFace 凸包构建过程中的三角形面，顶点按从外侧看逆时针排列
+type hullFace struct {
+	v       [3]int
+	normal  Vector3
+	d       float64 // normal·p = d
+	outside []int   // 位于该面外侧、尚未处理的点
+	deleted bool
+}
+
+// ConvexHull 用 QuickHull 算法计算点集的三维凸包，三角形法线朝外
+// 点数少于 4 个或所有点共面时返回错误
+func ConvexHull(points []Vector3) (*Mesh, error) {
+	if len(points) < 4 {
+		return nil, errors.New("凸包: 至少需要 4 个点")
+	}
+
+	// 按点集尺度确定容差
+	scale := 0.0
+	for _, p := range points {
+		scale = math.Max(scale, math.Max(math.Abs(p.X), math.Max(math.Abs(p.Y), math.Abs(p.Z))))
+	}
+	eps := 1e-10 * math.Max(1, scale)
+
+	initial, ok := hullInitialSimplex(points, eps)
+	if !ok {
+		return nil, errors.New("凸包: 所有点共面，无法构成三维凸包")
+	}
+
+	var faces []*hullFace
+	newFace := func(a, b, c int) *hullFace {
+		normal := points[b].Sub(points[a]).Cross(points[c].Sub(points[a])).Normalize()
+		f := &hullFace{v: [3]int{a, b, c}, normal: normal, d: normal.Dot(points[a])}
+		faces = append(faces, f)
+		return f
+	}
+
+	// 初始四面体，各面朝向远离第四个点的一侧
+	centroid := Vector3{}
+	for _, i := range initial {
+		centroid = centroid.Add(points[i].Scale(0.25))
+	}
+	for _, tri := range [][3]int{{0, 1, 2}, {0, 3, 1}, {0, 2, 3}, {1, 3, 2}} {
+		a, b, c := initial[tri[0]], initial[tri[1]], initial[tri[2]]
+		normal := points[b].Sub(points[a]).Cross(points[c].Sub(points[a]))
+		if normal.Dot(centroid.Sub(points[a])) > 0 {
+			b, c = c, b
+		}
+		newFace(a, b, c)
+	}
+
+	// 把点分配到第一个在其外侧的面
+	assign := func(candidates []int, targets []*hullFace) {
+		for _, i := range candidates {
+			for _, f := range targets {
+				if f.normal.Dot(points[i])-f.d > eps {
+					f.outside = append(f.outside, i)
+					break
+				}
+			}
+		}
+	}
+	all := make([]int, 0, len(points))
+	for i := range points {
+		if i != initial[0] && i != initial[1] && i != initial[2] && i != initial[3] {
+			all = append(all, i)
+		}
+	}
+	assign(all, faces)
+
+	for {
+		// 找一个仍有外侧点的面，取其中最远的点
+		var current *hullFace
+		for _, f := range faces {
+			if !f.deleted && len(f.outside) > 0 {
+				current = f
+				break
+			}
+		}
+		if current == nil {
+			break
+		}
+		apex, best := -1, -1.0
+		for _, i := range current.outside {
+			if d := current.normal.Dot(points[i]) - current.d; d > best {
+				apex, best = i, d
+			}
+		}
+		p := points[apex]
+
+		// 从该点可见的面
+		var visible []*hullFace
+		visibleEdges := make(map[[2]int]bool)
+		for _, f := range faces {
+			if !f.deleted && f.normal.Dot(p)-f.d > eps {
+				visible = append(visible, f)
+				for k := range 3 {
+					visibleEdges[[2]int{f.v[k], f.v[(k+1)%3]}] = true
+				}
+			}
+		}
+
+		// 地平线：可见面上反向边不属于可见面的边
+		var orphans []int
+		var created []*hullFace
+		for _, f := range visible {
+			f.deleted = true
+			for _, i := range f.outside {
+				if i != apex {
+					orphans = append(orphans, i)
+				}
+			}
+			f.outside = nil
+			for k := range 3 {
+				a, b := f.v[k], f.v[(k+1)%3]
+				if !visibleEdges[[2]int{b, a}] {
+					created = append(created, newFace(a, b, apex))
+				}
+			}
+		}
+		assign(orphans, created)
+	}
+
+	mesh := NewMesh()
+	used := make(map[int]bool)
+	for _, f := range faces {
+		if f.deleted {
+			continue
+		}
+		mesh.AddTriangle(Triangle{V0: points[f.v[0]], V1: points[f.v[1]], V2: points[f.v[2]]})
+		for _, i := range f.v {
+			if !used[i] {
+				used[i] = true
+				mesh.AddVertex(points[i])
+			}
+		}
+	}
+	return mesh, nil
+}
+
+// hullInitialSimplex 选出四个不共面的点作为初始四面体
+func hullInitialSimplex(points []Vector3, eps float64) ([4]int, bool) {
+	var simplex [4]int
+
+	// X 方向上的两个极值点（距离退化时改用其他坐标轴）
+	found := false
+	for axis := range 3 {
+		component := func(p Vector3) float64 { return [3]float64{p.X, p.Y, p.Z}[axis] }
+		lo, hi := 0, 0
+		for i, p := range points {
+			if component(p) < component(points[lo]) {
+				lo = i
+			}
+			if component(p) > component(points[hi]) {
+				hi = i
+			}
+		}
+		if points[hi].Sub(points[lo]).Length() > eps {
+			simplex[0], simplex[1] = lo, hi
+			found = true
+			break
+		}
+	}
+	if !found {
+		return simplex, false
+	}
+
+	// 离直线最远的点
+	a, b := points[simplex[0]], points[simplex[1]]
+	dir := b.Sub(a).Normalize()
+	best := 0.0
+	simplex[2] = -1
+	for i, p := range points {
+		d := p.Sub(a).Cross(dir).Length()
+		if d > best {
+			best, simplex[2] = d, i
+		}
+	}
+	if simplex[2] < 0 || best <= eps {
+		return simplex, false
+	}
+
+	// 离平面最远的点
+	plane := NewPlaneFromPoints(a, b, points[simplex[2]])
+	best = 0.0
+	simplex[3] = -1
+	for i, p := range points {
+		d := math.Abs(plane.Distance(p))
+		if d > best {
+			best, simplex[3] = d, i
+		}
+	}
+	if simplex[3] < 0 || best <= eps {
+		return simplex, false
+	}
+	return simplex, true
+}
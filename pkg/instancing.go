@@ -0,0 +1,114 @@
+package go3d
+
+import "math"
+
+// InstancedObject 实例化对象：同一个网格以不同变换绘制多次（如大量小行星、成片的树木）
+// 所有实例共享网格，绘制时不复制网格
+type InstancedObject struct {
+	Mesh       *Mesh
+	Transforms []Matrix4    // 每个实例的模型矩阵
+	Colors     [][3]float64 // 每个实例的颜色（可选，与 Transforms 一一对应）
+	Color      [3]float64   // 没有逐实例颜色时使用的颜色
+	Culling    bool         // 是否跳过包围球完全位于视锥外的实例
+
+	boundsMesh   *Mesh // 计算包围球时的网格，网格更换后重新计算
+	boundsCenter Vector3
+	boundsRadius float64
+}
+
+// NewInstancedObject 创建实例化对象，默认启用逐实例视锥剔除
+func NewInstancedObject(mesh *Mesh, color [3]float64) *InstancedObject {
+	return &InstancedObject{
+		Mesh:    mesh,
+		Color:   color,
+		Culling: true,
+	}
+}
+
+// AddInstance 添加一个实例
+// 已有逐实例颜色时新实例使用默认颜色，保持 Colors 与 Transforms 一一对应
+func (io *InstancedObject) AddInstance(transform Matrix4) *InstancedObject {
+	if io.HasColors() {
+		io.Colors = append(io.Colors, io.Color)
+	}
+	io.Transforms = append(io.Transforms, transform)
+	return io
+}
+
+// AddColoredInstance 添加一个指定颜色的实例，之前的实例补上默认颜色
+func (io *InstancedObject) AddColoredInstance(transform Matrix4, color [3]float64) *InstancedObject {
+	for len(io.Colors) < len(io.Transforms) {
+		io.Colors = append(io.Colors, io.Color)
+	}
+	io.Transforms = append(io.Transforms, transform)
+	io.Colors = append(io.Colors, color)
+	return io
+}
+
+// SetCulling 设置是否启用逐实例视锥剔除
+func (io *InstancedObject) SetCulling(enabled bool) *InstancedObject {
+	io.Culling = enabled
+	return io
+}
+
+// HasColors 是否带有逐实例颜色
+func (io *InstancedObject) HasColors() bool {
+	return len(io.Transforms) > 0 && len(io.Colors) == len(io.Transforms)
+}
+
+// Render 渲染所有实例
+func (io *InstancedObject) Render(renderer *Renderer, t float64) {
+	if io.Mesh == nil || len(io.Mesh.Triangles) == 0 {
+		return
+	}
+
+	var frustum Frustum
+	if io.Culling {
+		if io.boundsMesh != io.Mesh {
+			io.boundsCenter, io.boundsRadius = io.Mesh.BoundingSphere()
+			io.boundsMesh = io.Mesh
+		}
+		frustum = renderer.ViewFrustum()
+	}
+
+	hasColors := io.HasColors()
+	for i, transform := range io.Transforms {
+		if io.Culling {
+			center := transform.TransformVector(io.boundsCenter)
+			if !frustum.IntersectsSphere(center, io.boundsRadius*transform.maxScale()) {
+				continue
+			}
+		}
+
+		color := io.Color
+		if hasColors {
+			color = io.Colors[i]
+		}
+		renderer.DrawMeshTransformed(io.Mesh, transform, color)
+	}
+}
+
+// WorldBounds 所有实例的包围盒
+func (io *InstancedObject) WorldBounds(t float64) AABB {
+	if io.Mesh == nil || len(io.Transforms) == 0 {
+		return AABB{}
+	}
+	meshBox := io.Mesh.BoundingBox()
+	var box AABB
+	for i, transform := range io.Transforms {
+		if i == 0 {
+			box = transformBounds(meshBox, transform)
+		} else {
+			box = box.union(transformBounds(meshBox, transform))
+		}
+	}
+	return box
+}
+
+// maxScale 矩阵线性部分沿各轴的最大缩放倍数，用于估计变换后包围球的半径
+func (m Matrix4) maxScale() float64 {
+	sx := math.Sqrt(m[0]*m[0] + m[4]*m[4] + m[8]*m[8])
+	sy := math.Sqrt(m[1]*m[1] + m[5]*m[5] + m[9]*m[9])
+	sz := math.Sqrt(m[2]*m[2] + m[6]*m[6] + m[10]*m[10])
+	return math.Max(sx, math.Max(sy, sz))
+}
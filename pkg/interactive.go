@@ -0,0 +1,161 @@
+package go3d
+
+import (
+	"image"
+	"math"
+)
+
+// ScrubberHeight 预览窗口底部时间轴的高度（像素），窗口后端据此判断鼠标是否落在时间轴上
+const ScrubberHeight = 16
+
+// InteractiveView 交互式预览：环绕相机、时间轴和按需渲染
+// 不依赖任何窗口库，由窗口后端把鼠标键盘输入转换为这里的操作（见 window 模块和 canvas_js.go）
+type InteractiveView struct {
+	Scene      *Scene
+	Orbit      *OrbitController // 环绕相机，决定相机位置和朝向
+	Camera     Camera           // 基础相机参数（视场角、近远平面、景深），位置和朝向由 Orbit 覆盖
+	Background [3]float64       // 清屏颜色
+
+	Setup func(renderer *Renderer) // 可选：创建渲染器后配置渲染模式、光照、后期处理等
+
+	Time     float64 // 当前时间（0-1）
+	Playing  bool    // 是否自动播放
+	Duration float64 // 自动播放时从 0 到 1 的时长（秒）
+
+	RotateSpeed float64 // 每拖动一个像素旋转的弧度
+	ZoomStep    float64 // 滚轮每一格的缩放比例
+
+	Recorder *CameraRecorder // 可选：记录每次渲染时的时间和视角，用于在离线渲染中重放调好的运镜
+
+	renderer *Renderer
+	width    int
+	height   int
+	dirty    bool // 画面需要重新渲染
+}
+
+// NewInteractiveView 创建交互式预览，初始视角与 camera 一致
+func NewInteractiveView(scene *Scene, camera *Camera, width, height int) *InteractiveView {
+	return &InteractiveView{
+		Scene:       scene,
+		Orbit:       NewOrbitControllerFromCamera(camera),
+		Camera:      *camera,
+		Duration:    10,
+		RotateSpeed: 0.01,
+		ZoomStep:    0.9,
+		width:       max(1, width),
+		height:      max(1, height),
+		dirty:       true,
+	}
+}
+
+// Size 画面尺寸
+func (v *InteractiveView) Size() (int, int) {
+	return v.width, v.height
+}
+
+// Resize 改变画面尺寸（窗口大小改变时调用）
+func (v *InteractiveView) Resize(width, height int) {
+	width, height = max(1, width), max(1, height)
+	if width != v.width || height != v.height {
+		v.width, v.height = width, height
+		v.dirty = true
+	}
+}
+
+// Drag 按拖动的像素数旋转视角（向右拖动时场景向右转）
+func (v *InteractiveView) Drag(dx, dy float64) {
+	if dx == 0 && dy == 0 {
+		return
+	}
+	v.Orbit.Rotate(-dx*v.RotateSpeed, dy*v.RotateSpeed)
+	v.dirty = true
+}
+
+// PanPixels 按拖动的像素数平移视角，平移量与目标处的画面比例一致
+func (v *InteractiveView) PanPixels(dx, dy float64) {
+	if dx == 0 && dy == 0 {
+		return
+	}
+	unitsPerPixel := 2 * v.Orbit.Distance * math.Tan(v.Camera.FOV/2) / float64(v.height)
+	v.Orbit.PanBy(-dx*unitsPerPixel, dy*unitsPerPixel)
+	v.dirty = true
+}
+
+// Scroll 按滚轮格数缩放（正值拉近）
+func (v *InteractiveView) Scroll(steps float64) {
+	if steps == 0 {
+		return
+	}
+	v.Orbit.Zoom(math.Pow(v.ZoomStep, steps))
+	v.dirty = true
+}
+
+// Seek 跳转到时间 t（0-1）
+func (v *InteractiveView) Seek(t float64) {
+	t = math.Max(0, math.Min(1, t))
+	if t != v.Time {
+		v.Time = t
+		v.dirty = true
+	}
+}
+
+// TogglePlay 切换自动播放
+func (v *InteractiveView) TogglePlay() {
+	v.Playing = !v.Playing
+}
+
+// Advance 自动播放时推进 dt 秒，播放到结尾后从头循环
+func (v *InteractiveView) Advance(dt float64) {
+	if !v.Playing || v.Duration <= 0 {
+		return
+	}
+	v.Time = math.Mod(v.Time+dt/v.Duration, 1)
+	v.dirty = true
+}
+
+// Invalidate 标记画面需要重新渲染（场景在外部被修改后调用）
+func (v *InteractiveView) Invalidate() {
+	v.dirty = true
+}
+
+// NeedsRender 画面是否需要重新渲染
+func (v *InteractiveView) NeedsRender() bool {
+	return v.dirty || v.renderer == nil
+}
+
+// Render 按当前视角和时间渲染一帧，返回渲染器的画布（预乘 alpha，下一次 Render 时会被覆盖）
+func (v *InteractiveView) Render() *image.RGBA {
+	if v.renderer == nil || v.renderer.Width != v.width || v.renderer.Height != v.height {
+		if v.renderer != nil {
+			v.renderer.Destroy()
+		}
+		v.renderer = NewRenderer(v.width, v.height)
+		if v.Setup != nil {
+			v.Setup(v.renderer)
+		}
+	}
+
+	camera := v.Camera
+	v.Orbit.Apply(&camera)
+	v.renderer.Camera = &camera
+	if v.Recorder != nil {
+		v.Recorder.Record(v.Time, &camera)
+	}
+	v.renderer.Clear(v.Background[0], v.Background[1], v.Background[2])
+	if v.Scene != nil {
+		v.Scene.Render(v.renderer, v.Time)
+	}
+	v.renderer.ApplyPostEffects()
+	v.dirty = false
+
+	img, _ := v.renderer.Surface.GetGoImage().(*image.RGBA)
+	return img
+}
+
+// Close 释放渲染器
+func (v *InteractiveView) Close() {
+	if v.renderer != nil {
+		v.renderer.Destroy()
+		v.renderer = nil
+	}
+}
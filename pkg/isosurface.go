@@ -0,0 +1,127 @@
+package go3d
+
+// tetrahedra 立方体格子沿主对角线（角点 0 到 7）分成的六个四面体，角点编号的三个二进制位分别为 x、y、z 方向的偏移。
+// 所有格子的分法相同，相邻格子共享面上的对角线一致，抽取出的曲面没有裂缝
+var tetrahedra = [6][4]int{
+	{0, 1, 3, 7}, {0, 1, 5, 7}, {0, 2, 3, 7},
+	{0, 2, 6, 7}, {0, 4, 5, 7}, {0, 4, 6, 7},
+}
+
+// ExtractIsosurface 抽取隐函数 f 在 bounds 内的等值面 f(p) = level，每个方向 resolution 个格子。
+// 使用行进四面体法（每个立方体格子分成六个四面体），法线指向 f 小于 level 的一侧，
+// 例如 f 为中心大、向外递减的密度时得到法线朝外的曲面；f 为到中心的距离时法线朝内，
+// 需要朝外时改用 -f 和 -level
+func ExtractIsosurface(f func(p Vector3) float64, bounds AABB, resolution int, level float64) *Mesh {
+	n := max(1, resolution)
+	size := bounds.Size()
+	position := func(i, j, k int) Vector3 {
+		return bounds.Min.Add(NewVector3(
+			size.X*float64(i)/float64(n),
+			size.Y*float64(j)/float64(n),
+			size.Z*float64(k)/float64(n),
+		))
+	}
+	values := make([]float64, (n+1)*(n+1)*(n+1))
+	for i := 0; i <= n; i++ {
+		for j := 0; j <= n; j++ {
+			for k := 0; k <= n; k++ {
+				values[(i*(n+1)+j)*(n+1)+k] = f(position(i, j, k))
+			}
+		}
+	}
+	return marchTetrahedra([3]int{n + 1, n + 1, n + 1}, func(i, j, k int) float64 {
+		return values[(i*(n+1)+j)*(n+1)+k]
+	}, position, level)
+}
+
+// marchTetrahedra 在 size[0] × size[1] × size[2] 个格点的标量网格上抽取等值面，
+// value 和 position 给出格点的数值和世界坐标
+func marchTetrahedra(size [3]int, value func(i, j, k int) float64, position func(i, j, k int) Vector3, level float64) *Mesh {
+	mesh := NewMesh()
+	var corners [8]Vector3
+	var values [8]float64
+	var ids [8]int
+	for i := 0; i+1 < size[0]; i++ {
+		for j := 0; j+1 < size[1]; j++ {
+			for k := 0; k+1 < size[2]; k++ {
+				above, below := false, false
+				for c := range 8 {
+					ci, cj, ck := i+c&1, j+c>>1&1, k+c>>2&1
+					corners[c] = position(ci, cj, ck)
+					values[c] = value(ci, cj, ck)
+					ids[c] = (ci*size[1]+cj)*size[2] + ck
+					if values[c] >= level {
+						above = true
+					} else {
+						below = true
+					}
+				}
+				if !above || !below {
+					continue // 整个格子在等值面同一侧
+				}
+				for _, tet := range tetrahedra {
+					var p [4]Vector3
+					var v [4]float64
+					var id [4]int
+					for m, c := range tet {
+						p[m], v[m], id[m] = corners[c], values[c], ids[c]
+					}
+					addTetrahedronSurface(mesh, p, v, id, level)
+				}
+			}
+		}
+	}
+	return mesh
+}
+
+// addTetrahedronSurface 把一个四面体内的等值面（一个三角形或两个三角形组成的四边形）加入网格
+func addTetrahedronSurface(mesh *Mesh, p [4]Vector3, v [4]float64, id [4]int, level float64) {
+	var inside, outside []int
+	for m := range 4 {
+		if v[m] >= level {
+			inside = append(inside, m)
+		} else {
+			outside = append(outside, m)
+		}
+	}
+	// 棱与等值面的交点；总是从格点编号小的一端插值，相邻四面体共享的交点完全相同，平滑法线不会断开
+	cross := func(a, b int) Vector3 {
+		if id[a] > id[b] {
+			a, b = b, a
+		}
+		s := 0.5
+		if d := v[b] - v[a]; d != 0 {
+			s = (level - v[a]) / d
+		}
+		return p[a].Add(p[b].Sub(p[a]).Scale(s))
+	}
+	// 法线指向数值小于 level 的一侧
+	add := func(a, b, c Vector3, towardOutside Vector3) {
+		tri := Triangle{V0: a, V1: b, V2: c}
+		if tri.V1.Sub(tri.V0).Cross(tri.V2.Sub(tri.V0)).Dot(towardOutside) < 0 {
+			tri.V1, tri.V2 = tri.V2, tri.V1
+		}
+		mesh.AddTriangle(tri)
+	}
+
+	switch len(inside) {
+	case 1, 3:
+		lone, others := inside[0], outside
+		if len(inside) == 3 {
+			lone, others = outside[0], inside
+		}
+		direction := p[others[0]].Sub(p[lone])
+		if len(inside) == 3 {
+			direction = direction.Scale(-1)
+		}
+		add(cross(lone, others[0]), cross(lone, others[1]), cross(lone, others[2]), direction)
+	case 2:
+		a, b := inside[0], inside[1]
+		c, d := outside[0], outside[1]
+		// 四边形的四个顶点按 ac、ad、bd、bc 的顺序环绕
+		ac, ad, bd, bc := cross(a, c), cross(a, d), cross(b, d), cross(b, c)
+		direction := p[c].Add(p[d]).Sub(p[a]).Sub(p[b])
+		add(ac, ad, bd, direction)
+		add(ac, bd, bc, direction)
+	}
+}
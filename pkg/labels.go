@@ -0,0 +1,140 @@
+package go3d
+
+import (
+	"math"
+	"sort"
+)
+
+// labelRect 屏幕空间矩形
+type labelRect struct {
+	X, Y, Width, Height float64
+}
+
+// overlaps 两个矩形是否相交（含间距）
+func (a labelRect) overlaps(b labelRect, margin float64) bool {
+	return a.X < b.X+b.Width+margin && b.X < a.X+a.Width+margin &&
+		a.Y < b.Y+b.Height+margin && b.Y < a.Y+a.Height+margin
+}
+
+// offset 平移矩形
+func (a labelRect) offset(dx, dy float64) labelRect {
+	return labelRect{X: a.X + dx, Y: a.Y + dy, Width: a.Width, Height: a.Height}
+}
+
+// nearestPoint 矩形边界上离点 (x, y) 最近的点
+func (a labelRect) nearestPoint(x, y float64) (float64, float64) {
+	return math.Max(a.X, math.Min(a.X+a.Width, x)), math.Max(a.Y, math.Min(a.Y+a.Height, y))
+}
+
+// labelPlacement 标签布局结果
+type labelPlacement struct {
+	label            *Label3D
+	anchorX, anchorY float64 // 投影后的锚点
+	depth            float64
+	fontSize         float64
+	bearingX         float64 // 文字墨迹相对于绘制起点（基线）的偏移
+	bearingY         float64
+	box              labelRect // 标签所占区域（含背景框内边距）
+	moved            bool      // 是否偏离默认位置（需要引导线）
+}
+
+// BeginLabels 开始收集标签，之后 Label3D.Render 只登记不绘制
+func (r *Renderer) BeginLabels() {
+	r.collectingLabels = true
+	r.labels = r.labels[:0]
+}
+
+// collectLabel 在收集期间登记标签，返回是否已登记
+func (r *Renderer) collectLabel(l *Label3D) bool {
+	if !r.collectingLabels {
+		return false
+	}
+	r.labels = append(r.labels, l)
+	return true
+}
+
+// FlushLabels 结束收集，对所有标签做碰撞避让后绘制
+// 近处的标签优先占据默认位置，被挤开的标签用引导线连回锚点
+func (r *Renderer) FlushLabels() {
+	if !r.collectingLabels {
+		return
+	}
+	r.collectingLabels = false
+
+	placements := make([]labelPlacement, 0, len(r.labels))
+	for _, l := range r.labels {
+		if placement, ok := l.measure(r); ok {
+			placements = append(placements, placement)
+		}
+	}
+	r.labels = r.labels[:0]
+
+	sort.SliceStable(placements, func(i, j int) bool {
+		return placements[i].depth < placements[j].depth
+	})
+
+	placed := make([]labelRect, 0, len(placements))
+	for i := range placements {
+		placements[i].box, placements[i].moved = r.placeLabel(placements[i].box, placed)
+		placed = append(placed, placements[i].box)
+	}
+
+	for _, placement := range placements {
+		if placement.moved {
+			r.drawLeaderLine(placement)
+		}
+		placement.label.drawAt(r, placement)
+	}
+}
+
+// placeLabel 在默认位置周围按由近到远的顺序寻找不与已放置标签重叠的位置
+// 找不到时保留默认位置
+func (r *Renderer) placeLabel(box labelRect, placed []labelRect) (labelRect, bool) {
+	const (
+		margin   = 2.0 // 标签之间的最小间距（像素）
+		maxSteps = 4   // 每个方向最多尝试的步数
+	)
+
+	free := func(candidate labelRect) bool {
+		for _, other := range placed {
+			if candidate.overlaps(other, margin) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if free(box) {
+		return box, false
+	}
+
+	stepX := box.Width/2 + margin
+	stepY := box.Height + margin
+	for step := 1; step <= maxSteps; step++ {
+		k := float64(step)
+		for _, d := range [][2]float64{
+			{0, -k}, {0, k}, {k, 0}, {-k, 0},
+			{k, -k}, {-k, -k}, {k, k}, {-k, k},
+		} {
+			candidate := box.offset(d[0]*stepX, d[1]*stepY)
+			if free(candidate) {
+				return candidate, true
+			}
+		}
+	}
+	return box, false
+}
+
+// drawLeaderLine 从锚点到标签边框绘制引导线
+func (r *Renderer) drawLeaderLine(placement labelPlacement) {
+	r.Context.Save()
+	defer r.Context.Restore()
+
+	x, y := placement.box.nearestPoint(placement.anchorX, placement.anchorY)
+	color := placement.label.Color
+	r.Context.SetSourceRGBA(color[0], color[1], color[2], 0.6)
+	r.Context.SetLineWidth(1.0)
+	r.Context.MoveTo(placement.anchorX, placement.anchorY)
+	r.Context.LineTo(x, y)
+	r.Context.Stroke()
+}
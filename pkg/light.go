@@ -0,0 +1,132 @@
+package go3d
+
+import "math"
+
+// NewLightKelvin 创建色温为 kelvin（开尔文）的光源，颜色由 ColorTemperature 计算
+func NewLightKelvin(pos Vector3, kelvin, intensity float64) *Light {
+	return NewLight(pos, ColorTemperature(kelvin), intensity)
+}
+
+// SetAttenuation 设置距离衰减系数：强度乘以 1 / (constant + linear·d + quadratic·d²)
+// 常用组合为 (1, 0, 0) 不衰减、(1, 0, 1) 平方反比，较大的 constant 让近处不会过亮
+func (l *Light) SetAttenuation(constant, linear, quadratic float64) *Light {
+	l.Constant = constant
+	l.Linear = linear
+	l.Quadratic = quadratic
+	return l
+}
+
+// SetRange 设置作用范围，超过 rangeDistance 的表面不受该光源照亮，0 表示不限
+func (l *Light) SetRange(rangeDistance float64) *Light {
+	l.Range = rangeDistance
+	return l
+}
+
+// Attenuation 与光源相距 distance 处的衰减系数（0-1 之间，不衰减时为 1）
+// 衰减系数与作用范围的窗口函数 (1 - (d/Range)²)² 相乘，在 Range 处平滑地降为 0
+func (l *Light) Attenuation(distance float64) float64 {
+	attenuation := 1.0
+	if l.Constant != 0 || l.Linear != 0 || l.Quadratic != 0 {
+		denominator := l.Constant + l.Linear*distance + l.Quadratic*distance*distance
+		if denominator <= 0 {
+			return 0
+		}
+		attenuation = math.Min(1, 1/denominator)
+	}
+	if l.Range > 0 {
+		ratio := distance / l.Range
+		if ratio >= 1 {
+			return 0
+		}
+		window := 1 - ratio*ratio
+		attenuation *= window * window
+	}
+	return attenuation
+}
+
+// ColorTemperature 色温 kelvin（1000-40000K）对应的光源颜色（sRGB，0-1）
+// 使用黑体辐射颜色的拟合公式：1900K 为烛光、2700K 为白炽灯、5500K 为正午阳光、6500K 为阴天
+func ColorTemperature(kelvin float64) [3]float64 {
+	t := math.Max(1000, math.Min(40000, kelvin)) / 100
+
+	var r, g, b float64
+	if t <= 66 {
+		r = 255
+		g = 99.4708025861*math.Log(t) - 161.1195681661
+	} else {
+		r = 329.698727446 * math.Pow(t-60, -0.1332047592)
+		g = 288.1221695283 * math.Pow(t-60, -0.0755148492)
+	}
+	switch {
+	case t >= 66:
+		b = 255
+	case t <= 19:
+		b = 0
+	default:
+		b = 138.5177312231*math.Log(t-10) - 305.0447927307
+	}
+
+	clamp := func(v float64) float64 { return math.Max(0, math.Min(255, v)) / 255 }
+	return [3]float64{clamp(r), clamp(g), clamp(b)}
+}
+
+// LayerDefault 默认光照层：没有设置 Layers 的光源和对象都在这一层
+// 其他层由调用者自行分配（1 << 1、1 << 2 ……），例如前景主体、HUD
+const LayerDefault uint32 = 1
+
+// layerMask 光照层掩码，0 表示 LayerDefault
+func layerMask(layers uint32) uint32 {
+	if layers == 0 {
+		return LayerDefault
+	}
+	return layers
+}
+
+// affects 光源是否照亮光照层 layers 中的对象
+func (l *Light) affects(layers uint32) bool {
+	return !l.Disabled && layerMask(l.Layers)&layerMask(layers) != 0
+}
+
+// litLayer 是否有光源照亮光照层 layers；没有时该层的对象与没有光源的场景一样直接使用基础色
+func (r *Renderer) litLayer(layers uint32) bool {
+	for _, light := range r.Lights {
+		if light.affects(layers) {
+			return true
+		}
+	}
+	return false
+}
+
+// LayeredObject 放在指定光照层上的场景对象：绘制期间设置 Renderer.LightLayers，
+// 只有 Layers 与之相交的光源照亮它。例如让补光只照亮前景主体、HUD 不受场景光源影响：
+//
+//	const foreground = 1 << 1
+//	scene.AddObject(go3d.OnLightLayers(subject, go3d.LayerDefault|foreground))
+//	fill := go3d.NewLight(pos, color, 0.5)
+//	fill.Layers = foreground
+//	scene.AddObject(go3d.OnLightLayers(hud, 1<<2)) // 没有光源照亮的层直接使用基础色
+type LayeredObject struct {
+	Object SceneObject
+	Layers uint32
+}
+
+// OnLightLayers 把场景对象放到光照层 layers 上
+func OnLightLayers(obj SceneObject, layers uint32) *LayeredObject {
+	return &LayeredObject{Object: obj, Layers: layers}
+}
+
+// Render 在光照层上绘制对象，之后恢复渲染器原来的光照层
+func (lo *LayeredObject) Render(renderer *Renderer, t float64) {
+	previous := renderer.LightLayers
+	renderer.LightLayers = lo.Layers
+	defer func() { renderer.LightLayers = previous }()
+	lo.Object.Render(renderer, t)
+}
+
+// ShadowSpheres 被包装对象的阴影遮挡体（阴影与光照层无关）
+func (lo *LayeredObject) ShadowSpheres(t float64) []SphereOccluder {
+	if caster, ok := lo.Object.(ShadowCaster); ok {
+		return caster.ShadowSpheres(t)
+	}
+	return nil
+}
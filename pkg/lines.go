@@ -0,0 +1,78 @@
+package go3d
+
+// clipSegmentToNear 在视图空间中将线段裁剪到近裁剪面之前
+// 返回裁剪后的世界坐标端点；线段完全位于相机后方时返回 false
+func (r *Renderer) clipSegmentToNear(a, b Vector3) (Vector3, Vector3, bool) {
+	view := LookAt(r.Camera.Position, r.Camera.Target, r.Camera.Up)
+	va := view.TransformVector(a)
+	vb := view.TransformVector(b)
+
+	// 相机朝 -Z 方向观察，可见点满足 z <= -Near
+	limit := -r.Camera.Near
+	aIn := va.Z <= limit
+	bIn := vb.Z <= limit
+
+	switch {
+	case aIn && bIn:
+		return a, b, true
+	case !aIn && !bIn:
+		return a, b, false
+	}
+
+	s := (limit - va.Z) / (vb.Z - va.Z)
+	p := a.Add(b.Sub(a).Scale(s))
+	if aIn {
+		return a, p, true
+	}
+	return p, b, true
+}
+
+// DrawLine3D 绘制3D线段
+func (r *Renderer) DrawLine3D(a, b Vector3, color [3]float64, width float64) {
+	r.DrawPolyline3D([]Vector3{a, b}, color, width)
+}
+
+// DrawPolyline3D 绘制3D折线，适用于轨迹、向量和标注
+// 与相机近裁剪面相交的线段会被裁剪；批量绘制期间推迟到几何体之后
+func (r *Renderer) DrawPolyline3D(points []Vector3, color [3]float64, width float64) {
+	if len(points) < 2 {
+		return
+	}
+
+	r.DrawOverlay(func() {
+		r.Context.Save()
+		defer r.Context.Restore()
+
+		r.Context.SetSourceRGB(color[0], color[1], color[2])
+		r.Context.SetLineWidth(width)
+		r.Context.SetLineCap(lineCapRound)
+		r.Context.SetLineJoin(lineJoinRound)
+
+		r.tracePolyline(points)
+		r.Context.Stroke()
+	})
+}
+
+// tracePolyline 将3D折线投影后加入当前路径，被裁剪处断开为新的子路径
+func (r *Renderer) tracePolyline(points []Vector3) {
+	connected := false
+	for i := 0; i+1 < len(points); i++ {
+		a, b, ok := r.clipSegmentToNear(points[i], points[i+1])
+		if !ok {
+			connected = false
+			continue
+		}
+
+		x0, y0, _ := r.ProjectToScreen(a)
+		x1, y1, _ := r.ProjectToScreen(b)
+
+		// 起点被裁剪过时也需要重新开始子路径
+		if !connected || a != points[i] {
+			r.Context.MoveTo(x0, y0)
+		}
+		r.Context.LineTo(x1, y1)
+
+		// 终点被裁剪时断开后续连接
+		connected = b == points[i+1]
+	}
+}
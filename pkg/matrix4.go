@@ -42,6 +42,42 @@ func (m Matrix4) TransformVector(v Vector3) Vector3 {
 	return Vector3{x, y, z}
 }
 
+// TransformVectors 批量变换向量，结果写入 dst，不分配内存
+// dst 的长度不能小于 src，dst 与 src 可以是同一个切片（原地变换）
+func (m Matrix4) TransformVectors(dst, src []Vector3) {
+	dst = dst[:len(src)]
+	if !m.isAffine() {
+		for i, v := range src {
+			dst[i] = m.TransformVector(v)
+		}
+		return
+	}
+
+	// 仿射变换的 w 恒为 1，省去齐次除法的判断
+	for i, v := range src {
+		dst[i] = Vector3{
+			m[0]*v.X + m[1]*v.Y + m[2]*v.Z + m[3],
+			m[4]*v.X + m[5]*v.Y + m[6]*v.Z + m[7],
+			m[8]*v.X + m[9]*v.Y + m[10]*v.Z + m[11],
+		}
+	}
+}
+
+// transformTriangles 批量变换三角形顶点，规则同 TransformVectors
+func (m Matrix4) transformTriangles(dst, src []Triangle) {
+	dst = dst[:len(src)]
+	for i, t := range src {
+		verts := [3]Vector3{t.V0, t.V1, t.V2}
+		m.TransformVectors(verts[:], verts[:])
+		dst[i] = Triangle{V0: verts[0], V1: verts[1], V2: verts[2]}
+	}
+}
+
+// isAffine 最后一行是否为 (0, 0, 0, 1)
+func (m Matrix4) isAffine() bool {
+	return m[12] == 0 && m[13] == 0 && m[14] == 0 && m[15] == 1
+}
+
 // Translation 创建平移矩阵
 func Translation(x, y, z float64) Matrix4 {
 	return Matrix4{
@@ -125,11 +161,7 @@ func LookAt(eye, target, up Vector3) Matrix4 {
 	right := forward.Cross(up).Normalize()
 	if right.Length() < 1e-10 {
 		// up 和 forward 平行，选择另一个 up 向量
-		if math.Abs(forward.Y) < 0.9 {
-			right = forward.Cross(Vector3{0, 1, 0}).Normalize()
-		} else {
-			right = forward.Cross(Vector3{1, 0, 0}).Normalize()
-		}
+		right = forward.Cross(stableUp(forward)).Normalize()
 	}
 
 	newUp := right.Cross(forward).Normalize()
@@ -1,6 +1,10 @@
 package go3d
 
-import "math"
+import (
+	"maps"
+	"math"
+	"slices"
+)
 
 // Triangle 表示3D三角形
 type Triangle struct {
@@ -24,10 +28,48 @@ func (t Triangle) Center() Vector3 {
 	return t.V0.Add(t.V1).Add(t.V2).Scale(1.0 / 3.0)
 }
 
+// Barycentric 计算三角形所在平面上一点的重心坐标
+func (t Triangle) Barycentric(p Vector3) (float64, float64, float64) {
+	v0 := t.V1.Sub(t.V0)
+	v1 := t.V2.Sub(t.V0)
+	v2 := p.Sub(t.V0)
+
+	d00 := v0.Dot(v0)
+	d01 := v0.Dot(v1)
+	d11 := v1.Dot(v1)
+	d20 := v2.Dot(v0)
+	d21 := v2.Dot(v1)
+	denom := d00*d11 - d01*d01
+	if math.Abs(denom) < 1e-20 {
+		return 1, 0, 0
+	}
+
+	b1 := (d11*d20 - d01*d21) / denom
+	b2 := (d00*d21 - d01*d20) / denom
+	return 1 - b1 - b2, b1, b2
+}
+
 // Mesh 表示3D网格
 type Mesh struct {
 	Vertices  []Vector3
 	Triangles []Triangle
+
+	FaceColors   [][3]float64    // 每个三角形的颜色（可选，与 Triangles 一一对应）
+	VertexColors [][3][3]float64 // 每个三角形三个顶点的颜色（可选，与 Triangles 一一对应）
+	FaceAlpha    []float64       // 每个三角形的不透明度（可选，0-1，与 Triangles 一一对应）
+	UVs          [][3][2]float64 // 每个三角形三个顶点的纹理坐标（可选，与 Triangles 一一对应）
+
+	Reflectivity float64 // 表面反射率（0-1），仅光线追踪模式使用
+
+	NormalMap *Texture // 切线空间法线贴图（OpenGL 约定，绿色通道指向纹理上方），需要纹理坐标
+	BumpMap   *Texture // 灰度凹凸贴图（亮处为高），需要纹理坐标；与法线贴图可以同时使用
+	BumpScale float64  // 凹凸强度：相邻像素亮度相差 1 时法线的倾斜量，0 表示 1
+
+	Emissive [3]float64 // 自发光颜色，不受光照和阴影影响，直接加到着色结果上
+	Additive bool       // 以加法合成绘制：颜色加到背后的画面上而不是覆盖它（发光体、光晕），仅光栅化模式使用
+
+	MorphTargets []MorphTarget      // 形变目标（可选，见 AddMorphTarget），绘制时按 MorphWeights 混合
+	MorphWeights map[string]float64 // 各形变目标的权重，可用 MorphWeightTrack 做动画
 }
 
 // NewMesh 创建新网格
@@ -50,24 +92,307 @@ func (m *Mesh) AddTriangle(t Triangle) {
 
 // Transform 变换网格
 func (m *Mesh) Transform(matrix Matrix4) *Mesh {
-	transformed := NewMesh()
-	for _, v := range m.Vertices {
-		transformed.AddVertex(matrix.TransformVector(v))
-	}
-	for _, t := range m.Triangles {
-		transformed.AddTriangle(Triangle{
-			V0: matrix.TransformVector(t.V0),
-			V1: matrix.TransformVector(t.V1),
-			V2: matrix.TransformVector(t.V2),
-		})
+	transformed := &Mesh{
+		Vertices:  make([]Vector3, len(m.Vertices)),
+		Triangles: make([]Triangle, len(m.Triangles)),
+
+		Reflectivity: m.Reflectivity,
+		NormalMap:    m.NormalMap,
+		BumpMap:      m.BumpMap,
+		BumpScale:    m.BumpScale,
+		Emissive:     m.Emissive,
+		Additive:     m.Additive,
+	}
+	matrix.TransformVectors(transformed.Vertices, m.Vertices)
+	matrix.transformTriangles(transformed.Triangles, m.Triangles)
+	if m.HasFaceColors() {
+		transformed.FaceColors = append([][3]float64(nil), m.FaceColors...)
+	}
+	if m.HasVertexColors() {
+		transformed.VertexColors = append([][3][3]float64(nil), m.VertexColors...)
+	}
+	if m.HasFaceAlpha() {
+		transformed.FaceAlpha = append([]float64(nil), m.FaceAlpha...)
+	}
+	if m.HasUVs() {
+		transformed.UVs = append([][3][2]float64(nil), m.UVs...)
+	}
+	for _, target := range m.MorphTargets {
+		morphed := MorphTarget{
+			Name:      target.Name,
+			Vertices:  make([]Vector3, len(target.Vertices)),
+			Triangles: make([]Triangle, len(target.Triangles)),
+		}
+		matrix.TransformVectors(morphed.Vertices, target.Vertices)
+		matrix.transformTriangles(morphed.Triangles, target.Triangles)
+		transformed.MorphTargets = append(transformed.MorphTargets, morphed)
+	}
+	if m.MorphWeights != nil {
+		transformed.MorphWeights = maps.Clone(m.MorphWeights)
 	}
 	return transformed
 }
 
+// TransformInPlace 原地变换网格顶点（包括形变目标），不分配新网格
+func (m *Mesh) TransformInPlace(matrix Matrix4) *Mesh {
+	matrix.TransformVectors(m.Vertices, m.Vertices)
+	matrix.transformTriangles(m.Triangles, m.Triangles)
+	for _, target := range m.MorphTargets {
+		matrix.TransformVectors(target.Vertices, target.Vertices)
+		matrix.transformTriangles(target.Triangles, target.Triangles)
+	}
+	return m
+}
+
 // Merge 合并多个网格
+// 只有双方都带有同类颜色属性时才会保留该属性，形变目标也只保留双方都有的同名目标
 func (m *Mesh) Merge(other *Mesh) {
+	var targets []MorphTarget
+	for _, target := range m.MorphTargets {
+		for _, o := range other.MorphTargets {
+			if o.Name == target.Name {
+				target.Vertices = append(slices.Clip(target.Vertices), o.Vertices...)
+				target.Triangles = append(slices.Clip(target.Triangles), o.Triangles...)
+				targets = append(targets, target)
+				break
+			}
+		}
+	}
+	m.MorphTargets = targets
+
+	keepFace := m.HasFaceColors() && other.HasFaceColors()
+	keepVertex := m.HasVertexColors() && other.HasVertexColors()
+	keepAlpha := m.HasFaceAlpha() && other.HasFaceAlpha()
+	keepUVs := m.HasUVs() && other.HasUVs()
+
 	m.Vertices = append(m.Vertices, other.Vertices...)
 	m.Triangles = append(m.Triangles, other.Triangles...)
+
+	if keepFace {
+		m.FaceColors = append(m.FaceColors, other.FaceColors...)
+	} else {
+		m.FaceColors = nil
+	}
+	if keepVertex {
+		m.VertexColors = append(m.VertexColors, other.VertexColors...)
+	} else {
+		m.VertexColors = nil
+	}
+	if keepAlpha {
+		m.FaceAlpha = append(m.FaceAlpha, other.FaceAlpha...)
+	} else {
+		m.FaceAlpha = nil
+	}
+	if keepUVs {
+		m.UVs = append(m.UVs, other.UVs...)
+	} else {
+		m.UVs = nil
+	}
+}
+
+// HasFaceColors 是否带有逐面颜色
+func (m *Mesh) HasFaceColors() bool {
+	return len(m.Triangles) > 0 && len(m.FaceColors) == len(m.Triangles)
+}
+
+// HasVertexColors 是否带有逐顶点颜色
+func (m *Mesh) HasVertexColors() bool {
+	return len(m.Triangles) > 0 && len(m.VertexColors) == len(m.Triangles)
+}
+
+// HasFaceAlpha 是否带有逐面不透明度
+func (m *Mesh) HasFaceAlpha() bool {
+	return len(m.Triangles) > 0 && len(m.FaceAlpha) == len(m.Triangles)
+}
+
+// HasUVs 是否带有纹理坐标
+func (m *Mesh) HasUVs() bool {
+	return len(m.Triangles) > 0 && len(m.UVs) == len(m.Triangles)
+}
+
+// TriangleUVs 第 i 个三角形三个顶点的纹理坐标，没有纹理坐标时返回零值
+func (m *Mesh) TriangleUVs(i int) [3]Vector2 {
+	if !m.HasUVs() {
+		return [3]Vector2{}
+	}
+	uv := m.UVs[i]
+	return [3]Vector2{NewVector2(uv[0][0], uv[0][1]), NewVector2(uv[1][0], uv[1][1]), NewVector2(uv[2][0], uv[2][1])}
+}
+
+// AddTexturedTriangle 添加带纹理坐标的三角形
+// 只有全部三角形都通过此方法添加时 UVs 才与 Triangles 一一对应
+func (m *Mesh) AddTexturedTriangle(tri Triangle, uv0, uv1, uv2 Vector2) {
+	m.AddTriangle(tri)
+	m.UVs = append(m.UVs, [3][2]float64{uv0.Array(), uv1.Array(), uv2.Array()})
+}
+
+// faceTransparency 第 i 个三角形的透明度（0 为完全不透明）
+func (m *Mesh) faceTransparency(i int) float64 {
+	if !m.HasFaceAlpha() {
+		return 0
+	}
+	return 1 - math.Max(0, math.Min(1, m.FaceAlpha[i]))
+}
+
+// ColorFaces 按三角形计算逐面颜色
+func (m *Mesh) ColorFaces(colorFunc func(tri Triangle) [3]float64) *Mesh {
+	m.FaceColors = make([][3]float64, len(m.Triangles))
+	for i, tri := range m.Triangles {
+		m.FaceColors[i] = colorFunc(tri)
+	}
+	return m
+}
+
+// ColorVertices 按顶点位置计算逐顶点颜色（适用于高度图、热力图等）
+func (m *Mesh) ColorVertices(colorFunc func(v Vector3) [3]float64) *Mesh {
+	m.VertexColors = make([][3][3]float64, len(m.Triangles))
+	for i, tri := range m.Triangles {
+		m.VertexColors[i] = [3][3]float64{
+			colorFunc(tri.V0),
+			colorFunc(tri.V1),
+			colorFunc(tri.V2),
+		}
+	}
+	return m
+}
+
+// Edge 表示网格的一条边
+type Edge struct {
+	V0, V1 Vector3
+}
+
+// vertexKey 量化后的顶点坐标，用于识别重合顶点
+type vertexKey [3]int64
+
+// quantizeVertex 将顶点坐标量化，消除浮点误差
+func quantizeVertex(v Vector3) vertexKey {
+	const scale = 1e6
+	return vertexKey{
+		int64(math.Round(v.X * scale)),
+		int64(math.Round(v.Y * scale)),
+		int64(math.Round(v.Z * scale)),
+	}
+}
+
+// undirectedEdgeKey 计算无向边的唯一标识（两端点键值排序），退化边返回 false
+func undirectedEdgeKey(a, b Vector3) ([2]vertexKey, bool) {
+	ka, kb := quantizeVertex(a), quantizeVertex(b)
+	if ka == kb {
+		return [2]vertexKey{}, false
+	}
+	if kb[0] < ka[0] || (kb[0] == ka[0] && (kb[1] < ka[1] || (kb[1] == ka[1] && kb[2] < ka[2]))) {
+		ka, kb = kb, ka
+	}
+	return [2]vertexKey{ka, kb}, true
+}
+
+// Edges 提取网格中不重复的边
+// creaseAngle 为折痕角（弧度）：大于 0 时只保留相邻面夹角超过该值的边以及边界边，
+// 可去掉四边形对角线等共面内部边；为 0 时返回全部唯一边
+func (m *Mesh) Edges(creaseAngle float64) []Edge {
+	type edgeInfo struct {
+		edge    Edge
+		normals []Vector3
+	}
+
+	edges := make(map[[2]vertexKey]*edgeInfo)
+	order := make([][2]vertexKey, 0)
+
+	for _, tri := range m.Triangles {
+		normal := tri.Normal()
+		verts := [3]Vector3{tri.V0, tri.V1, tri.V2}
+		for i := range 3 {
+			a, b := verts[i], verts[(i+1)%3]
+			key, ok := undirectedEdgeKey(a, b)
+			if !ok {
+				continue
+			}
+
+			info, ok := edges[key]
+			if !ok {
+				info = &edgeInfo{edge: Edge{V0: a, V1: b}}
+				edges[key] = info
+				order = append(order, key)
+			}
+			info.normals = append(info.normals, normal)
+		}
+	}
+
+	result := make([]Edge, 0, len(order))
+	for _, key := range order {
+		info := edges[key]
+		if creaseAngle > 0 && len(info.normals) == 2 {
+			cosAngle := math.Max(-1, math.Min(1, info.normals[0].Dot(info.normals[1])))
+			if math.Acos(cosAngle) <= creaseAngle {
+				continue
+			}
+		}
+		result = append(result, info.edge)
+	}
+	return result
+}
+
+// SilhouetteEdges 提取相对于视点的轮廓边：相邻两个面一个朝向视点、一个背向视点的边，
+// 以及朝向视点的面上的边界边
+func (m *Mesh) SilhouetteEdges(eye Vector3) []Edge {
+	type edgeInfo struct {
+		edge  Edge
+		front int
+		back  int
+	}
+
+	edges := make(map[[2]vertexKey]*edgeInfo)
+	order := make([][2]vertexKey, 0)
+
+	for _, tri := range m.Triangles {
+		facing := tri.Normal().Dot(eye.Sub(tri.Center())) > 0
+		verts := [3]Vector3{tri.V0, tri.V1, tri.V2}
+		for i := range 3 {
+			a, b := verts[i], verts[(i+1)%3]
+			key, ok := undirectedEdgeKey(a, b)
+			if !ok {
+				continue
+			}
+
+			info, ok := edges[key]
+			if !ok {
+				info = &edgeInfo{edge: Edge{V0: a, V1: b}}
+				edges[key] = info
+				order = append(order, key)
+			}
+			if facing {
+				info.front++
+			} else {
+				info.back++
+			}
+		}
+	}
+
+	result := make([]Edge, 0)
+	for _, key := range order {
+		info := edges[key]
+		if (info.front > 0 && info.back > 0) || (info.front == 1 && info.back == 0) {
+			result = append(result, info.edge)
+		}
+	}
+	return result
+}
+
+// smoothVertexNormals 计算平滑顶点法线：同一位置上所有相邻面法线的面积加权平均
+func (m *Mesh) smoothVertexNormals() map[vertexKey]Vector3 {
+	normals := make(map[vertexKey]Vector3, len(m.Triangles))
+	for _, tri := range m.Triangles {
+		// 未归一化的叉积长度与面积成正比，天然实现面积加权
+		faceNormal := tri.V1.Sub(tri.V0).Cross(tri.V2.Sub(tri.V0))
+		for _, v := range [3]Vector3{tri.V0, tri.V1, tri.V2} {
+			key := quantizeVertex(v)
+			normals[key] = normals[key].Add(faceNormal)
+		}
+	}
+	for key, n := range normals {
+		normals[key] = n.Normalize()
+	}
+	return normals
 }
 
 // CreateCube 创建立方体网格
@@ -81,14 +406,14 @@ func CreateCube(size float64) *Mesh {
 		{-s, -s, s}, {s, -s, s}, {s, s, s}, {-s, s, s},
 	}
 
-	// 12个三角形（6个面，每面2个三角形）
+	// 12个三角形（6个面，每面2个三角形），从外面看为逆时针，法线朝外
 	indices := [][3]int{
-		{0, 1, 2}, {0, 2, 3}, // 前面
-		{5, 4, 7}, {5, 7, 6}, // 后面
-		{4, 0, 3}, {4, 3, 7}, // 左面
-		{1, 5, 6}, {1, 6, 2}, // 右面
-		{3, 2, 6}, {3, 6, 7}, // 上面
-		{4, 5, 1}, {4, 1, 0}, // 下面
+		{0, 2, 1}, {0, 3, 2}, // 前面
+		{5, 7, 4}, {5, 6, 7}, // 后面
+		{4, 3, 0}, {4, 7, 3}, // 左面
+		{1, 6, 5}, {1, 2, 6}, // 右面
+		{3, 6, 2}, {3, 7, 6}, // 上面
+		{4, 1, 5}, {4, 0, 1}, // 下面
 	}
 
 	for _, idx := range indices {
@@ -130,17 +455,29 @@ func CreateSphere(radius float64, segments, rings int) *Mesh {
 			first := ring*(segments+1) + seg
 			second := first + segments + 1
 
+			// 逆时针环绕，法线朝外
 			mesh.AddTriangle(Triangle{
 				V0: mesh.Vertices[first],
-				V1: mesh.Vertices[second],
-				V2: mesh.Vertices[first+1],
+				V1: mesh.Vertices[first+1],
+				V2: mesh.Vertices[second],
 			})
 
 			mesh.AddTriangle(Triangle{
 				V0: mesh.Vertices[second],
-				V1: mesh.Vertices[second+1],
-				V2: mesh.Vertices[first+1],
+				V1: mesh.Vertices[first+1],
+				V2: mesh.Vertices[second+1],
 			})
+
+			// 等距圆柱投影纹理坐标：v 从北极（0）到南极（1）
+			// 从北极（+Y）俯视时 phi 顺时针增加（向西），而 u 应向东增加
+			u0 := 1 - float64(seg)/float64(segments)
+			u1 := 1 - float64(seg+1)/float64(segments)
+			v0 := float64(ring) / float64(rings)
+			v1 := float64(ring+1) / float64(rings)
+			mesh.UVs = append(mesh.UVs,
+				[3][2]float64{{u0, v0}, {u1, v0}, {u0, v1}},
+				[3][2]float64{{u0, v1}, {u1, v0}, {u1, v1}},
+			)
 		}
 	}
 
@@ -175,14 +512,14 @@ func CreateCylinder(radius, height float64, segments int) *Mesh {
 
 		mesh.AddTriangle(Triangle{
 			V0: mesh.Vertices[topIdx],
-			V1: mesh.Vertices[bottomIdx],
-			V2: mesh.Vertices[nextTopIdx],
+			V1: mesh.Vertices[nextTopIdx],
+			V2: mesh.Vertices[bottomIdx],
 		})
 
 		mesh.AddTriangle(Triangle{
 			V0: mesh.Vertices[bottomIdx],
-			V1: mesh.Vertices[nextBottomIdx],
-			V2: mesh.Vertices[nextTopIdx],
+			V1: mesh.Vertices[nextTopIdx],
+			V2: mesh.Vertices[nextBottomIdx],
 		})
 	}
 
@@ -195,14 +532,14 @@ func CreateCylinder(radius, height float64, segments int) *Mesh {
 
 		mesh.AddTriangle(Triangle{
 			V0: topCenter,
-			V1: mesh.Vertices[topIdx],
-			V2: mesh.Vertices[nextTopIdx],
+			V1: mesh.Vertices[nextTopIdx],
+			V2: mesh.Vertices[topIdx],
 		})
 
 		mesh.AddTriangle(Triangle{
 			V0: bottomCenter,
-			V1: mesh.Vertices[nextBottomIdx],
-			V2: mesh.Vertices[bottomIdx],
+			V1: mesh.Vertices[bottomIdx],
+			V2: mesh.Vertices[nextBottomIdx],
 		})
 	}
 
@@ -266,8 +603,8 @@ func CreateCone(radius, height float64, segments int) *Mesh {
 	for i := 0; i < segments; i++ {
 		mesh.AddTriangle(Triangle{
 			V0: apex,
-			V1: mesh.Vertices[i],
-			V2: mesh.Vertices[(i+1)%(segments+1)],
+			V1: mesh.Vertices[(i+1)%(segments+1)],
+			V2: mesh.Vertices[i],
 		})
 	}
 
@@ -275,8 +612,8 @@ func CreateCone(radius, height float64, segments int) *Mesh {
 	for i := 0; i < segments; i++ {
 		mesh.AddTriangle(Triangle{
 			V0: bottomCenter,
-			V1: mesh.Vertices[(i+1)%(segments+1)],
-			V2: mesh.Vertices[i],
+			V1: mesh.Vertices[i],
+			V2: mesh.Vertices[(i+1)%(segments+1)],
 		})
 	}
 
@@ -328,3 +665,163 @@ func CreateTorus(majorRadius, minorRadius float64, majorSegments, minorSegments
 
 	return mesh
 }
+
+// CreateCapsule 创建胶囊体网格（沿 Y 轴的圆柱两端接半球）
+// height 为包含两端半球的总高度，小于 2*radius 时退化为球体
+func CreateCapsule(radius, height float64, segments int) *Mesh {
+	mesh := NewMesh()
+	halfCylinder := math.Max(0, height/2.0-radius)
+	capRings := max(2, segments/4)
+
+	// 顶部半球、底部半球各 capRings 圈，赤道处的两圈之间即为圆柱侧面
+	for ring := 0; ring <= 2*capRings+1; ring++ {
+		var theta, offset float64
+		if ring <= capRings {
+			theta = float64(ring) * (math.Pi / 2) / float64(capRings)
+			offset = halfCylinder
+		} else {
+			theta = math.Pi/2 + float64(ring-capRings-1)*(math.Pi/2)/float64(capRings)
+			offset = -halfCylinder
+		}
+		sinTheta := math.Sin(theta)
+		cosTheta := math.Cos(theta)
+
+		for seg := 0; seg <= segments; seg++ {
+			phi := float64(seg) * 2.0 * math.Pi / float64(segments)
+			x := math.Cos(phi) * sinTheta
+			z := math.Sin(phi) * sinTheta
+			mesh.AddVertex(NewVector3(x*radius, cosTheta*radius+offset, z*radius))
+		}
+	}
+
+	addGridTriangles(mesh, 2*capRings+1, segments)
+	return mesh
+}
+
+// addGridTriangles 为按行排列的 (rows+1) x (columns+1) 顶点网格添加三角形
+// 三角形法线方向为（列增加方向）×（行增加方向）
+func addGridTriangles(mesh *Mesh, rows, columns int) {
+	for row := 0; row < rows; row++ {
+		for col := 0; col < columns; col++ {
+			first := row*(columns+1) + col
+			second := first + columns + 1
+
+			mesh.AddTriangle(Triangle{
+				V0: mesh.Vertices[first],
+				V1: mesh.Vertices[first+1],
+				V2: mesh.Vertices[second],
+			})
+
+			mesh.AddTriangle(Triangle{
+				V0: mesh.Vertices[second],
+				V1: mesh.Vertices[first+1],
+				V2: mesh.Vertices[second+1],
+			})
+		}
+	}
+}
+
+// CreateRoundedBox 创建圆角立方体网格
+// radius 为棱和角的圆角半径（不超过 size/2），segments 为每个圆角的分段数
+func CreateRoundedBox(size, radius float64, segments int) *Mesh {
+	mesh := NewMesh()
+	s := size / 2.0
+	radius = math.Max(0, math.Min(radius, s))
+	inner := s - radius
+	segments = max(1, segments)
+
+	// 每个轴向上的采样坐标：两端各 segments 段圆角，中间一段平面
+	coords := make([]float64, 0, 2*segments+2)
+	for k := segments; k >= 0; k-- {
+		coords = append(coords, -inner-radius*math.Sin(float64(k)/float64(segments)*math.Pi/2))
+	}
+	for k := 0; k <= segments; k++ {
+		coords = append(coords, inner+radius*math.Sin(float64(k)/float64(segments)*math.Pi/2))
+	}
+
+	// 立方体表面的点投影到内部立方体外 radius 处
+	round := func(p Vector3) Vector3 {
+		q := NewVector3(
+			math.Max(-inner, math.Min(inner, p.X)),
+			math.Max(-inner, math.Min(inner, p.Y)),
+			math.Max(-inner, math.Min(inner, p.Z)),
+		)
+		d := p.Sub(q)
+		if d.Length() < 1e-12 {
+			return p
+		}
+		return q.Add(d.Normalize().Scale(radius))
+	}
+
+	// 六个面：法线方向和两条切线方向（u × v = normal）
+	faces := [][3]Vector3{
+		{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}},
+		{{-1, 0, 0}, {0, 0, 1}, {0, 1, 0}},
+		{{0, 1, 0}, {0, 0, 1}, {1, 0, 0}},
+		{{0, -1, 0}, {1, 0, 0}, {0, 0, 1}},
+		{{0, 0, 1}, {1, 0, 0}, {0, 1, 0}},
+		{{0, 0, -1}, {0, 1, 0}, {1, 0, 0}},
+	}
+
+	n := len(coords)
+	for _, face := range faces {
+		normal, u, v := face[0], face[1], face[2]
+		grid := make([]Vector3, n*n)
+		for i, a := range coords {
+			for j, b := range coords {
+				grid[i*n+j] = round(normal.Scale(s).Add(u.Scale(a)).Add(v.Scale(b)))
+			}
+		}
+
+		for i := 0; i+1 < n; i++ {
+			for j := 0; j+1 < n; j++ {
+				p00, p10 := grid[i*n+j], grid[(i+1)*n+j]
+				p01, p11 := grid[i*n+j+1], grid[(i+1)*n+j+1]
+				// 平面部分相邻采样点重合，跳过退化三角形
+				if p00 == p10 || p00 == p01 {
+					continue
+				}
+				mesh.AddTriangle(Triangle{V0: p00, V1: p10, V2: p11})
+				mesh.AddTriangle(Triangle{V0: p00, V1: p11, V2: p01})
+			}
+		}
+	}
+
+	return mesh
+}
+
+// CreateTorusKnot 创建 (p, q) 环面纽结网格，位于 XY 平面
+// 中心线绕 Z 轴旋转 p 圈、绕环管旋转 q 圈；radius 为纽结的外半径，tubeRadius 为管半径
+func CreateTorusKnot(p, q int, radius, tubeRadius float64, segments, tubeSegments int) *Mesh {
+	mesh := NewMesh()
+
+	// 中心线：在半径为 2/3 的圆环上缠绕，最大半径为 radius
+	curve := func(t float64) Vector3 {
+		r := radius * (2 + math.Cos(float64(q)*t)) / 3
+		return NewVector3(
+			r*math.Cos(float64(p)*t),
+			r*math.Sin(float64(p)*t),
+			radius*math.Sin(float64(q)*t)/3,
+		)
+	}
+
+	for i := 0; i <= segments; i++ {
+		t := float64(i) * 2.0 * math.Pi / float64(segments)
+		center := curve(t)
+		next := curve(t + 0.01)
+
+		// 以切线和两点之和构造管截面的坐标系
+		tangent := next.Sub(center)
+		binormal := tangent.Cross(next.Add(center)).Normalize()
+		normal := binormal.Cross(tangent).Normalize()
+
+		for j := 0; j <= tubeSegments; j++ {
+			phi := float64(j) * 2.0 * math.Pi / float64(tubeSegments)
+			offset := normal.Scale(math.Cos(phi)).Add(binormal.Scale(math.Sin(phi)))
+			mesh.AddVertex(center.Add(offset.Scale(tubeRadius)))
+		}
+	}
+
+	addGridTriangles(mesh, segments, tubeSegments)
+	return mesh
+}
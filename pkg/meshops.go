@@ -0,0 +1,305 @@
+package go3d
+
+import (
+	"math"
+	"slices"
+)
+
+// Weld 合并距离不超过 epsilon 的重合顶点
+// 三角形顶点被吸附到所在簇的代表点上，Vertices 重建为不重复的顶点列表，
+// 焊接后退化（两个顶点重合）的三角形连同其逐面属性和形变目标中的对应三角形一起删除。
+// 形变目标中合并后的顶点取簇内第一个顶点的目标位置，保证焊接在一起的顶点形变后仍然重合。
+// epsilon 不大于 0 时只合并完全重合的顶点
+func (m *Mesh) Weld(epsilon float64) *Mesh {
+	var representatives []Vector3
+	find := m.vertexClusterer(epsilon, &representatives)
+
+	hasFace, hasVertex := m.HasFaceColors(), m.HasVertexColors()
+	hasAlpha, hasUVs := m.HasFaceAlpha(), m.HasUVs()
+
+	// 形变目标中各代表点的目标位置，代表点按出现顺序编号，取第一次出现时的位置；
+	// 拓扑与网格不一致的形变目标不处理
+	targetVertices := make([][]Vector3, len(m.MorphTargets))
+	morph := func(k, rep int, v Vector3) Vector3 {
+		if rep == len(targetVertices[k]) {
+			targetVertices[k] = append(targetVertices[k], v)
+		}
+		return targetVertices[k][rep]
+	}
+	morphable := make([]bool, len(m.MorphTargets))
+	for k, target := range m.MorphTargets {
+		morphable[k] = len(target.Triangles) == len(m.Triangles)
+	}
+
+	kept := 0
+	for i, tri := range m.Triangles {
+		a, b, c := find(tri.V0), find(tri.V1), find(tri.V2)
+		for k, target := range m.MorphTargets {
+			if morphable[k] {
+				t := target.Triangles[i]
+				target.Triangles[i] = Triangle{V0: morph(k, a, t.V0), V1: morph(k, b, t.V1), V2: morph(k, c, t.V2)}
+			}
+		}
+		if a == b || b == c || a == c {
+			continue
+		}
+		m.Triangles[kept] = Triangle{V0: representatives[a], V1: representatives[b], V2: representatives[c]}
+		for k, target := range m.MorphTargets {
+			if morphable[k] {
+				target.Triangles[kept] = target.Triangles[i]
+			}
+		}
+		if hasFace {
+			m.FaceColors[kept] = m.FaceColors[i]
+		}
+		if hasVertex {
+			m.VertexColors[kept] = m.VertexColors[i]
+		}
+		if hasAlpha {
+			m.FaceAlpha[kept] = m.FaceAlpha[i]
+		}
+		if hasUVs {
+			m.UVs[kept] = m.UVs[i]
+		}
+		kept++
+	}
+
+	m.Triangles = m.Triangles[:kept]
+	if hasFace {
+		m.FaceColors = m.FaceColors[:kept]
+	}
+	if hasVertex {
+		m.VertexColors = m.VertexColors[:kept]
+	}
+	if hasAlpha {
+		m.FaceAlpha = m.FaceAlpha[:kept]
+	}
+	if hasUVs {
+		m.UVs = m.UVs[:kept]
+	}
+	for k := range m.MorphTargets {
+		if morphable[k] {
+			m.MorphTargets[k].Triangles = m.MorphTargets[k].Triangles[:kept]
+			m.MorphTargets[k].Vertices = targetVertices[k]
+		}
+	}
+	m.Vertices = representatives
+	return m
+}
+
+// vertexClusterer 返回把顶点归入簇的函数：距离已有代表点不超过 epsilon 时返回其编号，否则新建代表点
+// 用边长为 epsilon 的网格加速近邻查找
+func (m *Mesh) vertexClusterer(epsilon float64, representatives *[]Vector3) func(v Vector3) int {
+	if epsilon <= 0 {
+		exact := make(map[Vector3]int)
+		return func(v Vector3) int {
+			if i, ok := exact[v]; ok {
+				return i
+			}
+			exact[v] = len(*representatives)
+			*representatives = append(*representatives, v)
+			return exact[v]
+		}
+	}
+
+	cells := make(map[[3]int64][]int)
+	cellOf := func(v Vector3) [3]int64 {
+		return [3]int64{
+			int64(math.Floor(v.X / epsilon)),
+			int64(math.Floor(v.Y / epsilon)),
+			int64(math.Floor(v.Z / epsilon)),
+		}
+	}
+
+	return func(v Vector3) int {
+		cell := cellOf(v)
+		best, bestDist := -1, epsilon
+		for dx := int64(-1); dx <= 1; dx++ {
+			for dy := int64(-1); dy <= 1; dy++ {
+				for dz := int64(-1); dz <= 1; dz++ {
+					for _, i := range cells[[3]int64{cell[0] + dx, cell[1] + dy, cell[2] + dz}] {
+						if d := (*representatives)[i].Sub(v).Length(); d <= bestDist {
+							best, bestDist = i, d
+						}
+					}
+				}
+			}
+		}
+		if best >= 0 {
+			return best
+		}
+
+		index := len(*representatives)
+		*representatives = append(*representatives, v)
+		cells[cell] = append(cells[cell], index)
+		return index
+	}
+}
+
+// IndexedTriangles 生成索引形式的网格数据：不重复的顶点列表和每个三角形的三个顶点编号
+// 只有位置完全相同的顶点才共用编号，近似重合的顶点需先调用 Weld
+func (m *Mesh) IndexedTriangles() ([]Vector3, [][3]int) {
+	var vertices []Vector3
+	find := m.vertexClusterer(0, &vertices)
+
+	indices := make([][3]int, len(m.Triangles))
+	for i, tri := range m.Triangles {
+		indices[i] = [3]int{find(tri.V0), find(tri.V1), find(tri.V2)}
+	}
+	return vertices, indices
+}
+
+// SmoothLaplacian 拉普拉斯平滑：每次迭代把顶点向相邻顶点的平均位置移动 lambda 倍（0-1）
+// 邻接关系按位置完全相同的顶点建立，近似重合的顶点需先调用 Weld；边界顶点保持不动以免开放边缘收缩
+func (m *Mesh) SmoothLaplacian(iterations int, lambda float64) *Mesh {
+	vertices, indices := m.IndexedTriangles()
+
+	neighbors := make([]map[int]bool, len(vertices))
+	for i := range neighbors {
+		neighbors[i] = make(map[int]bool)
+	}
+	edgeCount := make(map[[2]int]int)
+	for _, tri := range indices {
+		for k := range 3 {
+			a, b := tri[k], tri[(k+1)%3]
+			neighbors[a][b] = true
+			neighbors[b][a] = true
+			edgeCount[[2]int{min(a, b), max(a, b)}]++
+		}
+	}
+
+	// 只属于一个三角形的边为边界边
+	boundary := make([]bool, len(vertices))
+	for edge, count := range edgeCount {
+		if count == 1 {
+			boundary[edge[0]] = true
+			boundary[edge[1]] = true
+		}
+	}
+
+	// 邻接顶点按编号排序后求和，浮点累加顺序固定，结果可复现
+	adjacent := make([][]int, len(vertices))
+	for i, set := range neighbors {
+		for j := range set {
+			adjacent[i] = append(adjacent[i], j)
+		}
+		slices.Sort(adjacent[i])
+	}
+
+	next := make([]Vector3, len(vertices))
+	for range iterations {
+		for i, v := range vertices {
+			if boundary[i] || len(adjacent[i]) == 0 {
+				next[i] = v
+				continue
+			}
+			var sum Vector3
+			for _, j := range adjacent[i] {
+				sum = sum.Add(vertices[j])
+			}
+			average := sum.Scale(1 / float64(len(adjacent[i])))
+			next[i] = v.Add(average.Sub(v).Scale(lambda))
+		}
+		vertices, next = next, vertices
+	}
+
+	for i, tri := range indices {
+		m.Triangles[i] = Triangle{V0: vertices[tri[0]], V1: vertices[tri[1]], V2: vertices[tri[2]]}
+	}
+	m.Vertices = vertices
+	return m
+}
+
+// RecomputeNormals 重新整理三角形绕序，使法线方向一致
+// 网格的面法线由绕序决定（平滑着色的顶点法线也由面法线平均得到），因此这里按共享边
+// 传播绕序：相邻三角形必须以相反方向经过共享边。之后对每个连通部分，若其有向体积为负
+// （法线整体朝内）则整体翻转。开放曲面只保证一致，不保证朝向
+func (m *Mesh) RecomputeNormals() *Mesh {
+	_, indices := m.IndexedTriangles()
+
+	// 无向边 → 经过它的三角形及经过方向
+	type halfEdge struct {
+		triangle int
+		forward  bool // 是否从编号小的端点走向编号大的端点
+	}
+	edges := make(map[[2]int][]halfEdge)
+	for i, tri := range indices {
+		for k := range 3 {
+			a, b := tri[k], tri[(k+1)%3]
+			key := [2]int{min(a, b), max(a, b)}
+			edges[key] = append(edges[key], halfEdge{i, a < b})
+		}
+	}
+
+	flipped := make([]bool, len(indices))
+	visited := make([]bool, len(indices))
+	for start := range indices {
+		if visited[start] {
+			continue
+		}
+
+		// 广度优先遍历连通部分，确定每个三角形是否需要翻转
+		component := []int{start}
+		visited[start] = true
+		for q := 0; q < len(component); q++ {
+			i := component[q]
+			tri := indices[i]
+			for k := range 3 {
+				a, b := tri[k], tri[(k+1)%3]
+				forward := (a < b) != flipped[i]
+				for _, he := range edges[[2]int{min(a, b), max(a, b)}] {
+					if visited[he.triangle] {
+						continue
+					}
+					visited[he.triangle] = true
+					// 相邻三角形应反向经过共享边
+					flipped[he.triangle] = he.forward == forward
+					component = append(component, he.triangle)
+				}
+			}
+		}
+
+		// 有向体积为负时整体翻转
+		volume := 0.0
+		for _, i := range component {
+			tri := m.Triangles[i]
+			v := tri.V0.Dot(tri.V1.Cross(tri.V2))
+			if flipped[i] {
+				v = -v
+			}
+			volume += v
+		}
+		if volume < 0 {
+			for _, i := range component {
+				flipped[i] = !flipped[i]
+			}
+		}
+	}
+
+	for i, flip := range flipped {
+		if flip {
+			m.flipTriangle(i)
+		}
+	}
+	return m
+}
+
+// flipTriangle 交换第 i 个三角形的后两个顶点以翻转法线，同步交换逐顶点属性
+func (m *Mesh) flipTriangle(i int) {
+	tri := m.Triangles[i]
+	m.Triangles[i] = Triangle{V0: tri.V0, V1: tri.V2, V2: tri.V1}
+	if m.HasVertexColors() {
+		c := m.VertexColors[i]
+		m.VertexColors[i] = [3][3]float64{c[0], c[2], c[1]}
+	}
+	if m.HasUVs() {
+		uv := m.UVs[i]
+		m.UVs[i] = [3][2]float64{uv[0], uv[2], uv[1]}
+	}
+	for _, target := range m.MorphTargets {
+		if i < len(target.Triangles) {
+			t := target.Triangles[i]
+			target.Triangles[i] = Triangle{V0: t.V0, V1: t.V2, V2: t.V1}
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package go3d
+
+import "testing"
+
+// 焊接删除退化三角形时，形变目标的三角形和顶点同步压缩
+func TestWeldMorphTargets(t *testing.T) {
+	a, b, c, d := Vector3{0, 0, 0}, Vector3{1, 0, 0}, Vector3{0, 1, 0}, Vector3{1, 1, 0}
+	mesh := NewMesh()
+	mesh.Vertices = []Vector3{a, b, c, d}
+	mesh.AddTriangle(Triangle{V0: a, V1: b, V2: c})
+	mesh.AddTriangle(Triangle{V0: b, V1: b, V2: d}) // 退化
+	mesh.AddTriangle(Triangle{V0: b, V1: d, V2: c})
+	mesh.AddMorphTargetFunc("up", func(v Vector3) Vector3 { return v.Add(Vector3{0, 0, 1}) })
+
+	mesh.Weld(0)
+
+	if len(mesh.Triangles) != 2 {
+		t.Fatalf("三角形数为 %d，应为 2", len(mesh.Triangles))
+	}
+	target := mesh.MorphTargets[0]
+	if len(target.Triangles) != len(mesh.Triangles) || len(target.Vertices) != len(mesh.Vertices) {
+		t.Fatalf("形变目标有 %d 个三角形、%d 个顶点，网格为 %d 个三角形、%d 个顶点",
+			len(target.Triangles), len(target.Vertices), len(mesh.Triangles), len(mesh.Vertices))
+	}
+	want := Triangle{V0: Vector3{1, 0, 1}, V1: Vector3{1, 1, 1}, V2: Vector3{0, 1, 1}}
+	if target.Triangles[1] != want {
+		t.Errorf("第 2 个三角形的形变目标为 %v，应为 %v", target.Triangles[1], want)
+	}
+
+	mesh.SetMorphWeight("up", 1)
+	if !mesh.hasActiveMorphs() {
+		t.Error("焊接后形变目标不再生效")
+	}
+}
@@ -0,0 +1,43 @@
+package go3d
+
+import "slices"
+
+// modelMesh 把网格按模型矩阵变换到渲染器复用的缓冲网格中
+// 只变换顶点位置，逐面属性直接引用原网格，因此原网格不会被复制，每帧也不会分配新网格。
+// 返回的网格在下一次调用前有效，绘制方法不会保留对它的引用
+func (r *Renderer) modelMesh(mesh *Mesh, model Matrix4) *Mesh {
+	mesh = r.morphMesh(mesh)
+	s := &r.frameArena().scratch
+	s.Vertices = slices.Grow(s.Vertices[:0], len(mesh.Vertices))[:len(mesh.Vertices)]
+	s.Triangles = slices.Grow(s.Triangles[:0], len(mesh.Triangles))[:len(mesh.Triangles)]
+	model.TransformVectors(s.Vertices, mesh.Vertices)
+	model.transformTriangles(s.Triangles, mesh.Triangles)
+
+	s.FaceColors = mesh.FaceColors
+	s.VertexColors = mesh.VertexColors
+	s.FaceAlpha = mesh.FaceAlpha
+	s.UVs = mesh.UVs
+	s.Reflectivity = mesh.Reflectivity
+	s.NormalMap = mesh.NormalMap
+	s.BumpMap = mesh.BumpMap
+	s.BumpScale = mesh.BumpScale
+	s.Emissive = mesh.Emissive
+	s.Additive = mesh.Additive
+	return s
+}
+
+// DrawMeshTransformed 按模型矩阵绘制网格，等价于 DrawMesh(mesh.Transform(model), color) 但不复制网格
+// 适合每帧都以不同位姿绘制的静态几何体
+func (r *Renderer) DrawMeshTransformed(mesh *Mesh, model Matrix4, color [3]float64) {
+	r.DrawMesh(r.modelMesh(mesh, model), color)
+}
+
+// DrawTexturedMeshTransformed 按模型矩阵绘制带纹理的网格，不复制网格
+func (r *Renderer) DrawTexturedMeshTransformed(mesh *Mesh, model Matrix4, texture *Texture, color [3]float64) {
+	r.DrawTexturedMesh(r.modelMesh(mesh, model), texture, color)
+}
+
+// DrawMeshWithGradientTransformed 按模型矩阵绘制渐变色网格，不复制网格
+func (r *Renderer) DrawMeshWithGradientTransformed(mesh *Mesh, model Matrix4, color1, color2 [3]float64) {
+	r.DrawMeshWithGradient(r.modelMesh(mesh, model), color1, color2)
+}
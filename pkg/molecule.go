@@ -0,0 +1,340 @@
+package go3d
+
+import (
+	"math"
+	"strings"
+	"sync"
+)
+
+// elementData 元素的 CPK 颜色、共价半径和范德华半径（埃）
+type elementData struct {
+	color             [3]float64
+	covalent, vanDerW float64
+}
+
+// elements 常见元素的数据，颜色按 CPK 配色（碳用深灰代替黑色，在深色背景上也能看清）
+var elements = map[string]elementData{
+	"H":  {[3]float64{1, 1, 1}, 0.31, 1.20},
+	"C":  {[3]float64{0.25, 0.25, 0.25}, 0.76, 1.70},
+	"N":  {[3]float64{0.13, 0.2, 1}, 0.71, 1.55},
+	"O":  {[3]float64{1, 0.13, 0}, 0.66, 1.52},
+	"F":  {[3]float64{0.12, 0.94, 0.12}, 0.57, 1.47},
+	"Cl": {[3]float64{0.12, 0.94, 0.12}, 1.02, 1.75},
+	"Br": {[3]float64{0.6, 0.13, 0}, 1.20, 1.85},
+	"I":  {[3]float64{0.4, 0, 0.73}, 1.39, 1.98},
+	"He": {[3]float64{0, 1, 1}, 0.28, 1.40},
+	"Ne": {[3]float64{0, 1, 1}, 0.58, 1.54},
+	"Ar": {[3]float64{0, 1, 1}, 1.06, 1.88},
+	"Kr": {[3]float64{0, 1, 1}, 1.16, 2.02},
+	"Xe": {[3]float64{0, 1, 1}, 1.40, 2.16},
+	"P":  {[3]float64{1, 0.6, 0}, 1.07, 1.80},
+	"S":  {[3]float64{0.87, 0.87, 0}, 1.05, 1.80},
+	"B":  {[3]float64{1, 0.67, 0.47}, 0.84, 1.92},
+	"Li": {[3]float64{0.47, 0, 1}, 1.28, 1.82},
+	"Na": {[3]float64{0.47, 0, 1}, 1.66, 2.27},
+	"K":  {[3]float64{0.47, 0, 1}, 2.03, 2.75},
+	"Rb": {[3]float64{0.47, 0, 1}, 2.20, 3.03},
+	"Cs": {[3]float64{0.47, 0, 1}, 2.44, 3.43},
+	"Be": {[3]float64{0, 0.47, 0}, 0.96, 1.53},
+	"Mg": {[3]float64{0, 0.47, 0}, 1.41, 1.73},
+	"Ca": {[3]float64{0, 0.47, 0}, 1.76, 2.31},
+	"Sr": {[3]float64{0, 0.47, 0}, 1.95, 2.49},
+	"Ba": {[3]float64{0, 0.47, 0}, 2.15, 2.68},
+	"Ti": {[3]float64{0.6, 0.6, 0.6}, 1.60, 2.11},
+	"Fe": {[3]float64{0.87, 0.47, 0}, 1.32, 2.04},
+	"Si": {[3]float64{0.94, 0.78, 0.63}, 1.11, 2.10},
+	"Se": {[3]float64{1, 0.63, 0}, 1.20, 1.90},
+	"Cu": {[3]float64{0.78, 0.5, 0.2}, 1.32, 1.40},
+	"Zn": {[3]float64{0.49, 0.5, 0.69}, 1.22, 1.39},
+	"Ag": {[3]float64{0.75, 0.75, 0.75}, 1.45, 1.72},
+	"Au": {[3]float64{1, 0.82, 0.14}, 1.36, 1.66},
+}
+
+// otherElement 表中没有的元素：CPK 配色中的粉色
+var otherElement = elementData{[3]float64{0.87, 0.47, 1}, 1.50, 2.00}
+
+// element 元素符号（大小写不敏感）对应的数据
+func element(symbol string) elementData {
+	if data, ok := elements[normalizeElement(symbol)]; ok {
+		return data
+	}
+	return otherElement
+}
+
+// normalizeElement 把元素符号规范为首字母大写、其余小写（"CL" → "Cl"）
+func normalizeElement(symbol string) string {
+	symbol = strings.TrimSpace(symbol)
+	if symbol == "" {
+		return ""
+	}
+	return strings.ToUpper(symbol[:1]) + strings.ToLower(symbol[1:])
+}
+
+// ElementColor 元素的 CPK 颜色，未知元素为粉色
+func ElementColor(symbol string) [3]float64 {
+	return element(symbol).color
+}
+
+// Atom 分子中的一个原子，坐标单位为埃
+type Atom struct {
+	Element  string
+	Position Vector3
+	Name     string // 原子名（PDB 中的 CA、N 等，可选）
+	Residue  string // 所属残基名（PDB，可选）
+}
+
+// Bond 两个原子之间的化学键，A、B 为 Atoms 的下标
+type Bond struct {
+	A, B  int
+	Order int // 键级：1 为单键，2 为双键，3 为三键
+}
+
+// MoleculeStyle 分子的显示方式
+type MoleculeStyle int
+
+const (
+	MoleculeBallAndStick MoleculeStyle = iota // 球棍模型：小球表示原子，圆柱表示化学键（默认）
+	MoleculeSpaceFilling                      // 空间填充模型：原子画成范德华半径的球，不画化学键
+)
+
+// Molecule 分子结构：原子和化学键，可以从 PDB、XYZ 文件加载（见 LoadMolecule），
+// 按球棍模型或空间填充模型生成网格，原子使用 CPK 配色，化学键两半分别使用两端原子的颜色。
+// 分子以几何中心为原点放在 Transform 处，SpinSpeed 不为 0 时绕 SpinAxis 匀速旋转，适合分子旋转动画
+type Molecule struct {
+	Name  string
+	Atoms []Atom
+	Bonds []Bond
+
+	Style      MoleculeStyle
+	AtomScale  float64 // 球棍模型中原子半径与范德华半径之比
+	BondRadius float64 // 化学键的半径（埃）
+	Segments   int     // 球体的经线分段数
+
+	Transform *Transform
+	SpinAxis  Vector3
+	SpinSpeed float64 // 自转角速度（弧度/单位时间）
+
+	mu       sync.Mutex
+	mesh     *Mesh
+	meshKey  moleculeMeshKey
+	centroid Vector3
+}
+
+// moleculeMeshKey 决定网格形状的参数，改变时重新生成网格
+type moleculeMeshKey struct {
+	style                MoleculeStyle
+	atomScale, bond      float64
+	segments             int
+	atomCount, bondCount int
+}
+
+// NewMolecule 创建球棍模型显示的分子，bonds 为空时按原子间距离推断化学键
+func NewMolecule(atoms []Atom, bonds []Bond) *Molecule {
+	m := &Molecule{
+		Atoms:      atoms,
+		Bonds:      bonds,
+		AtomScale:  0.25,
+		BondRadius: 0.12,
+		Segments:   16,
+		Transform:  NewTransform(),
+		SpinAxis:   NewVector3(0, 1, 0),
+	}
+	if len(bonds) == 0 {
+		m.InferBonds(0.45)
+	}
+	return m
+}
+
+// SetStyle 设置显示方式
+func (m *Molecule) SetStyle(style MoleculeStyle) *Molecule {
+	m.Style = style
+	return m
+}
+
+// SetSpin 设置绕 axis 匀速旋转的角速度（弧度/单位时间）
+func (m *Molecule) SetSpin(axis Vector3, speed float64) *Molecule {
+	m.SpinAxis = axis
+	m.SpinSpeed = speed
+	return m
+}
+
+// InferBonds 按距离推断化学键：两原子的距离小于共价半径之和加 tolerance（埃）时成键，替换已有的化学键。
+// 按空间网格只比较相邻格子中的原子，大分子也很快
+func (m *Molecule) InferBonds(tolerance float64) *Molecule {
+	maxCovalent := 0.0
+	for _, a := range m.Atoms {
+		maxCovalent = math.Max(maxCovalent, element(a.Element).covalent)
+	}
+	cell := 2*maxCovalent + tolerance
+	if cell <= 0 {
+		m.Bonds = nil
+		return m
+	}
+	key := func(p Vector3) [3]int {
+		return [3]int{int(math.Floor(p.X / cell)), int(math.Floor(p.Y / cell)), int(math.Floor(p.Z / cell))}
+	}
+	grid := make(map[[3]int][]int)
+	for i, a := range m.Atoms {
+		k := key(a.Position)
+		grid[k] = append(grid[k], i)
+	}
+
+	m.Bonds = nil
+	for i, a := range m.Atoms {
+		k := key(a.Position)
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				for dz := -1; dz <= 1; dz++ {
+					for _, j := range grid[[3]int{k[0] + dx, k[1] + dy, k[2] + dz}] {
+						if j <= i {
+							continue
+						}
+						b := m.Atoms[j]
+						limit := element(a.Element).covalent + element(b.Element).covalent + tolerance
+						d := a.Position.Sub(b.Position).Length()
+						if d > 0.4 && d < limit {
+							m.Bonds = append(m.Bonds, Bond{A: i, B: j, Order: 1})
+						}
+					}
+				}
+			}
+		}
+	}
+	return m
+}
+
+// Centroid 所有原子的几何中心
+func (m *Molecule) Centroid() Vector3 {
+	var sum Vector3
+	for _, a := range m.Atoms {
+		sum = sum.Add(a.Position)
+	}
+	if len(m.Atoms) == 0 {
+		return sum
+	}
+	return sum.Scale(1 / float64(len(m.Atoms)))
+}
+
+// atomRadius 原子在当前显示方式下的半径
+func (m *Molecule) atomRadius(a Atom) float64 {
+	if m.Style == MoleculeSpaceFilling {
+		return element(a.Element).vanDerW
+	}
+	return element(a.Element).vanDerW * m.AtomScale
+}
+
+// Mesh 生成分子网格（原子坐标系，逐面颜色），原子和化学键合并为一个网格
+func (m *Molecule) Mesh() *Mesh {
+	segments := max(6, m.Segments)
+	sphere := unitPrimitive(primitiveSphere, segments, max(3, segments/2), 0)
+	cylinder := unitPrimitive(primitiveCylinder, max(6, segments/2), 0, 0)
+	mesh := NewMesh()
+	add := func(piece *Mesh, model Matrix4, color [3]float64) {
+		start := len(mesh.Triangles)
+		mesh.Vertices = append(mesh.Vertices, piece.Vertices...)
+		mesh.Triangles = append(mesh.Triangles, piece.Triangles...)
+		model.TransformVectors(mesh.Vertices[len(mesh.Vertices)-len(piece.Vertices):], piece.Vertices)
+		model.transformTriangles(mesh.Triangles[start:], piece.Triangles)
+		for range piece.Triangles {
+			mesh.FaceColors = append(mesh.FaceColors, color)
+		}
+	}
+
+	for _, a := range m.Atoms {
+		r := m.atomRadius(a)
+		add(sphere, Translation(a.Position.X, a.Position.Y, a.Position.Z).Multiply(Scale(r, r, r)), ElementColor(a.Element))
+	}
+	if m.Style == MoleculeSpaceFilling {
+		return mesh
+	}
+	for _, b := range m.Bonds {
+		if b.A < 0 || b.B < 0 || b.A >= len(m.Atoms) || b.B >= len(m.Atoms) {
+			continue
+		}
+		from, to := m.Atoms[b.A], m.Atoms[b.B]
+		axis := to.Position.Sub(from.Position)
+		length := axis.Length()
+		if length < 1e-9 {
+			continue
+		}
+		direction := axis.Scale(1 / length)
+		rotation := alignY(direction)
+
+		// 多重键画成几根平行的细圆柱
+		order := max(1, min(3, b.Order))
+		radius := m.BondRadius
+		var offsets []float64
+		switch order {
+		case 2:
+			radius *= 0.6
+			offsets = []float64{-1.3, 1.3}
+		case 3:
+			radius *= 0.5
+			offsets = []float64{-2.2, 0, 2.2}
+		default:
+			offsets = []float64{0}
+		}
+		side := direction.Cross(NewVector3(0, 0, 1))
+		if side.Length() < 1e-6 {
+			side = direction.Cross(NewVector3(1, 0, 0))
+		}
+		side = side.Normalize()
+
+		// 每根键分成两半，分别使用两端原子的颜色
+		for _, offset := range offsets {
+			shift := side.Scale(offset * radius)
+			for half, atom := range [2]Atom{from, to} {
+				center := from.Position.Add(axis.Scale(0.25 + 0.5*float64(half))).Add(shift)
+				model := NewTransform().SetPosition(center).SetRotation(rotation).Matrix().Multiply(Scale(radius, length/2, radius))
+				add(cylinder, model, ElementColor(atom.Element))
+			}
+		}
+	}
+	return mesh
+}
+
+// cachedMesh 参数没有变化时复用上次生成的网格
+func (m *Molecule) cachedMesh() (*Mesh, Vector3) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := moleculeMeshKey{m.Style, m.AtomScale, m.BondRadius, m.Segments, len(m.Atoms), len(m.Bonds)}
+	if m.mesh == nil || m.meshKey != key {
+		m.mesh, m.meshKey, m.centroid = m.Mesh(), key, m.Centroid()
+	}
+	return m.mesh, m.centroid
+}
+
+// Model 时间 t 的模型矩阵：几何中心移到原点，绕 SpinAxis 自转，再应用 Transform
+func (m *Molecule) Model(t float64) Matrix4 {
+	_, centroid := m.cachedMesh()
+	model := Translation(-centroid.X, -centroid.Y, -centroid.Z)
+	if m.SpinSpeed != 0 && m.SpinAxis.Length() > 0 {
+		model = QuaternionFromAxisAngle(m.SpinAxis.Normalize(), m.SpinSpeed*t).Matrix().Multiply(model)
+	}
+	if m.Transform != nil {
+		model = m.Transform.Matrix().Multiply(model)
+	}
+	return model
+}
+
+// Render 绘制分子网格；修改原子或化学键后，数量不变时需要调用 Invalidate 重新生成网格
+func (m *Molecule) Render(renderer *Renderer, t float64) {
+	if len(m.Atoms) == 0 {
+		return
+	}
+	mesh, _ := m.cachedMesh()
+	renderer.DrawMeshTransformed(mesh, m.Model(t), otherElement.color)
+}
+
+// Invalidate 丢弃缓存的网格，下次绘制时重新生成
+func (m *Molecule) Invalidate() {
+	m.mu.Lock()
+	m.mesh = nil
+	m.mu.Unlock()
+}
+
+// WorldBounds 分子网格在时间 t 的包围盒
+func (m *Molecule) WorldBounds(t float64) AABB {
+	mesh, _ := m.cachedMesh()
+	return transformBounds(mesh.BoundingBox(), m.Model(t))
+}
@@ -0,0 +1,210 @@
+package go3d
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// LoadMolecule 从文件加载分子：.pdb 和 .ent 按 ReadPDB 解析，其余按 ReadXYZMolecule 解析
+func LoadMolecule(filename string) (*Molecule, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".pdb", ".ent":
+		return ReadPDB(file)
+	}
+	return ReadXYZMolecule(file)
+}
+
+// ReadXYZMolecule 读取 XYZ 分子文件：第一行为原子数，第二行为注释（作为分子名称），
+// 之后每行为元素符号（或原子序数）和 x y z 坐标（埃）。多帧文件（分子动力学轨迹）只读取第一帧；
+// 化学键按距离推断
+func ReadXYZMolecule(r io.Reader) (*Molecule, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("XYZ: 文件为空")
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(scanner.Text()))
+	if err != nil || count < 0 {
+		return nil, errors.New("XYZ: 第一行应为原子数")
+	}
+	name := ""
+	if scanner.Scan() {
+		name = strings.TrimSpace(scanner.Text())
+	}
+
+	atoms := make([]Atom, 0, count)
+	for line := 3; len(atoms) < count && scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("XYZ: 第 %d 行需要元素符号和三个坐标", line)
+		}
+		x, err1 := strconv.ParseFloat(fields[1], 64)
+		y, err2 := strconv.ParseFloat(fields[2], 64)
+		z, err3 := strconv.ParseFloat(fields[3], 64)
+		if err1 != nil || err2 != nil || err3 != nil {
+			return nil, fmt.Errorf("XYZ: 第 %d 行的坐标无效", line)
+		}
+		symbol := fields[0]
+		if number, err := strconv.Atoi(symbol); err == nil {
+			symbol = elementByNumber(number)
+		}
+		atoms = append(atoms, Atom{Element: normalizeElement(symbol), Position: NewVector3(x, y, z)})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(atoms) < count {
+		return nil, fmt.Errorf("XYZ: 应有 %d 个原子，实际为 %d 个", count, len(atoms))
+	}
+	molecule := NewMolecule(atoms, nil)
+	molecule.Name = name
+	return molecule, nil
+}
+
+// elementByNumber 原子序数 1-36 对应的元素符号，超出范围时返回空字符串
+func elementByNumber(number int) string {
+	symbols := strings.Fields("H He Li Be B C N O F Ne Na Mg Al Si P S Cl Ar K Ca Sc Ti V Cr Mn Fe Co Ni Cu Zn Ga Ge As Se Br Kr")
+	if number < 1 || number > len(symbols) {
+		return ""
+	}
+	return symbols[number-1]
+}
+
+// ReadPDB 读取蛋白质数据库（PDB）格式的 ATOM 和 HETATM 记录，多模型文件只读取第一个模型。
+// 元素取自第 77-78 列，缺失时从原子名推断；化学键按距离推断，CONECT 记录中重复列出的键作为双键或三键
+func ReadPDB(r io.Reader) (*Molecule, error) {
+	// field 按 PDB 格式说明的列位置（从 1 开始，含两端）截取字段
+	field := func(line string, from, to int) string {
+		if len(line) < from {
+			return ""
+		}
+		return strings.TrimSpace(line[from-1 : min(to, len(line))])
+	}
+
+	var atoms []Atom
+	serials := make(map[int]int) // 原子序号 → Atoms 下标
+	connections := make(map[[2]int]int)
+	name := ""
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		record := field(text, 1, 6)
+		switch record {
+		case "HEADER":
+			if name == "" {
+				name = field(text, 11, 50)
+			}
+		case "TITLE":
+			if name == "" {
+				name = field(text, 11, 80)
+			}
+		case "ATOM", "HETATM":
+			x, err1 := strconv.ParseFloat(field(text, 31, 38), 64)
+			y, err2 := strconv.ParseFloat(field(text, 39, 46), 64)
+			z, err3 := strconv.ParseFloat(field(text, 47, 54), 64)
+			if err1 != nil || err2 != nil || err3 != nil {
+				return nil, fmt.Errorf("PDB: 第 %d 行的坐标无效", line)
+			}
+			atomName := field(text, 13, 16)
+			symbol := field(text, 77, 78)
+			if symbol == "" && len(text) >= 13 {
+				symbol = elementFromAtomName(text[12:min(16, len(text))])
+			}
+			if serial, err := strconv.Atoi(field(text, 7, 11)); err == nil {
+				serials[serial] = len(atoms)
+			}
+			atoms = append(atoms, Atom{
+				Element:  normalizeElement(symbol),
+				Position: NewVector3(x, y, z),
+				Name:     atomName,
+				Residue:  field(text, 18, 20),
+			})
+		case "CONECT":
+			from, err := strconv.Atoi(field(text, 7, 11))
+			if err != nil {
+				continue
+			}
+			for _, column := range [][2]int{{12, 16}, {17, 21}, {22, 26}, {27, 31}} {
+				if to, err := strconv.Atoi(field(text, column[0], column[1])); err == nil && to > from {
+					connections[[2]int{from, to}]++
+				}
+			}
+		case "ENDMDL":
+			if len(atoms) > 0 {
+				return newPDBMolecule(name, atoms, serials, connections), nil
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(atoms) == 0 {
+		return nil, errors.New("PDB: 没有 ATOM 或 HETATM 记录")
+	}
+	return newPDBMolecule(name, atoms, serials, connections), nil
+}
+
+// newPDBMolecule 按距离推断化学键，再用 CONECT 记录补充缺失的键并设置键级
+func newPDBMolecule(name string, atoms []Atom, serials map[int]int, connections map[[2]int]int) *Molecule {
+	molecule := NewMolecule(atoms, nil)
+	molecule.Name = name
+	index := make(map[[2]int]int, len(molecule.Bonds))
+	for i, b := range molecule.Bonds {
+		index[[2]int{b.A, b.B}] = i
+	}
+	for pair, count := range connections {
+		a, okA := serials[pair[0]]
+		b, okB := serials[pair[1]]
+		if !okA || !okB {
+			continue
+		}
+		if a > b {
+			a, b = b, a
+		}
+		order := min(3, count)
+		if i, ok := index[[2]int{a, b}]; ok {
+			molecule.Bonds[i].Order = order
+		} else {
+			molecule.Bonds = append(molecule.Bonds, Bond{A: a, B: b, Order: order})
+		}
+	}
+	return molecule
+}
+
+// elementFromAtomName 从 PDB 第 13-16 列的原子名推断元素：按格式约定，元素符号右对齐在第 13-14 列，
+// 第 13 列为字母时是两字母元素（如 "FE  "），否则取第 14 列（如 " CA "）；不符合约定时取第一个字母
+func elementFromAtomName(raw string) string {
+	if len(raw) >= 2 && unicode.IsLetter(rune(raw[0])) && unicode.IsLetter(rune(raw[1])) {
+		if _, ok := elements[normalizeElement(raw[:2])]; ok {
+			return raw[:2]
+		}
+	}
+	if len(raw) >= 2 && !unicode.IsLetter(rune(raw[0])) && unicode.IsLetter(rune(raw[1])) {
+		return raw[1:2]
+	}
+	for _, r := range raw {
+		if unicode.IsLetter(r) {
+			return string(r)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,179 @@
+package go3d
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// MorphTarget 形变目标（混合形状）：与基础网格拓扑相同、只有顶点位置不同的形状
+type MorphTarget struct {
+	Name      string
+	Vertices  []Vector3  // 与 Mesh.Vertices 一一对应
+	Triangles []Triangle // 与 Mesh.Triangles 一一对应
+}
+
+// AddMorphTarget 以 target 的顶点位置添加形变目标，target 必须与网格的三角形和顶点数量相同
+// （例如由同一网格变形得到）。同名目标会被替换
+func (m *Mesh) AddMorphTarget(name string, target *Mesh) error {
+	if len(target.Triangles) != len(m.Triangles) || len(target.Vertices) != len(m.Vertices) {
+		return fmt.Errorf("形变目标 %q 的拓扑与网格不同：%d 个三角形、%d 个顶点，网格为 %d 个三角形、%d 个顶点",
+			name, len(target.Triangles), len(target.Vertices), len(m.Triangles), len(m.Vertices))
+	}
+	m.setMorphTarget(MorphTarget{
+		Name:      name,
+		Vertices:  slices.Clone(target.Vertices),
+		Triangles: slices.Clone(target.Triangles),
+	})
+	return nil
+}
+
+// AddMorphTargetFunc 把每个顶点经 f 移动后的形状添加为形变目标，
+// 例如把球面顶点投影到立方体表面得到球体到立方体的变形。同名目标会被替换
+func (m *Mesh) AddMorphTargetFunc(name string, f func(v Vector3) Vector3) *Mesh {
+	target := MorphTarget{
+		Name:      name,
+		Vertices:  make([]Vector3, len(m.Vertices)),
+		Triangles: make([]Triangle, len(m.Triangles)),
+	}
+	for i, v := range m.Vertices {
+		target.Vertices[i] = f(v)
+	}
+	for i, tri := range m.Triangles {
+		target.Triangles[i] = Triangle{V0: f(tri.V0), V1: f(tri.V1), V2: f(tri.V2)}
+	}
+	m.setMorphTarget(target)
+	return m
+}
+
+// setMorphTarget 添加或替换同名形变目标
+func (m *Mesh) setMorphTarget(target MorphTarget) {
+	for i := range m.MorphTargets {
+		if m.MorphTargets[i].Name == target.Name {
+			m.MorphTargets[i] = target
+			return
+		}
+	}
+	m.MorphTargets = append(m.MorphTargets, target)
+}
+
+// SetMorphWeight 设置形变目标的权重：0 为基础形状，1 为完全变成目标形状，多个目标的位移按权重叠加
+func (m *Mesh) SetMorphWeight(name string, weight float64) *Mesh {
+	if m.MorphWeights == nil {
+		m.MorphWeights = make(map[string]float64)
+	}
+	m.MorphWeights[name] = weight
+	return m
+}
+
+// MorphTargetNames 按添加顺序返回所有形变目标的名称
+func (m *Mesh) MorphTargetNames() []string {
+	names := make([]string, len(m.MorphTargets))
+	for i, target := range m.MorphTargets {
+		names[i] = target.Name
+	}
+	return names
+}
+
+// hasActiveMorphs 是否有权重不为 0 且与网格拓扑一致的形变目标
+func (m *Mesh) hasActiveMorphs() bool {
+	for _, target := range m.MorphTargets {
+		if m.MorphWeights[target.Name] != 0 && len(target.Triangles) == len(m.Triangles) {
+			return true
+		}
+	}
+	return false
+}
+
+// blendMorphs 把按权重混合后的顶点位置写入 dst，逐面属性直接引用原网格，dst 不带形变目标
+func (m *Mesh) blendMorphs(dst *Mesh) {
+	dst.Vertices = append(dst.Vertices[:0], m.Vertices...)
+	dst.Triangles = append(dst.Triangles[:0], m.Triangles...)
+	for _, target := range m.MorphTargets {
+		weight := m.MorphWeights[target.Name]
+		if weight == 0 || len(target.Triangles) != len(m.Triangles) {
+			continue
+		}
+		for i, tri := range m.Triangles {
+			t := target.Triangles[i]
+			d := &dst.Triangles[i]
+			d.V0 = d.V0.Add(t.V0.Sub(tri.V0).Scale(weight))
+			d.V1 = d.V1.Add(t.V1.Sub(tri.V1).Scale(weight))
+			d.V2 = d.V2.Add(t.V2.Sub(tri.V2).Scale(weight))
+		}
+		if len(target.Vertices) == len(m.Vertices) {
+			for i, v := range m.Vertices {
+				dst.Vertices[i] = dst.Vertices[i].Add(target.Vertices[i].Sub(v).Scale(weight))
+			}
+		}
+	}
+
+	dst.MorphTargets = nil
+	dst.MorphWeights = nil
+	dst.FaceColors = m.FaceColors
+	dst.VertexColors = m.VertexColors
+	dst.FaceAlpha = m.FaceAlpha
+	dst.UVs = m.UVs
+	dst.Reflectivity = m.Reflectivity
+	dst.NormalMap = m.NormalMap
+	dst.BumpMap = m.BumpMap
+	dst.BumpScale = m.BumpScale
+	dst.Emissive = m.Emissive
+	dst.Additive = m.Additive
+}
+
+// Morphed 按当前权重混合形变目标后的新网格（不带形变目标），逐面颜色、纹理坐标等属性与原网格共享
+func (m *Mesh) Morphed() *Mesh {
+	morphed := &Mesh{}
+	m.blendMorphs(morphed)
+	return morphed
+}
+
+// morphMesh 网格有生效的形变目标时，把混合结果写入渲染器复用的缓冲网格，否则原样返回
+// 绘制方法在入口处调用，因此只要设置权重，网格就会以变形后的形状绘制
+func (r *Renderer) morphMesh(mesh *Mesh) *Mesh {
+	if !mesh.hasActiveMorphs() {
+		return mesh
+	}
+	s := &r.frameArena().morph
+	mesh.blendMorphs(s)
+	return s
+}
+
+// MorphWeightTrack 形变权重轨道：把关键帧插值结果写入网格 MorphWeights 中名为 Name 的权重
+type MorphWeightTrack struct {
+	Mesh      *Mesh
+	Name      string
+	Keyframes []FloatKeyframe
+}
+
+// NewMorphWeightTrack 创建驱动 mesh 上名为 name 的形变目标权重的轨道
+func NewMorphWeightTrack(mesh *Mesh, name string) *MorphWeightTrack {
+	return &MorphWeightTrack{
+		Mesh:      mesh,
+		Name:      name,
+		Keyframes: make([]FloatKeyframe, 0),
+	}
+}
+
+// AddKeyframe 添加关键帧（按时间保持有序）
+func (mt *MorphWeightTrack) AddKeyframe(time, weight float64, easing func(float64) float64) *MorphWeightTrack {
+	mt.Keyframes = append(mt.Keyframes, FloatKeyframe{Time: time, Value: weight, Easing: easing})
+	sort.SliceStable(mt.Keyframes, func(i, j int) bool {
+		return mt.Keyframes[i].Time < mt.Keyframes[j].Time
+	})
+	return mt
+}
+
+// Evaluate 计算时间 t 的权重
+func (mt *MorphWeightTrack) Evaluate(t float64) float64 {
+	return (&FloatTrack{Keyframes: mt.Keyframes}).Evaluate(t)
+}
+
+// Apply 将求值结果写入网格的形变权重
+func (mt *MorphWeightTrack) Apply(t float64) {
+	if mt.Mesh == nil || len(mt.Keyframes) == 0 {
+		return
+	}
+	mt.Mesh.SetMorphWeight(mt.Name, mt.Evaluate(t))
+}
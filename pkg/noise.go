@@ -0,0 +1,86 @@
+package go3d
+
+import "math"
+
+// PerlinNoise 经典 Perlin 梯度噪声，相同种子生成相同的噪声场
+type PerlinNoise struct {
+	perm [512]int
+}
+
+// NewPerlinNoise 按种子创建 Perlin 噪声
+func NewPerlinNoise(seed int64) *PerlinNoise {
+	pn := &PerlinNoise{}
+	p := NewRandom(seed, 0).Perm(256)
+	for i := range 512 {
+		pn.perm[i] = p[i&255]
+	}
+	return pn
+}
+
+// fade Perlin 的五次平滑曲线 6t^5 - 15t^4 + 10t^3
+func fade(t float64) float64 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+// grad2 按哈希值选择八个梯度方向之一，返回与偏移量的点积
+func grad2(hash int, x, y float64) float64 {
+	switch hash & 7 {
+	case 0:
+		return x + y
+	case 1:
+		return -x + y
+	case 2:
+		return x - y
+	case 3:
+		return -x - y
+	case 4:
+		return x
+	case 5:
+		return -x
+	case 6:
+		return y
+	default:
+		return -y
+	}
+}
+
+// Noise2D 二维噪声，返回值约在 [-1, 1] 之间，整数格点处为 0
+func (pn *PerlinNoise) Noise2D(x, y float64) float64 {
+	return pn.periodic2D(x, y, 256)
+}
+
+// periodic2D 二维噪声，x 方向每 period 个单位重复一次（period 在 1-256 之间），
+// 用于在 u 方向无缝环绕的纹理；period 为 256 时与 Noise2D 相同
+func (pn *PerlinNoise) periodic2D(x, y float64, period int) float64 {
+	xf, yf := math.Floor(x), math.Floor(y)
+	x0 := ((int(xf) % period) + period) % period
+	x1, yi := (x0+1)%period, int(yf)&255
+	x -= xf
+	y -= yf
+	u, v := fade(x), fade(y)
+
+	aa := pn.perm[pn.perm[x0]+yi]
+	ab := pn.perm[pn.perm[x0]+yi+1]
+	ba := pn.perm[pn.perm[x1]+yi]
+	bb := pn.perm[pn.perm[x1]+yi+1]
+
+	lerp := func(a, b, t float64) float64 { return a + (b-a)*t }
+	return lerp(
+		lerp(grad2(aa, x, y), grad2(ba, x-1, y), u),
+		lerp(grad2(ab, x, y-1), grad2(bb, x-1, y-1), u),
+		v,
+	)
+}
+
+// Fractal2D 分形布朗运动：叠加 octaves 层频率依次翻倍、振幅依次减半的噪声
+// 返回值归一化到约 [-1, 1]
+func (pn *PerlinNoise) Fractal2D(x, y float64, octaves int) float64 {
+	sum, amplitude, frequency, total := 0.0, 1.0, 1.0, 0.0
+	for range max(1, octaves) {
+		sum += pn.Noise2D(x*frequency, y*frequency) * amplitude
+		total += amplitude
+		amplitude *= 0.5
+		frequency *= 2
+	}
+	return sum / total
+}
@@ -0,0 +1,94 @@
+package go3d
+
+import "math"
+
+// surfaceDetail 网格的法线贴图和凹凸贴图，在不增加三角形的情况下扰动着色法线
+type surfaceDetail struct {
+	normalMap *Texture
+	bumpMap   *Texture
+	bumpScale float64
+}
+
+// centroid 三角形中心的重心坐标，按面着色时在此处扰动法线
+var centroid = [3]float64{1.0 / 3, 1.0 / 3, 1.0 / 3}
+
+// cornerWeights 三个顶点的重心坐标，按顶点着色时在顶点处扰动法线
+var cornerWeights = [3][3]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+
+// detail 网格设置了法线贴图或凹凸贴图且有纹理坐标时返回扰动参数，否则返回 nil
+func (m *Mesh) detail() *surfaceDetail {
+	if (m.NormalMap == nil && m.BumpMap == nil) || !m.HasUVs() {
+		return nil
+	}
+	return &surfaceDetail{normalMap: m.NormalMap, bumpMap: m.BumpMap, bumpScale: orDefault(m.BumpScale, 1)}
+}
+
+// tangentFrame 三角形表面上纹理坐标 u、v 增大的方向（单位向量），纹理坐标退化时 ok 为 false
+func tangentFrame(tri Triangle, uvs [3][2]float64) (tangent, bitangent Vector3, ok bool) {
+	e1, e2 := tri.V1.Sub(tri.V0), tri.V2.Sub(tri.V0)
+	du1, dv1 := uvs[1][0]-uvs[0][0], uvs[1][1]-uvs[0][1]
+	du2, dv2 := uvs[2][0]-uvs[0][0], uvs[2][1]-uvs[0][1]
+	det := du1*dv2 - du2*dv1
+	if math.Abs(det) < 1e-12 {
+		return Vector3{}, Vector3{}, false
+	}
+	tangent = e1.Scale(dv2).Sub(e2.Scale(dv1)).Scale(1 / det).Normalize()
+	bitangent = e2.Scale(du1).Sub(e1.Scale(du2)).Scale(1 / det).Normalize()
+	return tangent, bitangent, true
+}
+
+// at 三角形在重心坐标 w 处的扰动法线，normal 为该处未扰动的着色法线
+func (d *surfaceDetail) at(tri Triangle, uvs [3][2]float64, w [3]float64, normal Vector3) Vector3 {
+	tangent, bitangent, ok := tangentFrame(tri, uvs)
+	if !ok {
+		return normal
+	}
+	uv := [2]float64{
+		w[0]*uvs[0][0] + w[1]*uvs[1][0] + w[2]*uvs[2][0],
+		w[0]*uvs[0][1] + w[1]*uvs[1][1] + w[2]*uvs[2][1],
+	}
+	return d.perturb(normal, tangent, bitangent, uv)
+}
+
+// perturb 按纹理坐标 uv 处的贴图扰动着色法线 normal，tangent、bitangent 为 u、v 增大的方向
+// 切线基先对法线做正交化，平滑法线与面法线不一致时仍然保持正交
+func (d *surfaceDetail) perturb(normal, tangent, bitangent Vector3, uv [2]float64) Vector3 {
+	t := tangent.Sub(normal.Scale(normal.Dot(tangent))).Normalize()
+	b := normal.Cross(t)
+	if b.Dot(bitangent) < 0 {
+		b = b.Scale(-1) // 纹理镜像时 v 方向与 N×T 相反
+	}
+
+	result := normal
+	if d.normalMap != nil {
+		// OpenGL 约定：绿色通道指向纹理上方，即 v 减小的方向
+		c := d.normalMap.Sample(uv[0], uv[1])
+		x, y, z := c[0]*2-1, c[1]*2-1, c[2]*2-1
+		result = t.Scale(x).Sub(b.Scale(y)).Add(result.Scale(z))
+	}
+	if d.bumpMap != nil {
+		// 中心差分求亮度沿 u、v 每个像素的变化，法线向高度降低的方向倾斜
+		du, dv := textureStep(d.bumpMap.Width), textureStep(d.bumpMap.Height)
+		hu := (bumpHeight(d.bumpMap, uv[0]+du, uv[1]) - bumpHeight(d.bumpMap, uv[0]-du, uv[1])) / 2
+		hv := (bumpHeight(d.bumpMap, uv[0], uv[1]+dv) - bumpHeight(d.bumpMap, uv[0], uv[1]-dv)) / 2
+		result = result.Sub(t.Scale(hu * d.bumpScale)).Sub(b.Scale(hv * d.bumpScale))
+	}
+	if result.Length() < 1e-10 {
+		return normal
+	}
+	return result.Normalize()
+}
+
+// textureStep 纹理一个像素对应的纹理坐标间隔，程序纹理（没有像素尺寸）按 512 像素计算
+func textureStep(size int) float64 {
+	if size <= 0 {
+		return 1.0 / 512
+	}
+	return 1 / float64(size)
+}
+
+// bumpHeight 凹凸贴图在 (u, v) 处的高度（亮度）
+func bumpHeight(tex *Texture, u, v float64) float64 {
+	c := tex.Sample(u, v)
+	return 0.2126*c[0] + 0.7152*c[1] + 0.0722*c[2]
+}
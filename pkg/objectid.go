@@ -0,0 +1,83 @@
+package go3d
+
+import "image"
+
+// objectIDMultiplier 打散物体编号的奇数乘数：相邻编号得到差别明显的颜色，且可在模 2^24 下求逆
+const objectIDMultiplier = 0x9E3779
+
+// ObjectIDColor 物体编号对应的纯色，编号 0（背景）为黑色
+// 编号与颜色一一对应（编号取低 24 位），可用 ObjectIDFromRGB 还原
+func ObjectIDColor(id int) [3]float64 {
+	code := uint32(id) * objectIDMultiplier & 0xFFFFFF
+	return [3]float64{
+		float64(code>>16) / 255,
+		float64(code>>8&0xFF) / 255,
+		float64(code&0xFF) / 255,
+	}
+}
+
+// ObjectIDFromRGB 从物体编号画面的像素颜色还原物体编号
+func ObjectIDFromRGB(red, green, blue uint8) int {
+	// 牛顿迭代求乘数在模 2^24 下的逆元，每次迭代有效位数翻倍
+	inverse := uint32(objectIDMultiplier)
+	for range 5 {
+		inverse *= 2 - objectIDMultiplier*inverse
+	}
+	code := uint32(red)<<16 | uint32(green)<<8 | uint32(blue)
+	return int(code * inverse & 0xFFFFFF)
+}
+
+// ObjectIDAt 物体编号模式渲染后画布上一个像素的物体编号，可用于拾取
+// 坐标超出画布时返回 0
+func (r *Renderer) ObjectIDAt(x, y int) int {
+	img, ok := r.Surface.GetGoImage().(*image.RGBA)
+	if !ok || x < 0 || y < 0 || x >= r.Width || y >= r.Height {
+		return 0
+	}
+	offset := y*img.Stride + x*4
+	return ObjectIDFromRGB(img.Pix[offset], img.Pix[offset+1], img.Pix[offset+2])
+}
+
+// paintObjectIDs 对每个像素中心投射一条主光线，把最近表面所属物体的编号颜色写入画布
+// 不做超采样和混合，保证每个像素的颜色都能还原为编号；没有击中的像素保留原内容
+func (rt *rayTracer) paintObjectIDs(img *image.RGBA) {
+	r := rt.r
+	rt.parallelRows(func(y int) {
+		for x := range r.Width {
+			dir, tMin, tMax := rt.cameraRay(float64(x)+0.5, float64(y)+0.5)
+			hit, ok := rt.bvh.Intersect(r.Camera.Position, dir, tMin, tMax)
+			if !ok {
+				continue
+			}
+			c := ObjectIDColor(rt.info[hit.Triangle].object)
+			offset := y*img.Stride + x*4
+			for k := range 3 {
+				img.Pix[offset+k] = uint8(c[k]*255 + 0.5)
+			}
+			img.Pix[offset+3] = 255
+		}
+	})
+}
+
+// ObjectIDs 场景对象的物体编号映射：编号为对象在场景中的顺序加 1，与 Scene.Render 设置的一致
+func (s *Scene) ObjectIDs() map[int]SceneObject {
+	ids := make(map[int]SceneObject, len(s.Objects))
+	for i, obj := range s.Objects {
+		ids[i+1] = obj
+	}
+	return ids
+}
+
+// RenderObjectIDs 以物体编号模式渲染一帧分割掩码（背景为黑色），返回编号到对象的映射
+// 渲染结束后恢复原来的渲染模式，画布保留掩码画面，可直接保存或用 ObjectIDAt 查询
+func (s *Scene) RenderObjectIDs(renderer *Renderer, t float64) map[int]SceneObject {
+	mode := renderer.RenderMode
+	renderer.SetRenderMode(RenderObjectID)
+	defer renderer.SetRenderMode(mode)
+
+	renderer.Clear(0, 0, 0)
+	s.Render(renderer, t)
+	// 恢复渲染模式后保存画面时也不再应用后期处理
+	renderer.postApplied = true
+	return s.ObjectIDs()
+}
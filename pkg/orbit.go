@@ -4,10 +4,15 @@ import "math"
 
 // Orbit 轨道
 type Orbit struct {
-	Radius    float64
+	Radius    float64 // 半径（椭圆轨道时为半长轴）
 	Color     [3]float64
 	Thickness float64
 	Segments  int
+
+	Eccentricity  float64 // 轨道偏心率
+	Inclination   float64 // 轨道倾角（弧度）
+	AscendingNode float64 // 升交点经度（弧度）
+	ArgPeriapsis  float64 // 近心点幅角（弧度）
 }
 
 // NewOrbit 创建轨道
@@ -20,13 +25,41 @@ func NewOrbit(radius float64, color [3]float64) *Orbit {
 	}
 }
 
+// NewOrbitForPlanet 创建与行星轨道根数一致的轨道
+func NewOrbitForPlanet(planet *Planet, color [3]float64) *Orbit {
+	orbit := NewOrbit(planet.OrbitRadius, color)
+	orbit.Eccentricity = planet.Eccentricity
+	orbit.Inclination = planet.Inclination
+	orbit.AscendingNode = planet.AscendingNode
+	orbit.ArgPeriapsis = planet.ArgPeriapsis
+	return orbit
+}
+
+// OrbitalElements 获取轨道根数
+func (o *Orbit) OrbitalElements() OrbitalElements {
+	return OrbitalElements{
+		SemiMajorAxis: o.Radius,
+		Eccentricity:  o.Eccentricity,
+		Inclination:   o.Inclination,
+		AscendingNode: o.AscendingNode,
+		ArgPeriapsis:  o.ArgPeriapsis,
+	}
+}
+
 // Render 渲染轨道
 func (o *Orbit) Render(renderer *Renderer, t float64) {
-	orbit := CreateTorus(o.Radius, o.Thickness, o.Segments, 4)
-	transform := Identity()
-	// 不需要旋转，轨道默认就在XY平面上
-	transformedOrbit := orbit.Transform(transform)
-	renderer.DrawMesh(transformedOrbit, o.Color)
+	if o.Radius <= 0 {
+		return
+	}
+	// 单位圆环默认在XY平面上，缩放到轨道半径后按轨道根数变换为对应的椭圆
+	orbit := unitPrimitive(primitiveTorus, o.Segments, 4, o.Thickness/o.Radius)
+	transform := o.OrbitalElements().EllipseTransform().Multiply(Scale(o.Radius, o.Radius, o.Radius))
+	renderer.DrawMeshTransformed(orbit, transform, o.Color)
+}
+
+// WorldBounds 轨道线的包围盒
+func (o *Orbit) WorldBounds(t float64) AABB {
+	return o.OrbitalElements().Bounds().grow(o.Thickness)
 }
 
 // Star 星星
@@ -37,6 +70,9 @@ type Star struct {
 	Brightness float64
 	Twinkle    bool
 	Phase      float64 // 闪烁相位
+	Magnitude  float64 // 视星等，越小越亮
+	ID         int     // 星表编号（由星表加载时有效）
+	Name       string  // 专有名称
 }
 
 // NewStar 创建星星
@@ -58,12 +94,17 @@ func (s *Star) SetTwinkle(phase float64) *Star {
 	return s
 }
 
-// Render 渲染星星
-func (s *Star) Render(renderer *Renderer, t float64) {
-	brightness := s.Brightness
+// currentBrightness 计算时间 t 的亮度（含闪烁）
+func (s *Star) currentBrightness(t float64) float64 {
 	if s.Twinkle {
-		brightness = s.Brightness * (0.7 + 0.3*math.Sin(t*4*math.Pi+s.Phase))
+		return s.Brightness * (0.7 + 0.3*math.Sin(t*4*math.Pi+s.Phase))
 	}
+	return s.Brightness
+}
+
+// Render 渲染星星
+func (s *Star) Render(renderer *Renderer, t float64) {
+	brightness := s.currentBrightness(t)
 
 	color := [3]float64{
 		s.Color[0] * brightness,
@@ -71,38 +112,100 @@ func (s *Star) Render(renderer *Renderer, t float64) {
 		s.Color[2] * brightness,
 	}
 
-	star := CreateSphere(s.Radius, 6, 6)
-	transform := Identity()
-	transform = transform.Multiply(Translation(s.Position.X, s.Position.Y, s.Position.Z))
-	transformedStar := star.Transform(transform)
-	renderer.DrawMesh(transformedStar, color)
+	star := unitPrimitive(primitiveSphere, 6, 6, 0)
+	transform := Translation(s.Position.X, s.Position.Y, s.Position.Z).Multiply(Scale(s.Radius, s.Radius, s.Radius))
+	renderer.DrawMeshTransformed(star, transform, color)
 }
 
+// renderPoint 将星星绘制为屏幕空间圆点，半径和不透明度随星等变化
+func (s *Star) renderPoint(renderer *Renderer, t float64, pointSize float64) {
+	x, y, z := renderer.ProjectToScreen(s.Position)
+	if z < -1 || z > 1 {
+		return
+	}
+
+	// 星等每差 5 等亮度差 100 倍；取四次方根压缩动态范围，让暗星仍然可见
+	scale := math.Pow(10, -0.1*s.Magnitude)
+	radius := math.Max(0.8, pointSize*scale)
+	alpha := math.Min(1, 0.35+0.65*scale) * s.currentBrightness(t)
+
+	renderer.Context.SetSourceRGBA(s.Color[0], s.Color[1], s.Color[2], math.Max(0, math.Min(1, alpha)))
+	renderer.Context.Arc(x, y, radius, 0, 2*math.Pi)
+	renderer.Context.Fill()
+}
+
+// StarDistribution 星空中星星的空间分布
+type StarDistribution int
+
+const (
+	StarDistributionShell    StarDistribution = iota // 球壳内均匀分布（默认）
+	StarDistributionSphere                           // 整个球体内均匀分布
+	StarDistributionMilkyWay                         // 集中在倾斜的银河带附近
+)
+
 // StarField 星空场
 type StarField struct {
-	Stars []Star
+	Stars     []Star
+	PointSize float64 // 0 等星的点半径（像素），星等每增加 1 半径缩小约 20%（6 等星约为四分之一）
+
+	Constellations     []Constellation // 星座连线
+	ConstellationColor [3]float64
+	ConstellationWidth float64
 }
 
-// NewStarField 创建星空场
+// NewStarField 创建星空场（固定种子，球壳分布）
 func NewStarField(numStars int, distance float64) *StarField {
+	return NewSeededStarField(NewRandom(1, 0), numStars, distance, StarDistributionShell)
+}
+
+// NewSeededStarField 使用指定随机源创建星空场
+// 星星位于距原点 distance 到 1.5 倍 distance 之间，星等按真实星空的分布采样：暗星远多于亮星
+func NewSeededStarField(rng RandomSource, numStars int, distance float64, distribution StarDistribution) *StarField {
+	const (
+		minMagnitude = -1.0 // 最亮的星（天狼星约为 -1.5）
+		maxMagnitude = 6.0  // 肉眼极限星等
+		bandWidth    = 0.15 // 银河带的纬度标准差（弧度）
+		bandTilt     = 1.05 // 银河带相对于 XY 平面的倾角（弧度，约 60°）
+	)
+
 	sf := &StarField{
-		Stars: make([]Star, numStars),
+		Stars:     make([]Star, numStars),
+		PointSize: 2.5,
 	}
 
 	for i := range numStars {
-		// 伪随机位置
-		angle1 := float64(i) * 2.4
-		angle2 := float64(i) * 1.7
-		dist := distance + float64(i%10)*2.0
+		// 方向：球面均匀分布，银河带模式下纬度服从正态分布
+		var dir Vector3
+		switch distribution {
+		case StarDistributionMilkyWay:
+			lon := rng.Float64() * 2 * math.Pi
+			lat := math.Max(-math.Pi/2, math.Min(math.Pi/2, rng.NormFloat64()*bandWidth))
+			dir = RotationX(bandTilt).TransformVector(NewVector3(
+				math.Cos(lat)*math.Cos(lon),
+				math.Cos(lat)*math.Sin(lon),
+				math.Sin(lat),
+			))
+		default:
+			z := rng.Float64()*2 - 1
+			phi := rng.Float64() * 2 * math.Pi
+			r := math.Sqrt(1 - z*z)
+			dir = NewVector3(r*math.Cos(phi), r*math.Sin(phi), z)
+		}
 
-		x := dist * math.Cos(angle1) * math.Sin(angle2)
-		y := dist * math.Sin(angle1) * 0.5
-		z := dist * math.Cos(angle1) * math.Cos(angle2)
+		// 距离：球体模式按体积均匀分布，其余在球壳内
+		dist := distance * (1 + 0.5*rng.Float64())
+		if distribution == StarDistributionSphere {
+			dist = distance * 1.5 * math.Cbrt(rng.Float64())
+		}
+
+		// 星等：累计星数 N(<m) ∝ 10^(0.5m)，按逆变换采样
+		lo := math.Pow(10, 0.5*minMagnitude)
+		hi := math.Pow(10, 0.5*maxMagnitude)
+		magnitude := 2 * math.Log10(lo+rng.Float64()*(hi-lo))
 
 		// 不同颜色的星星
 		var starColor [3]float64
-		colorType := i % 3
-		switch colorType {
+		switch rng.Intn(3) {
 		case 0:
 			starColor = [3]float64{1.0, 1.0, 1.0} // 白色
 		case 1:
@@ -111,15 +214,22 @@ func NewStarField(numStars int, distance float64) *StarField {
 			starColor = [3]float64{1.0, 0.95, 0.7} // 淡黄色
 		}
 
-		sf.Stars[i] = *NewStar(NewVector3(x, y, z), 0.05, starColor).SetTwinkle(float64(i))
+		star := NewStar(dir.Scale(dist), 0.05, starColor).SetTwinkle(rng.Float64() * 2 * math.Pi)
+		star.Magnitude = magnitude
+		sf.Stars[i] = *star
 	}
 
 	return sf
 }
 
-// Render 渲染星空场
+// Render 渲染星空场：每颗星投影后绘制为一个圆点，而不是球体网格
 func (sf *StarField) Render(renderer *Renderer, t float64) {
+	renderer.Context.Save()
+	defer renderer.Context.Restore()
+
+	sf.renderConstellations(renderer)
+
 	for i := range sf.Stars {
-		sf.Stars[i].Render(renderer, t)
+		sf.Stars[i].renderPoint(renderer, t, sf.PointSize)
 	}
 }
@@ -0,0 +1,142 @@
+package go3d
+
+import "math"
+
+// OrbitalElements 轨道根数（参考平面为 XY 平面，Z 轴为参考法线）
+type OrbitalElements struct {
+	SemiMajorAxis float64 // 半长轴
+	Eccentricity  float64 // 偏心率（0 为圆，0 < e < 1 为椭圆）
+	Inclination   float64 // 轨道倾角（弧度）
+	AscendingNode float64 // 升交点经度（弧度）
+	ArgPeriapsis  float64 // 近心点幅角（弧度）
+}
+
+// SemiMinorAxis 半短轴
+func (oe OrbitalElements) SemiMinorAxis() float64 {
+	e := oe.clampedEccentricity()
+	return oe.SemiMajorAxis * math.Sqrt(1-e*e)
+}
+
+// clampedEccentricity 将偏心率限制在椭圆范围内
+func (oe OrbitalElements) clampedEccentricity() float64 {
+	return math.Max(0, math.Min(0.999, oe.Eccentricity))
+}
+
+// OrientationMatrix 从轨道平面到参考坐标系的旋转：Rz(Ω)·Rx(i)·Rz(ω)
+func (oe OrbitalElements) OrientationMatrix() Matrix4 {
+	return RotationZ(oe.AscendingNode).
+		Multiply(RotationX(oe.Inclination)).
+		Multiply(RotationZ(oe.ArgPeriapsis))
+}
+
+// PositionAtEccentricAnomaly 根据偏近点角计算相对于焦点（中心天体）的位置
+func (oe OrbitalElements) PositionAtEccentricAnomaly(eccentricAnomaly float64) Vector3 {
+	e := oe.clampedEccentricity()
+	x := oe.SemiMajorAxis * (math.Cos(eccentricAnomaly) - e)
+	y := oe.SemiMinorAxis() * math.Sin(eccentricAnomaly)
+	return oe.OrientationMatrix().TransformVector(NewVector3(x, y, 0))
+}
+
+// Bounds 轨道椭圆的包围盒（沿轨道取 64 个点）
+func (oe OrbitalElements) Bounds() AABB {
+	var box AABB
+	for i := range 64 {
+		p := oe.PositionAtEccentricAnomaly(float64(i) / 64 * 2 * math.Pi)
+		if i == 0 {
+			box = AABB{Min: p, Max: p}
+		} else {
+			box = box.union(AABB{Min: p, Max: p})
+		}
+	}
+	return box
+}
+
+// EllipseTransform 将 XY 平面上半径为半长轴、圆心在原点的圆变换为该轨道椭圆
+func (oe OrbitalElements) EllipseTransform() Matrix4 {
+	e := oe.clampedEccentricity()
+	transform := oe.OrientationMatrix()
+	transform = transform.Multiply(Translation(-oe.SemiMajorAxis*e, 0, 0))
+	transform = transform.Multiply(Scale(1, math.Sqrt(1-e*e), 1))
+	return transform
+}
+
+// SolveKepler 求解开普勒方程 M = E - e·sin(E)，返回偏近点角 E
+func SolveKepler(meanAnomaly, eccentricity float64) float64 {
+	e := math.Max(0, math.Min(0.999, eccentricity))
+	m := math.Remainder(meanAnomaly, 2*math.Pi)
+
+	// 高偏心率时以 π 作为初值更稳定
+	eccAnomaly := m
+	if e > 0.8 {
+		eccAnomaly = math.Pi
+		if m < 0 {
+			eccAnomaly = -math.Pi
+		}
+	}
+
+	// 牛顿迭代
+	for range 50 {
+		f := eccAnomaly - e*math.Sin(eccAnomaly) - m
+		fPrime := 1 - e*math.Cos(eccAnomaly)
+		delta := f / fPrime
+		eccAnomaly -= delta
+		if math.Abs(delta) < 1e-12 {
+			break
+		}
+	}
+
+	// 还原被 Remainder 去掉的整圈数，保证 E 随 M 连续增长
+	return eccAnomaly + (meanAnomaly - m)
+}
+
+// KeplerOrbit 开普勒轨道运动：轨道根数加上周期和历元
+type KeplerOrbit struct {
+	Elements           OrbitalElements
+	Period             float64 // 轨道周期（与 t 使用相同的时间单位）
+	Epoch              float64 // 历元时间
+	MeanAnomalyAtEpoch float64 // 历元时的平近点角（弧度）
+}
+
+// NewKeplerOrbit 创建开普勒轨道
+func NewKeplerOrbit(elements OrbitalElements, period float64) *KeplerOrbit {
+	return &KeplerOrbit{
+		Elements: elements,
+		Period:   period,
+	}
+}
+
+// MeanMotion 平均角速度
+func (ko *KeplerOrbit) MeanMotion() float64 {
+	if ko.Period == 0 {
+		return 0
+	}
+	return 2 * math.Pi / ko.Period
+}
+
+// MeanAnomaly 时间 t 的平近点角
+func (ko *KeplerOrbit) MeanAnomaly(t float64) float64 {
+	return ko.MeanAnomalyAtEpoch + ko.MeanMotion()*(t-ko.Epoch)
+}
+
+// StateAt 计算时间 t 相对于焦点的位置和速度
+// 近心点附近速度更快，远心点附近速度更慢
+func (ko *KeplerOrbit) StateAt(t float64) (position, velocity Vector3) {
+	oe := ko.Elements
+	e := oe.clampedEccentricity()
+	eccAnomaly := SolveKepler(ko.MeanAnomaly(t), e)
+
+	// 偏近点角随时间的变化率
+	dE := ko.MeanMotion() / (1 - e*math.Cos(eccAnomaly))
+
+	orientation := oe.OrientationMatrix()
+	position = oe.PositionAtEccentricAnomaly(eccAnomaly)
+
+	// 速度只需旋转，不需要平移
+	localVelocity := NewVector3(
+		-oe.SemiMajorAxis*math.Sin(eccAnomaly)*dE,
+		oe.SemiMinorAxis()*math.Cos(eccAnomaly)*dE,
+		0,
+	)
+	velocity = orientation.TransformVector(localVelocity)
+	return position, velocity
+}
@@ -0,0 +1,246 @@
+package go3d
+
+import (
+	"math"
+	"sort"
+)
+
+// EmitterShape 粒子发射器形状
+type EmitterShape int
+
+const (
+	EmitterPoint  EmitterShape = iota // 从一点发射
+	EmitterSphere                     // 从球体内随机位置发射（Extent.X 为半径）
+	EmitterBox                        // 从长方体内随机位置发射（Extent 为半边长）
+	EmitterDisc                       // 从 XY 平面圆盘内随机位置发射（Extent.X 为半径）
+)
+
+// Particle 某一时刻的粒子状态
+type Particle struct {
+	Position Vector3
+	Color    [3]float64
+	Alpha    float64
+	Size     float64 // 世界空间半径
+	Age      float64 // 0 为刚发射，1 为寿命结束
+}
+
+// ParticleEmitter 粒子发射器
+// 粒子状态完全由时间 t 和种子决定（第 i 个粒子在 StartTime + i/Rate 时发射），
+// 不保存逐帧状态，因此多线程渲染时每一帧的结果都可复现
+type ParticleEmitter struct {
+	Position Vector3
+	Shape    EmitterShape
+	Extent   Vector3 // 发射区域大小，含义取决于 Shape
+
+	Rate           float64 // 每单位时间发射的粒子数
+	StartTime      float64 // 开始发射的时间
+	EndTime        float64 // 停止发射的时间，不大于 StartTime 时持续发射
+	Lifetime       float64 // 粒子寿命
+	LifetimeJitter float64 // 寿命随机变化比例（0-1）
+
+	Velocity       Vector3 // 初速度
+	VelocityJitter float64 // 叠加在初速度上的随机方向速度大小
+	Gravity        Vector3 // 加速度
+
+	StartColor, EndColor [3]float64 // 随寿命变化的颜色
+	StartAlpha, EndAlpha float64    // 随寿命变化的不透明度
+	StartSize, EndSize   float64    // 随寿命变化的世界空间半径
+
+	Seed int64
+}
+
+// NewParticleEmitter 创建粒子发射器
+func NewParticleEmitter(position Vector3, rate, lifetime float64) *ParticleEmitter {
+	return &ParticleEmitter{
+		Position:       position,
+		Shape:          EmitterPoint,
+		Rate:           rate,
+		Lifetime:       lifetime,
+		LifetimeJitter: 0.2,
+		Velocity:       NewVector3(0, 0, 1),
+		VelocityJitter: 0.3,
+		StartColor:     [3]float64{1.0, 0.9, 0.5},
+		EndColor:       [3]float64{1.0, 0.3, 0.1},
+		StartAlpha:     1.0,
+		EndAlpha:       0.0,
+		StartSize:      0.05,
+		EndSize:        0.02,
+		Seed:           1,
+	}
+}
+
+// SetShape 设置发射区域形状和大小
+func (pe *ParticleEmitter) SetShape(shape EmitterShape, extent Vector3) *ParticleEmitter {
+	pe.Shape = shape
+	pe.Extent = extent
+	return pe
+}
+
+// SetVelocity 设置初速度和随机速度
+func (pe *ParticleEmitter) SetVelocity(velocity Vector3, jitter float64) *ParticleEmitter {
+	pe.Velocity = velocity
+	pe.VelocityJitter = jitter
+	return pe
+}
+
+// SetGravity 设置加速度
+func (pe *ParticleEmitter) SetGravity(gravity Vector3) *ParticleEmitter {
+	pe.Gravity = gravity
+	return pe
+}
+
+// SetColorOverLife 设置颜色和不透明度随寿命的变化
+func (pe *ParticleEmitter) SetColorOverLife(start, end [3]float64, startAlpha, endAlpha float64) *ParticleEmitter {
+	pe.StartColor = start
+	pe.EndColor = end
+	pe.StartAlpha = startAlpha
+	pe.EndAlpha = endAlpha
+	return pe
+}
+
+// SetSizeOverLife 设置大小随寿命的变化
+func (pe *ParticleEmitter) SetSizeOverLife(start, end float64) *ParticleEmitter {
+	pe.StartSize = start
+	pe.EndSize = end
+	return pe
+}
+
+// spawnOffset 按发射器形状生成发射位置偏移
+func (pe *ParticleEmitter) spawnOffset(rng *Random) Vector3 {
+	switch pe.Shape {
+	case EmitterSphere:
+		return rng.unitVector().Scale(pe.Extent.X * math.Cbrt(rng.Float64()))
+	case EmitterBox:
+		return NewVector3(
+			(rng.Float64()*2-1)*pe.Extent.X,
+			(rng.Float64()*2-1)*pe.Extent.Y,
+			(rng.Float64()*2-1)*pe.Extent.Z,
+		)
+	case EmitterDisc:
+		r := pe.Extent.X * math.Sqrt(rng.Float64())
+		angle := rng.Float64() * 2 * math.Pi
+		return NewVector3(r*math.Cos(angle), r*math.Sin(angle), 0)
+	default:
+		return NewVector3(0, 0, 0)
+	}
+}
+
+// Particles 计算时间 t 所有存活的粒子
+func (pe *ParticleEmitter) Particles(t float64) []Particle {
+	if pe.Rate <= 0 || pe.Lifetime <= 0 || t < pe.StartTime {
+		return nil
+	}
+
+	// 只有在 [t - 最长寿命, t] 内发射的粒子可能存活
+	maxLifetime := pe.Lifetime * (1 + math.Abs(pe.LifetimeJitter))
+	lastSpawn := t
+	if pe.EndTime > pe.StartTime {
+		lastSpawn = math.Min(t, pe.EndTime)
+	}
+	first := int64(math.Max(0, math.Ceil((t-maxLifetime-pe.StartTime)*pe.Rate)))
+	last := int64(math.Floor((lastSpawn - pe.StartTime) * pe.Rate))
+
+	var particles []Particle
+	for i := first; i <= last; i++ {
+		rng := NewRandom(pe.Seed, i)
+		spawn := pe.StartTime + float64(i)/pe.Rate
+		lifetime := pe.Lifetime * (1 + (rng.Float64()*2-1)*pe.LifetimeJitter)
+		elapsed := t - spawn
+		if lifetime <= 0 || elapsed < 0 || elapsed > lifetime {
+			continue
+		}
+
+		origin := pe.Position.Add(pe.spawnOffset(rng))
+		velocity := pe.Velocity.Add(rng.unitVector().Scale(pe.VelocityJitter * rng.Float64()))
+		age := elapsed / lifetime
+
+		particles = append(particles, Particle{
+			Position: origin.Add(velocity.Scale(elapsed)).Add(pe.Gravity.Scale(0.5 * elapsed * elapsed)),
+			Color: [3]float64{
+				pe.StartColor[0] + (pe.EndColor[0]-pe.StartColor[0])*age,
+				pe.StartColor[1] + (pe.EndColor[1]-pe.StartColor[1])*age,
+				pe.StartColor[2] + (pe.EndColor[2]-pe.StartColor[2])*age,
+			},
+			Alpha: pe.StartAlpha + (pe.EndAlpha-pe.StartAlpha)*age,
+			Size:  pe.StartSize + (pe.EndSize-pe.StartSize)*age,
+			Age:   age,
+		})
+	}
+	return particles
+}
+
+// Render 渲染发射器的粒子
+func (pe *ParticleEmitter) Render(renderer *Renderer, t float64) {
+	renderer.DrawParticles(pe.Particles(t))
+}
+
+// ParticleSystem 粒子系统，统一排序并绘制多个发射器的粒子
+type ParticleSystem struct {
+	Emitters []*ParticleEmitter
+}
+
+// NewParticleSystem 创建粒子系统
+func NewParticleSystem() *ParticleSystem {
+	return &ParticleSystem{
+		Emitters: make([]*ParticleEmitter, 0),
+	}
+}
+
+// AddEmitter 添加发射器
+func (ps *ParticleSystem) AddEmitter(emitter *ParticleEmitter) *ParticleSystem {
+	ps.Emitters = append(ps.Emitters, emitter)
+	return ps
+}
+
+// Render 渲染粒子系统
+func (ps *ParticleSystem) Render(renderer *Renderer, t float64) {
+	var particles []Particle
+	for _, emitter := range ps.Emitters {
+		particles = append(particles, emitter.Particles(t)...)
+	}
+	renderer.DrawParticles(particles)
+}
+
+// DrawParticles 将粒子绘制为始终面向相机的圆形公告板，从远到近绘制
+// 物体编号模式下不绘制粒子
+func (r *Renderer) DrawParticles(particles []Particle) {
+	if len(particles) == 0 || r.RenderMode == RenderObjectID {
+		return
+	}
+
+	// 相机右方向，用于把世界空间半径换算为像素半径
+	_, right, _ := r.Camera.Basis()
+
+	type sprite struct {
+		x, y, z, radius float64
+		particle        Particle
+	}
+	sprites := make([]sprite, 0, len(particles))
+	for _, p := range particles {
+		if p.Alpha <= 0 || p.Size <= 0 {
+			continue
+		}
+		x, y, z := r.ProjectToScreen(p.Position)
+		if z < -1 || z > 1 {
+			continue
+		}
+		ex, ey, _ := r.ProjectToScreen(p.Position.Add(right.Scale(p.Size)))
+		sprites = append(sprites, sprite{x: x, y: y, z: z, radius: math.Hypot(ex-x, ey-y), particle: p})
+	}
+
+	sort.SliceStable(sprites, func(i, j int) bool {
+		return sprites[i].z > sprites[j].z
+	})
+
+	r.DrawOverlay(func() {
+		r.Context.Save()
+		defer r.Context.Restore()
+
+		for _, s := range sprites {
+			c := s.particle.Color
+			r.Context.SetSourceRGBA(c[0], c[1], c[2], math.Min(1, s.particle.Alpha))
+			r.Context.Arc(s.x, s.y, math.Max(0.5, s.radius), 0, 2*math.Pi)
+			r.Context.Fill()
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package go3d
+
+import (
+	"image"
+	"math"
+	"time"
+)
+
+// pathTrace 渐进式路径追踪：每一遍为每个像素追加一个采样并累积，
+// 直到达到 PathSamples 或超出 TimeBudget，最后把平均结果合成到画布上
+// 每个采样的随机数只由种子、遍数和像素位置决定，与线程数和调度无关
+func (rt *rayTracer) pathTrace(img *image.RGBA) {
+	r := rt.r
+	width, height := r.Width, r.Height
+	accum := make([][4]float64, width*height)
+	maxSamples := max(1, rt.options.PathSamples)
+	start := time.Now()
+
+	passes := 0
+	for passes < maxSamples {
+		pass := passes
+		rt.parallelRows(func(y int) {
+			for x := range width {
+				rng := NewRandom(rt.options.Seed, int64((pass*height+y)*width+x))
+				dir, tMin, tMax := rt.cameraRay(float64(x)+rng.Float64(), float64(y)+rng.Float64())
+				c, a := rt.radiance(r.Camera.Position, dir, tMin, tMax, 0, true, rng)
+
+				acc := &accum[y*width+x]
+				acc[0] += c[0]
+				acc[1] += c[1]
+				acc[2] += c[2]
+				acc[3] += a
+			}
+		})
+		passes++
+		if rt.options.TimeBudget > 0 && time.Since(start) >= rt.options.TimeBudget {
+			break
+		}
+	}
+	r.PathSamplesDone = passes
+
+	n := float64(passes)
+	for y := range height {
+		for x := range width {
+			acc := accum[y*width+x]
+			rt.composite(img, x, y, [3]float64{acc[0] / n, acc[1] / n, acc[2] / n}, acc[3]/n)
+		}
+	}
+}
+
+// radiance 沿光线路径估计到达的光（光照空间，预乘 alpha）和覆盖率
+// 每个交点按透明度、反射率随机选择穿过、镜面反射或漫反射；漫反射直接采样点光源，
+// 再按余弦分布反弹一次收集间接光。primary 为 true 表示光线仍未被挡住（主光线或只穿过了透明面），
+// 此时未击中任何物体的覆盖率为 0，保留画布原有内容；其余光线未击中时返回天空光
+func (rt *rayTracer) radiance(origin, dir Vector3, tMin, tMax float64, depth int, primary bool, rng *Random) ([3]float64, float64) {
+	hit, ok := rt.bvh.Intersect(origin, dir, tMin, tMax)
+	if !ok {
+		if primary {
+			return [3]float64{}, 0
+		}
+		return rt.missColor(dir, rt.options.Sky), 1
+	}
+	surface := rt.surfaceAt(hit, origin, dir)
+	info := surface.info
+	if info.unlit {
+		return [3]float64{surface.base[0] + info.emissive[0], surface.base[1] + info.emissive[1], surface.base[2] + info.emissive[2]}, 1
+	}
+
+	if rng.Float64() < info.transparency {
+		if depth >= rt.options.MaxBounces {
+			return [3]float64{}, 0
+		}
+		below := surface.position.Sub(surface.faceNormal.Scale(rt.bias))
+		return rt.radiance(below, dir, 0, math.Inf(1), depth+1, primary, rng)
+	}
+
+	above := surface.position.Add(surface.faceNormal.Scale(rt.bias))
+	if rng.Float64() < info.reflectivity {
+		if depth >= rt.options.MaxBounces {
+			return [3]float64{}, 1
+		}
+		reflected := dir.Sub(surface.normal.Scale(2 * dir.Dot(surface.normal)))
+		c, _ := rt.radiance(above, reflected, 0, math.Inf(1), depth+1, false, rng)
+		return c, 1
+	}
+
+	light := rt.directLight(above, surface.position, surface.normal, info.layers)
+	if depth < rt.options.MaxBounces {
+		bounce := cosineSampleHemisphere(surface.normal, rng)
+		if bounce.Dot(surface.faceNormal) > 0 {
+			indirect, _ := rt.radiance(above, bounce, 0, math.Inf(1), depth+1, false, rng)
+			for k := range 3 {
+				light[k] += indirect[k]
+			}
+		}
+	}
+	// 自发光的表面同时照亮其它表面：漫反射反弹击中它时带回自发光
+	var c [3]float64
+	for k := range 3 {
+		c[k] = light[k]*surface.base[k] + info.emissive[k]
+	}
+	return c, 1
+}
+
+// cosineSampleHemisphere 以 normal 为轴按余弦分布采样半球方向（漫反射的重要性采样）
+func cosineSampleHemisphere(normal Vector3, rng *Random) Vector3 {
+	helper := NewVector3(1, 0, 0)
+	if math.Abs(normal.X) > 0.9 {
+		helper = NewVector3(0, 1, 0)
+	}
+	tangent := helper.Cross(normal).Normalize()
+	bitangent := normal.Cross(tangent)
+
+	phi := 2 * math.Pi * rng.Float64()
+	r2 := rng.Float64()
+	radius := math.Sqrt(r2)
+	return tangent.Scale(radius * math.Cos(phi)).
+		Add(bitangent.Scale(radius * math.Sin(phi))).
+		Add(normal.Scale(math.Sqrt(1 - r2)))
+}
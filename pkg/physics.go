@@ -0,0 +1,289 @@
+package go3d
+
+import "math"
+
+// RigidBody 参与物理模拟的刚体，碰撞形状为以 Transform.Position 为球心、半径为 Radius 的球。
+// 模拟直接更新 Transform，同一个 Transform 可以同时交给分子、骨架等对象，使它们随刚体运动
+type RigidBody struct {
+	Transform       *Transform
+	Velocity        Vector3
+	AngularVelocity Vector3 // 角速度（弧度/单位时间，方向为转轴），只用于翻滚效果，碰撞不改变它
+	Radius          float64
+	Mass            float64 // 质量，0 表示固定不动的障碍物
+	Restitution     float64 // 恢复系数：0 为完全非弹性，1 为完全弹性
+	Friction        float64 // 与地面碰撞时切向速度的损失比例（0-1），贴地滑动时为每单位时间的损失比例
+
+	Mesh  *Mesh // 绘制用的网格（可选），按 Transform 绘制
+	Color [3]float64
+
+	initial bodyState
+}
+
+// bodyState 刚体的运动状态，用于重新模拟
+type bodyState struct {
+	position, velocity, angularVelocity Vector3
+	rotation                            Quaternion
+}
+
+// PhysicsWorld 简单的物理模拟：重力、速度积分、地平面以及球与球之间的碰撞。
+// 以固定步长积分，同一场景每次模拟的结果相同。PhysicsWorld 既是场景对象也实现了 Track 接口，
+// 加入场景或时间线后每帧会自动推进到当前时间，不需要在 FrameRenderer 中手写运动学
+type PhysicsWorld struct {
+	Bodies  []*RigidBody
+	Gravity Vector3
+
+	Ground       bool    // 是否有水平地面
+	GroundHeight float64 // 地面的高度（Y 坐标）
+
+	TimeStep float64 // 积分步长，默认 1/240
+
+	// OnCollision 碰撞回调（可选），other 为 nil 表示与地面碰撞，impulse 为冲量大小，可用于触发音效、闪光等效果
+	OnCollision func(body, other *RigidBody, impulse float64)
+
+	time    float64
+	started bool
+}
+
+// NewPhysicsWorld 创建带地面（Y = 0）和标准重力的物理世界
+func NewPhysicsWorld() *PhysicsWorld {
+	return &PhysicsWorld{
+		Gravity:  NewVector3(0, -9.81, 0),
+		Ground:   true,
+		TimeStep: 1.0 / 240,
+	}
+}
+
+// SetGravity 设置重力加速度
+func (w *PhysicsWorld) SetGravity(gravity Vector3) *PhysicsWorld {
+	w.Gravity = gravity
+	return w
+}
+
+// SetGround 设置地面高度，enabled 为 false 时没有地面
+func (w *PhysicsWorld) SetGround(enabled bool, height float64) *PhysicsWorld {
+	w.Ground = enabled
+	w.GroundHeight = height
+	return w
+}
+
+// AddBody 添加位于 position、半径为 radius 的刚体，mass 为 0 时固定不动
+func (w *PhysicsWorld) AddBody(position Vector3, radius, mass float64) *RigidBody {
+	body := &RigidBody{
+		Transform:   NewTransform().SetPosition(position),
+		Radius:      radius,
+		Mass:        mass,
+		Restitution: 0.6,
+		Friction:    0.1,
+		Color:       [3]float64{0.8, 0.8, 0.8},
+	}
+	w.Bodies = append(w.Bodies, body)
+	return body
+}
+
+// SetVelocity 设置初速度
+func (b *RigidBody) SetVelocity(velocity Vector3) *RigidBody {
+	b.Velocity = velocity
+	return b
+}
+
+// SetAngularVelocity 设置角速度
+func (b *RigidBody) SetAngularVelocity(angularVelocity Vector3) *RigidBody {
+	b.AngularVelocity = angularVelocity
+	return b
+}
+
+// SetRestitution 设置恢复系数
+func (b *RigidBody) SetRestitution(restitution float64) *RigidBody {
+	b.Restitution = restitution
+	return b
+}
+
+// SetMesh 设置绘制用的网格和颜色
+func (b *RigidBody) SetMesh(mesh *Mesh, color [3]float64) *RigidBody {
+	b.Mesh = mesh
+	b.Color = color
+	return b
+}
+
+// inverseMass 质量的倒数，固定刚体为 0
+func (b *RigidBody) inverseMass() float64 {
+	if b.Mass <= 0 {
+		return 0
+	}
+	return 1 / b.Mass
+}
+
+// Time 模拟已经推进到的时间
+func (w *PhysicsWorld) Time() float64 {
+	return w.time
+}
+
+// start 第一次推进时记录所有刚体的初始状态
+func (w *PhysicsWorld) start() {
+	if w.started {
+		return
+	}
+	w.started = true
+	for _, body := range w.Bodies {
+		body.initial = bodyState{body.Transform.Position, body.Velocity, body.AngularVelocity, body.Transform.Rotation}
+	}
+}
+
+// Reset 把所有刚体恢复到第一次推进前的状态，时间归零
+func (w *PhysicsWorld) Reset() {
+	if w.started {
+		for _, body := range w.Bodies {
+			body.Transform.Position = body.initial.position
+			body.Transform.Rotation = body.initial.rotation
+			body.Velocity = body.initial.velocity
+			body.AngularVelocity = body.initial.angularVelocity
+		}
+	}
+	w.time = 0
+	w.started = false
+}
+
+// Advance 以固定步长模拟到时间 t。t 早于当前时间时从头重新模拟，因此帧可以按任意顺序渲染
+func (w *PhysicsWorld) Advance(t float64) {
+	if t < w.time-1e-9 {
+		w.Reset()
+	}
+	w.start()
+	step := w.TimeStep
+	if step <= 0 {
+		step = 1.0 / 240
+	}
+	for w.time+step <= t+1e-9 {
+		w.Step(step)
+	}
+}
+
+// Apply 实现 Track 接口：推进到时间 t
+func (w *PhysicsWorld) Apply(t float64) {
+	w.Advance(t)
+}
+
+// Step 推进一个时间步：积分速度和位置，再处理地面和刚体之间的碰撞
+func (w *PhysicsWorld) Step(dt float64) {
+	w.start()
+	for _, body := range w.Bodies {
+		if body.inverseMass() == 0 {
+			continue
+		}
+		body.Velocity = body.Velocity.Add(w.Gravity.Scale(dt))
+		body.Transform.Position = body.Transform.Position.Add(body.Velocity.Scale(dt))
+		if angle := body.AngularVelocity.Length() * dt; angle > 0 {
+			spin := QuaternionFromAxisAngle(body.AngularVelocity.Normalize(), angle)
+			body.Transform.Rotation = spin.Multiply(body.Transform.Rotation).Normalize()
+		}
+	}
+
+	if w.Ground {
+		for _, body := range w.Bodies {
+			w.collideGround(body, dt)
+		}
+	}
+	// 球与球两两检测，适合动画中几十到几百个刚体的规模
+	for i, a := range w.Bodies {
+		for _, b := range w.Bodies[i+1:] {
+			w.collideBodies(a, b)
+		}
+	}
+	w.time += dt
+}
+
+// collideGround 刚体穿入地面时推回地面，法向速度按恢复系数反弹，切向速度按摩擦衰减。
+// 下落速度小于两个时间步内重力产生的速度时视为贴地静止，不再反弹，避免物体在地面上抖动
+func (w *PhysicsWorld) collideGround(body *RigidBody, dt float64) {
+	if body.inverseMass() == 0 {
+		return
+	}
+	position := &body.Transform.Position
+	depth := w.GroundHeight + body.Radius - position.Y
+	if depth <= 0 {
+		return
+	}
+	position.Y += depth
+	if body.Velocity.Y >= 0 {
+		return
+	}
+
+	incoming := -body.Velocity.Y
+	friction := math.Max(0, math.Min(1, body.Friction))
+	if incoming < 2*math.Abs(w.Gravity.Y)*dt {
+		body.Velocity.Y = 0
+		keep := math.Pow(1-friction, dt)
+		body.Velocity.X *= keep
+		body.Velocity.Z *= keep
+		return
+	}
+	body.Velocity.Y = incoming * body.Restitution
+	body.Velocity.X *= 1 - friction
+	body.Velocity.Z *= 1 - friction
+	if w.OnCollision != nil {
+		w.OnCollision(body, nil, incoming*(1+body.Restitution)*body.Mass)
+	}
+}
+
+// collideBodies 两个球相交时按质量倒数分开，并沿连心线施加冲量，恢复系数取两者的平均值
+func (w *PhysicsWorld) collideBodies(a, b *RigidBody) {
+	invA, invB := a.inverseMass(), b.inverseMass()
+	if invA+invB == 0 {
+		return
+	}
+	delta := b.Transform.Position.Sub(a.Transform.Position)
+	distance := delta.Length()
+	depth := a.Radius + b.Radius - distance
+	if depth <= 0 {
+		return
+	}
+	normal := NewVector3(0, 1, 0)
+	if distance > 1e-12 {
+		normal = delta.Scale(1 / distance)
+	}
+
+	correction := normal.Scale(depth / (invA + invB))
+	a.Transform.Position = a.Transform.Position.Sub(correction.Scale(invA))
+	b.Transform.Position = b.Transform.Position.Add(correction.Scale(invB))
+
+	approach := b.Velocity.Sub(a.Velocity).Dot(normal)
+	if approach >= 0 {
+		return
+	}
+	restitution := (a.Restitution + b.Restitution) / 2
+	impulse := -(1 + restitution) * approach / (invA + invB)
+	a.Velocity = a.Velocity.Sub(normal.Scale(impulse * invA))
+	b.Velocity = b.Velocity.Add(normal.Scale(impulse * invB))
+	if w.OnCollision != nil {
+		w.OnCollision(a, b, impulse)
+	}
+}
+
+// Render 推进到时间 t 并绘制设置了网格的刚体。其他对象共享刚体的 Transform 时，
+// 应同时把物理世界加入时间线，使模拟在所有对象绘制之前推进
+func (w *PhysicsWorld) Render(renderer *Renderer, t float64) {
+	w.Advance(t)
+	for _, body := range w.Bodies {
+		if body.Mesh != nil {
+			renderer.DrawMeshTransformed(body.Mesh, body.Transform.Matrix(), body.Color)
+		}
+	}
+}
+
+// WorldBounds 所有刚体（碰撞球和网格）在时间 t 的范围
+func (w *PhysicsWorld) WorldBounds(t float64) AABB {
+	w.Advance(t)
+	var box AABB
+	for i, body := range w.Bodies {
+		part := sphereBounds(body.Transform.Position, body.Radius)
+		if body.Mesh != nil && len(body.Mesh.Triangles) > 0 {
+			part = part.union(transformBounds(body.Mesh.BoundingBox(), body.Transform.Matrix()))
+		}
+		if i == 0 {
+			box = part
+		} else {
+			box = box.union(part)
+		}
+	}
+	return box
+}
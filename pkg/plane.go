@@ -0,0 +1,65 @@
+package go3d
+
+// Plane 平面：满足 Normal·p = D 的点集，Normal 为单位向量，指向平面的正侧
+type Plane struct {
+	Normal Vector3
+	D      float64
+}
+
+// NewPlane 由法线和平面上一点创建平面
+func NewPlane(normal, point Vector3) Plane {
+	n := normal.Normalize()
+	return Plane{Normal: n, D: n.Dot(point)}
+}
+
+// NewPlaneFromPoints 由三个点创建平面，法线方向为 (b-a)×(c-a)
+func NewPlaneFromPoints(a, b, c Vector3) Plane {
+	return NewPlane(b.Sub(a).Cross(c.Sub(a)), a)
+}
+
+// Distance 点到平面的有向距离，正侧为正
+func (p Plane) Distance(point Vector3) float64 {
+	return p.Normal.Dot(point) - p.D
+}
+
+// Project 将点投影到平面上
+func (p Plane) Project(point Vector3) Vector3 {
+	return point.Sub(p.Normal.Scale(p.Distance(point)))
+}
+
+// basis 平面内两个互相垂直的单位向量 u、v，满足 u×v = Normal
+func (p Plane) basis() (Vector3, Vector3) {
+	helper := NewVector3(1, 0, 0)
+	if p.Normal.X*p.Normal.X > 0.5 {
+		helper = NewVector3(0, 1, 0)
+	}
+	u := helper.Sub(p.Normal.Scale(helper.Dot(p.Normal))).Normalize()
+	return u, p.Normal.Cross(u)
+}
+
+// PlaneSide 点相对平面的位置
+type PlaneSide int
+
+const (
+	PlaneOn    PlaneSide = 0  // 在平面上（容差范围内）
+	PlaneFront PlaneSide = 1  // 正侧
+	PlaneBack  PlaneSide = -1 // 负侧
+)
+
+// Classify 判断点位于平面的哪一侧，距离不超过 epsilon 视为在平面上
+func (p Plane) Classify(point Vector3, epsilon float64) PlaneSide {
+	d := p.Distance(point)
+	switch {
+	case d > epsilon:
+		return PlaneFront
+	case d < -epsilon:
+		return PlaneBack
+	default:
+		return PlaneOn
+	}
+}
+
+// Flip 返回方向相反的同一平面
+func (p Plane) Flip() Plane {
+	return Plane{Normal: p.Normal.Scale(-1), D: -p.D}
+}
@@ -0,0 +1,361 @@
+package go3d
+
+import (
+	"math"
+	"strconv"
+)
+
+// PlotAxes 数据图表的坐标框：把数据坐标 (x, y, z) 线性映射到世界空间中的长方体，
+// 并绘制网格线、坐标轴、刻度标签和轴标题。数据坐标以 z 为竖直方向，映射到世界坐标时
+// x → X、z → Y、y → -Z（保持右手系）。
+// 网格画在背对相机的三个面上，坐标轴画在靠近相机的棱上，相机绕图表旋转时自动换边
+type PlotAxes struct {
+	DataMin, DataMax Vector3 // 数据范围，两端相等的方向映射到长方体中央
+
+	Position Vector3 // 长方体中心的世界坐标
+	Size     Vector3 // 长方体沿数据 x、y、z 方向的世界尺寸
+
+	Labels     [3]string   // x、y、z 轴标题，空字符串表示不绘制
+	Ticks      int         // 每条轴大约的刻度数，0 表示不绘制刻度和网格
+	Categories [3][]string // 分类轴的类别名称：数据范围等分为同样多的格子，名称标在格子中心，网格线画在边界上
+	ShowGrid   bool
+	Color      [3]float64 // 坐标轴和文字颜色
+	GridColor  [3]float64
+	FontSize   float64 // 刻度标签字号，轴标题大 2 号
+}
+
+// NewPlotAxes 创建数据范围为 [min, max] 的坐标框，世界尺寸为 4 × 4 × 2.5，中心在原点
+func NewPlotAxes(min, max Vector3) *PlotAxes {
+	return &PlotAxes{
+		DataMin:   min,
+		DataMax:   max,
+		Size:      NewVector3(4, 4, 2.5),
+		Labels:    [3]string{"x", "y", "z"},
+		Ticks:     5,
+		ShowGrid:  true,
+		Color:     [3]float64{0.85, 0.85, 0.85},
+		GridColor: [3]float64{0.35, 0.35, 0.4},
+		FontSize:  11,
+	}
+}
+
+// component 向量按数据轴顺序（0 为 x，1 为 y，2 为 z）的分量
+func component(v Vector3, i int) float64 {
+	switch i {
+	case 0:
+		return v.X
+	case 1:
+		return v.Y
+	}
+	return v.Z
+}
+
+// normalize 第 i 条数据轴上的值在长方体中的相对位置（-0.5 到 0.5）
+func (a *PlotAxes) normalize(i int, value float64) float64 {
+	lo, hi := component(a.DataMin, i), component(a.DataMax, i)
+	if hi == lo {
+		return 0
+	}
+	return (value-lo)/(hi-lo) - 0.5
+}
+
+// point 相对位置（按数据轴顺序，-0.5 到 0.5）对应的世界坐标
+func (a *PlotAxes) point(n [3]float64) Vector3 {
+	return a.Position.Add(NewVector3(n[0]*a.Size.X, n[2]*a.Size.Z, -n[1]*a.Size.Y))
+}
+
+// ToWorld 数据坐标对应的世界坐标
+func (a *PlotAxes) ToWorld(p Vector3) Vector3 {
+	return a.point([3]float64{a.normalize(0, p.X), a.normalize(1, p.Y), a.normalize(2, p.Z)})
+}
+
+// model 把相对位置（已按世界轴排列：X、Y 为竖直、Z）变换到世界空间的矩阵，用于绘制归一化的网格
+func (a *PlotAxes) model() Matrix4 {
+	return Translation(a.Position.X, a.Position.Y, a.Position.Z).Multiply(Scale(a.Size.X, a.Size.Z, a.Size.Y))
+}
+
+// WorldBounds 坐标框长方体的范围（不含标签）
+func (a *PlotAxes) WorldBounds(t float64) AABB {
+	box := AABB{Min: a.point([3]float64{-0.5, -0.5, -0.5}), Max: a.point([3]float64{-0.5, -0.5, -0.5})}
+	return box.union(AABB{Min: a.point([3]float64{0.5, 0.5, 0.5}), Max: a.point([3]float64{0.5, 0.5, 0.5})})
+}
+
+// sides 每个方向上背对相机的一侧（-0.5 或 0.5），网格画在这一侧，坐标轴画在另一侧
+func (a *PlotAxes) sides(renderer *Renderer) [3]float64 {
+	offset := renderer.Camera.Position.Sub(a.Position)
+	camera := [3]float64{offset.X, -offset.Z, offset.Y}
+	var far [3]float64
+	for i := range 3 {
+		far[i] = 0.5
+		if camera[i] > 0 {
+			far[i] = -0.5
+		}
+	}
+	return far
+}
+
+// ticks 第 i 条轴上网格线的位置：数值轴为刻度值，分类轴为格子边界
+func (a *PlotAxes) ticks(i int) []float64 {
+	lo, hi := component(a.DataMin, i), component(a.DataMax, i)
+	if n := len(a.Categories[i]); n > 0 {
+		bounds := make([]float64, n+1)
+		for k := range bounds {
+			bounds[k] = lo + (hi-lo)*float64(k)/float64(n)
+		}
+		return bounds
+	}
+	return niceTicks(lo, hi, a.Ticks)
+}
+
+// tickLabels 第 i 条轴上刻度标签的位置和文字
+func (a *PlotAxes) tickLabels(i int) ([]float64, []string) {
+	names := a.Categories[i]
+	if len(names) == 0 {
+		values := a.ticks(i)
+		texts := make([]string, len(values))
+		for k, v := range values {
+			texts[k] = formatTick(v)
+		}
+		return values, texts
+	}
+	lo, hi := component(a.DataMin, i), component(a.DataMax, i)
+	values := make([]float64, len(names))
+	for k := range names {
+		values[k] = lo + (hi-lo)*(float64(k)+0.5)/float64(len(names))
+	}
+	return values, names
+}
+
+// Fit 把数据范围设为包含 [min, max] 的整刻度范围，两端相等的方向向两侧各扩展 1（或数值的 10%）
+func (a *PlotAxes) Fit(min, max Vector3) *PlotAxes {
+	var lo, hi [3]float64
+	for i := range 3 {
+		lo[i], hi[i] = niceRange(component(min, i), component(max, i), a.Ticks)
+	}
+	a.DataMin = NewVector3(lo[0], lo[1], lo[2])
+	a.DataMax = NewVector3(hi[0], hi[1], hi[2])
+	return a
+}
+
+// niceRange 把 [lo, hi] 向外扩展到刻度间隔的整数倍
+func niceRange(lo, hi float64, count int) (float64, float64) {
+	if lo == hi {
+		pad := math.Max(1, math.Abs(lo)*0.1)
+		lo, hi = lo-pad, hi+pad
+	}
+	ticks := niceTicks(lo, hi, max(1, count))
+	if len(ticks) < 2 {
+		return lo, hi
+	}
+	step := ticks[1] - ticks[0]
+	return math.Floor(lo/step+1e-9) * step, math.Ceil(hi/step-1e-9) * step
+}
+
+// Render 绘制网格和坐标轴
+func (a *PlotAxes) Render(renderer *Renderer, t float64) {
+	a.renderBack(renderer)
+	a.renderFront(renderer)
+}
+
+// renderBack 在背对相机的三个面上绘制网格线；图表内容在它之后绘制，默认排序模式下网格不会挡住内容
+func (a *PlotAxes) renderBack(renderer *Renderer) {
+	if !a.ShowGrid {
+		return
+	}
+	far := a.sides(renderer)
+	for k := range 3 {
+		i, j := (k+1)%3, (k+2)%3
+		for _, pair := range [2][2]int{{i, j}, {j, i}} {
+			along, across := pair[0], pair[1]
+			for _, v := range a.ticks(along) {
+				var p, q [3]float64
+				p[k], q[k] = far[k], far[k]
+				p[along], q[along] = a.normalize(along, v), a.normalize(along, v)
+				p[across], q[across] = -0.5, 0.5
+				renderer.DrawLine3D(a.point(p), a.point(q), a.GridColor, 1)
+			}
+		}
+	}
+}
+
+// renderFront 绘制三条坐标轴、刻度标签和轴标题：x、y 轴在底面靠近相机的棱上，z 轴在靠近相机的竖直棱上
+func (a *PlotAxes) renderFront(renderer *Renderer) {
+	far := a.sides(renderer)
+	near := [3]float64{-far[0], -far[1], -far[2]}
+
+	// 每条轴所在的棱（另外两个方向的位置）和标签向外偏移的方向
+	edges := [3]struct {
+		base    [3]float64
+		outward int
+	}{
+		{[3]float64{0, near[1], far[2]}, 1},
+		{[3]float64{near[0], 0, far[2]}, 0},
+		{[3]float64{near[0], far[1], 0}, 0},
+	}
+	for i, edge := range edges {
+		p, q := edge.base, edge.base
+		p[i], q[i] = -0.5, 0.5
+		renderer.DrawLine3D(a.point(p), a.point(q), a.Color, 1.5)
+
+		label := edge.base
+		label[edge.outward] *= 1.25
+		values, texts := a.tickLabels(i)
+		for k, v := range values {
+			label[i] = a.normalize(i, v)
+			a.drawText(renderer, a.point(label), texts[k], a.FontSize, false)
+		}
+		if a.Labels[i] != "" {
+			title := edge.base
+			title[i] = 0
+			title[edge.outward] *= 1.6
+			a.drawText(renderer, a.point(title), a.Labels[i], a.FontSize+2, true)
+		}
+	}
+}
+
+// drawText 以位置为中心绘制没有背景框的文字
+func (a *PlotAxes) drawText(renderer *Renderer, position Vector3, text string, size float64, bold bool) {
+	label := NewLabel3D(position, text, a.Color).SetAnchor(LabelAnchorCenter, 0)
+	label.FontSize = size
+	label.Bold = bold
+	label.Render(renderer, 0)
+}
+
+// niceTicks [lo, hi] 内大约 count 个刻度值，间隔取 1、2、5 乘以 10 的整数次幂
+func niceTicks(lo, hi float64, count int) []float64 {
+	if count <= 0 || !(hi > lo) || !finite(lo) || !finite(hi) {
+		return nil
+	}
+	raw := (hi - lo) / float64(count)
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	step := 10 * magnitude
+	for _, m := range []float64{1, 2, 5} {
+		if raw <= m*magnitude {
+			step = m * magnitude
+			break
+		}
+	}
+
+	var ticks []float64
+	for k := math.Ceil(lo/step - 1e-9); k*step <= hi+step*1e-9; k++ {
+		v := k * step
+		if math.Abs(v) < step*1e-9 {
+			v = 0 // 避免显示 -0
+		}
+		ticks = append(ticks, v)
+	}
+	return ticks
+}
+
+// formatTick 刻度标签文字，6 位有效数字消除 0.1 × 3 这类浮点误差
+func formatTick(v float64) string {
+	return strconv.FormatFloat(v, 'g', 6, 64)
+}
+
+// ViridisRamp 感知均匀、对色盲友好的默认图表色带（深紫 → 蓝绿 → 黄）
+func ViridisRamp() ColorRamp {
+	return EvenRamp(
+		[3]float64{0.267, 0.005, 0.329},
+		[3]float64{0.231, 0.322, 0.545},
+		[3]float64{0.129, 0.569, 0.549},
+		[3]float64{0.369, 0.788, 0.384},
+		[3]float64{0.993, 0.906, 0.144},
+	)
+}
+
+// Colorbar 画面右侧的颜色图例：竖直色条表示数值 Min 到 Max 在色带上的颜色，旁边标注刻度。
+// 作为场景对象添加时固定在屏幕上，不随相机移动
+type Colorbar struct {
+	Ramp     ColorRamp
+	Min, Max float64
+	Title    string
+
+	Width     float64 // 色条宽度（像素）
+	Height    float64 // 色条高度（像素），0 表示画面高度的一半
+	Margin    float64 // 与画面右边缘的距离（像素）
+	FontSize  float64
+	Ticks     int // 大约的刻度数
+	TextColor [3]float64
+}
+
+// NewColorbar 创建数值范围为 [min, max] 的颜色图例
+func NewColorbar(ramp ColorRamp, min, max float64) *Colorbar {
+	return &Colorbar{
+		Ramp:      ramp,
+		Min:       min,
+		Max:       max,
+		Width:     16,
+		Margin:    16,
+		FontSize:  11,
+		Ticks:     5,
+		TextColor: [3]float64{0.85, 0.85, 0.85},
+	}
+}
+
+// Render 在几何体之后绘制颜色图例
+func (cb *Colorbar) Render(renderer *Renderer, t float64) {
+	renderer.DrawOverlay(func() {
+		cb.draw(renderer)
+	})
+}
+
+// draw 绘制色条、刻度和标题：先测量最宽的刻度标签，使标签不超出画面右边缘
+func (cb *Colorbar) draw(r *Renderer) {
+	height := cb.Height
+	if height <= 0 {
+		height = float64(r.Height) / 2
+	}
+	ticks := niceTicks(cb.Min, cb.Max, cb.Ticks)
+	labelWidth := 0.0
+	for _, v := range ticks {
+		if layout, ok := newTextLayout(r.Context, formatTick(v), false, cb.FontSize); ok {
+			labelWidth = math.Max(labelWidth, layout.inkExtents().Width)
+			layout.destroy()
+		}
+	}
+	x := float64(r.Width) - cb.Margin - labelWidth - 6 - cb.Width
+	y := (float64(r.Height) - height) / 2
+
+	r.Context.Save()
+	defer r.Context.Restore()
+
+	// 逐像素行填充色条，色带顶端对应 Max
+	rows := max(1, int(math.Ceil(height)))
+	for row := range rows {
+		c := cb.Ramp.At(1 - (float64(row)+0.5)/float64(rows))
+		r.Context.SetSourceRGB(c[0], c[1], c[2])
+		r.Context.Rectangle(x, y+height*float64(row)/float64(rows), cb.Width, height/float64(rows)+0.5)
+		r.Context.Fill()
+	}
+
+	r.Context.SetSourceRGB(cb.TextColor[0], cb.TextColor[1], cb.TextColor[2])
+	r.Context.SetLineWidth(1)
+	r.Context.Rectangle(x, y, cb.Width, height)
+	r.Context.Stroke()
+	for _, v := range ticks {
+		ty := y + height*(cb.Max-v)/(cb.Max-cb.Min)
+		r.Context.MoveTo(x+cb.Width, ty)
+		r.Context.LineTo(x+cb.Width+4, ty)
+		r.Context.Stroke()
+		showTextLeft(r, formatTick(v), x+cb.Width+6, ty, cb.FontSize)
+	}
+	if cb.Title != "" {
+		if layout, ok := newTextLayout(r.Context, cb.Title, true, cb.FontSize+1); ok {
+			extents := layout.inkExtents()
+			tx := math.Min(x+cb.Width/2-extents.Width/2, float64(r.Width)-cb.Margin-extents.Width)
+			layout.show(tx-extents.X, y-8-extents.Height-extents.Y)
+			layout.destroy()
+		}
+	}
+}
+
+// showTextLeft 以当前颜色绘制左端位于 x、竖直居中于 y 的文字
+func showTextLeft(r *Renderer, text string, x, y, size float64) {
+	layout, ok := newTextLayout(r.Context, text, false, size)
+	if !ok {
+		return
+	}
+	extents := layout.inkExtents()
+	layout.show(x-extents.X, y-extents.Y-extents.Height/2)
+	layout.destroy()
+}
@@ -0,0 +1,180 @@
+package go3d
+
+import (
+	"image"
+	"math"
+	"sort"
+)
+
+// PointCloud 点云：激光雷达扫描、摄影测量等产生的大量离散点，每个点可以带颜色或强度。
+// 点投影到屏幕后按深度从远到近直接写入像素（圆点），不生成网格，数百万个点也能快速绘制。
+// 可以用 LoadPointCloud 从 XYZ、PLY、PCD 文件加载
+type PointCloud struct {
+	Points    []Vector3
+	Colors    [][3]float64 // 每个点的颜色（可选，与 Points 一一对应）
+	Intensity []float64    // 每个点的强度（可选，与 Points 一一对应），没有颜色时按 Ramp 着色
+	Color     [3]float64   // 既没有颜色也没有强度时所有点的颜色
+	Ramp      ColorRamp    // 强度着色的色带，位置 0 对应最小强度，1 对应最大强度
+
+	PointSize float64 // 点的直径（像素）
+	WorldSize float64 // 点的直径（世界单位），大于 0 时近大远小，优先于 PointSize
+}
+
+// NewPointCloud 创建点云，默认为直径 2 像素的白色点，强度按灰度着色
+func NewPointCloud(points []Vector3) *PointCloud {
+	return &PointCloud{
+		Points:    points,
+		Color:     [3]float64{1, 1, 1},
+		Ramp:      ColorRamp{{0, [3]float64{0.1, 0.1, 0.1}}, {1, [3]float64{1, 1, 1}}},
+		PointSize: 2,
+	}
+}
+
+// SetColors 设置每个点的颜色
+func (pc *PointCloud) SetColors(colors [][3]float64) *PointCloud {
+	pc.Colors = colors
+	return pc
+}
+
+// SetIntensity 设置每个点的强度和着色用的色带（nil 表示保留当前色带）
+func (pc *PointCloud) SetIntensity(intensity []float64, ramp ColorRamp) *PointCloud {
+	pc.Intensity = intensity
+	if ramp != nil {
+		pc.Ramp = ramp
+	}
+	return pc
+}
+
+// SetPointSize 设置点的屏幕直径（像素）
+func (pc *PointCloud) SetPointSize(pixels float64) *PointCloud {
+	pc.PointSize = pixels
+	pc.WorldSize = 0
+	return pc
+}
+
+// colorFunc 返回第 i 个点颜色的函数：优先使用逐点颜色，其次按强度在色带上取色
+func (pc *PointCloud) colorFunc() func(i int) [3]float64 {
+	switch {
+	case len(pc.Colors) == len(pc.Points):
+		return func(i int) [3]float64 { return pc.Colors[i] }
+	case len(pc.Intensity) == len(pc.Points):
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for _, v := range pc.Intensity {
+			if finite(v) {
+				lo, hi = math.Min(lo, v), math.Max(hi, v)
+			}
+		}
+		return func(i int) [3]float64 {
+			if hi <= lo {
+				return pc.Ramp.At(1)
+			}
+			return pc.Ramp.At((pc.Intensity[i] - lo) / (hi - lo))
+		}
+	}
+	return func(int) [3]float64 { return pc.Color }
+}
+
+// Render 在几何体之后绘制点云；物体编号模式下不绘制
+func (pc *PointCloud) Render(renderer *Renderer, t float64) {
+	if len(pc.Points) == 0 {
+		return
+	}
+	renderer.DrawOverlay(func() {
+		pc.draw(renderer)
+	})
+}
+
+// draw 一次性计算视图投影矩阵投影所有点，按深度从远到近排序后逐点写入图像表面，近处的点覆盖远处的点
+func (pc *PointCloud) draw(renderer *Renderer) {
+	width, height := renderer.Width, renderer.Height
+	aspect := float64(width) / float64(height)
+	viewProjection := Perspective(renderer.Camera.FOV, aspect, renderer.Camera.Near, renderer.Camera.Far).
+		Multiply(LookAt(renderer.Camera.Position, renderer.Camera.Target, renderer.Camera.Up))
+	forward, _, _ := renderer.Camera.Basis()
+	// 距离相机 1 个单位处 1 个世界单位对应的像素数
+	pixelsPerUnit := float64(height) / (2 * math.Tan(renderer.Camera.FOV/2))
+
+	type splat struct {
+		x, y, z, radius float32
+		index           int32
+	}
+	splats := make([]splat, 0, len(pc.Points))
+	for i, p := range pc.Points {
+		projected := viewProjection.TransformVector(p)
+		if projected.Z < -1 || projected.Z > 1 {
+			continue
+		}
+		radius := pc.PointSize / 2
+		if pc.WorldSize > 0 {
+			radius = pc.WorldSize / 2 * pixelsPerUnit / p.Sub(renderer.Camera.Position).Dot(forward)
+		}
+		x := (projected.X + 1) * float64(width) / 2
+		y := (1 - projected.Y) * float64(height) / 2
+		if x+radius < 0 || y+radius < 0 || x-radius > float64(width) || y-radius > float64(height) {
+			continue
+		}
+		splats = append(splats, splat{float32(x), float32(y), float32(projected.Z), float32(radius), int32(i)})
+	}
+	if len(splats) == 0 {
+		return
+	}
+	sort.Slice(splats, func(i, j int) bool {
+		return splats[i].z > splats[j].z
+	})
+
+	surface := newImageSurface(width, height)
+	defer surface.Destroy()
+	img, ok := surface.GetGoImage().(*image.RGBA)
+	if !ok {
+		return
+	}
+	color := pc.colorFunc()
+	for _, s := range splats {
+		c := color(int(s.index))
+		var rgba [4]uint8
+		for k := range 3 {
+			rgba[k] = uint8(math.Max(0, math.Min(1, c[k]))*255 + 0.5)
+		}
+		rgba[3] = 255
+
+		// 覆盖像素中心落在圆内的像素，不足一个像素的点至少占一个像素
+		r := float64(s.radius)
+		x, y := float64(s.x), float64(s.y)
+		x0, x1 := max(0, int(math.Floor(x-r))), min(width-1, int(math.Ceil(x+r)))
+		y0, y1 := max(0, int(math.Floor(y-r))), min(height-1, int(math.Ceil(y+r)))
+		if r < 0.75 {
+			x0, y0 = int(x), int(y)
+			x1, y1 = x0, y0
+		}
+		for py := y0; py <= y1; py++ {
+			for px := x0; px <= x1; px++ {
+				dx, dy := float64(px)+0.5-x, float64(py)+0.5-y
+				if r >= 0.75 && dx*dx+dy*dy > r*r {
+					continue
+				}
+				if px < 0 || py < 0 || px >= width || py >= height {
+					continue
+				}
+				offset := py*img.Stride + px*4
+				copy(img.Pix[offset:offset+4], rgba[:])
+			}
+		}
+	}
+
+	renderer.Context.Save()
+	defer renderer.Context.Restore()
+	renderer.Context.SetSourceSurface(surface, 0, 0)
+	renderer.Context.Paint()
+}
+
+// WorldBounds 所有点的包围盒
+func (pc *PointCloud) WorldBounds(t float64) AABB {
+	if len(pc.Points) == 0 {
+		return AABB{}
+	}
+	bounds := AABB{Min: pc.Points[0], Max: pc.Points[0]}
+	for _, p := range pc.Points[1:] {
+		bounds = bounds.union(AABB{Min: p, Max: p})
+	}
+	return bounds
+}
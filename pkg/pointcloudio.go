@@ -0,0 +1,565 @@
+package go3d
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// LoadPointCloud 从文件加载点云：.ply 按 ReadPointCloudPLY 解析，.pcd 按 ReadPointCloudPCD 解析，
+// 其余（.xyz、.txt、.pts、.csv 等）按 ReadPointCloudXYZ 解析
+func LoadPointCloud(filename string) (*PointCloud, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".ply":
+		return ReadPointCloudPLY(file)
+	case ".pcd":
+		return ReadPointCloudPCD(file)
+	}
+	return ReadPointCloudXYZ(file)
+}
+
+// ReadPointCloudXYZ 读取每行一个点的文本点云，字段以空白、逗号或分号分隔：
+// 3 列为 x y z，4 列为 x y z 强度，6 列为 x y z r g b，7 列及以上为 x y z 强度 r g b（PTS 格式）。
+// 颜色分量都不超过 1 时按 0-1 解释，否则按 0-255 解释；不能解析出坐标的行（表头、注释、点数）被跳过
+func ReadPointCloudXYZ(r io.Reader) (*PointCloud, error) {
+	var points []Vector3
+	var colors [][3]float64
+	var intensity []float64
+	hasColor, hasIntensity, byteColor := true, true, false
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fields := strings.FieldsFunc(scanner.Text(), func(c rune) bool {
+			return unicode.IsSpace(c) || c == ',' || c == ';'
+		})
+		if len(fields) < 3 {
+			continue
+		}
+		values := make([]float64, 0, len(fields))
+		for _, field := range fields {
+			v, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				break
+			}
+			values = append(values, v)
+		}
+		if len(values) < 3 {
+			continue
+		}
+		p := NewVector3(values[0], values[1], values[2])
+		if !finite(p.X) || !finite(p.Y) || !finite(p.Z) {
+			continue
+		}
+		points = append(points, p)
+
+		var c [3]float64
+		i := 0.0
+		switch {
+		case len(values) >= 7:
+			i, c = values[3], [3]float64{values[4], values[5], values[6]}
+		case len(values) == 6:
+			c = [3]float64{values[3], values[4], values[5]}
+			hasIntensity = false
+		case len(values) == 4:
+			i = values[3]
+			hasColor = false
+		default:
+			hasColor, hasIntensity = false, false
+		}
+		byteColor = byteColor || c[0] > 1 || c[1] > 1 || c[2] > 1
+		colors = append(colors, c)
+		intensity = append(intensity, i)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(points) == 0 {
+		return nil, errors.New("点云文件中没有点")
+	}
+
+	pc := NewPointCloud(points)
+	if hasColor {
+		if byteColor {
+			for i := range colors {
+				colors[i] = [3]float64{colors[i][0] / 255, colors[i][1] / 255, colors[i][2] / 255}
+			}
+		}
+		pc.Colors = colors
+	}
+	if hasIntensity {
+		pc.Intensity = intensity
+	}
+	return pc, nil
+}
+
+// maxCloudPoints 点云文件头中允许的最大点数（元素个数），更大的值视为损坏的文件
+const maxCloudPoints = 1 << 28
+
+// plyProperty PLY 元素的一个属性，list 属性先存储 countType 类型的个数再存储个数个 valueType 类型的值
+type plyProperty struct {
+	name      string
+	valueType string
+	countType string // 非空表示 list 属性
+}
+
+// plyElement PLY 文件中的一种元素（vertex、face 等）
+type plyElement struct {
+	name       string
+	count      int
+	properties []plyProperty
+}
+
+// plyTypeSize PLY 数据类型的字节数，未知类型返回 0
+func plyTypeSize(kind string) int {
+	switch kind {
+	case "char", "int8", "uchar", "uint8":
+		return 1
+	case "short", "int16", "ushort", "uint16":
+		return 2
+	case "int", "int32", "uint", "uint32", "float", "float32":
+		return 4
+	case "double", "float64":
+		return 8
+	}
+	return 0
+}
+
+// plyColorScale 颜色属性换算到 0-1 的系数：整数按类型的最大值归一化，浮点数原样使用
+func plyColorScale(kind string) float64 {
+	switch kind {
+	case "uchar", "uint8", "char", "int8":
+		return 1.0 / 255
+	case "ushort", "uint16", "short", "int16":
+		return 1.0 / 65535
+	}
+	return 1
+}
+
+// ReadPointCloudPLY 读取 PLY 点云（ascii、binary_little_endian 和 binary_big_endian 格式），
+// 使用 vertex 元素的 x、y、z，可选的 red、green、blue 颜色和 intensity（或 scalar_intensity）强度；
+// 面等其他元素被忽略
+func ReadPointCloudPLY(r io.Reader) (*PointCloud, error) {
+	reader := bufio.NewReader(r)
+	line, err := reader.ReadString('\n')
+	if err != nil || strings.TrimSpace(line) != "ply" {
+		return nil, errors.New("PLY: 缺少文件头标识 ply")
+	}
+
+	var format string
+	var elements []*plyElement
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, errors.New("PLY: 文件头没有结束")
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "format":
+			if len(fields) < 2 {
+				return nil, errors.New("PLY: 无效的 format 行")
+			}
+			format = fields[1]
+		case "element":
+			if len(fields) < 3 {
+				return nil, errors.New("PLY: 无效的 element 行")
+			}
+			count, err := strconv.Atoi(fields[2])
+			if err != nil || count < 0 || count > maxCloudPoints {
+				return nil, fmt.Errorf("PLY: 无效的元素个数 %q", fields[2])
+			}
+			elements = append(elements, &plyElement{name: fields[1], count: count})
+		case "property":
+			if len(elements) == 0 {
+				return nil, errors.New("PLY: property 出现在 element 之前")
+			}
+			element := elements[len(elements)-1]
+			switch {
+			case len(fields) >= 5 && fields[1] == "list":
+				element.properties = append(element.properties, plyProperty{name: fields[4], valueType: fields[3], countType: fields[2]})
+			case len(fields) >= 3:
+				element.properties = append(element.properties, plyProperty{name: fields[2], valueType: fields[1]})
+			default:
+				return nil, errors.New("PLY: 无效的 property 行")
+			}
+		}
+		if fields[0] == "end_header" {
+			break
+		}
+	}
+
+	var read func(kind string) (float64, error)
+	switch format {
+	case "ascii":
+		words := bufio.NewScanner(reader)
+		words.Split(bufio.ScanWords)
+		read = func(string) (float64, error) {
+			if !words.Scan() {
+				if err := words.Err(); err != nil {
+					return 0, err
+				}
+				return 0, io.ErrUnexpectedEOF
+			}
+			return strconv.ParseFloat(words.Text(), 64)
+		}
+	case "binary_little_endian", "binary_big_endian":
+		var order binary.ByteOrder = binary.LittleEndian
+		if format == "binary_big_endian" {
+			order = binary.BigEndian
+		}
+		var buffer [8]byte
+		read = func(kind string) (float64, error) {
+			size := plyTypeSize(kind)
+			if size == 0 {
+				return 0, fmt.Errorf("PLY: 未知的数据类型 %q", kind)
+			}
+			if _, err := io.ReadFull(reader, buffer[:size]); err != nil {
+				return 0, err
+			}
+			return decodeBinaryValue(buffer[:size], kind, order), nil
+		}
+	default:
+		return nil, fmt.Errorf("PLY: 不支持的格式 %q", format)
+	}
+
+	for _, element := range elements {
+		if element.name != "vertex" {
+			// 跳过顶点之前的其他元素
+			for range element.count {
+				for _, property := range element.properties {
+					if _, err := readPLYProperty(property, read); err != nil {
+						return nil, err
+					}
+				}
+			}
+			continue
+		}
+		return readPLYVertices(element, read)
+	}
+	return nil, errors.New("PLY: 没有 vertex 元素")
+}
+
+// readPLYProperty 读取一个属性的值，list 属性返回第一个值（没有值时返回 0）
+func readPLYProperty(property plyProperty, read func(kind string) (float64, error)) (float64, error) {
+	if property.countType == "" {
+		return read(property.valueType)
+	}
+	count, err := read(property.countType)
+	if err != nil {
+		return 0, err
+	}
+	first := 0.0
+	for i := range int(count) {
+		v, err := read(property.valueType)
+		if err != nil {
+			return 0, err
+		}
+		if i == 0 {
+			first = v
+		}
+	}
+	return first, nil
+}
+
+// readPLYVertices 读取 vertex 元素的所有顶点
+func readPLYVertices(element *plyElement, read func(kind string) (float64, error)) (*PointCloud, error) {
+	index := map[string]int{}
+	for i, property := range element.properties {
+		index[strings.ToLower(property.name)] = i
+	}
+	find := func(names ...string) int {
+		for _, name := range names {
+			if i, ok := index[name]; ok {
+				return i
+			}
+		}
+		return -1
+	}
+	x, y, z := find("x"), find("y"), find("z")
+	if x < 0 || y < 0 || z < 0 {
+		return nil, errors.New("PLY: vertex 元素缺少 x、y 或 z 属性")
+	}
+	red, green, blue := find("red", "r", "diffuse_red"), find("green", "g", "diffuse_green"), find("blue", "b", "diffuse_blue")
+	hasColor := red >= 0 && green >= 0 && blue >= 0
+	intensityIndex := find("intensity", "scalar_intensity")
+
+	if element.count < 1 {
+		return nil, errors.New("PLY: vertex 元素个数为 0")
+	}
+
+	// 文件头中的个数不可信，按实际读到的顶点逐个追加，不预先分配
+	var points []Vector3
+	var colors [][3]float64
+	var intensity []float64
+	values := make([]float64, len(element.properties))
+	for range element.count {
+		for i, property := range element.properties {
+			v, err := readPLYProperty(property, read)
+			if err != nil {
+				return nil, fmt.Errorf("PLY: 读取顶点失败: %w", err)
+			}
+			values[i] = v
+		}
+		p := NewVector3(values[x], values[y], values[z])
+		if !finite(p.X) || !finite(p.Y) || !finite(p.Z) {
+			continue
+		}
+		points = append(points, p)
+		if hasColor {
+			colors = append(colors, [3]float64{
+				values[red] * plyColorScale(element.properties[red].valueType),
+				values[green] * plyColorScale(element.properties[green].valueType),
+				values[blue] * plyColorScale(element.properties[blue].valueType),
+			})
+		}
+		if intensityIndex >= 0 {
+			intensity = append(intensity, values[intensityIndex])
+		}
+	}
+	return NewPointCloud(points).SetColors(colors).SetIntensity(intensity, nil), nil
+}
+
+// decodeBinaryValue 按 PLY 或 PCD 的类型名解码一个二进制数值
+func decodeBinaryValue(data []byte, kind string, order binary.ByteOrder) float64 {
+	switch kind {
+	case "char", "int8":
+		return float64(int8(data[0]))
+	case "uchar", "uint8":
+		return float64(data[0])
+	case "short", "int16":
+		return float64(int16(order.Uint16(data)))
+	case "ushort", "uint16":
+		return float64(order.Uint16(data))
+	case "int", "int32":
+		return float64(int32(order.Uint32(data)))
+	case "uint", "uint32":
+		return float64(order.Uint32(data))
+	case "float", "float32":
+		return float64(math.Float32frombits(order.Uint32(data)))
+	case "double", "float64":
+		return math.Float64frombits(order.Uint64(data))
+	}
+	return 0
+}
+
+// pcdField PCD 文件的一个字段
+type pcdField struct {
+	name  string
+	size  int
+	kind  string // PLY 风格的类型名
+	count int
+}
+
+// ReadPointCloudPCD 读取 PCL 的 PCD 点云（DATA ascii 或 binary），
+// 使用 x、y、z 字段，可选的 rgb/rgba（打包为 0x00RRGGBB）颜色和 intensity 强度；坐标为 NaN 的点被跳过。
+// 不支持 binary_compressed
+func ReadPointCloudPCD(r io.Reader) (*PointCloud, error) {
+	reader := bufio.NewReader(r)
+	var fields []pcdField
+	var names, sizes, types, counts []string
+	points := -1
+	width, height := 0, 1
+	data := ""
+	for data == "" {
+		line, err := reader.ReadString('\n')
+		if err != nil && line == "" {
+			return nil, errors.New("PCD: 文件头没有结束")
+		}
+		words := strings.Fields(line)
+		if len(words) == 0 || strings.HasPrefix(words[0], "#") {
+			continue
+		}
+		values := words[1:]
+		var invalid error
+		switch strings.ToUpper(words[0]) {
+		case "FIELDS":
+			names = values
+		case "SIZE":
+			sizes = values
+		case "TYPE":
+			types = values
+		case "COUNT":
+			counts = values
+		case "WIDTH":
+			width, invalid = strconv.Atoi(firstWord(values))
+		case "HEIGHT":
+			height, invalid = strconv.Atoi(firstWord(values))
+		case "POINTS":
+			points, invalid = strconv.Atoi(firstWord(values))
+		case "DATA":
+			data = strings.ToLower(firstWord(values))
+			if data == "" {
+				return nil, errors.New("PCD: 缺少 DATA 格式")
+			}
+		}
+		if invalid != nil {
+			return nil, fmt.Errorf("PCD: 无效的 %s 行", strings.ToUpper(words[0]))
+		}
+	}
+	if points < 0 {
+		if width < 1 || height < 1 || width > maxCloudPoints/height {
+			return nil, fmt.Errorf("PCD: 无效的点数 WIDTH %d × HEIGHT %d", width, height)
+		}
+		points = width * height
+	}
+	if points < 1 || points > maxCloudPoints {
+		return nil, fmt.Errorf("PCD: 无效的点数 %d", points)
+	}
+	if len(sizes) != len(names) || len(types) != len(names) || (counts != nil && len(counts) != len(names)) {
+		return nil, errors.New("PCD: FIELDS、SIZE、TYPE 和 COUNT 的个数不一致")
+	}
+	for i, name := range names {
+		size, err := strconv.Atoi(sizes[i])
+		if err != nil {
+			return nil, fmt.Errorf("PCD: 无效的字节数 %q", sizes[i])
+		}
+		count := 1
+		if counts != nil {
+			if count, err = strconv.Atoi(counts[i]); err != nil || count < 1 {
+				return nil, fmt.Errorf("PCD: 无效的 COUNT %q", counts[i])
+			}
+		}
+		kind, ok := pcdType(strings.ToUpper(types[i]), size)
+		if !ok {
+			return nil, fmt.Errorf("PCD: 字段 %s 的类型 %s%d 不受支持", name, types[i], size)
+		}
+		fields = append(fields, pcdField{name: strings.ToLower(name), size: size, kind: kind, count: count})
+	}
+
+	// 每个点的所有数值按字段顺序展开，column 给出字段第一个数值的位置
+	column := map[string]int{}
+	total := 0
+	for _, f := range fields {
+		column[f.name] = total
+		total += f.count
+	}
+	find := func(names ...string) int {
+		for _, name := range names {
+			if i, ok := column[name]; ok {
+				return i
+			}
+		}
+		return -1
+	}
+	x, y, z := find("x"), find("y"), find("z")
+	if x < 0 || y < 0 || z < 0 {
+		return nil, errors.New("PCD: 缺少 x、y 或 z 字段")
+	}
+	rgb, intensityColumn := find("rgb", "rgba"), find("intensity")
+	kinds := make([]string, 0, total)
+	for _, f := range fields {
+		for range f.count {
+			kinds = append(kinds, f.kind)
+		}
+	}
+
+	var next func(values []float64, raw []uint32) error
+	switch data {
+	case "ascii":
+		words := bufio.NewScanner(reader)
+		words.Split(bufio.ScanWords)
+		next = func(values []float64, raw []uint32) error {
+			for i := range values {
+				if !words.Scan() {
+					if err := words.Err(); err != nil {
+						return err
+					}
+					return io.ErrUnexpectedEOF
+				}
+				v, err := strconv.ParseFloat(words.Text(), 64)
+				if err != nil {
+					return err
+				}
+				values[i] = v
+				// ascii 格式中打包的颜色写成与二进制相同位模式的浮点数或整数
+				if kinds[i] == "float" {
+					raw[i] = math.Float32bits(float32(v))
+				} else {
+					raw[i] = uint32(v)
+				}
+			}
+			return nil
+		}
+	case "binary":
+		var buffer [8]byte
+		next = func(values []float64, raw []uint32) error {
+			for i, kind := range kinds {
+				size := plyTypeSize(kind)
+				if _, err := io.ReadFull(reader, buffer[:size]); err != nil {
+					return err
+				}
+				values[i] = decodeBinaryValue(buffer[:size], kind, binary.LittleEndian)
+				if size == 4 {
+					raw[i] = binary.LittleEndian.Uint32(buffer[:4])
+				}
+			}
+			return nil
+		}
+	default:
+		return nil, fmt.Errorf("PCD: 不支持的 DATA 格式 %q", data)
+	}
+
+	// 文件头中的点数不可信，按实际读到的点逐个追加，不预先分配
+	var positions []Vector3
+	var colors [][3]float64
+	var intensity []float64
+	values, raw := make([]float64, total), make([]uint32, total)
+	for range points {
+		if err := next(values, raw); err != nil {
+			return nil, fmt.Errorf("PCD: 读取点失败: %w", err)
+		}
+		p := NewVector3(values[x], values[y], values[z])
+		if !finite(p.X) || !finite(p.Y) || !finite(p.Z) {
+			continue
+		}
+		positions = append(positions, p)
+		if rgb >= 0 {
+			packed := raw[rgb]
+			colors = append(colors, [3]float64{
+				float64(packed>>16&0xff) / 255,
+				float64(packed>>8&0xff) / 255,
+				float64(packed&0xff) / 255,
+			})
+		}
+		if intensityColumn >= 0 {
+			intensity = append(intensity, values[intensityColumn])
+		}
+	}
+	return NewPointCloud(positions).SetColors(colors).SetIntensity(intensity, nil), nil
+}
+
+// pcdType PCD 的类型字母（F 浮点、I 有符号整数、U 无符号整数）和字节数对应的 PLY 风格类型名
+func pcdType(letter string, size int) (string, bool) {
+	kinds := map[string]string{
+		"F4": "float", "F8": "double",
+		"I1": "char", "I2": "short", "I4": "int",
+		"U1": "uchar", "U2": "ushort", "U4": "uint",
+	}
+	kind, ok := kinds[letter+strconv.Itoa(size)]
+	return kind, ok
+}
+
+// firstWord 第一个单词，没有时返回空字符串
+func firstWord(words []string) string {
+	if len(words) == 0 {
+		return ""
+	}
+	return words[0]
+}
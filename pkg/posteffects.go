@@ -0,0 +1,206 @@
+package go3d
+
+import (
+	"image"
+	"math"
+)
+
+// PostEffect 后期处理效果，直接修改渲染结果的像素
+type PostEffect func(img *image.RGBA)
+
+// AddPostEffect 添加后期处理效果，按添加顺序依次应用
+func (r *Renderer) AddPostEffect(effect PostEffect) *Renderer {
+	r.postEffects = append(r.postEffects, effect)
+	return r
+}
+
+// ClearPostEffects 移除所有后期处理效果
+func (r *Renderer) ClearPostEffects() {
+	r.postEffects = nil
+}
+
+// ApplyPostEffects 对当前画面应用后期处理效果
+// 每一帧只应用一次：SaveToPNG 会自动调用，Clear 之后可以再次应用
+func (r *Renderer) ApplyPostEffects() {
+	r.ResetViewport()
+
+	// 物体编号画面的颜色即编号，不能被后期处理改变
+	if r.postApplied || (len(r.postEffects) == 0 && !r.depthOfField()) || r.RenderMode == RenderObjectID {
+		return
+	}
+	r.postApplied = true
+
+	img, ok := r.Surface.GetGoImage().(*image.RGBA)
+	if !ok {
+		return
+	}
+	// 景深先于其他效果应用，泛光、暗角等作用在模糊后的画面上
+	if r.depthOfField() {
+		r.applyDepthOfField(img)
+	}
+	for _, effect := range r.postEffects {
+		effect(img)
+	}
+	// 泛光、色差等效果可能把颜色加到透明像素上，提高 alpha 保持预乘格式有效
+	if r.TransparentBackground {
+		fixPremultiplied(img)
+	}
+}
+
+// clampByte 将 0-1 的颜色分量转换为字节
+func clampByte(v float64) uint8 {
+	return uint8(math.Max(0, math.Min(1, v))*255 + 0.5)
+}
+
+// Vignette 暗角：画面边缘逐渐变暗
+// strength 为边角处的变暗程度（0-1），radius 为开始变暗的位置（相对于中心到角的距离）
+func Vignette(strength, radius float64) PostEffect {
+	return func(img *image.RGBA) {
+		bounds := img.Bounds()
+		cx := float64(bounds.Dx()) / 2
+		cy := float64(bounds.Dy()) / 2
+		maxDist := math.Hypot(cx, cy)
+
+		for y := range bounds.Dy() {
+			for x := range bounds.Dx() {
+				d := math.Hypot(float64(x)+0.5-cx, float64(y)+0.5-cy) / maxDist
+				fade := Smoothstep(math.Max(0, math.Min(1, (d-radius)/math.Max(1e-6, 1-radius))))
+				scale := 1 - strength*fade
+
+				offset := y*img.Stride + x*4
+				for k := range 3 {
+					img.Pix[offset+k] = clampByte(float64(img.Pix[offset+k]) / 255 * scale)
+				}
+			}
+		}
+	}
+}
+
+// Bloom 泛光：提取亮度超过阈值的部分，模糊后叠加回画面
+// radius 为模糊半径（像素），intensity 为叠加强度
+func Bloom(threshold float64, radius int, intensity float64) PostEffect {
+	return func(img *image.RGBA) {
+		bounds := img.Bounds()
+		w, h := bounds.Dx(), bounds.Dy()
+
+		// 亮部提取
+		bright := make([][3]float64, w*h)
+		for y := range h {
+			for x := range w {
+				offset := y*img.Stride + x*4
+				r := float64(img.Pix[offset]) / 255
+				g := float64(img.Pix[offset+1]) / 255
+				b := float64(img.Pix[offset+2]) / 255
+				luminance := 0.2126*r + 0.7152*g + 0.0722*b
+				if luminance > threshold {
+					k := (luminance - threshold) / math.Max(1e-6, luminance)
+					bright[y*w+x] = [3]float64{r * k, g * k, b * k}
+				}
+			}
+		}
+
+		// 两次可分离的盒式模糊近似高斯模糊
+		for range 2 {
+			bright = boxBlur(bright, w, h, radius)
+		}
+
+		for y := range h {
+			for x := range w {
+				offset := y*img.Stride + x*4
+				glow := bright[y*w+x]
+				for k := range 3 {
+					img.Pix[offset+k] = clampByte(float64(img.Pix[offset+k])/255 + glow[k]*intensity)
+				}
+			}
+		}
+	}
+}
+
+// boxBlur 可分离盒式模糊（先水平后垂直）
+func boxBlur(src [][3]float64, w, h, radius int) [][3]float64 {
+	if radius <= 0 {
+		return src
+	}
+
+	blur1D := func(dst, src [][3]float64, count, length int, index func(line, i int) int) {
+		for line := range count {
+			var sum [3]float64
+			// 初始化窗口
+			for i := -radius; i <= radius; i++ {
+				c := src[index(line, max(0, min(length-1, i)))]
+				sum[0] += c[0]
+				sum[1] += c[1]
+				sum[2] += c[2]
+			}
+			norm := 1.0 / float64(2*radius+1)
+			for i := range length {
+				dst[index(line, i)] = [3]float64{sum[0] * norm, sum[1] * norm, sum[2] * norm}
+
+				// 滑动窗口：移出左端，移入右端
+				out := src[index(line, max(0, i-radius))]
+				in := src[index(line, min(length-1, i+radius+1))]
+				for k := range 3 {
+					sum[k] += in[k] - out[k]
+				}
+			}
+		}
+	}
+
+	tmp := make([][3]float64, len(src))
+	dst := make([][3]float64, len(src))
+	blur1D(tmp, src, h, w, func(y, x int) int { return y*w + x })
+	blur1D(dst, tmp, w, h, func(x, y int) int { return y*w + x })
+	return dst
+}
+
+// ChromaticAberration 色差：红色通道向外、蓝色通道向内沿径向偏移
+// offset 为画面边角处的偏移量（像素）
+func ChromaticAberration(offset float64) PostEffect {
+	return func(img *image.RGBA) {
+		bounds := img.Bounds()
+		w, h := bounds.Dx(), bounds.Dy()
+		src := make([]uint8, len(img.Pix))
+		copy(src, img.Pix)
+
+		cx := float64(w) / 2
+		cy := float64(h) / 2
+		maxDist := math.Hypot(cx, cy)
+
+		sample := func(x, y float64, channel int) uint8 {
+			px := max(0, min(w-1, int(math.Round(x))))
+			py := max(0, min(h-1, int(math.Round(y))))
+			return src[py*img.Stride+px*4+channel]
+		}
+
+		for y := range h {
+			for x := range w {
+				dx := (float64(x) - cx) / maxDist
+				dy := (float64(y) - cy) / maxDist
+				o := y*img.Stride + x*4
+				img.Pix[o] = sample(float64(x)-dx*offset, float64(y)-dy*offset, 0)
+				img.Pix[o+2] = sample(float64(x)+dx*offset, float64(y)+dy*offset, 2)
+			}
+		}
+	}
+}
+
+// FilmGrain 胶片颗粒噪点
+// amount 为噪点强度（0-1）；相同 seed 生成相同的噪点，动画中可传入 FrameSeed(seed, renderer.Frame) 使颗粒逐帧变化
+func FilmGrain(amount float64, seed int64) PostEffect {
+	return func(img *image.RGBA) {
+		bounds := img.Bounds()
+		w := bounds.Dx()
+		for y := range bounds.Dy() {
+			for x := range w {
+				rng := NewRandom(seed, int64(y*w+x))
+				offset := y*img.Stride + x*4
+				// 预乘 alpha：噪点按覆盖率缩放，透明像素不产生颗粒
+				noise := (rng.Float64()*2 - 1) * amount * float64(img.Pix[offset+3]) / 255
+
+				for k := range 3 {
+					img.Pix[offset+k] = clampByte(float64(img.Pix[offset+k])/255 + noise)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package go3d
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// PreviewServer 渲染预览服务器：通过 HTTP 以 MJPEG 流推送最近完成的一帧，
+// 长时间渲染时可以在浏览器中查看进度，而不必等待视频合成
+//
+//	/            预览页面（画面和帧号）
+//	/stream      MJPEG 流（multipart/x-mixed-replace），可直接用作 <img> 的地址
+//	/frame.jpg   最近一帧的 JPEG 图像
+type PreviewServer struct {
+	Addr    string // 监听地址（如 ":8080"，":0" 表示随机端口）
+	Quality int    // JPEG 质量（1-100）
+
+	mu       sync.Mutex
+	frame    []byte        // 最近一帧的 JPEG 数据
+	number   int           // 最近一帧的帧号
+	updated  chan struct{} // 有新帧时关闭并替换，通知所有等待的连接
+	listener net.Listener
+	server   *http.Server
+}
+
+// NewPreviewServer 创建预览服务器，调用 Start 后开始监听
+func NewPreviewServer(addr string) *PreviewServer {
+	return &PreviewServer{
+		Addr:    addr,
+		Quality: 80,
+		updated: make(chan struct{}),
+	}
+}
+
+// Start 开始监听并在后台处理请求
+func (ps *PreviewServer) Start() error {
+	listener, err := net.Listen("tcp", ps.Addr)
+	if err != nil {
+		return fmt.Errorf("预览服务器监听失败: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", ps.handleIndex)
+	mux.HandleFunc("/stream", ps.handleStream)
+	mux.HandleFunc("/frame.jpg", ps.handleFrame)
+
+	ps.mu.Lock()
+	ps.listener = listener
+	ps.server = &http.Server{Handler: mux}
+	ps.mu.Unlock()
+
+	go ps.server.Serve(listener)
+	return nil
+}
+
+// URL 预览页面地址（Start 之后有效）
+func (ps *PreviewServer) URL() string {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if ps.listener == nil {
+		return ""
+	}
+	addr := ps.listener.Addr().(*net.TCPAddr)
+	host := "localhost"
+	if !addr.IP.IsUnspecified() {
+		host = addr.IP.String()
+	}
+	return fmt.Sprintf("http://%s/", net.JoinHostPort(host, fmt.Sprint(addr.Port)))
+}
+
+// Close 关闭服务器，断开所有预览连接
+func (ps *PreviewServer) Close() error {
+	ps.mu.Lock()
+	server := ps.server
+	ps.server, ps.listener = nil, nil
+	// 唤醒等待新帧的连接，使其发现服务器已关闭
+	close(ps.updated)
+	ps.updated = make(chan struct{})
+	ps.mu.Unlock()
+
+	if server == nil {
+		return nil
+	}
+	return server.Close()
+}
+
+// Publish 发布一帧画面，推送给所有正在预览的连接（可并行调用）
+// 带透明通道的画面按预乘颜色编码，透明区域显示为黑色
+func (ps *PreviewServer) Publish(frame int, img image.Image) error {
+	var encoded bytes.Buffer
+	if err := jpeg.Encode(&encoded, img, &jpeg.Options{Quality: max(1, min(100, ps.Quality))}); err != nil {
+		return err
+	}
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.frame = encoded.Bytes()
+	ps.number = frame
+	close(ps.updated)
+	ps.updated = make(chan struct{})
+	return nil
+}
+
+// latest 返回最近一帧、帧号和下一次更新的通知通道
+func (ps *PreviewServer) latest() ([]byte, int, chan struct{}) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return ps.frame, ps.number, ps.updated
+}
+
+// running 服务器是否仍在运行
+func (ps *PreviewServer) running() bool {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return ps.server != nil
+}
+
+// handleIndex 预览页面
+func (ps *PreviewServer) handleIndex(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path != "/" {
+		http.NotFound(w, req)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, previewPage)
+}
+
+// handleFrame 返回最近一帧，还没有帧时返回 204
+func (ps *PreviewServer) handleFrame(w http.ResponseWriter, req *http.Request) {
+	frame, number, _ := ps.latest()
+	if frame == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "image/jpeg")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Frame-Number", fmt.Sprint(number))
+	w.Write(frame)
+}
+
+// handleStream MJPEG 流：先发送当前帧，之后每发布一帧发送一次
+func (ps *PreviewServer) handleStream(w http.ResponseWriter, req *http.Request) {
+	const boundary = "go3dframe"
+	w.Header().Set("Content-Type", "multipart/x-mixed-replace; boundary="+boundary)
+	w.Header().Set("Cache-Control", "no-store")
+	flusher, _ := w.(http.Flusher)
+	// 立即发送响应头，还没有帧时浏览器也能建立连接
+	w.WriteHeader(http.StatusOK)
+	if flusher != nil {
+		flusher.Flush()
+	}
+
+	// 定期发送当前帧，防止代理或浏览器因长时间没有数据断开连接
+	keepAlive := time.NewTicker(10 * time.Second)
+	defer keepAlive.Stop()
+
+	for {
+		frame, number, updated := ps.latest()
+		if frame != nil {
+			_, err := fmt.Fprintf(w, "--%s\r\nContent-Type: image/jpeg\r\nContent-Length: %d\r\nX-Frame-Number: %d\r\n\r\n",
+				boundary, len(frame), number)
+			if err == nil {
+				_, err = w.Write(frame)
+			}
+			if err == nil {
+				_, err = fmt.Fprint(w, "\r\n")
+			}
+			if err != nil {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+
+		select {
+		case <-updated:
+			if !ps.running() {
+				return
+			}
+		case <-keepAlive.C:
+		case <-req.Context().Done():
+			return
+		}
+	}
+}
+
+// previewPage 预览页面：显示 MJPEG 流，并定期读取最近一帧的帧号
+const previewPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>go-3d 渲染预览</title>
+<style>
+body { margin: 0; background: #202020; color: #ccc; font-family: sans-serif; text-align: center; }
+img { max-width: 100vw; max-height: 92vh; margin-top: 1vh; background: #000; }
+</style>
+</head>
+<body>
+<img src="/stream" alt="等待第一帧...">
+<div id="status">等待第一帧...</div>
+<script>
+setInterval(function () {
+	fetch("/frame.jpg", { method: "HEAD", cache: "no-store" }).then(function (r) {
+		var n = r.headers.get("X-Frame-Number");
+		if (n) document.getElementById("status").textContent = "帧 " + n;
+	}).catch(function () {
+		document.getElementById("status").textContent = "预览服务器已关闭";
+	});
+}, 1000);
+</script>
+</body>
+</html>
+`
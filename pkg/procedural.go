@@ -0,0 +1,182 @@
+package go3d
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// ProceduralTexture 按纹理坐标计算颜色的程序纹理，不需要图像文件。
+// u、v 的含义与图像纹理相同（行星贴图中 u 为经度、v 为纬度，0 为北极），
+// u 方向应当在 0 和 1 处无缝衔接。内置棋盘格、噪声、条带和渐变四种
+type ProceduralTexture interface {
+	Sample(u, v float64) [3]float64
+}
+
+// NewProceduralTexture 把程序纹理包装为 *Texture，可用于行星、天体、DrawTexturedMesh 和天空背景等
+// 任何使用图像纹理的地方；需要像素尺寸的场合（ImageBackground）使用 BakeTexture
+func NewProceduralTexture(procedural ProceduralTexture) *Texture {
+	return &Texture{Procedural: procedural}
+}
+
+// BakeTexture 把程序纹理按 width×height 的像素中心采样为图像纹理，
+// 用于需要图像的场合，或避免每个像素重复计算复杂的程序纹理
+func BakeTexture(procedural ProceduralTexture, width, height int) *Texture {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			c := procedural.Sample((float64(x)+0.5)/float64(width), (float64(y)+0.5)/float64(height))
+			img.SetNRGBA(x, y, color.NRGBA{clampByte(c[0]), clampByte(c[1]), clampByte(c[2]), 255})
+		}
+	}
+	return NewTexture(img)
+}
+
+// ColorStop 渐变色标：Position（0-1）处的颜色
+type ColorStop struct {
+	Position float64
+	Color    [3]float64
+}
+
+// ColorRamp 渐变色带，色标按 Position 升序排列，色标之间线性插值，两端之外使用端点颜色
+type ColorRamp []ColorStop
+
+// At 色带在位置 x 的颜色，色带为空时返回白色
+func (ramp ColorRamp) At(x float64) [3]float64 {
+	switch {
+	case len(ramp) == 0:
+		return [3]float64{1, 1, 1}
+	case x <= ramp[0].Position:
+		return ramp[0].Color
+	}
+	for i := 1; i < len(ramp); i++ {
+		a, b := ramp[i-1], ramp[i]
+		if x > b.Position {
+			continue
+		}
+		f := 0.0
+		if b.Position > a.Position {
+			f = (x - a.Position) / (b.Position - a.Position)
+		}
+		return [3]float64{
+			a.Color[0] + (b.Color[0]-a.Color[0])*f,
+			a.Color[1] + (b.Color[1]-a.Color[1])*f,
+			a.Color[2] + (b.Color[2]-a.Color[2])*f,
+		}
+	}
+	return ramp[len(ramp)-1].Color
+}
+
+// EvenRamp 颜色均匀分布在 0 到 1 之间的色带
+func EvenRamp(colors ...[3]float64) ColorRamp {
+	ramp := make(ColorRamp, len(colors))
+	for i, c := range colors {
+		ramp[i] = ColorStop{Color: c}
+		if len(colors) > 1 {
+			ramp[i].Position = float64(i) / float64(len(colors)-1)
+		}
+	}
+	return ramp
+}
+
+// CheckerTexture 棋盘格：u、v 方向分别分为 Columns、Rows 格，两种颜色交替
+type CheckerTexture struct {
+	Colors  [2][3]float64
+	Columns int
+	Rows    int
+}
+
+// NewCheckerTexture 创建棋盘格纹理
+func NewCheckerTexture(color1, color2 [3]float64, columns, rows int) *CheckerTexture {
+	return &CheckerTexture{Colors: [2][3]float64{color1, color2}, Columns: columns, Rows: rows}
+}
+
+// Sample 纹理坐标处的颜色
+func (ct *CheckerTexture) Sample(u, v float64) [3]float64 {
+	i := int(math.Floor(u*float64(ct.Columns))) + int(math.Floor(v*float64(ct.Rows)))
+	return ct.Colors[i&1]
+}
+
+// GradientTexture 沿 v 方向（从上到下）的渐变，Horizontal 为 true 时沿 u 方向
+type GradientTexture struct {
+	Ramp       ColorRamp
+	Horizontal bool
+}
+
+// NewGradientTexture 创建渐变纹理
+func NewGradientTexture(ramp ColorRamp) *GradientTexture {
+	return &GradientTexture{Ramp: ramp}
+}
+
+// Sample 纹理坐标处的颜色
+func (gt *GradientTexture) Sample(u, v float64) [3]float64 {
+	if gt.Horizontal {
+		return gt.Ramp.At(u)
+	}
+	return gt.Ramp.At(v)
+}
+
+// NoiseTexture 分形 Perlin 噪声（fBm）纹理：噪声值映射到 0-1 后在色带上取色
+// u 方向有 Scale 个噪声格（取整，保证经度方向无缝），v 方向为 Scale/2 个，
+// 在等距圆柱投影的球面上赤道附近的斑块大致各向同性
+type NoiseTexture struct {
+	Noise   *PerlinNoise
+	Ramp    ColorRamp
+	Scale   float64
+	Octaves int
+}
+
+// NewNoiseTexture 创建噪声纹理，相同种子生成相同的图案
+func NewNoiseTexture(seed int64, scale float64, octaves int, ramp ColorRamp) *NoiseTexture {
+	return &NoiseTexture{Noise: NewPerlinNoise(seed), Ramp: ramp, Scale: scale, Octaves: octaves}
+}
+
+// Sample 纹理坐标处的颜色
+func (nt *NoiseTexture) Sample(u, v float64) [3]float64 {
+	n := nt.Noise.periodicFractal(u, v, nt.Scale, nt.Octaves)
+	return nt.Ramp.At((n + 1) / 2)
+}
+
+// StripeTexture 沿 v 方向（纬度）交替的条带：v 从 0 到 1 经过 Count 个周期，每个周期走完一遍色带
+// （色带首尾颜色相同时周期之间平滑过渡）。Turbulence 用噪声扰动条带边界，生成气态巨行星的云带
+type StripeTexture struct {
+	Ramp       ColorRamp
+	Count      float64
+	Turbulence float64 // 扰动幅度（条带周期的比例），0 为笔直的条带
+	Noise      *PerlinNoise
+}
+
+// NewStripeTexture 创建笔直的条带纹理
+func NewStripeTexture(ramp ColorRamp, count float64) *StripeTexture {
+	return &StripeTexture{Ramp: ramp, Count: count}
+}
+
+// SetTurbulence 设置条带边界的扰动幅度和噪声种子
+func (st *StripeTexture) SetTurbulence(amount float64, seed int64) *StripeTexture {
+	st.Turbulence = amount
+	st.Noise = NewPerlinNoise(seed)
+	return st
+}
+
+// Sample 纹理坐标处的颜色
+func (st *StripeTexture) Sample(u, v float64) [3]float64 {
+	x := v * st.Count
+	if st.Turbulence != 0 && st.Noise != nil {
+		x += st.Turbulence * st.Noise.periodicFractal(u, v, 8, 4)
+	}
+	return st.Ramp.At(x - math.Floor(x))
+}
+
+// periodicFractal u 方向无缝的分形噪声：第一层在 u 方向有 scale 个噪声格（至少 1 个），
+// v 方向为 scale/2 个，之后每层频率翻倍、振幅减半；返回值约在 [-1, 1] 之间
+func (pn *PerlinNoise) periodicFractal(u, v, scale float64, octaves int) float64 {
+	period := max(1, int(math.Round(scale)))
+	sum, amplitude, total := 0.0, 1.0, 0.0
+	for range max(1, octaves) {
+		sum += pn.periodic2D(u*float64(period), v*float64(period)/2, min(period, 256)) * amplitude
+		total += amplitude
+		amplitude *= 0.5
+		period *= 2
+	}
+	return sum / total
+}
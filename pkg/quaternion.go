@@ -0,0 +1,108 @@
+package go3d
+
+import "math"
+
+// Quaternion 四元数，用于表示旋转：W 为实部，X、Y、Z 为虚部
+type Quaternion struct {
+	W, X, Y, Z float64
+}
+
+// IdentityQuaternion 返回表示无旋转的单位四元数
+func IdentityQuaternion() Quaternion {
+	return Quaternion{W: 1}
+}
+
+// QuaternionFromAxisAngle 由旋转轴和角度（弧度，右手定则）创建四元数，与 RotationFromAxisAngle 一致
+func QuaternionFromAxisAngle(axis Vector3, angle float64) Quaternion {
+	axis = axis.Normalize()
+	s := math.Sin(angle / 2)
+	return Quaternion{W: math.Cos(angle / 2), X: axis.X * s, Y: axis.Y * s, Z: axis.Z * s}
+}
+
+// QuaternionFromEuler 由欧拉角创建四元数，依次绕 X、Y、Z 轴旋转
+// 等价于 RotationZ(z).Multiply(RotationY(y)).Multiply(RotationX(x))
+func QuaternionFromEuler(x, y, z float64) Quaternion {
+	qx := QuaternionFromAxisAngle(NewVector3(1, 0, 0), x)
+	qy := QuaternionFromAxisAngle(NewVector3(0, 1, 0), y)
+	qz := QuaternionFromAxisAngle(NewVector3(0, 0, 1), z)
+	return qz.Multiply(qy).Multiply(qx)
+}
+
+// Multiply 四元数乘法：q.Multiply(other) 表示先做 other 旋转，再做 q 旋转
+func (q Quaternion) Multiply(other Quaternion) Quaternion {
+	return Quaternion{
+		W: q.W*other.W - q.X*other.X - q.Y*other.Y - q.Z*other.Z,
+		X: q.W*other.X + q.X*other.W + q.Y*other.Z - q.Z*other.Y,
+		Y: q.W*other.Y - q.X*other.Z + q.Y*other.W + q.Z*other.X,
+		Z: q.W*other.Z + q.X*other.Y - q.Y*other.X + q.Z*other.W,
+	}
+}
+
+// Dot 四元数点积
+func (q Quaternion) Dot(other Quaternion) float64 {
+	return q.W*other.W + q.X*other.X + q.Y*other.Y + q.Z*other.Z
+}
+
+// Length 四元数的模
+func (q Quaternion) Length() float64 {
+	return math.Sqrt(q.Dot(q))
+}
+
+// Normalize 归一化，模为零时返回单位四元数
+func (q Quaternion) Normalize() Quaternion {
+	length := q.Length()
+	if length < 1e-10 {
+		return IdentityQuaternion()
+	}
+	return Quaternion{W: q.W / length, X: q.X / length, Y: q.Y / length, Z: q.Z / length}
+}
+
+// Conjugate 共轭四元数，对单位四元数即为逆旋转
+func (q Quaternion) Conjugate() Quaternion {
+	return Quaternion{W: q.W, X: -q.X, Y: -q.Y, Z: -q.Z}
+}
+
+// Rotate 用四元数旋转向量
+func (q Quaternion) Rotate(v Vector3) Vector3 {
+	u := NewVector3(q.X, q.Y, q.Z)
+	// v' = v + 2w(u×v) + 2u×(u×v)
+	t := u.Cross(v).Scale(2)
+	return v.Add(t.Scale(q.W)).Add(u.Cross(t))
+}
+
+// Matrix 转换为旋转矩阵
+func (q Quaternion) Matrix() Matrix4 {
+	q = q.Normalize()
+	w, x, y, z := q.W, q.X, q.Y, q.Z
+	return Matrix4{
+		1 - 2*(y*y+z*z), 2 * (x*y - w*z), 2 * (x*z + w*y), 0,
+		2 * (x*y + w*z), 1 - 2*(x*x+z*z), 2 * (y*z - w*x), 0,
+		2 * (x*z - w*y), 2 * (y*z + w*x), 1 - 2*(x*x+y*y), 0,
+		0, 0, 0, 1,
+	}
+}
+
+// Slerp 球面线性插值，t 为 0 时返回 q，为 1 时返回 other，沿最短路径旋转
+func (q Quaternion) Slerp(other Quaternion, t float64) Quaternion {
+	cosTheta := q.Dot(other)
+	if cosTheta < 0 {
+		other = Quaternion{W: -other.W, X: -other.X, Y: -other.Y, Z: -other.Z}
+		cosTheta = -cosTheta
+	}
+
+	// 角度很小时退化为线性插值
+	a, b := 1-t, t
+	if cosTheta < 0.9995 {
+		theta := math.Acos(cosTheta)
+		sinTheta := math.Sin(theta)
+		a = math.Sin((1-t)*theta) / sinTheta
+		b = math.Sin(t*theta) / sinTheta
+	}
+
+	return Quaternion{
+		W: a*q.W + b*other.W,
+		X: a*q.X + b*other.X,
+		Y: a*q.Y + b*other.Y,
+		Z: a*q.Z + b*other.Z,
+	}.Normalize()
+}
@@ -0,0 +1,450 @@
+package go3d
+
+import (
+	"image"
+	"math"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// RaytraceOptions 光线追踪渲染模式的参数
+type RaytraceOptions struct {
+	Samples    int        // 超采样：每个像素 Samples×Samples 条主光线
+	MaxBounces int        // 反射与透明光线的最大递归深度
+	Shadows    bool       // 是否追踪阴影光线
+	Background [3]float64 // 反射光线没有击中任何物体时的颜色（设置了环境贴图时改为采样环境）
+	Workers    int        // 并行追踪的线程数，0 表示使用全部 CPU
+
+	// 以下参数用于路径追踪模式（RenderPathTraced）
+	PathSamples int        // 每个像素的最大采样数
+	Seed        int64      // 随机种子：种子和采样数相同时画面完全相同，动画中可设置为 FrameSeed(seed, renderer.Frame)
+	Sky         [3]float64 // 次级光线没有击中物体时的天空光颜色，提供间接的环境光照（设置了环境贴图时改为采样环境）
+
+	// TimeBudget 每帧的时间预算，0 表示不限时：每次渲染单独计时，每遍采样之后检查耗时，
+	// 超时后不再追加采样（至少完成一遍）。完成的遍数取决于机器速度和负载，因此设置预算后即使种子相同
+	// 结果也不能复现，动画中各帧的噪点水平也可能不同（实际遍数见 Renderer.PathSamplesDone）；
+	// 需要可复现的结果时设为 0，只用 PathSamples 控制采样数
+	TimeBudget time.Duration
+}
+
+// NewRaytraceOptions 创建默认光线追踪参数
+func NewRaytraceOptions() *RaytraceOptions {
+	return &RaytraceOptions{
+		Samples:    2,
+		MaxBounces: 3,
+		Shadows:    true,
+
+		PathSamples: 64,
+		Sky:         [3]float64{0.2, 0.2, 0.2},
+	}
+}
+
+// SetRaytrace 设置光线追踪参数，nil 表示使用默认参数
+func (r *Renderer) SetRaytrace(options *RaytraceOptions) {
+	r.Raytrace = options
+}
+
+// SetReflectivity 设置表面反射率（0-1），仅光线追踪模式使用
+func (m *Mesh) SetReflectivity(reflectivity float64) *Mesh {
+	m.Reflectivity = reflectivity
+	return m
+}
+
+// raytracing 是否处于光线追踪、路径追踪或物体编号模式：网格只收集三角形，之后统一追踪
+func (r *Renderer) raytracing() bool {
+	return r.RenderMode == RenderRaytraced || r.RenderMode == RenderPathTraced || r.RenderMode == RenderObjectID
+}
+
+// rtTriangle 光线追踪用的三角形着色信息（与 Renderer.rtTriangles 一一对应）
+type rtTriangle struct {
+	normals      [3]Vector3    // 三个顶点的着色法线
+	colors       [3][3]float64 // 三个顶点的基础色（已转换到光照空间）
+	texture      *Texture
+	uvs          [3][2]float64
+	detail       *surfaceDetail // 法线贴图和凹凸贴图（为空时不扰动法线）
+	tangent      Vector3        // 纹理坐标 u 增大的方向（detail 非空时有效）
+	bitangent    Vector3        // 纹理坐标 v 增大的方向
+	transparency float64
+	reflectivity float64
+	emissive     [3]float64 // 自发光（已转换到光照空间）
+	unlit        bool       // 不参与光照，直接使用基础色（与光栅化的渐变绘制一致）
+	layers       uint32     // 绘制时的光照层（见 Renderer.LightLayers）
+	mesh         int        // 所属网格在 Renderer.rtMeshes 中的编号
+	object       int        // 绘制时的物体编号（见 SetObjectID）
+}
+
+// rtCreaseCos 顶点平滑法线与面法线夹角的余弦小于该值时使用面法线，保持立方体等的硬边
+const rtCreaseCos = 0.8
+
+// collectRaytraced 收集网格的三角形等待光线追踪，unlit 为 true 时不计算光照
+// 批量绘制期间在 FlushBatch 时统一追踪（物体之间的阴影和反射正确），否则立即只追踪这一个网格
+func (r *Renderer) collectRaytraced(mesh *Mesh, color [3]float64, texture *Texture, unlit bool) {
+	if len(mesh.Triangles) == 0 {
+		return
+	}
+
+	meshIndex := len(r.rtMeshes)
+	r.rtMeshes = append(r.rtMeshes, mesh.BoundingBox())
+	r.rtTriangles, r.rtInfo = r.appendRaytraced(r.rtTriangles, r.rtInfo, mesh, color, texture, unlit, meshIndex)
+
+	if !r.batching {
+		r.traceRaytraced()
+	}
+}
+
+// appendRaytraced 把网格的三角形及着色信息追加到 triangles 和 infos（跳过退化三角形）
+func (r *Renderer) appendRaytraced(triangles []Triangle, infos []rtTriangle, mesh *Mesh, color [3]float64, texture *Texture, unlit bool, meshIndex int) ([]Triangle, []rtTriangle) {
+	normals := mesh.smoothVertexNormals()
+	hasFaceColors := mesh.HasFaceColors()
+	hasVertexColors := mesh.HasVertexColors()
+	if !mesh.HasUVs() {
+		texture = nil
+	}
+	reflectivity := math.Max(0, math.Min(1, mesh.Reflectivity))
+	emissive := r.decodeColor(mesh.Emissive)
+	detail := mesh.detail()
+
+	for i, tri := range mesh.Triangles {
+		faceNormal := tri.Normal()
+		if faceNormal.Length() < 0.5 {
+			continue // 退化三角形
+		}
+
+		info := rtTriangle{
+			transparency: mesh.faceTransparency(i),
+			reflectivity: reflectivity,
+			emissive:     emissive,
+			texture:      texture,
+			unlit:        unlit,
+			mesh:         meshIndex,
+			object:       r.objectID,
+			layers:       r.LightLayers,
+		}
+		for k, v := range [3]Vector3{tri.V0, tri.V1, tri.V2} {
+			info.normals[k] = faceNormal
+			if n := normals[quantizeVertex(v)]; n.Dot(faceNormal) >= rtCreaseCos {
+				info.normals[k] = n
+			}
+
+			base := color
+			if hasVertexColors {
+				base = mesh.VertexColors[i][k]
+			} else if hasFaceColors {
+				base = mesh.FaceColors[i]
+			}
+			info.colors[k] = r.decodeColor(base)
+		}
+		if texture != nil || detail != nil {
+			info.uvs = mesh.UVs[i]
+		}
+		if detail != nil {
+			if tangent, bitangent, ok := tangentFrame(tri, info.uvs); ok {
+				info.detail, info.tangent, info.bitangent = detail, tangent, bitangent
+			}
+		}
+
+		triangles = append(triangles, tri)
+		infos = append(infos, info)
+	}
+	return triangles, infos
+}
+
+// rayTracer 一次追踪所需的只读数据，可在多个线程间共享
+type rayTracer struct {
+	r         *Renderer
+	options   *RaytraceOptions
+	bvh       *BVH
+	info      []rtTriangle
+	bias      float64  // 次级光线起点沿法线的偏移，避免自相交
+	excluded  [][]bool // 每个光源：包围盒包含光源的网格（如太阳）不遮挡该光源
+	lightCols [][3]float64
+
+	// 相机坐标系，与 LookAt 和 ProjectToScreen 一致
+	forward, right, up Vector3
+	tanHalf, aspect    float64
+}
+
+// newRayTracer 为收集到的三角形构建 BVH、相机参数和光源数据
+func (r *Renderer) newRayTracer() *rayTracer {
+	rt := r.newRayCaster(r.rtTriangles, r.rtInfo)
+	rt.bias = math.Max(1e-9, rt.bvh.Bounds().Size().Length()*1e-6)
+	for _, light := range r.Lights {
+		excluded := make([]bool, len(r.rtMeshes))
+		for i, bounds := range r.rtMeshes {
+			excluded[i] = bounds.Contains(light.Position)
+		}
+		rt.excluded = append(rt.excluded, excluded)
+		rt.lightCols = append(rt.lightCols, r.decodeColor(light.Color))
+	}
+	return rt
+}
+
+// newRayCaster 为三角形构建 BVH 和相机参数，只投射主光线（如渲染通道）时使用
+func (r *Renderer) newRayCaster(triangles []Triangle, info []rtTriangle) *rayTracer {
+	options := r.Raytrace
+	if options == nil {
+		options = NewRaytraceOptions()
+	}
+	rt := &rayTracer{
+		r:       r,
+		options: options,
+		bvh:     NewBVH(triangles),
+		info:    info,
+	}
+
+	camera := r.Camera
+	rt.forward, rt.right, rt.up = camera.Basis()
+	rt.tanHalf = math.Tan(camera.FOV / 2)
+	rt.aspect = float64(r.Width) / float64(r.Height)
+	return rt
+}
+
+// cameraRay 穿过屏幕坐标 (px, py) 的主光线方向，以及近、远裁剪面对应的光线参数范围
+func (rt *rayTracer) cameraRay(px, py float64) (Vector3, float64, float64) {
+	ndcX := px/float64(rt.r.Width)*2 - 1
+	ndcY := 1 - py/float64(rt.r.Height)*2
+	dir := rt.forward.
+		Add(rt.right.Scale(ndcX * rt.tanHalf * rt.aspect)).
+		Add(rt.up.Scale(ndcY * rt.tanHalf)).
+		Normalize()
+
+	// 近、远裁剪面沿视线方向计算
+	along := dir.Dot(rt.forward)
+	return dir, rt.r.Camera.Near / along, rt.r.Camera.Far / along
+}
+
+// parallelRows 用多个线程处理所有像素行，每一行只由一个线程处理
+func (rt *rayTracer) parallelRows(process func(y int)) {
+	workers := rt.options.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for y := w; y < rt.r.Height; y += workers {
+				process(y)
+			}
+		}(w)
+	}
+	wg.Wait()
+}
+
+// composite 把预乘 alpha 的光照空间颜色合成到画布像素上，覆盖率为 0 时保留原像素
+func (rt *rayTracer) composite(img *image.RGBA, x, y int, color [3]float64, alpha float64) {
+	if alpha <= 0 {
+		return
+	}
+	alpha = math.Min(1, alpha)
+	out := rt.r.encodeColor([3]float64{color[0] / alpha, color[1] / alpha, color[2] / alpha})
+	offset := y*img.Stride + x*4
+	for k := range 3 {
+		img.Pix[offset+k] = clampByte(out[k]*alpha + float64(img.Pix[offset+k])/255*(1-alpha))
+	}
+	img.Pix[offset+3] = clampByte(alpha + float64(img.Pix[offset+3])/255*(1-alpha))
+}
+
+// traceRaytraced 追踪收集到的所有三角形，把结果合成到画布上，然后清空收集的三角形
+// 没有击中任何物体的像素保留原来的内容（如背景）
+func (r *Renderer) traceRaytraced() {
+	defer func() {
+		r.rtTriangles = r.rtTriangles[:0]
+		r.rtInfo = r.rtInfo[:0]
+		r.rtMeshes = r.rtMeshes[:0]
+	}()
+	if len(r.rtTriangles) == 0 {
+		return
+	}
+	img, ok := r.Surface.GetGoImage().(*image.RGBA)
+	if !ok {
+		return
+	}
+
+	rt := r.newRayTracer()
+	switch r.RenderMode {
+	case RenderObjectID:
+		rt.paintObjectIDs(img)
+		return
+	case RenderPathTraced:
+		rt.pathTrace(img)
+		return
+	}
+
+	samples := max(1, rt.options.Samples)
+	n := float64(samples * samples)
+	rt.parallelRows(func(y int) {
+		for x := range r.Width {
+			var color [3]float64
+			alpha := 0.0
+			for sy := range samples {
+				for sx := range samples {
+					px := float64(x) + (float64(sx)+0.5)/float64(samples)
+					py := float64(y) + (float64(sy)+0.5)/float64(samples)
+					dir, tMin, tMax := rt.cameraRay(px, py)
+					c, a := rt.trace(r.Camera.Position, dir, tMin, tMax, 0)
+					for k := range 3 {
+						color[k] += c[k] / n
+					}
+					alpha += a / n
+				}
+			}
+			rt.composite(img, x, y, color, alpha)
+		}
+	})
+}
+
+// rtSurface 光线与表面交点处的着色数据
+type rtSurface struct {
+	info       rtTriangle
+	position   Vector3
+	faceNormal Vector3    // 几何法线，朝向光线来的一侧
+	normal     Vector3    // 插值后的着色法线，朝向光线来的一侧
+	base       [3]float64 // 基础色（光照空间，已乘纹理颜色）
+}
+
+// surfaceAt 计算交点处的着色数据，三角形双面着色：法线翻转到光线来的一侧
+func (rt *rayTracer) surfaceAt(hit RayHit, origin, dir Vector3) rtSurface {
+	tri := rt.bvh.triangles[hit.Triangle]
+	info := rt.info[hit.Triangle]
+	w := [3]float64{1 - hit.U - hit.V, hit.U, hit.V}
+
+	faceNormal := tri.Normal()
+	var normal Vector3
+	for k := range 3 {
+		normal = normal.Add(info.normals[k].Scale(w[k]))
+	}
+	normal = normal.Normalize()
+	uv := [2]float64{
+		w[0]*info.uvs[0][0] + w[1]*info.uvs[1][0] + w[2]*info.uvs[2][0],
+		w[0]*info.uvs[0][1] + w[1]*info.uvs[1][1] + w[2]*info.uvs[2][1],
+	}
+	if info.detail != nil {
+		normal = info.detail.perturb(normal, info.tangent, info.bitangent, uv)
+	}
+	if faceNormal.Dot(dir) > 0 {
+		faceNormal = faceNormal.Scale(-1)
+		normal = normal.Scale(-1)
+	}
+
+	var base [3]float64
+	for k := range 3 {
+		base[k] = w[0]*info.colors[0][k] + w[1]*info.colors[1][k] + w[2]*info.colors[2][k]
+	}
+	if info.texture != nil {
+		texel := rt.r.decodeColor(info.texture.Sample(uv[0], uv[1]))
+		for k := range 3 {
+			base[k] *= texel[k]
+		}
+	}
+
+	return rtSurface{
+		info:       info,
+		position:   origin.Add(dir.Scale(hit.T)),
+		faceNormal: faceNormal,
+		normal:     normal,
+		base:       base,
+	}
+}
+
+// trace 追踪一条光线，返回预乘 alpha 的颜色（光照空间）和覆盖率
+func (rt *rayTracer) trace(origin, dir Vector3, tMin, tMax float64, depth int) ([3]float64, float64) {
+	hit, ok := rt.bvh.Intersect(origin, dir, tMin, tMax)
+	if !ok {
+		return [3]float64{}, 0
+	}
+	surface := rt.surfaceAt(hit, origin, dir)
+	info, position, faceNormal, normal := surface.info, surface.position, surface.faceNormal, surface.normal
+
+	above := position.Add(faceNormal.Scale(rt.bias))
+	local := surface.base
+	if !info.unlit {
+		local = rt.shade(above, position, normal, surface.base, info.layers)
+	}
+	for k := range 3 {
+		local[k] += info.emissive[k]
+	}
+
+	if info.reflectivity > 0 && depth < rt.options.MaxBounces {
+		reflected := dir.Sub(normal.Scale(2 * dir.Dot(normal)))
+		rc, ra := rt.trace(above, reflected, 0, math.Inf(1), depth+1)
+		background := rt.missColor(reflected, rt.options.Background)
+		for k := range 3 {
+			rc[k] += background[k] * (1 - ra)
+			local[k] = local[k]*(1-info.reflectivity) + rc[k]*info.reflectivity
+		}
+	}
+
+	opacity := 1 - info.transparency
+	color := [3]float64{local[0] * opacity, local[1] * opacity, local[2] * opacity}
+	alpha := opacity
+	if info.transparency > 0 && depth < rt.options.MaxBounces {
+		below := position.Sub(faceNormal.Scale(rt.bias))
+		bc, ba := rt.trace(below, dir, 0, math.Inf(1), depth+1)
+		for k := range 3 {
+			color[k] += bc[k] * info.transparency
+		}
+		alpha += ba * info.transparency
+	}
+	return color, alpha
+}
+
+// missColor 次级光线没有击中物体时的颜色（光照空间）：有环境贴图时按方向采样，否则为 fallback
+func (rt *rayTracer) missColor(dir Vector3, fallback [3]float64) [3]float64 {
+	if rt.r.Environment != nil {
+		return rt.r.environmentColor(rt.r.Environment.Sample(dir))
+	}
+	return rt.r.decodeColor(fallback)
+}
+
+// shade 计算表面一点的直接光照（环境光加漫反射），与 CalculateLighting 的模型一致
+// 阴影光线从沿面法线偏移后的 origin 出发
+func (rt *rayTracer) shade(origin, position, normal Vector3, base [3]float64, layers uint32) [3]float64 {
+	if !rt.r.litLayer(layers) && rt.r.Environment == nil {
+		return base
+	}
+
+	light := rt.directLight(origin, position, normal, layers)
+	ambient := rt.r.ambientLight(normal)
+	for k := range 3 {
+		light[k] += ambient[k]
+	}
+	return [3]float64{light[0] * base[0], light[1] * base[1], light[2] * base[2]}
+}
+
+// directLight 所有光源在表面一点产生的漫反射光照（不含环境光，未乘基础色）
+func (rt *rayTracer) directLight(origin, position, normal Vector3, layers uint32) [3]float64 {
+	r := rt.r
+	var light [3]float64
+	for i, l := range r.Lights {
+		if !l.affects(layers) {
+			continue
+		}
+		toLight := l.Position.Sub(position)
+		distance := toLight.Length()
+		if distance < 1e-12 {
+			continue
+		}
+		lightDir := toLight.Scale(1 / distance)
+		intensity := normal.Dot(lightDir) * l.Intensity * l.Attenuation(distance)
+		if intensity <= 0 || r.inShadow(position, l.Position) {
+			continue
+		}
+		if rt.options.Shadows {
+			excluded := rt.excluded[i]
+			occluded := rt.bvh.Occluded(origin, lightDir, 0, distance, func(t int) bool {
+				return excluded[rt.info[t].mesh] || rt.info[t].transparency >= 1
+			})
+			if occluded {
+				continue
+			}
+		}
+		for k := range 3 {
+			light[k] += rt.lightCols[i][k] * intensity
+		}
+	}
+	return light
+}
@@ -1,10 +1,9 @@
 package go3d
 
 import (
+	"image"
 	"math"
 	"sort"
-
-	"github.com/novvoo/go-cairo/pkg/cairo"
 )
 
 // Camera 表示相机
@@ -15,6 +14,11 @@ type Camera struct {
 	FOV      float64
 	Near     float64
 	Far      float64
+
+	FocusDistance float64 // 景深的对焦距离（沿视线方向），0 表示对焦在 Target
+	Aperture      float64 // 景深的光圈直径（世界单位），0 表示不启用景深
+
+	AutoUp bool // 渲染场景前把 Up 修正为与视线垂直，Up 与视线平行时自动选择稳定的上方向而不是报错
 }
 
 // NewCamera 创建新相机
@@ -34,6 +38,15 @@ type Light struct {
 	Position  Vector3
 	Color     [3]float64
 	Intensity float64
+
+	// 距离衰减（见 light.go）：强度乘以 1 / (Constant + Linear·d + Quadratic·d²)，三者都为 0 时不衰减
+	Constant  float64
+	Linear    float64
+	Quadratic float64
+	Range     float64 // 作用范围：光照在 Range 处平滑衰减到 0，0 表示不限
+
+	Disabled bool   // 暂时关闭该光源
+	Layers   uint32 // 照亮的光照层（位掩码），0 表示 LayerDefault
 }
 
 // NewLight 创建新光源
@@ -49,30 +62,110 @@ func NewLight(pos Vector3, color [3]float64, intensity float64) *Light {
 type RenderMode int
 
 const (
-	RenderWireframe RenderMode = iota // 线框模式
-	RenderFlat                        // 平面着色
-	RenderShaded                      // 光照着色
+	RenderWireframe  RenderMode = iota // 线框模式
+	RenderFlat                         // 平面着色
+	RenderShaded                       // 光照着色
+	RenderGouraud                      // 逐顶点光照，三角形内颜色平滑插值
+	RenderToon                         // 卡通着色：分段光照加轮廓描边
+	RenderRaytraced                    // 光线追踪：逐像素追踪主光线，带阴影和反射，用于高质量静帧
+	RenderPathTraced                   // 路径追踪：渐进式累积采样，带间接光照，用于照片级静帧
+	RenderObjectID                     // 物体编号：每个场景对象填充唯一的纯色（见 ObjectIDColor），用于分割掩码
+)
+
+// SortMode 三角形深度排序模式
+type SortMode int
+
+const (
+	SortAverageDepth SortMode = iota // 按平均深度排序（默认，速度快）
+	SortBSP                          // BSP 树排序，切分相交三角形以消除循环遮挡
 )
 
 // Renderer 3D渲染器
 type Renderer struct {
-	Surface    cairo.ImageSurface
-	Context    cairo.Context
+	Surface    ImageSurface
+	Context    Context
 	Width      int
 	Height     int
 	Camera     *Camera
 	Lights     []*Light
 	RenderMode RenderMode
+	SortMode   SortMode
 	Antialias  bool
+
+	WireframeCreaseAngle float64 // 线框模式的折痕角（弧度），0 表示绘制全部唯一边
+
+	ToonBands    int        // 卡通着色的光照分段数
+	OutlineWidth float64    // 卡通着色的轮廓线宽度（像素），0 表示不描边
+	OutlineColor [3]float64 // 卡通着色的轮廓线颜色
+
+	Shadows bool // 是否启用天体之间的阴影（日食、月食）
+
+	LabelLayout bool // 场景渲染时是否对标签做碰撞避让布局
+
+	LinearLighting bool        // 是否在线性空间计算光照（输出时转换回 sRGB）
+	ToneMapping    ToneMapping // 光照结果的色调映射方式
+
+	Debug *DebugOptions // 调试可视化选项，nil 表示关闭
+
+	AxisGizmo *AxisGizmo // 画面角落的方向指示器，nil 表示不绘制
+
+	FrustumCulling bool // 是否跳过包围球完全位于视锥外的网格
+
+	Raytrace        *RaytraceOptions // 光线追踪和路径追踪模式的参数，nil 表示使用默认参数
+	PathSamplesDone int              // 最近一次路径追踪每个像素实际完成的采样数
+
+	Environment *Environment // 环境贴图：提供随法线方向变化的环境光，nil 表示使用常量环境光
+
+	GBuffer *GBuffer // 最近一次生成的渲染通道（见 SetGBuffer）
+
+	TransparentBackground bool // Clear 时清空为完全透明（预乘 alpha），用于叠加到其他画面上
+
+	// Frame 当前帧号（从 1 开始），由 AnimationGenerator 设置，0 表示不在动画中
+	// 逐帧变化的随机效果用 FrameSeed(seed, r.Frame) 派生种子，多线程渲染的结果与线程数无关
+	Frame int
+
+	batching bool     // 是否处于批量绘制状态
+	overlays []func() // 批量绘制结束后再绘制的覆盖层（如标签）
+
+	occluders []SphereOccluder // 阴影测试使用的遮挡体
+
+	collectingLabels bool       // 是否处于标签收集状态
+	labels           []*Label3D // 等待布局的标签
+
+	postEffects []PostEffect // 后期处理效果
+	postApplied bool         // 当前画面是否已应用后期处理
+
+	arena *frameArena // 复用的临时缓冲区（见 arena.go），Destroy 时归还
+
+	cameraErr error     // 渲染场景时遇到的相机错误（见 CameraError）
+	frameErr  error     // 帧渲染函数报告的错误（见 ReportError）
+	viewport  *viewport // 正在绘制的视口（见 SetViewport）
+
+	cameraLocked bool // 相机由剪辑表设置，ApplyCameraPath 不再修改
+
+	// LightLayers 当前绘制的对象所在的光照层（位掩码），0 表示 LayerDefault；
+	// 只有 Layers 与之相交的光源照亮该对象，通常由 LayeredObject 在绘制期间设置
+	LightLayers uint32
+
+	rtTriangles []Triangle   // 等待光线追踪的三角形
+	rtInfo      []rtTriangle // 与 rtTriangles 一一对应的着色信息
+	rtMeshes    []AABB       // 收集的网格的包围盒
+
+	gbufferEnabled bool         // 是否收集网格用于生成渲染通道
+	gbTriangles    []Triangle   // 等待生成渲染通道的三角形
+	gbInfo         []rtTriangle // 与 gbTriangles 一一对应的着色信息
+	objectID       int          // 之后绘制的网格所属的物体编号
+
+	svg *svgRecorder // 记录的 SVG 元素（见 RecordSVG），nil 表示不记录
 }
 
 // NewRenderer 创建新渲染器
 func NewRenderer(width, height int) *Renderer {
-	surface := cairo.NewImageSurface(cairo.FormatARGB32, width, height)
-	context := cairo.NewContext(surface)
+	surface := newImageSurface(width, height)
+	context := newContext(surface)
 
 	renderer := &Renderer{
-		Surface:    surface.(cairo.ImageSurface),
+		Surface:    surface,
 		Context:    context,
 		Width:      width,
 		Height:     height,
@@ -80,17 +173,24 @@ func NewRenderer(width, height int) *Renderer {
 		Lights:     make([]*Light, 0),
 		RenderMode: RenderWireframe,
 		Antialias:  true,
+
+		ToonBands:    3,
+		OutlineWidth: 3.0,
+		OutlineColor: [3]float64{0, 0, 0},
+
+		Shadows:     true,
+		LabelLayout: true,
 	}
 
 	// 设置合成模式为 SOURCE，确保完全覆盖
-	renderer.Context.SetOperator(cairo.OperatorSource)
+	renderer.Context.SetOperator(operatorSource)
 
 	// 初始化时清除画布为完全透明的黑色
 	renderer.Context.SetSourceRGBA(0, 0, 0, 1.0)
 	renderer.Context.Paint()
 
 	// 恢复为正常的 OVER 模式用于后续绘制
-	renderer.Context.SetOperator(cairo.OperatorOver)
+	renderer.Context.SetOperator(operatorOver)
 
 	return renderer
 }
@@ -105,20 +205,37 @@ func (r *Renderer) SetRenderMode(mode RenderMode) {
 	r.RenderMode = mode
 }
 
+// SetSortMode 设置深度排序模式
+func (r *Renderer) SetSortMode(mode SortMode) {
+	r.SortMode = mode
+}
+
 // SetAntialias 设置抗锯齿
 func (r *Renderer) SetAntialias(enabled bool) {
 	r.Antialias = enabled
 	if enabled {
-		r.Context.SetAntialias(cairo.AntialiasGood)
+		r.Context.SetAntialias(antialiasGood)
 	} else {
-		r.Context.SetAntialias(cairo.AntialiasNone)
+		r.Context.SetAntialias(antialiasNone)
 	}
 }
 
 // Clear 清空画布
+// 启用 TransparentBackground 时忽略颜色，清空为完全透明
 func (r *Renderer) Clear(red, green, blue float64) {
-	r.Context.SetSourceRGB(red, green, blue)
-	r.Context.Paint()
+	if r.TransparentBackground {
+		r.clearTransparent()
+	} else {
+		r.Context.SetSourceRGB(red, green, blue)
+		r.Context.Paint()
+	}
+	r.postApplied = false
+	if r.svg != nil {
+		r.svg.reset()
+	}
+	r.gbTriangles = r.gbTriangles[:0]
+	r.gbInfo = r.gbInfo[:0]
+	r.GBuffer = nil
 }
 
 // ProjectToScreen 将3D坐标投影到屏幕坐标
@@ -140,40 +257,114 @@ func (r *Renderer) ProjectToScreen(v Vector3) (float64, float64, float64) {
 	return x, y, projected.Z
 }
 
+// ProjectPoint 将3D坐标投影为屏幕上的点，第二个返回值为深度（与 ProjectToScreen 的 z 相同）
+func (r *Renderer) ProjectPoint(v Vector3) (Vector2, float64) {
+	x, y, z := r.ProjectToScreen(v)
+	return NewVector2(x, y), z
+}
+
 // CalculateLighting 计算光照
 func (r *Renderer) CalculateLighting(position, normal Vector3, baseColor [3]float64) [3]float64 {
-	if len(r.Lights) == 0 {
+	if len(r.Lights) == 0 && r.Environment == nil {
 		return baseColor
 	}
 
-	ambient := [3]float64{0.2, 0.2, 0.2}
+	if !r.litLayer(r.LightLayers) && r.Environment == nil {
+		return baseColor
+	}
+
+	ambient := r.ambientLight(normal)
 	diffuse := [3]float64{0, 0, 0}
 
 	for _, light := range r.Lights {
-		lightDir := light.Position.Sub(position).Normalize()
-		intensity := math.Max(0, normal.Dot(lightDir)) * light.Intensity
+		if !light.affects(r.LightLayers) {
+			continue
+		}
+		// 被其他天体挡住的光源只贡献环境光
+		if r.inShadow(position, light.Position) {
+			continue
+		}
 
-		diffuse[0] += light.Color[0] * intensity
-		diffuse[1] += light.Color[1] * intensity
-		diffuse[2] += light.Color[2] * intensity
-	}
+		toLight := light.Position.Sub(position)
+		lightDir := toLight.Normalize()
+		intensity := math.Max(0, normal.Dot(lightDir)) * light.Intensity * light.Attenuation(toLight.Length())
+		lightColor := r.decodeColor(light.Color)
 
-	return [3]float64{
-		math.Min(1.0, (ambient[0]+diffuse[0])*baseColor[0]),
-		math.Min(1.0, (ambient[1]+diffuse[1])*baseColor[1]),
-		math.Min(1.0, (ambient[2]+diffuse[2])*baseColor[2]),
+		diffuse[0] += lightColor[0] * intensity
+		diffuse[1] += lightColor[1] * intensity
+		diffuse[2] += lightColor[2] * intensity
 	}
+
+	base := r.decodeColor(baseColor)
+	return r.encodeColor([3]float64{
+		(ambient[0] + diffuse[0]) * base[0],
+		(ambient[1] + diffuse[1]) * base[1],
+		(ambient[2] + diffuse[2]) * base[2],
+	})
 }
 
 // triangleWithDepth 带深度信息的三角形
 type triangleWithDepth struct {
-	tri   Triangle
-	depth float64
-	color [3]float64
+	tri          Triangle
+	depth        float64
+	color        [3]float64
+	smooth       bool          // 是否按顶点颜色插值填充
+	vertexColors [3][3]float64 // 三个顶点的颜色（smooth 为 true 时有效）
+	transparency float64       // 透明度（0 为不透明）
+	texture      *Texture      // 纹理（非空时 vertexColors 为顶点光照强度）
+	uvs          [3][2]float64 // 三个顶点的纹理坐标
+	additive     bool          // 是否以加法合成绘制（见 Mesh.Additive）
+}
+
+// barycentric 计算三角形内一点的重心坐标
+func (td triangleWithDepth) barycentric(p Vector3) (float64, float64, float64) {
+	return td.tri.Barycentric(p)
+}
+
+// colorAt 按重心坐标插值三角形内一点的顶点颜色
+func (td triangleWithDepth) colorAt(p Vector3) [3]float64 {
+	b0, b1, b2 := td.barycentric(p)
+
+	var c [3]float64
+	for k := range 3 {
+		c[k] = b0*td.vertexColors[0][k] + b1*td.vertexColors[1][k] + b2*td.vertexColors[2][k]
+	}
+	return c
+}
+
+// uvAt 按重心坐标插值三角形内一点的纹理坐标
+func (td triangleWithDepth) uvAt(p Vector3) [2]float64 {
+	b0, b1, b2 := td.barycentric(p)
+	return [2]float64{
+		b0*td.uvs[0][0] + b1*td.uvs[1][0] + b2*td.uvs[2][0],
+		b0*td.uvs[0][1] + b1*td.uvs[1][1] + b2*td.uvs[2][1],
+	}
+}
+
+// emit 把网格的自发光颜色加到三角形的着色结果上，并按网格设置标记加法合成
+// 带纹理的三角形的顶点颜色是光照强度，自发光同样与纹理颜色相乘
+func (m *Mesh) emit(td triangleWithDepth) triangleWithDepth {
+	td.additive = m.Additive
+	if m.Emissive == ([3]float64{}) {
+		return td
+	}
+	for k := range 3 {
+		td.color[k] += m.Emissive[k]
+		for v := range 3 {
+			td.vertexColors[v][k] += m.Emissive[k]
+		}
+	}
+	return td
 }
 
 // DrawMesh 绘制网格
 func (r *Renderer) DrawMesh(mesh *Mesh, color [3]float64) {
+	mesh = r.morphMesh(mesh)
+	if r.culled(mesh) {
+		return
+	}
+	r.collectGBuffer(mesh, color, nil)
+
 	switch r.RenderMode {
 	case RenderWireframe:
 		r.drawWireframe(mesh, color)
@@ -181,7 +372,14 @@ func (r *Renderer) DrawMesh(mesh *Mesh, color [3]float64) {
 		r.drawFlat(mesh, color)
 	case RenderShaded:
 		r.drawShaded(mesh, color)
+	case RenderGouraud:
+		r.drawGouraud(mesh, color)
+	case RenderToon:
+		r.drawToon(mesh, color)
+	case RenderRaytraced, RenderPathTraced, RenderObjectID:
+		r.collectRaytraced(mesh, color, nil, false)
 	}
+	r.drawMeshDebug(mesh)
 }
 
 // drawWireframe 绘制线框
@@ -195,24 +393,32 @@ func (r *Renderer) drawWireframe(mesh *Mesh, color [3]float64) {
 
 	r.Context.SetSourceRGB(color[0], color[1], color[2])
 	r.Context.SetLineWidth(1.5)
-	r.Context.SetLineJoin(cairo.LineJoinRound)
+	r.Context.SetLineJoin(lineJoinRound)
 
-	for _, tri := range mesh.Triangles {
-		x0, y0, z0 := r.ProjectToScreen(tri.V0)
-		x1, y1, z1 := r.ProjectToScreen(tri.V1)
-		x2, y2, z2 := r.ProjectToScreen(tri.V2)
+	// 每条边只描一次，避免内部边重复绘制
+	var segments [][2][2]float64
+	for _, edge := range mesh.Edges(r.WireframeCreaseAngle) {
+		x0, y0, z0 := r.ProjectToScreen(edge.V0)
+		x1, y1, z1 := r.ProjectToScreen(edge.V1)
 
 		// 简单的视锥剔除
-		if z0 < -1 || z0 > 1 || z1 < -1 || z1 > 1 || z2 < -1 || z2 > 1 {
+		if z0 < -1 || z0 > 1 || z1 < -1 || z1 > 1 {
 			continue
 		}
 
+		if r.svg != nil {
+			segments = append(segments, [2][2]float64{{x0, y0}, {x1, y1}})
+			continue
+		}
 		r.Context.MoveTo(x0, y0)
 		r.Context.LineTo(x1, y1)
-		r.Context.LineTo(x2, y2)
-		r.Context.ClosePath()
-		r.Context.Stroke()
 	}
+	if r.svg != nil {
+		r.svg.clean = false
+		r.svg.lines(r, segments, color, 1.5)
+		return
+	}
+	r.Context.Stroke()
 }
 
 // drawFlat 绘制平面着色
@@ -224,46 +430,43 @@ func (r *Renderer) drawFlat(mesh *Mesh, color [3]float64) {
 	r.Context.Save()
 	defer r.Context.Restore()
 
-	// 预分配切片容量
-	triangles := make([]triangleWithDepth, 0, len(mesh.Triangles))
+	// 从帧缓冲池借用切片，绘制完成后归还
+	triangles := r.triangleBuffer(len(mesh.Triangles))
 
-	for _, tri := range mesh.Triangles {
+	hasFaceColors := mesh.HasFaceColors()
+	hasVertexColors := mesh.HasVertexColors()
+
+	for i, tri := range mesh.Triangles {
 		_, _, z0 := r.ProjectToScreen(tri.V0)
 		_, _, z1 := r.ProjectToScreen(tri.V1)
 		_, _, z2 := r.ProjectToScreen(tri.V2)
 
 		// 视锥剔除
-		if z0 < -1 || z1 < -1 || z2 < -1 {
+		if z0 < -1 || z0 > 1 || z1 < -1 || z1 > 1 || z2 < -1 || z2 > 1 {
 			continue
 		}
 
 		avgDepth := (z0 + z1 + z2) / 3.0
 
-		triangles = append(triangles, triangleWithDepth{
-			tri:   tri,
-			depth: avgDepth,
-			color: color,
-		})
-	}
-
-	// 从远到近排序
-	sort.Slice(triangles, func(i, j int) bool {
-		return triangles[i].depth > triangles[j].depth
-	})
-
-	// 绘制三角形
-	r.Context.SetSourceRGB(color[0], color[1], color[2])
-	for _, td := range triangles {
-		x0, y0, _ := r.ProjectToScreen(td.tri.V0)
-		x1, y1, _ := r.ProjectToScreen(td.tri.V1)
-		x2, y2, _ := r.ProjectToScreen(td.tri.V2)
+		td := triangleWithDepth{
+			tri:          tri,
+			depth:        avgDepth,
+			color:        color,
+			transparency: mesh.faceTransparency(i),
+		}
+		if hasFaceColors {
+			td.color = mesh.FaceColors[i]
+		}
+		if hasVertexColors {
+			td.smooth = true
+			td.vertexColors = mesh.VertexColors[i]
+		}
 
-		r.Context.MoveTo(x0, y0)
-		r.Context.LineTo(x1, y1)
-		r.Context.LineTo(x2, y2)
-		r.Context.ClosePath()
-		r.Context.Fill()
+		triangles = append(triangles, mesh.emit(td))
 	}
+
+	r.paintTriangles(triangles)
+	r.releaseTriangles(triangles)
 }
 
 // drawShaded 绘制光照着色
@@ -275,16 +478,20 @@ func (r *Renderer) drawShaded(mesh *Mesh, color [3]float64) {
 	r.Context.Save()
 	defer r.Context.Restore()
 
-	// 预分配切片容量
-	triangles := make([]triangleWithDepth, 0, len(mesh.Triangles))
+	// 从帧缓冲池借用切片，绘制完成后归还
+	triangles := r.triangleBuffer(len(mesh.Triangles))
 
-	for _, tri := range mesh.Triangles {
+	hasFaceColors := mesh.HasFaceColors()
+	hasVertexColors := mesh.HasVertexColors()
+	detail := mesh.detail()
+
+	for i, tri := range mesh.Triangles {
 		_, _, z0 := r.ProjectToScreen(tri.V0)
 		_, _, z1 := r.ProjectToScreen(tri.V1)
 		_, _, z2 := r.ProjectToScreen(tri.V2)
 
 		// 视锥剔除
-		if z0 < -1 || z1 < -1 || z2 < -1 {
+		if z0 < -1 || z0 > 1 || z1 < -1 || z1 > 1 || z2 < -1 || z2 > 1 {
 			continue
 		}
 
@@ -301,49 +508,319 @@ func (r *Renderer) drawShaded(mesh *Mesh, color [3]float64) {
 
 		// 计算三角形中心
 		center := tri.Center()
+		if detail != nil {
+			normal = detail.at(tri, mesh.UVs[i], centroid, normal)
+		}
 
 		// 计算光照颜色
-		litColor := r.CalculateLighting(center, normal, color)
+		baseColor := color
+		if hasFaceColors {
+			baseColor = mesh.FaceColors[i]
+		}
+		td := triangleWithDepth{
+			tri:          tri,
+			depth:        avgDepth,
+			color:        r.CalculateLighting(center, normal, baseColor),
+			transparency: mesh.faceTransparency(i),
+		}
 
-		triangles = append(triangles, triangleWithDepth{
-			tri:   tri,
-			depth: avgDepth,
-			color: litColor,
+		// 逐顶点颜色：分别计算每个顶点的光照
+		if hasVertexColors {
+			vc := mesh.VertexColors[i]
+			td.smooth = true
+			td.vertexColors = [3][3]float64{
+				r.CalculateLighting(tri.V0, normal, vc[0]),
+				r.CalculateLighting(tri.V1, normal, vc[1]),
+				r.CalculateLighting(tri.V2, normal, vc[2]),
+			}
+		}
+
+		triangles = append(triangles, mesh.emit(td))
+	}
+
+	r.paintTriangles(triangles)
+	r.releaseTriangles(triangles)
+}
+
+// drawGouraud 绘制 Gouraud 着色：按平滑顶点法线计算顶点光照，再在三角形内插值
+// （插值方式取决于绘图后端，见 fillGouraudTriangle）
+func (r *Renderer) drawGouraud(mesh *Mesh, color [3]float64) {
+	if len(mesh.Triangles) == 0 {
+		return
+	}
+
+	r.Context.Save()
+	defer r.Context.Restore()
+
+	normals := mesh.smoothVertexNormals()
+	hasFaceColors := mesh.HasFaceColors()
+	hasVertexColors := mesh.HasVertexColors()
+	detail := mesh.detail()
+
+	// 从帧缓冲池借用切片，绘制完成后归还
+	triangles := r.triangleBuffer(len(mesh.Triangles))
+
+	for i, tri := range mesh.Triangles {
+		_, _, z0 := r.ProjectToScreen(tri.V0)
+		_, _, z1 := r.ProjectToScreen(tri.V1)
+		_, _, z2 := r.ProjectToScreen(tri.V2)
+
+		// 视锥剔除
+		if z0 < -1 || z0 > 1 || z1 < -1 || z1 > 1 || z2 < -1 || z2 > 1 {
+			continue
+		}
+
+		// 背面剔除
+		viewDir := r.Camera.Position.Sub(tri.Center()).Normalize()
+		if tri.Normal().Dot(viewDir) < 0 {
+			continue
+		}
+
+		baseColors := [3][3]float64{color, color, color}
+		if hasFaceColors {
+			fc := mesh.FaceColors[i]
+			baseColors = [3][3]float64{fc, fc, fc}
+		}
+		if hasVertexColors {
+			baseColors = mesh.VertexColors[i]
+		}
+
+		verts := [3]Vector3{tri.V0, tri.V1, tri.V2}
+		var litColors [3][3]float64
+		for k, v := range verts {
+			n := normals[quantizeVertex(v)]
+			if detail != nil {
+				n = detail.at(tri, mesh.UVs[i], cornerWeights[k], n)
+			}
+			litColors[k] = r.CalculateLighting(v, n, baseColors[k])
+		}
+
+		triangles = append(triangles, mesh.emit(triangleWithDepth{
+			tri:          tri,
+			depth:        (z0 + z1 + z2) / 3.0,
+			color:        litColors[0],
+			smooth:       true,
+			vertexColors: litColors,
+			transparency: mesh.faceTransparency(i),
+		}))
+	}
+
+	r.paintTriangles(triangles)
+	r.releaseTriangles(triangles)
+}
+
+// drawToon 绘制卡通着色：光照量化为若干色带，再描出轮廓边
+func (r *Renderer) drawToon(mesh *Mesh, color [3]float64) {
+	if len(mesh.Triangles) == 0 {
+		return
+	}
+
+	r.Context.Save()
+	defer r.Context.Restore()
+
+	hasFaceColors := mesh.HasFaceColors()
+	detail := mesh.detail()
+	triangles := r.triangleBuffer(len(mesh.Triangles))
+
+	for i, tri := range mesh.Triangles {
+		_, _, z0 := r.ProjectToScreen(tri.V0)
+		_, _, z1 := r.ProjectToScreen(tri.V1)
+		_, _, z2 := r.ProjectToScreen(tri.V2)
+
+		// 视锥剔除
+		if z0 < -1 || z0 > 1 || z1 < -1 || z1 > 1 || z2 < -1 || z2 > 1 {
+			continue
+		}
+
+		// 背面剔除
+		normal := tri.Normal()
+		center := tri.Center()
+		if normal.Dot(r.Camera.Position.Sub(center)) < 0 {
+			continue
+		}
+
+		baseColor := color
+		if hasFaceColors {
+			baseColor = mesh.FaceColors[i]
+		}
+		if detail != nil {
+			normal = detail.at(tri, mesh.UVs[i], centroid, normal)
+		}
+
+		triangles = append(triangles, mesh.emit(triangleWithDepth{
+			tri:          tri,
+			depth:        (z0 + z1 + z2) / 3.0,
+			color:        r.quantizeLighting(r.CalculateLighting(center, normal, baseColor), baseColor),
+			transparency: mesh.faceTransparency(i),
+		}))
+	}
+
+	r.paintTriangles(triangles)
+	r.releaseTriangles(triangles)
+
+	// 轮廓边在推迟之前求出：批量绘制时 mesh 可能是每次绘制复用的临时网格
+	if r.OutlineWidth > 0 {
+		edges := mesh.SilhouetteEdges(r.Camera.Position)
+		r.DrawOverlay(func() {
+			r.strokeSilhouette(edges)
 		})
 	}
+}
 
-	// 从远到近排序
-	sort.Slice(triangles, func(i, j int) bool {
-		return triangles[i].depth > triangles[j].depth
-	})
+// quantizeLighting 将光照后的颜色按相对于基础色的亮度比例量化为色带
+func (r *Renderer) quantizeLighting(lit, base [3]float64) [3]float64 {
+	bands := float64(r.ToonBands)
+	if bands < 1 {
+		return lit
+	}
 
-	// 绘制三角形
-	for _, td := range triangles {
-		x0, y0, _ := r.ProjectToScreen(td.tri.V0)
-		x1, y1, _ := r.ProjectToScreen(td.tri.V1)
-		x2, y2, _ := r.ProjectToScreen(td.tri.V2)
+	var result [3]float64
+	for k := range 3 {
+		if base[k] < 1e-10 {
+			continue
+		}
+		ratio := lit[k] / base[k]
+		level := math.Ceil(ratio*bands) / bands
+		result[k] = math.Min(1.0, base[k]*level)
+	}
+	return result
+}
+
+// strokeSilhouette 描绘网格相对于相机的轮廓边
+func (r *Renderer) strokeSilhouette(edges []Edge) {
+	r.Context.Save()
+	defer r.Context.Restore()
+
+	r.Context.SetSourceRGB(r.OutlineColor[0], r.OutlineColor[1], r.OutlineColor[2])
+	r.Context.SetLineWidth(r.OutlineWidth)
+	r.Context.SetLineCap(lineCapRound)
+	r.Context.SetLineJoin(lineJoinRound)
 
+	for _, edge := range edges {
+		x0, y0, z0 := r.ProjectToScreen(edge.V0)
+		x1, y1, z1 := r.ProjectToScreen(edge.V1)
+		if z0 < -1 || z0 > 1 || z1 < -1 || z1 > 1 {
+			continue
+		}
 		r.Context.MoveTo(x0, y0)
 		r.Context.LineTo(x1, y1)
-		r.Context.LineTo(x2, y2)
-		r.Context.ClosePath()
+	}
+	r.Context.Stroke()
+}
 
-		r.Context.SetSourceRGB(td.color[0], td.color[1], td.color[2])
-		r.Context.Fill()
+// DrawTexturedMesh 使用纹理绘制网格，纹理颜色与光照相乘
+// 网格没有纹理坐标或处于线框模式时退化为使用 color 的 DrawMesh
+func (r *Renderer) DrawTexturedMesh(mesh *Mesh, texture *Texture, color [3]float64) {
+	mesh = r.morphMesh(mesh)
+	if r.culled(mesh) {
+		return
+	}
+	if texture == nil || !mesh.HasUVs() || r.RenderMode == RenderWireframe {
+		r.DrawMesh(mesh, color)
+		return
+	}
+	r.collectGBuffer(mesh, color, texture)
+	if r.raytracing() {
+		r.collectRaytraced(mesh, color, texture, false)
+		r.drawMeshDebug(mesh)
+		return
+	}
+
+	r.Context.Save()
+	defer r.Context.Restore()
+
+	var normals map[vertexKey]Vector3
+	if r.RenderMode == RenderGouraud {
+		normals = mesh.smoothVertexNormals()
+	}
+	white := [3]float64{1, 1, 1}
+	detail := mesh.detail()
+
+	triangles := r.triangleBuffer(len(mesh.Triangles))
+	for i, tri := range mesh.Triangles {
+		_, _, z0 := r.ProjectToScreen(tri.V0)
+		_, _, z1 := r.ProjectToScreen(tri.V1)
+		_, _, z2 := r.ProjectToScreen(tri.V2)
+
+		// 视锥剔除
+		if z0 < -1 || z0 > 1 || z1 < -1 || z1 > 1 || z2 < -1 || z2 > 1 {
+			continue
+		}
+
+		normal := tri.Normal()
+		center := tri.Center()
+
+		// 背面剔除（平面着色模式与 drawFlat 一致，不剔除）
+		if r.RenderMode != RenderFlat && normal.Dot(r.Camera.Position.Sub(center)) < 0 {
+			continue
+		}
+
+		if detail != nil && r.RenderMode != RenderFlat && r.RenderMode != RenderGouraud {
+			normal = detail.at(tri, mesh.UVs[i], centroid, normal)
+		}
+
+		// 光照强度：以白色为基础色计算，再在像素上与纹理颜色相乘
+		var light [3][3]float64
+		switch r.RenderMode {
+		case RenderFlat:
+			light = [3][3]float64{white, white, white}
+		case RenderGouraud:
+			verts := [3]Vector3{tri.V0, tri.V1, tri.V2}
+			for k, v := range verts {
+				n := normals[quantizeVertex(v)]
+				if detail != nil {
+					n = detail.at(tri, mesh.UVs[i], cornerWeights[k], n)
+				}
+				light[k] = r.CalculateLighting(v, n, white)
+			}
+		case RenderToon:
+			lit := r.quantizeLighting(r.CalculateLighting(center, normal, white), white)
+			light = [3][3]float64{lit, lit, lit}
+		default:
+			lit := r.CalculateLighting(center, normal, white)
+			light = [3][3]float64{lit, lit, lit}
+		}
+
+		triangles = append(triangles, mesh.emit(triangleWithDepth{
+			tri:          tri,
+			depth:        (z0 + z1 + z2) / 3.0,
+			color:        color,
+			smooth:       true,
+			vertexColors: light,
+			transparency: mesh.faceTransparency(i),
+			texture:      texture,
+			uvs:          mesh.UVs[i],
+		}))
 	}
+
+	r.paintTriangles(triangles)
+	r.releaseTriangles(triangles)
+	r.drawMeshDebug(mesh)
 }
 
 // DrawMeshWithGradient 使用渐变绘制网格
 func (r *Renderer) DrawMeshWithGradient(mesh *Mesh, color1, color2 [3]float64) {
-	if len(mesh.Triangles) == 0 {
+	mesh = r.morphMesh(mesh)
+	if len(mesh.Triangles) == 0 || r.culled(mesh) {
+		return
+	}
+
+	var gradient *Mesh
+	if r.raytracing() || r.collectingGBuffer() {
+		gradient = r.gradientMesh(mesh, color1, color2)
+		r.collectGBuffer(gradient, color1, nil)
+	}
+	if r.raytracing() {
+		r.collectRaytraced(gradient, color1, nil, true)
+		r.drawMeshDebug(mesh)
 		return
 	}
 
 	r.Context.Save()
 	defer r.Context.Restore()
 
-	// 预分配切片容量
-	triangles := make([]triangleWithDepth, 0, len(mesh.Triangles))
+	// 从帧缓冲池借用切片，绘制完成后归还
+	triangles := r.triangleBuffer(len(mesh.Triangles))
 
 	for _, tri := range mesh.Triangles {
 		_, _, z0 := r.ProjectToScreen(tri.V0)
@@ -351,56 +828,392 @@ func (r *Renderer) DrawMeshWithGradient(mesh *Mesh, color1, color2 [3]float64) {
 		_, _, z2 := r.ProjectToScreen(tri.V2)
 
 		// 视锥剔除
-		if z0 < -1 || z1 < -1 || z2 < -1 {
+		if z0 < -1 || z0 > 1 || z1 < -1 || z1 > 1 || z2 < -1 || z2 > 1 {
 			continue
 		}
 
 		avgDepth := (z0 + z1 + z2) / 3.0
 
-		// 根据深度计算渐变颜色
-		t := (avgDepth + 1.0) / 2.0 // 归一化到 0-1
-		color := [3]float64{
-			color1[0]*(1-t) + color2[0]*t,
-			color1[1]*(1-t) + color2[1]*t,
-			color1[2]*(1-t) + color2[2]*t,
-		}
-
-		triangles = append(triangles, triangleWithDepth{
+		triangles = append(triangles, mesh.emit(triangleWithDepth{
 			tri:   tri,
 			depth: avgDepth,
-			color: color,
-		})
+			color: depthGradient(avgDepth, color1, color2),
+		}))
 	}
 
-	// 从远到近排序
-	sort.Slice(triangles, func(i, j int) bool {
-		return triangles[i].depth > triangles[j].depth
-	})
+	r.paintTriangles(triangles)
+	r.releaseTriangles(triangles)
+	r.drawMeshDebug(mesh)
+}
+
+// gradientMesh 按深度渐变给每个三角形设置面颜色，返回网格的浅拷贝（原网格不变）
+func (r *Renderer) gradientMesh(mesh *Mesh, color1, color2 [3]float64) *Mesh {
+	shaded := *mesh
+	shaded.FaceColors = make([][3]float64, len(mesh.Triangles))
+	shaded.VertexColors = nil
+	for i, tri := range mesh.Triangles {
+		_, _, z0 := r.ProjectToScreen(tri.V0)
+		_, _, z1 := r.ProjectToScreen(tri.V1)
+		_, _, z2 := r.ProjectToScreen(tri.V2)
+		shaded.FaceColors[i] = depthGradient((z0+z1+z2)/3.0, color1, color2)
+	}
+	return &shaded
+}
+
+// depthGradient 根据深度计算渐变颜色
+func depthGradient(depth float64, color1, color2 [3]float64) [3]float64 {
+	t := (depth + 1.0) / 2.0 // 归一化到 0-1
+	return [3]float64{
+		color1[0]*(1-t) + color2[0]*t,
+		color1[1]*(1-t) + color2[1]*t,
+		color1[2]*(1-t) + color2[2]*t,
+	}
+}
+
+// BeginBatch 开始批量绘制
+// 之后的填充类绘制只收集三角形，直到 FlushBatch 时统一排序，
+// 这样不同网格之间（如坐标轴穿过行星）的遮挡关系也能正确处理
+func (r *Renderer) BeginBatch() {
+	r.batching = true
+	arena := r.frameArena()
+	arena.batch = arena.batch[:0]
+	r.overlays = r.overlays[:0]
+	r.rtTriangles = r.rtTriangles[:0]
+	r.rtInfo = r.rtInfo[:0]
+	r.rtMeshes = r.rtMeshes[:0]
+}
 
-	// 绘制三角形
+// FlushBatch 结束批量绘制，排序并绘制所有累积的三角形，再绘制覆盖层
+func (r *Renderer) FlushBatch() {
+	if !r.batching {
+		return
+	}
+	r.batching = false
+
+	r.Context.Save()
+	arena := r.frameArena()
+	r.paintTriangles(arena.batch)
+	r.Context.Restore()
+	r.traceRaytraced()
+
+	for _, overlay := range r.overlays {
+		overlay()
+	}
+
+	clear(arena.batch)
+	arena.batch = arena.batch[:0]
+	r.overlays = r.overlays[:0]
+}
+
+// DrawOverlay 绘制覆盖层内容；批量绘制期间推迟到三角形绘制完成之后
+// 物体编号模式只输出网格表面，覆盖层（线条、标签等）不绘制
+func (r *Renderer) DrawOverlay(draw func()) {
+	if r.RenderMode == RenderObjectID {
+		return
+	}
+	if r.batching {
+		r.overlays = append(r.overlays, draw)
+		return
+	}
+	draw()
+}
+
+// paintTriangles 按排序模式排序并填充三角形
+func (r *Renderer) paintTriangles(triangles []triangleWithDepth) {
+	if r.batching {
+		arena := r.frameArena()
+		arena.batch = append(arena.batch, triangles...)
+		return
+	}
+
+	switch r.SortMode {
+	case SortBSP:
+		triangles = sortTrianglesBSP(triangles, r.Camera.Position)
+	default:
+		// 从远到近排序；深度相同时保持提交顺序，画面与渲染线程数无关
+		sort.SliceStable(triangles, func(i, j int) bool {
+			return triangles[i].depth > triangles[j].depth
+		})
+	}
+
+	if r.svg != nil {
+		r.svg.clean = false
+	}
+	additive := false
 	for _, td := range triangles {
 		x0, y0, _ := r.ProjectToScreen(td.tri.V0)
 		x1, y1, _ := r.ProjectToScreen(td.tri.V1)
 		x2, y2, _ := r.ProjectToScreen(td.tri.V2)
 
-		r.Context.MoveTo(x0, y0)
-		r.Context.LineTo(x1, y1)
-		r.Context.LineTo(x2, y2)
+		// 只在合成模式变化时切换，批量绘制中加法和普通三角形可能交错
+		if td.additive != additive {
+			additive = td.additive
+			if additive {
+				r.Context.SetOperator(operatorAdd)
+			} else {
+				r.Context.SetOperator(operatorOver)
+			}
+		}
+
+		// 记录 SVG 时平面着色的三角形输出为矢量路径，其余的画在画布上
+		if r.svg != nil {
+			if !td.smooth && !td.additive {
+				r.svg.polygon(r, [][2]float64{{x0, y0}, {x1, y1}, {x2, y2}}, td.color, 1-td.transparency)
+				continue
+			}
+			r.svg.clean = false
+		}
+
+		if td.smooth && td.texture == nil && r.fillGouraudTriangle([3][2]float64{{x0, y0}, {x1, y1}, {x2, y2}}, td.vertexColors, 1-td.transparency, !td.additive) {
+			continue
+		}
+		if td.smooth {
+			r.fillSmoothTriangle([3][2]float64{{x0, y0}, {x1, y1}, {x2, y2}}, td.shader(), 1-td.transparency, !td.additive)
+			continue
+		}
+
+		pts := [3][2]float64{{x0, y0}, {x1, y1}, {x2, y2}}
+		// 透明背景上接缝处的部分覆盖会留下半透明的缝隙，不透明三角形同样向外扩张
+		// （加法合成的三角形扩张后接缝处会重复相加，不扩张）
+		if r.TransparentBackground && td.transparency == 0 && !td.additive {
+			pts = dilateTriangle(pts)
+		}
+		r.Context.MoveTo(pts[0][0], pts[0][1])
+		r.Context.LineTo(pts[1][0], pts[1][1])
+		r.Context.LineTo(pts[2][0], pts[2][1])
 		r.Context.ClosePath()
 
-		r.Context.SetSourceRGB(td.color[0], td.color[1], td.color[2])
+		if td.transparency > 0 {
+			r.Context.SetSourceRGBA(td.color[0], td.color[1], td.color[2], 1-td.transparency)
+		} else {
+			r.Context.SetSourceRGB(td.color[0], td.color[1], td.color[2])
+		}
 		r.Context.Fill()
 	}
+	if additive {
+		r.Context.SetOperator(operatorOver)
+	}
 }
 
-// SaveToPNG 保存为PNG文件
+// shader 返回按屏幕空间重心坐标计算像素颜色的函数
+func (td triangleWithDepth) shader() func(b0, b1, b2 float64) [3]float64 {
+	colors := td.vertexColors
+	interpolate := func(b0, b1, b2 float64) [3]float64 {
+		var c [3]float64
+		for k := range 3 {
+			c[k] = b0*colors[0][k] + b1*colors[1][k] + b2*colors[2][k]
+		}
+		return c
+	}
+	if td.texture == nil {
+		return interpolate
+	}
+
+	// 纹理颜色乘以插值后的光照强度（屏幕空间仿射映射，未做透视校正）
+	uvs := td.uvs
+	return func(b0, b1, b2 float64) [3]float64 {
+		light := interpolate(b0, b1, b2)
+		texel := td.texture.Sample(
+			b0*uvs[0][0]+b1*uvs[1][0]+b2*uvs[2][0],
+			b0*uvs[0][1]+b1*uvs[1][1]+b2*uvs[2][1],
+		)
+		return [3]float64{light[0] * texel[0], light[1] * texel[1], light[2] * texel[2]}
+	}
+}
+
+// fillGouraudTriangle 用三个顶点颜色线性插值填充屏幕空间三角形：三角形作为只有三条边的
+// 网格渐变（Coons 面片）图案，由绘图后端插值，不需要逐像素着色
+// opacity 为整个三角形的不透明度；dilate 为 false 时不向外扩张（加法合成）
+// 目前只有 purego 后端支持网格渐变；默认的 go-cairo 后端返回 false，调用方改用 fillSmoothTriangle
+func (r *Renderer) fillGouraudTriangle(pts [3][2]float64, colors [3][3]float64, opacity float64, dilate bool) bool {
+	patch, path := pts, pts[:]
+	if dilate {
+		// 面片扩张到填充范围之外，扩张后的角沿用原顶点颜色；尖锐顶角的斜接
+		// 裁剪到三角形包围盒外一个像素以内，与纹理三角形的填充范围一致
+		patch = dilateTriangle(pts)
+		minX := math.Floor(math.Min(pts[0][0], math.Min(pts[1][0], pts[2][0]))) - 1
+		minY := math.Floor(math.Min(pts[0][1], math.Min(pts[1][1], pts[2][1]))) - 1
+		maxX := math.Ceil(math.Max(pts[0][0], math.Max(pts[1][0], pts[2][0]))) + 1
+		maxY := math.Ceil(math.Max(pts[0][1], math.Max(pts[1][1], pts[2][1]))) + 1
+		path = clipPolygonToRect(patch[:], minX, minY, maxX, maxY)
+		if len(path) < 3 {
+			return true
+		}
+	}
+	return fillMeshTriangle(r.Context, patch, colors, opacity, path)
+}
+
+// clipPolygonToRect 把凸多边形裁剪到轴对齐矩形内（Sutherland-Hodgman）
+func clipPolygonToRect(polygon [][2]float64, minX, minY, maxX, maxY float64) [][2]float64 {
+	// 四条边界依次为 x >= minX、x <= maxX、y >= minY、y <= maxY
+	bounds := [4]struct {
+		axis  int
+		limit float64
+		lower bool
+	}{{0, minX, true}, {0, maxX, false}, {1, minY, true}, {1, maxY, false}}
+
+	for _, b := range bounds {
+		inside := func(p [2]float64) bool {
+			if b.lower {
+				return p[b.axis] >= b.limit
+			}
+			return p[b.axis] <= b.limit
+		}
+		var clipped [][2]float64
+		for i, p := range polygon {
+			q := polygon[(i+1)%len(polygon)]
+			if inside(p) {
+				clipped = append(clipped, p)
+			}
+			if inside(p) != inside(q) {
+				t := (b.limit - p[b.axis]) / (q[b.axis] - p[b.axis])
+				clipped = append(clipped, [2]float64{p[0] + (q[0]-p[0])*t, p[1] + (q[1]-p[1])*t})
+			}
+		}
+		polygon = clipped
+		if len(polygon) == 0 {
+			break
+		}
+	}
+	return polygon
+}
+
+// fillSmoothTriangle 按逐像素着色函数填充屏幕空间三角形，用于带纹理的三角形
+// （网格渐变只能插值颜色，不能采样纹理）和不支持网格渐变的绘图后端：先把重心插值结果
+// 写入三角形包围盒大小的图像表面，再作为表面图案填充三角形路径
+// opacity 为整个三角形的不透明度，像素按预乘 alpha 写入；dilate 为 false 时填充路径不向外扩张（加法合成）
+func (r *Renderer) fillSmoothTriangle(pts [3][2]float64, shade func(b0, b1, b2 float64) [3]float64, opacity float64, dilate bool) {
+	// 包围盒四周各留一个像素，容纳下面向外扩张的填充路径
+	minX := math.Floor(math.Min(pts[0][0], math.Min(pts[1][0], pts[2][0]))) - 1
+	minY := math.Floor(math.Min(pts[0][1], math.Min(pts[1][1], pts[2][1]))) - 1
+	maxX := math.Ceil(math.Max(pts[0][0], math.Max(pts[1][0], pts[2][0]))) + 1
+	maxY := math.Ceil(math.Max(pts[0][1], math.Max(pts[1][1], pts[2][1]))) + 1
+
+	// 裁剪到画布范围
+	minX = math.Max(minX, 0)
+	minY = math.Max(minY, 0)
+	maxX = math.Min(maxX, float64(r.Width))
+	maxY = math.Min(maxY, float64(r.Height))
+
+	if maxX <= minX || maxY <= minY {
+		return
+	}
+	w := int(maxX-minX) + 1
+	h := int(maxY-minY) + 1
+
+	denom := (pts[1][1]-pts[2][1])*(pts[0][0]-pts[2][0]) + (pts[2][0]-pts[1][0])*(pts[0][1]-pts[2][1])
+	if math.Abs(denom) < 1e-10 {
+		return
+	}
+
+	surface := newImageSurface(w, h)
+	defer surface.Destroy()
+	img, ok := surface.GetGoImage().(*image.RGBA)
+	if !ok {
+		return
+	}
+
+	for py := range h {
+		for px := range w {
+			// 采样像素中心
+			x := minX + float64(px) + 0.5
+			y := minY + float64(py) + 0.5
+
+			b0 := ((pts[1][1]-pts[2][1])*(x-pts[2][0]) + (pts[2][0]-pts[1][0])*(y-pts[2][1])) / denom
+			b1 := ((pts[2][1]-pts[0][1])*(x-pts[2][0]) + (pts[0][0]-pts[2][0])*(y-pts[2][1])) / denom
+			b2 := 1 - b0 - b1
+
+			// 边缘像素可能落在三角形外，钳制重心坐标避免颜色外推
+			b0 = math.Max(0, math.Min(1, b0))
+			b1 = math.Max(0, math.Min(1, b1))
+			b2 = math.Max(0, math.Min(1, b2))
+			sum := b0 + b1 + b2
+
+			color := shade(b0/sum, b1/sum, b2/sum)
+			offset := py*img.Stride + px*4
+			for k := range 3 {
+				img.Pix[offset+k] = uint8(math.Max(0, math.Min(1, color[k]))*opacity*255 + 0.5)
+			}
+			img.Pix[offset+3] = uint8(opacity*255 + 0.5)
+		}
+	}
+
+	path := pts
+	if dilate {
+		path = dilateTriangle(pts)
+	}
+	r.Context.SetSourceSurface(surface, minX, minY)
+	r.Context.MoveTo(path[0][0], path[0][1])
+	r.Context.LineTo(path[1][0], path[1][1])
+	r.Context.LineTo(path[2][0], path[2][1])
+	r.Context.ClosePath()
+	r.Context.Fill()
+}
+
+// dilateTriangle 将三角形的每条边沿法线向外平移半个像素，消除相邻三角形之间抗锯齿产生的接缝
+// 尖锐顶角处的斜接长度限制为两个像素，避免细长三角形产生尖刺
+func dilateTriangle(pts [3][2]float64) [3][2]float64 {
+	// 三角形的绕向决定边的外法线方向
+	area := (pts[1][0]-pts[0][0])*(pts[2][1]-pts[0][1]) - (pts[2][0]-pts[0][0])*(pts[1][1]-pts[0][1])
+	if math.Abs(area) < 1e-10 {
+		return pts
+	}
+	sign := 1.0
+	if area < 0 {
+		sign = -1
+	}
+
+	var normals [3][2]float64
+	for i := range 3 {
+		a, b := pts[i], pts[(i+1)%3]
+		dx, dy := b[0]-a[0], b[1]-a[1]
+		length := math.Hypot(dx, dy)
+		if length < 1e-10 {
+			return pts
+		}
+		normals[i] = [2]float64{dy / length * sign, -dx / length * sign}
+	}
+
+	var path [3][2]float64
+	for i, p := range pts {
+		// 顶点 i 位于边 i-1 和边 i 之间，沿两条边外法线的斜接方向移动
+		n0, n1 := normals[(i+2)%3], normals[i]
+		mx, my := n0[0]+n1[0], n0[1]+n1[1]
+		scale := 0.5 / math.Max(1e-10, 1+n0[0]*n1[0]+n0[1]*n1[1])
+		if length := math.Hypot(mx, my) * scale; length > 2 {
+			scale *= 2 / length
+		}
+		path[i] = [2]float64{p[0] + mx*scale, p[1] + my*scale}
+	}
+	return path
+}
+
+// SaveToPNG 保存为PNG文件（保存前应用后期处理效果）
 func (r *Renderer) SaveToPNG(filename string) error {
+	r.ApplyPostEffects()
 	r.Surface.WriteToPNG(filename)
 	return nil
 }
 
+// Image 返回当前画面的副本（已应用后期处理），用于在内存中继续处理而不必写入文件
+func (r *Renderer) Image() *image.RGBA {
+	r.ApplyPostEffects()
+	out := image.NewRGBA(image.Rect(0, 0, r.Width, r.Height))
+	if img, ok := r.Surface.GetGoImage().(*image.RGBA); ok {
+		for y := range r.Height {
+			copy(out.Pix[y*out.Stride:y*out.Stride+r.Width*4], img.Pix[y*img.Stride:y*img.Stride+r.Width*4])
+		}
+	}
+	return out
+}
+
 // Destroy 释放资源
 func (r *Renderer) Destroy() {
+	if r.viewport != nil {
+		r.viewport.renderer.Destroy()
+		r.viewport = nil
+	}
+	r.releaseArena()
 	r.Context.Destroy()
 	r.Surface.Destroy()
 }
@@ -0,0 +1,232 @@
+package go3d
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// RevealOrder 逐个显示三角形的顺序
+type RevealOrder int
+
+const (
+	RevealAlongAxis  RevealOrder = iota // 沿 Axis 方向从后往前
+	RevealFromCenter                    // 按到 Origin 的距离由近及远（默认 Origin 为网格中心）
+	RevealRandom                        // 按 Seed 打乱的随机顺序
+	RevealMeshOrder                     // 网格中三角形的原始顺序
+)
+
+// MeshReveal 构建动画：随 Progress 从 0 到 1，网格的三角形按 Order 的顺序逐个出现，
+// 每个三角形在出现时可以从偏移处飞入、从重心放大并淡入，适合讲解视频中模型逐步搭建的效果
+type MeshReveal struct {
+	Mesh     *Mesh
+	Color    [3]float64
+	Progress float64 // 0 为完全不可见，1 为全部出现
+
+	Order  RevealOrder
+	Axis   Vector3 // RevealAlongAxis 的方向
+	Origin Vector3 // RevealFromCenter 的中心
+	Seed   int64   // RevealRandom 的随机种子
+
+	Overlap float64 // 每个三角形出现过程占总进度的比例，0 为瞬间出现
+	FlyIn   Vector3 // 三角形飞入前的偏移（世界单位），零向量表示原地出现
+	Scatter float64 // 在 FlyIn 之外再叠加的随机偏移距离
+	ScaleIn bool    // 三角形是否从重心放大出现
+	FadeIn  bool    // 三角形是否淡入
+
+	mu       sync.Mutex
+	orderKey revealKey
+	starts   []float64 // 每个三角形开始出现的进度（已按 Overlap 压缩到 [0, 1-Overlap]）
+	scatter  []Vector3 // 每个三角形的随机偏移方向
+}
+
+// revealKey 决定出现顺序的参数，改变时重新排序
+type revealKey struct {
+	mesh      *Mesh
+	count     int
+	order     RevealOrder
+	axis      Vector3
+	origin    Vector3
+	seed      int64
+	overlap   float64
+	scattered bool
+}
+
+// NewMeshReveal 创建沿 +Y 方向自下而上出现的构建动画，Origin 为网格包围盒中心
+func NewMeshReveal(mesh *Mesh, color [3]float64) *MeshReveal {
+	box := mesh.BoundingBox()
+	return &MeshReveal{
+		Mesh:    mesh,
+		Color:   color,
+		Order:   RevealAlongAxis,
+		Axis:    NewVector3(0, 1, 0),
+		Origin:  box.Min.Add(box.Max).Scale(0.5),
+		Seed:    1,
+		Overlap: 0.15,
+		ScaleIn: true,
+	}
+}
+
+// SetOrder 设置出现顺序
+func (mr *MeshReveal) SetOrder(order RevealOrder) *MeshReveal {
+	mr.Order = order
+	return mr
+}
+
+// SetAxis 设置 RevealAlongAxis 的方向
+func (mr *MeshReveal) SetAxis(axis Vector3) *MeshReveal {
+	mr.Axis = axis
+	return mr
+}
+
+// SetFlyIn 设置三角形飞入前的偏移和随机散布距离
+func (mr *MeshReveal) SetFlyIn(offset Vector3, scatter float64) *MeshReveal {
+	mr.FlyIn = offset
+	mr.Scatter = scatter
+	return mr
+}
+
+// Animate 在时间线上添加轨道，使网格在 [start, end] 内逐步出现
+func (mr *MeshReveal) Animate(timeline *Timeline, start, end float64, easing func(float64) float64) *MeshReveal {
+	timeline.AddTrack(NewFloatTrack(&mr.Progress).AddKeyframe(start, 0, easing).AddKeyframe(end, 1, nil))
+	return mr
+}
+
+// schedule 按出现顺序给每个三角形分配开始进度和随机偏移方向，参数不变时复用上次的结果
+func (mr *MeshReveal) schedule() ([]float64, []Vector3) {
+	mr.mu.Lock()
+	defer mr.mu.Unlock()
+	overlap := math.Max(0, math.Min(1, mr.Overlap))
+	key := revealKey{mr.Mesh, len(mr.Mesh.Triangles), mr.Order, mr.Axis, mr.Origin, mr.Seed, overlap, mr.Scatter != 0}
+	if key == mr.orderKey && mr.starts != nil {
+		return mr.starts, mr.scatter
+	}
+	mr.orderKey = key
+
+	n := len(mr.Mesh.Triangles)
+	rank := make([]int, n)
+	for i := range rank {
+		rank[i] = i
+	}
+	rng := NewRandom(mr.Seed, 0)
+	switch mr.Order {
+	case RevealAlongAxis, RevealFromCenter:
+		axis := mr.Axis
+		if axis.Length() > 0 {
+			axis = axis.Normalize()
+		}
+		keys := make([]float64, n)
+		for i, tri := range mr.Mesh.Triangles {
+			if mr.Order == RevealAlongAxis {
+				keys[i] = tri.Center().Dot(axis)
+			} else {
+				keys[i] = tri.Center().Sub(mr.Origin).Length()
+			}
+		}
+		sort.SliceStable(rank, func(a, b int) bool { return keys[rank[a]] < keys[rank[b]] })
+	case RevealRandom:
+		rank = rng.Perm(n)
+	}
+
+	mr.starts = make([]float64, n)
+	for position, i := range rank {
+		if n > 1 {
+			mr.starts[i] = float64(position) / float64(n-1) * (1 - overlap)
+		}
+	}
+	mr.scatter = nil
+	if mr.Scatter != 0 {
+		mr.scatter = make([]Vector3, n)
+		for i := range mr.scatter {
+			mr.scatter[i] = rng.unitVector()
+		}
+	}
+	return mr.starts, mr.scatter
+}
+
+// Frame 按当前进度生成要绘制的网格：只包含已经开始出现的三角形，正在出现的三角形带有飞入、放大和淡入效果
+func (mr *MeshReveal) Frame() *Mesh {
+	frame := NewMesh()
+	if mr.Mesh == nil || len(mr.Mesh.Triangles) == 0 || mr.Progress <= 0 {
+		return frame
+	}
+	starts, scatter := mr.schedule()
+	overlap := math.Max(0, math.Min(1, mr.Overlap))
+	source := mr.Mesh
+	transparent := mr.FadeIn || source.HasFaceAlpha()
+
+	for i, tri := range source.Triangles {
+		amount := 1.0
+		if mr.Progress < 1 {
+			switch {
+			case mr.Progress < starts[i]:
+				continue
+			case overlap > 0:
+				amount = EaseOut(math.Min(1, (mr.Progress-starts[i])/overlap))
+			}
+		}
+
+		if amount < 1 {
+			remaining := 1 - amount
+			offset := mr.FlyIn.Scale(remaining)
+			if scatter != nil {
+				offset = offset.Add(scatter[i].Scale(mr.Scatter * remaining))
+			}
+			center := tri.Center()
+			scale := 1.0
+			if mr.ScaleIn {
+				scale = amount
+			}
+			place := func(v Vector3) Vector3 {
+				return center.Add(v.Sub(center).Scale(scale)).Add(offset)
+			}
+			tri = Triangle{V0: place(tri.V0), V1: place(tri.V1), V2: place(tri.V2)}
+		}
+		frame.AddTriangle(tri)
+
+		if source.HasFaceColors() {
+			frame.FaceColors = append(frame.FaceColors, source.FaceColors[i])
+		}
+		if source.HasVertexColors() {
+			frame.VertexColors = append(frame.VertexColors, source.VertexColors[i])
+		}
+		if source.HasUVs() {
+			frame.UVs = append(frame.UVs, source.UVs[i])
+		}
+		if transparent {
+			alpha := 1.0
+			if source.HasFaceAlpha() {
+				alpha = source.FaceAlpha[i]
+			}
+			if mr.FadeIn {
+				alpha *= amount
+			}
+			frame.FaceAlpha = append(frame.FaceAlpha, alpha)
+		}
+	}
+
+	frame.Reflectivity = source.Reflectivity
+	frame.NormalMap = source.NormalMap
+	frame.BumpMap = source.BumpMap
+	frame.BumpScale = source.BumpScale
+	frame.Emissive = source.Emissive
+	frame.Additive = source.Additive
+	return frame
+}
+
+// Render 绘制当前进度的网格
+func (mr *MeshReveal) Render(renderer *Renderer, t float64) {
+	if frame := mr.Frame(); len(frame.Triangles) > 0 {
+		renderer.DrawMesh(frame, mr.Color)
+	}
+}
+
+// WorldBounds 完整网格的包围盒，按飞入偏移扩展（构建过程中取景保持不变）
+func (mr *MeshReveal) WorldBounds(t float64) AABB {
+	box := mr.Mesh.BoundingBox()
+	if mr.FlyIn.Length() > 0 || mr.Scatter != 0 {
+		shifted := AABB{Min: box.Min.Add(mr.FlyIn), Max: box.Max.Add(mr.FlyIn)}
+		box = box.union(shifted).grow(math.Abs(mr.Scatter))
+	}
+	return box
+}
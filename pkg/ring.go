@@ -0,0 +1,154 @@
+package go3d
+
+import "math"
+
+// RingGap 环面上的空隙（如土星环的卡西尼缝）
+type RingGap struct {
+	InnerRadius float64
+	OuterRadius float64
+}
+
+// RingDisc 位于 XY 平面、以原点为圆心的平面圆环（环形盘）
+// 沿半径方向划分为若干环带，每条环带可以有不同的颜色和不透明度
+type RingDisc struct {
+	InnerRadius float64
+	OuterRadius float64
+	Segments    int          // 圆周方向的分段数
+	Bands       int          // 半径方向的环带数
+	Colors      [][3]float64 // 从内到外的颜色，环带之间线性插值
+	InnerAlpha  float64      // 内缘不透明度
+	OuterAlpha  float64      // 外缘不透明度
+	Gaps        []RingGap
+}
+
+// NewRingDisc 创建平面圆环
+func NewRingDisc(innerRadius, outerRadius float64) *RingDisc {
+	return &RingDisc{
+		InnerRadius: innerRadius,
+		OuterRadius: outerRadius,
+		Segments:    64,
+		Bands:       8,
+		Colors:      [][3]float64{{0.8, 0.8, 0.8}},
+		InnerAlpha:  1.0,
+		OuterAlpha:  1.0,
+	}
+}
+
+// SetColors 设置从内到外的颜色
+func (rd *RingDisc) SetColors(colors [][3]float64) *RingDisc {
+	rd.Colors = colors
+	return rd
+}
+
+// SetAlphaGradient 设置从内缘到外缘的不透明度渐变
+func (rd *RingDisc) SetAlphaGradient(innerAlpha, outerAlpha float64) *RingDisc {
+	rd.InnerAlpha = innerAlpha
+	rd.OuterAlpha = outerAlpha
+	return rd
+}
+
+// AddGap 添加空隙
+func (rd *RingDisc) AddGap(innerRadius, outerRadius float64) *RingDisc {
+	rd.Gaps = append(rd.Gaps, RingGap{InnerRadius: innerRadius, OuterRadius: outerRadius})
+	return rd
+}
+
+// colorAt 按半径比例（0 为内缘，1 为外缘）插值颜色
+func (rd *RingDisc) colorAt(ratio float64) [3]float64 {
+	if len(rd.Colors) == 0 {
+		return [3]float64{1, 1, 1}
+	}
+	if len(rd.Colors) == 1 {
+		return rd.Colors[0]
+	}
+
+	scaled := ratio * float64(len(rd.Colors)-1)
+	i := int(math.Floor(scaled))
+	if i >= len(rd.Colors)-1 {
+		i = len(rd.Colors) - 2
+	}
+	local := scaled - float64(i)
+
+	a, b := rd.Colors[i], rd.Colors[i+1]
+	return [3]float64{
+		a[0] + (b[0]-a[0])*local,
+		a[1] + (b[1]-a[1])*local,
+		a[2] + (b[2]-a[2])*local,
+	}
+}
+
+// bandRanges 计算去掉空隙后的环带半径区间
+func (rd *RingDisc) bandRanges() [][2]float64 {
+	bands := max(rd.Bands, 1)
+	step := (rd.OuterRadius - rd.InnerRadius) / float64(bands)
+
+	var ranges [][2]float64
+	for i := range bands {
+		pieces := [][2]float64{{rd.InnerRadius + float64(i)*step, rd.InnerRadius + float64(i+1)*step}}
+		for _, gap := range rd.Gaps {
+			var kept [][2]float64
+			for _, p := range pieces {
+				if gap.OuterRadius <= p[0] || gap.InnerRadius >= p[1] {
+					kept = append(kept, p)
+					continue
+				}
+				if gap.InnerRadius > p[0] {
+					kept = append(kept, [2]float64{p[0], gap.InnerRadius})
+				}
+				if gap.OuterRadius < p[1] {
+					kept = append(kept, [2]float64{gap.OuterRadius, p[1]})
+				}
+			}
+			pieces = kept
+		}
+		ranges = append(ranges, pieces...)
+	}
+	return ranges
+}
+
+// Mesh 生成圆环网格，带逐面颜色和不透明度
+// 正反两面都会生成，从环面上方或下方观察都不会被背面剔除
+func (rd *RingDisc) Mesh() *Mesh {
+	mesh := NewMesh()
+	segments := max(rd.Segments, 3)
+	width := rd.OuterRadius - rd.InnerRadius
+
+	for _, band := range rd.bandRanges() {
+		ratio := 0.5
+		if width > 0 {
+			ratio = ((band[0]+band[1])/2 - rd.InnerRadius) / width
+		}
+		color := rd.colorAt(ratio)
+		alpha := rd.InnerAlpha + (rd.OuterAlpha-rd.InnerAlpha)*ratio
+
+		for j := range segments {
+			a0 := 2 * math.Pi * float64(j) / float64(segments)
+			a1 := 2 * math.Pi * float64(j+1) / float64(segments)
+
+			in0 := NewVector3(band[0]*math.Cos(a0), band[0]*math.Sin(a0), 0)
+			in1 := NewVector3(band[0]*math.Cos(a1), band[0]*math.Sin(a1), 0)
+			out0 := NewVector3(band[1]*math.Cos(a0), band[1]*math.Sin(a0), 0)
+			out1 := NewVector3(band[1]*math.Cos(a1), band[1]*math.Sin(a1), 0)
+
+			mesh.AddVertex(in0)
+			mesh.AddVertex(out0)
+
+			for _, tri := range []Triangle{
+				{V0: in0, V1: out0, V2: out1}, // 正面（+Z）
+				{V0: in0, V1: out1, V2: in1},
+				{V0: in0, V1: out1, V2: out0}, // 背面（-Z）
+				{V0: in0, V1: in1, V2: out1},
+			} {
+				mesh.AddTriangle(tri)
+				mesh.FaceColors = append(mesh.FaceColors, color)
+				mesh.FaceAlpha = append(mesh.FaceAlpha, alpha)
+			}
+		}
+	}
+	return mesh
+}
+
+// Render 渲染圆环（位于原点的 XY 平面，通常先用 Mesh 生成网格再变换）
+func (rd *RingDisc) Render(renderer *Renderer, t float64) {
+	renderer.DrawMesh(rd.Mesh(), rd.colorAt(0))
+}
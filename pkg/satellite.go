@@ -0,0 +1,194 @@
+package go3d
+
+import (
+	"math"
+	"time"
+)
+
+// Satellite 按 TLE 用 SGP4 模型外推位置的人造卫星，绘制在地球仪（Globe）周围。
+// 位置换算到随地球转动的坐标系，与地球仪的经纬度一致，因此星下点轨迹（GroundTrack）能直接贴在球面上
+type Satellite struct {
+	Name  string
+	TLE   TLE
+	Globe *Globe // 参照的地球，决定球心和半径（半径对应 6378.135 km）
+
+	Start     time.Time // 场景时间 t = 0 对应的时刻，默认为 TLE 历元
+	TimeScale float64   // 场景时间每单位对应的秒数，默认 60（1 秒动画 = 1 分钟）
+
+	Color [3]float64
+	Size  float64 // 卫星标记的半径（像素）
+	Label bool    // 是否在标记旁显示名称
+
+	OrbitColor  [3]float64
+	OrbitWidth  float64
+	OrbitLength float64 // 绘制的轨道长度（圈数），0 不绘制；1 为完整一圈，绘制当前时刻之前和之后各半圈
+
+	GroundTrack      bool // 是否绘制星下点轨迹
+	GroundTrackColor [3]float64
+	GroundTrackSpan  float64 // 星下点轨迹覆盖的时长（圈数，当前时刻之前）
+
+	propagator *sgp4Propagator
+}
+
+// NewSatellite 由 TLE 创建卫星，globe 为 nil 时以原点处半径为 1 的地球为参照
+func NewSatellite(tle TLE, globe *Globe) (*Satellite, error) {
+	propagator, err := newSGP4(tle)
+	if err != nil {
+		return nil, err
+	}
+	if globe == nil {
+		globe = NewGlobe(1)
+	}
+	return &Satellite{
+		Name:             tle.Name,
+		TLE:              tle,
+		Globe:            globe,
+		Start:            tle.Epoch,
+		TimeScale:        60,
+		Color:            [3]float64{1, 0.9, 0.3},
+		Size:             3,
+		OrbitColor:       [3]float64{1, 0.9, 0.3},
+		OrbitWidth:       1,
+		OrbitLength:      1,
+		GroundTrackColor: [3]float64{0.3, 1, 0.6},
+		GroundTrackSpan:  1,
+		propagator:       propagator,
+	}, nil
+}
+
+// SetStart 设置 t = 0 对应的时刻和时间比例（秒/单位时间）
+func (s *Satellite) SetStart(start time.Time, timeScale float64) *Satellite {
+	s.Start = start
+	s.TimeScale = timeScale
+	return s
+}
+
+// SetGroundTrack 设置是否绘制星下点轨迹及其颜色
+func (s *Satellite) SetGroundTrack(enabled bool, color [3]float64) *Satellite {
+	s.GroundTrack = enabled
+	s.GroundTrackColor = color
+	return s
+}
+
+// Time 场景时间 t 对应的时刻
+func (s *Satellite) Time(t float64) time.Time {
+	return s.Start.Add(time.Duration(t * s.TimeScale * float64(time.Second)))
+}
+
+// Period 轨道周期
+func (s *Satellite) Period() time.Duration {
+	return time.Duration(86400 / s.TLE.MeanMotion * float64(time.Second))
+}
+
+// PositionAt 时刻 date 在 TEME 惯性坐标系中的位置（km）和速度（km/s），Z 轴指向北极
+func (s *Satellite) PositionAt(date time.Time) (position, velocity Vector3, err error) {
+	return s.propagator.propagate(date.Sub(s.TLE.Epoch).Minutes())
+}
+
+// SubPoint 时刻 date 的星下点（按球形地球计算的地心纬度和经度）和高度（km）
+func (s *Satellite) SubPoint(date time.Time) (GeoCoord, float64, error) {
+	position, _, err := s.PositionAt(date)
+	if err != nil {
+		return GeoCoord{}, 0, err
+	}
+	fixed := rotateAboutZ(position, -GreenwichSiderealTime(date))
+	coord := GeoCoord{
+		Lat: math.Atan2(fixed.Z, math.Hypot(fixed.X, fixed.Y)) * 180 / math.Pi,
+		Lon: math.Atan2(fixed.Y, fixed.X) * 180 / math.Pi,
+	}
+	return coord, fixed.Length() - sgp4EarthRadius, nil
+}
+
+// GetPosition 场景时间 t 时卫星在场景中的位置，可交给 Trail 或相机跟随
+func (s *Satellite) GetPosition(t float64) Vector3 {
+	date := s.Time(t)
+	position, ok := s.scenePosition(date, GreenwichSiderealTime(date))
+	if !ok {
+		return s.Globe.Center
+	}
+	return position
+}
+
+// scenePosition 把时刻 date 的 TEME 位置按恒星时 gmst 转到地球固连坐标，再换算为场景坐标。
+// gmst 取绘制时刻的值时，整条轨道按同一地球朝向绘制，成为绕地球的闭合椭圆
+func (s *Satellite) scenePosition(date time.Time, gmst float64) (Vector3, bool) {
+	position, _, err := s.PositionAt(date)
+	if err != nil {
+		return Vector3{}, false
+	}
+	fixed := rotateAboutZ(position, -gmst).Scale(s.Globe.Radius / sgp4EarthRadius)
+	// 地球固连坐标（Z 指向北极，X 指向本初子午线）与 LatLonToVector3 的约定一致
+	return s.Globe.Center.Add(NewVector3(-fixed.X, fixed.Z, fixed.Y)), true
+}
+
+// rotateAboutZ 绕 Z 轴旋转 angle 弧度
+func rotateAboutZ(v Vector3, angle float64) Vector3 {
+	sin, cos := math.Sincos(angle)
+	return NewVector3(v.X*cos-v.Y*sin, v.X*sin+v.Y*cos, v.Z)
+}
+
+// Render 绘制轨道、星下点轨迹和卫星标记，被地球挡住的部分不绘制
+func (s *Satellite) Render(renderer *Renderer, t float64) {
+	date := s.Time(t)
+	gmst := GreenwichSiderealTime(date)
+	period := s.Period()
+
+	if s.OrbitLength > 0 {
+		span := time.Duration(s.OrbitLength * float64(period))
+		samples := max(16, int(s.OrbitLength*128))
+		points := make([]Vector3, 0, samples+1)
+		for i := range samples + 1 {
+			offset := time.Duration((float64(i)/float64(samples) - 0.5) * float64(span))
+			if p, ok := s.scenePosition(date.Add(offset), gmst); ok {
+				points = append(points, p)
+			}
+		}
+		s.Globe.drawVisible(renderer, points, s.OrbitColor, s.OrbitWidth)
+	}
+
+	if s.GroundTrack && s.GroundTrackSpan > 0 {
+		span := time.Duration(s.GroundTrackSpan * float64(period))
+		samples := max(16, int(s.GroundTrackSpan*256))
+		var coords []GeoCoord
+		for i := range samples + 1 {
+			coord, _, err := s.SubPoint(date.Add(-span + span*time.Duration(i)/time.Duration(samples)))
+			if err != nil {
+				continue
+			}
+			// 跨越日期变更线时断开，避免沿大圆绕回
+			if n := len(coords); n > 0 && math.Abs(coord.Lon-coords[n-1].Lon) > 180 {
+				s.Globe.drawPath(renderer, coords, s.GroundTrackColor, s.OrbitWidth)
+				coords = coords[:0]
+			}
+			coords = append(coords, coord)
+		}
+		s.Globe.drawPath(renderer, coords, s.GroundTrackColor, s.OrbitWidth)
+	}
+
+	position, ok := s.scenePosition(date, gmst)
+	if !ok || !s.Globe.visible(renderer.Camera.Position, position) {
+		return
+	}
+	renderer.DrawOverlay(func() {
+		x, y, z := renderer.ProjectToScreen(position)
+		if z < -1 || z > 1 {
+			return
+		}
+		renderer.Context.Save()
+		defer renderer.Context.Restore()
+		renderer.Context.SetSourceRGB(s.Color[0], s.Color[1], s.Color[2])
+		renderer.Context.NewSubPath()
+		renderer.Context.Arc(x, y, s.Size, 0, 2*math.Pi)
+		renderer.Context.Fill()
+		if s.Label && s.Name != "" {
+			showTextLeft(renderer, s.Name, x+s.Size+4, y, 11)
+		}
+	})
+}
+
+// WorldBounds 以地球为中心、半径为卫星远地点距离的范围
+func (s *Satellite) WorldBounds(t float64) AABB {
+	semiMajor := math.Pow(sgp4XKE/(s.TLE.MeanMotion*2*math.Pi/1440), 2.0/3)
+	apogee := semiMajor * (1 + s.TLE.Eccentricity)
+	return sphereBounds(s.Globe.Center, s.Globe.Radius*math.Max(1, apogee))
+}
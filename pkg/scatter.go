@@ -0,0 +1,245 @@
+package go3d
+
+import (
+	"math"
+	"sort"
+)
+
+// MarkerShape 散点图数据点的标记形状
+type MarkerShape int
+
+const (
+	MarkerSphere  MarkerShape = iota // 球（图例中为圆）
+	MarkerCube                       // 立方体（图例中为正方形）
+	MarkerDiamond                    // 八面体（图例中为菱形）
+	MarkerPyramid                    // 三棱锥（图例中为三角形）
+)
+
+// mesh 标记形状对应的共享单位网格，以及相对于标记半径的缩放倍数（使不同形状看起来大小相近）
+func (shape MarkerShape) mesh() (*Mesh, float64) {
+	switch shape {
+	case MarkerCube:
+		return unitPrimitive(primitiveCube, 0, 0, 0), 0.75
+	case MarkerDiamond:
+		return unitPrimitive(primitiveSphere, 4, 2, 0), 1.2
+	case MarkerPyramid:
+		return unitPrimitive(primitiveCone, 3, 0, 0), 1.4
+	}
+	return unitPrimitive(primitiveSphere, 12, 8, 0), 1
+}
+
+// ScatterSeries 散点图中的一组数据点，同一组的点使用相同的标记
+type ScatterSeries struct {
+	Name   string // 图例中的名称
+	Points []Vector3
+	Color  [3]float64
+	Marker MarkerShape
+	Size   float64 // 标记半径（世界单位）
+}
+
+// SetMarker 设置标记形状和半径
+func (s *ScatterSeries) SetMarker(shape MarkerShape, size float64) *ScatterSeries {
+	s.Marker = shape
+	s.Size = size
+	return s
+}
+
+// ScatterPlot3D 三维散点图：多组数据点以不同的标记绘制在坐标框中，画面左上角显示图例。
+// 添加数据时坐标框自动缩放到包含所有点的整刻度范围，超出坐标框的点不绘制
+type ScatterPlot3D struct {
+	Axes   *PlotAxes
+	Series []*ScatterSeries
+	Legend *PlotLegend // 图例，nil 表示不绘制
+}
+
+// NewScatterPlot3D 创建空的散点图
+func NewScatterPlot3D() *ScatterPlot3D {
+	return &ScatterPlot3D{
+		Axes:   NewPlotAxes(NewVector3(0, 0, 0), NewVector3(1, 1, 1)),
+		Legend: NewPlotLegend(),
+	}
+}
+
+// AddSeries 添加一组数据点并重新缩放坐标框；标记形状按添加顺序在球、立方体、八面体、三棱锥之间轮换，
+// 可以用 SetMarker 修改
+func (sp *ScatterPlot3D) AddSeries(name string, points []Vector3, color [3]float64) *ScatterSeries {
+	shape := MarkerShape(len(sp.Series) % 4)
+	series := &ScatterSeries{Name: name, Points: points, Color: color, Marker: shape, Size: 0.06}
+	sp.Series = append(sp.Series, series)
+	sp.Autoscale()
+	return series
+}
+
+// Autoscale 把坐标框的数据范围设为包含所有数据点的整刻度范围，修改数据点后调用
+func (sp *ScatterPlot3D) Autoscale() *ScatterPlot3D {
+	lo := NewVector3(math.Inf(1), math.Inf(1), math.Inf(1))
+	hi := NewVector3(math.Inf(-1), math.Inf(-1), math.Inf(-1))
+	for _, s := range sp.Series {
+		for _, p := range s.Points {
+			if !finite(p.X) || !finite(p.Y) || !finite(p.Z) {
+				continue
+			}
+			lo = NewVector3(math.Min(lo.X, p.X), math.Min(lo.Y, p.Y), math.Min(lo.Z, p.Z))
+			hi = NewVector3(math.Max(hi.X, p.X), math.Max(hi.Y, p.Y), math.Max(hi.Z, p.Z))
+		}
+	}
+	if lo.X > hi.X {
+		return sp // 没有有效的数据点
+	}
+	sp.Axes.Fit(lo, hi)
+	return sp
+}
+
+// inside 数据点是否在坐标框内
+func (sp *ScatterPlot3D) inside(p Vector3) bool {
+	for i := range 3 {
+		if n := sp.Axes.normalize(i, component(p, i)); !(n >= -0.5-1e-9 && n <= 0.5+1e-9) {
+			return false
+		}
+	}
+	return true
+}
+
+// Render 依次绘制网格线、数据点、坐标轴和图例；数据点按与相机的距离从远到近绘制，标记之间正确遮挡
+func (sp *ScatterPlot3D) Render(renderer *Renderer, t float64) {
+	sp.Axes.renderBack(renderer)
+
+	type marker struct {
+		center   Vector3
+		series   *ScatterSeries
+		distance float64
+	}
+	var markers []marker
+	for _, s := range sp.Series {
+		for _, p := range s.Points {
+			if !sp.inside(p) {
+				continue
+			}
+			center := sp.Axes.ToWorld(p)
+			markers = append(markers, marker{center, s, center.Sub(renderer.Camera.Position).Length()})
+		}
+	}
+	sort.SliceStable(markers, func(i, j int) bool {
+		return markers[i].distance > markers[j].distance
+	})
+	for _, m := range markers {
+		mesh, scale := m.series.Marker.mesh()
+		size := m.series.Size * scale
+		model := Translation(m.center.X, m.center.Y, m.center.Z).Multiply(Scale(size, size, size))
+		renderer.DrawMeshTransformed(mesh, model, m.series.Color)
+	}
+
+	sp.Axes.renderFront(renderer)
+	if sp.Legend != nil {
+		sp.Legend.Entries = sp.Legend.Entries[:0]
+		for _, s := range sp.Series {
+			if s.Name != "" {
+				sp.Legend.Entries = append(sp.Legend.Entries, LegendEntry{s.Name, s.Color, s.Marker})
+			}
+		}
+		sp.Legend.Render(renderer, t)
+	}
+}
+
+// WorldBounds 坐标框的范围
+func (sp *ScatterPlot3D) WorldBounds(t float64) AABB {
+	return sp.Axes.WorldBounds(t)
+}
+
+// LegendEntry 图例中的一项
+type LegendEntry struct {
+	Label  string
+	Color  [3]float64
+	Marker MarkerShape
+}
+
+// PlotLegend 画面左上角的图例：每项一行，标记符号后跟名称，绘制在半透明背景框中。
+// 作为场景对象添加时固定在屏幕上，不随相机移动
+type PlotLegend struct {
+	Entries []LegendEntry
+
+	Margin          float64 // 与画面左、上边缘的距离（像素）
+	FontSize        float64
+	TextColor       [3]float64
+	BackgroundColor [3]float64
+	BackgroundAlpha float64
+}
+
+// NewPlotLegend 创建空的图例
+func NewPlotLegend() *PlotLegend {
+	return &PlotLegend{
+		Margin:          16,
+		FontSize:        12,
+		TextColor:       [3]float64{0.9, 0.9, 0.9},
+		BackgroundColor: [3]float64{0, 0, 0},
+		BackgroundAlpha: 0.5,
+	}
+}
+
+// Render 在几何体之后绘制图例，没有条目时不绘制
+func (l *PlotLegend) Render(renderer *Renderer, t float64) {
+	if len(l.Entries) == 0 {
+		return
+	}
+	// 条目在绘制时才读取，复制一份使批量绘制推迟期间的修改不影响本帧
+	entries := append([]LegendEntry(nil), l.Entries...)
+	renderer.DrawOverlay(func() {
+		l.draw(renderer, entries)
+	})
+}
+
+// draw 先测量最宽的名称确定背景框大小，再逐行绘制标记符号和名称
+func (l *PlotLegend) draw(r *Renderer, entries []LegendEntry) {
+	row := l.FontSize * 1.6
+	symbol := l.FontSize * 0.45 // 标记符号的半径
+	pad := l.FontSize * 0.6
+	textWidth := 0.0
+	for _, e := range entries {
+		if layout, ok := newTextLayout(r.Context, e.Label, false, l.FontSize); ok {
+			textWidth = math.Max(textWidth, layout.inkExtents().Width)
+			layout.destroy()
+		}
+	}
+	width := pad*3 + symbol*2 + textWidth
+	height := pad*2 + row*float64(len(entries)-1) + l.FontSize
+
+	r.Context.Save()
+	defer r.Context.Restore()
+	r.Context.SetSourceRGBA(l.BackgroundColor[0], l.BackgroundColor[1], l.BackgroundColor[2], l.BackgroundAlpha)
+	r.Context.Rectangle(l.Margin, l.Margin, width, height)
+	r.Context.Fill()
+
+	for i, e := range entries {
+		cx := l.Margin + pad + symbol
+		cy := l.Margin + pad + l.FontSize/2 + row*float64(i)
+		r.Context.SetSourceRGB(e.Color[0], e.Color[1], e.Color[2])
+		traceMarkerSymbol(r.Context, e.Marker, cx, cy, symbol)
+		r.Context.Fill()
+
+		r.Context.SetSourceRGB(l.TextColor[0], l.TextColor[1], l.TextColor[2])
+		showTextLeft(r, e.Label, cx+symbol+pad, cy, l.FontSize)
+	}
+}
+
+// traceMarkerSymbol 把标记形状对应的平面符号加入当前路径，中心在 (x, y)，外接圆半径为 radius
+func traceMarkerSymbol(context Context, shape MarkerShape, x, y, radius float64) {
+	switch shape {
+	case MarkerCube:
+		s := radius * 0.8
+		context.Rectangle(x-s, y-s, 2*s, 2*s)
+	case MarkerDiamond:
+		context.MoveTo(x, y-radius)
+		context.LineTo(x+radius, y)
+		context.LineTo(x, y+radius)
+		context.LineTo(x-radius, y)
+		context.ClosePath()
+	case MarkerPyramid:
+		context.MoveTo(x, y-radius)
+		context.LineTo(x+radius*0.87, y+radius*0.5)
+		context.LineTo(x-radius*0.87, y+radius*0.5)
+		context.ClosePath()
+	default:
+		context.NewSubPath()
+		context.Arc(x, y, radius, 0, 2*math.Pi)
+	}
+}
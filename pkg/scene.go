@@ -1,10 +1,6 @@
 package go3d
 
-import (
-	"math"
-
-	"github.com/novvoo/go-cairo/pkg/cairo"
-)
+import "math"
 
 // SceneObject 场景对象接口
 type SceneObject interface {
@@ -16,6 +12,7 @@ type Scene struct {
 	Objects    []SceneObject
 	Lights     []*Light
 	Background BackgroundRenderer
+	Timeline   *Timeline // 属性动画时间线
 }
 
 // NewScene 创建场景
@@ -41,20 +38,56 @@ func (s *Scene) SetBackground(bg BackgroundRenderer) {
 	s.Background = bg
 }
 
+// SetTimeline 设置属性动画时间线
+func (s *Scene) SetTimeline(timeline *Timeline) {
+	s.Timeline = timeline
+}
+
 // Render 渲染整个场景
 func (s *Scene) Render(renderer *Renderer, t float64) {
-	// 设置光源
+	// 先对时间线求值，更新所有被动画的属性
+	if s.Timeline != nil {
+		s.Timeline.Evaluate(t)
+	}
+
+	// 相机无效时不绘制退化的画面，错误由 Renderer.CameraError 报告
+	if err := renderer.checkCamera(); err != nil {
+		return
+	}
+
+	// 设置光源和阴影遮挡体
 	renderer.Lights = s.Lights
+	renderer.SetOccluders(collectOccluders(s.Objects, t))
 
-	// 渲染背景
-	if s.Background != nil {
+	// 渲染背景（物体编号模式下背景保持编号 0）
+	if s.Background != nil && renderer.RenderMode != RenderObjectID {
 		s.Background.Render(renderer, t)
 	}
 
-	// 渲染所有对象
-	for _, obj := range s.Objects {
+	// 标签在所有内容之后统一布局（延迟调用后进先出，因此先登记）
+	if renderer.LabelLayout {
+		renderer.BeginLabels()
+		defer renderer.FlushLabels()
+	}
+
+	// BSP 排序模式下统一批量绘制所有对象，正确处理跨网格的遮挡；
+	// 光线追踪模式下统一追踪，物体之间的阴影和反射才能正确计算
+	if renderer.SortMode == SortBSP || renderer.raytracing() {
+		renderer.BeginBatch()
+		defer renderer.FlushBatch()
+	}
+
+	// 渲染所有对象，物体编号从 1 开始，用于物体编号通道
+	for i, obj := range s.Objects {
+		renderer.SetObjectID(i + 1)
 		obj.Render(renderer, t)
 	}
+	renderer.SetObjectID(0)
+	renderer.ResolveGBuffer()
+
+	// 调试标记（光源、辅助相机视锥）
+	renderer.drawSceneDebug()
+	renderer.drawAxisGizmo()
 }
 
 // BackgroundRenderer 背景渲染器接口
@@ -140,6 +173,17 @@ func (sb *SolidBackground) Render(renderer *Renderer, t float64) {
 	renderer.Context.Restore()
 }
 
+// LabelAnchor 标签相对于投影点的位置
+type LabelAnchor int
+
+const (
+	LabelAnchorAbove  LabelAnchor = iota // 投影点正上方（默认）
+	LabelAnchorBelow                     // 投影点正下方
+	LabelAnchorLeft                      // 投影点左侧
+	LabelAnchorRight                     // 投影点右侧
+	LabelAnchorCenter                    // 以投影点为中心
+)
+
 // Label3D 3D 标签
 type Label3D struct {
 	Position Vector3
@@ -147,73 +191,209 @@ type Label3D struct {
 	Color    [3]float64
 	FontSize float64
 	Bold     bool
+
+	Anchor LabelAnchor // 标签相对于投影点的位置
+	Offset float64     // 标签与投影点之间的间距（像素）
+
+	Background      bool       // 是否绘制圆角背景框
+	BackgroundColor [3]float64 // 背景框颜色
+	BackgroundAlpha float64    // 背景框不透明度
+	Padding         float64    // 背景框内边距（像素）
+	CornerRadius    float64    // 背景框圆角半径（像素），超过高度一半时为胶囊形
+
+	OutlineWidth float64    // 文字描边宽度（像素），0 表示不描边
+	OutlineColor [3]float64 // 文字描边颜色
 }
 
 // NewLabel3D 创建 3D 标签
 func NewLabel3D(position Vector3, text string, color [3]float64) *Label3D {
 	return &Label3D{
-		Position: position,
-		Text:     text,
-		Color:    color,
-		FontSize: 20.0,
-		Bold:     true,
+		Position:        position,
+		Text:            text,
+		Color:           color,
+		FontSize:        20.0,
+		Bold:            true,
+		BackgroundColor: [3]float64{0, 0, 0},
+		BackgroundAlpha: 0.6,
+		Padding:         4.0,
+		CornerRadius:    100.0,
+		OutlineColor:    [3]float64{0, 0, 0},
+	}
+}
+
+// SetAnchor 设置标签相对于投影点的位置和间距
+func (l *Label3D) SetAnchor(anchor LabelAnchor, offset float64) *Label3D {
+	l.Anchor = anchor
+	l.Offset = offset
+	return l
+}
+
+// SetBackground 设置背景框颜色和不透明度
+func (l *Label3D) SetBackground(color [3]float64, alpha float64) *Label3D {
+	l.Background = true
+	l.BackgroundColor = color
+	l.BackgroundAlpha = alpha
+	return l
+}
+
+// SetOutline 设置文字描边，便于在明亮的几何体上辨认
+func (l *Label3D) SetOutline(color [3]float64, width float64) *Label3D {
+	l.OutlineColor = color
+	l.OutlineWidth = width
+	return l
+}
+
+// padding 背景框实际使用的内边距
+func (l *Label3D) padding() float64 {
+	if !l.Background {
+		return 0
 	}
+	return l.Padding
 }
 
-// Render 渲染标签
+// Render 渲染标签（批量绘制期间推迟到几何体之后，保证标签在最上层）
+// 启用标签布局时只登记标签，由 FlushLabels 统一避让后绘制
 func (l *Label3D) Render(renderer *Renderer, t float64) {
+	if renderer.collectLabel(l) {
+		return
+	}
+	renderer.DrawOverlay(func() {
+		l.draw(renderer)
+	})
+}
+
+// draw 在默认位置绘制标签
+func (l *Label3D) draw(renderer *Renderer) {
+	if placement, ok := l.measure(renderer); ok {
+		l.drawAt(renderer, placement)
+	}
+}
+
+// measure 投影标签并测量文字尺寸，得到默认位置（锚点正上方居中）
+// 标签不在视野内时返回 false
+func (l *Label3D) measure(renderer *Renderer) (labelPlacement, bool) {
 	x, y, z := renderer.ProjectToScreen(l.Position)
 
 	// 只绘制在视野内的标签
-	if z > -1 && z < 1 {
-		renderer.Context.Save()
-		defer renderer.Context.Restore()
-
-		// 根据深度调整大小，但保持完全不透明
-		depth := (z + 1) / 2
-		fontSize := l.FontSize * (1.0 - depth*0.3)
-
-		// 创建 Pango 布局用于文字渲染
-		layout := renderer.Context.PangoCairoCreateLayout()
-		defer func() {
-			// 确保布局资源被释放
-			if pangoLayout, ok := layout.(*cairo.PangoCairoLayout); ok {
-				pangoLayout.Destroy()
-			}
-		}()
-
-		if pangoLayout, ok := layout.(*cairo.PangoCairoLayout); ok {
-			fontDesc := cairo.NewPangoFontDescription()
-
-			fontDesc.SetFamily("sans-serif")
-			if l.Bold {
-				fontDesc.SetWeight(700)
-			}
-			fontDesc.SetSize(fontSize)
-
-			pangoLayout.SetFontDescription(fontDesc)
-			pangoLayout.SetText(l.Text)
-
-			extents := pangoLayout.GetPixelExtents()
-			textWidth := float64(extents.Width)
-			textHeight := float64(extents.Height)
-
-			textX := x - textWidth/2
-			textY := y - textHeight
-
-			// 使用完全不透明的颜色，alpha = 1.0
-			renderer.Context.SetSourceRGBA(l.Color[0], l.Color[1], l.Color[2], 1.0)
-			renderer.Context.MoveTo(textX, textY)
-			renderer.Context.PangoCairoShowText(layout)
+	if z <= -1 || z >= 1 {
+		return labelPlacement{}, false
+	}
+
+	// 根据深度调整大小，但保持完全不透明
+	depth := (z + 1) / 2
+	fontSize := l.FontSize * (1.0 - depth*0.3)
+
+	// 排版文字以测量尺寸
+	layout, ok := newTextLayout(renderer.Context, l.Text, l.Bold, fontSize)
+	if !ok {
+		return labelPlacement{}, false
+	}
+	defer layout.destroy()
+
+	extents := layout.inkExtents()
+	pad := l.padding()
+	width := extents.Width + 2*pad
+	height := extents.Height + 2*pad
+
+	// 按锚点放置标签框
+	var box labelRect
+	switch l.Anchor {
+	case LabelAnchorBelow:
+		box = labelRect{X: x - width/2, Y: y + l.Offset}
+	case LabelAnchorLeft:
+		box = labelRect{X: x - width - l.Offset, Y: y - height/2}
+	case LabelAnchorRight:
+		box = labelRect{X: x + l.Offset, Y: y - height/2}
+	case LabelAnchorCenter:
+		box = labelRect{X: x - width/2, Y: y - height/2}
+	default:
+		box = labelRect{X: x - width/2, Y: y - height - l.Offset}
+	}
+	box.Width = width
+	box.Height = height
+
+	return labelPlacement{
+		label:    l,
+		anchorX:  x,
+		anchorY:  y,
+		depth:    z,
+		fontSize: fontSize,
+		bearingX: extents.X,
+		bearingY: extents.Y,
+		box:      box,
+	}, true
+}
+
+// drawAt 按布局结果绘制标签文字
+func (l *Label3D) drawAt(renderer *Renderer, placement labelPlacement) {
+	renderer.Context.Save()
+	defer renderer.Context.Restore()
+
+	if l.Background {
+		l.drawBackground(renderer, placement.box)
+	}
+
+	layout, ok := newTextLayout(renderer.Context, l.Text, l.Bold, placement.fontSize)
+	if !ok {
+		return
+	}
+	defer layout.destroy()
+
+	// 文字从排版原点开始绘制，减去墨迹偏移使文字落在标签框内
+	pad := l.padding()
+	textX := placement.box.X + pad - placement.bearingX
+	textY := placement.box.Y + pad - placement.bearingY
+
+	// 描边：在文字四周八个方向以描边颜色绘制，形成光晕
+	if l.OutlineWidth > 0 {
+		renderer.Context.SetSourceRGBA(l.OutlineColor[0], l.OutlineColor[1], l.OutlineColor[2], 1.0)
+		for i := range 8 {
+			angle := float64(i) * math.Pi / 4
+			layout.show(textX+math.Cos(angle)*l.OutlineWidth, textY+math.Sin(angle)*l.OutlineWidth)
 		}
 	}
+
+	// 使用完全不透明的颜色，alpha = 1.0
+	renderer.Context.SetSourceRGBA(l.Color[0], l.Color[1], l.Color[2], 1.0)
+	layout.show(textX, textY)
+}
+
+// drawBackground 绘制圆角背景框
+func (l *Label3D) drawBackground(renderer *Renderer, box labelRect) {
+	radius := math.Max(0, math.Min(l.CornerRadius, math.Min(box.Width, box.Height)/2))
+	left, top := box.X, box.Y
+	right, bottom := box.X+box.Width, box.Y+box.Height
+
+	renderer.Context.NewSubPath()
+	renderer.Context.Arc(right-radius, top+radius, radius, -math.Pi/2, 0)
+	renderer.Context.Arc(right-radius, bottom-radius, radius, 0, math.Pi/2)
+	renderer.Context.Arc(left+radius, bottom-radius, radius, math.Pi/2, math.Pi)
+	renderer.Context.Arc(left+radius, top+radius, radius, math.Pi, 3*math.Pi/2)
+	renderer.Context.ClosePath()
+
+	renderer.Context.SetSourceRGBA(l.BackgroundColor[0], l.BackgroundColor[1], l.BackgroundColor[2], l.BackgroundAlpha)
+	renderer.Context.Fill()
+}
+
+// WorldBounds 标签锚点（文字本身以像素为单位，不计入范围）
+func (l *Label3D) WorldBounds(t float64) AABB {
+	return AABB{Min: l.Position, Max: l.Position}
 }
 
 // CoordinateSystem 坐标系统
+// 默认只绘制三条正半轴；打开负半轴、刻度和网格后可以作为数据图表的坐标框（见 axes.go）
 type CoordinateSystem struct {
 	Length     float64
 	Thickness  float64
 	ShowLabels bool
+
+	Negative     bool       // 同时绘制负半轴（颜色较暗，没有箭头）
+	HideAxis     [3]bool    // 按 X、Y、Z 的顺序隐藏单条坐标轴（连同刻度）
+	TickInterval float64    // 刻度间隔，0 表示不绘制刻度
+	TickLabels   bool       // 是否在刻度旁标注数值
+	Grid         GridPlane  // 绘制网格的坐标平面，GridNone 表示不绘制
+	GridInterval float64    // 网格间隔，0 表示与刻度间隔相同（刻度间隔也为 0 时为轴长的 1/10）
+	GridColor    [3]float64 // 网格线颜色
 }
 
 // NewCoordinateSystem 创建坐标系统
@@ -222,22 +402,36 @@ func NewCoordinateSystem(length float64) *CoordinateSystem {
 		Length:     length,
 		Thickness:  0.03,
 		ShowLabels: true,
+		GridColor:  [3]float64{0.35, 0.35, 0.4},
 	}
 }
 
-// Render 渲染坐标系统
-func (cs *CoordinateSystem) Render(renderer *Renderer, t float64) {
-	// X轴 - 红色
-	cs.drawAxis(renderer, NewVector3(0, 0, 0), NewVector3(cs.Length, 0, 0),
-		[3]float64{1.0, 0.3, 0.3}, "X")
+// axisColors X、Y、Z 轴的颜色
+var axisColors = [3][3]float64{{1.0, 0.3, 0.3}, {0.3, 1.0, 0.3}, {0.3, 0.3, 1.0}}
 
-	// Y轴 - 绿色
-	cs.drawAxis(renderer, NewVector3(0, 0, 0), NewVector3(0, cs.Length, 0),
-		[3]float64{0.3, 1.0, 0.3}, "Y")
+// Render 渲染坐标系统：先画网格，再画各坐标轴及其刻度
+func (cs *CoordinateSystem) Render(renderer *Renderer, t float64) {
+	cs.drawGrid(renderer)
+	for i, label := range [3]string{"X", "Y", "Z"} {
+		if cs.HideAxis[i] {
+			continue
+		}
+		end := axisDirection(i).Scale(cs.Length)
+		if cs.Negative {
+			cs.drawNegativeAxis(renderer, end.Scale(-1), axisColors[i])
+		}
+		cs.drawAxis(renderer, NewVector3(0, 0, 0), end, axisColors[i], label)
+		cs.drawTicks(renderer, i)
+	}
+}
 
-	// Z轴 - 蓝色
-	cs.drawAxis(renderer, NewVector3(0, 0, 0), NewVector3(0, 0, cs.Length),
-		[3]float64{0.3, 0.3, 1.0}, "Z")
+// WorldBounds 三条坐标轴（含箭头和标签位置）的包围盒，包括负半轴和网格
+func (cs *CoordinateSystem) WorldBounds(t float64) AABB {
+	box := AABB{Max: NewVector3(cs.Length, cs.Length, cs.Length).Scale(1.15)}
+	if cs.Negative {
+		box.Min = box.Max.Scale(-1 / 1.15)
+	}
+	return box.grow(cs.Thickness * 4)
 }
 
 // drawAxis 绘制单个坐标轴
@@ -245,36 +439,30 @@ func (cs *CoordinateSystem) drawAxis(renderer *Renderer, start, end Vector3, col
 	direction := end.Sub(start)
 	length := direction.Length()
 
-	// 绘制轴线（圆柱体）
-	cylinder := CreateCylinder(cs.Thickness, length, 8)
+	// 绘制轴线（单位圆柱体缩放到轴的粗细和长度）
+	cylinder := unitPrimitive(primitiveCylinder, 8, 0, 0)
 
 	up := NewVector3(0, 1, 0)
 	axis := up.Cross(direction.Normalize())
 	angle := math.Acos(up.Dot(direction.Normalize()))
 
-	transform := Identity()
-	transform = transform.Multiply(Translation(
-		(start.X+end.X)/2,
-		(start.Y+end.Y)/2,
-		(start.Z+end.Z)/2,
-	))
-
+	rotation := IdentityQuaternion()
 	if axis.Length() > 0.001 {
-		transform = transform.Multiply(RotationFromAxisAngle(axis.Normalize(), angle))
+		rotation = QuaternionFromAxisAngle(axis, angle)
 	}
 
-	transformedCylinder := cylinder.Transform(transform)
-	renderer.DrawMesh(transformedCylinder, color)
+	transform := NewTransform().
+		SetPosition(start.Add(end).Scale(0.5)).
+		SetRotation(rotation)
+	renderer.DrawMeshTransformed(cylinder, transform.Matrix().Multiply(Scale(cs.Thickness, length, cs.Thickness)), color)
 
 	// 绘制箭头
-	cone := CreateCone(cs.Thickness*4, cs.Length*0.05, 8)
-	coneTransform := Identity()
-	coneTransform = coneTransform.Multiply(Translation(end.X, end.Y, end.Z))
-	if axis.Length() > 0.001 {
-		coneTransform = coneTransform.Multiply(RotationFromAxisAngle(axis.Normalize(), angle))
-	}
-	transformedCone := cone.Transform(coneTransform)
-	renderer.DrawMesh(transformedCone, color)
+	cone := unitPrimitive(primitiveCone, 8, 0, 0)
+	coneTransform := NewTransform().
+		SetPosition(end).
+		SetRotation(rotation)
+	coneRadius := cs.Thickness * 4
+	renderer.DrawMeshTransformed(cone, coneTransform.Matrix().Multiply(Scale(coneRadius, cs.Length*0.05, coneRadius)), color)
 
 	// 绘制标签
 	if cs.ShowLabels {
@@ -0,0 +1,596 @@
+package go3d
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// SceneFile 场景描述文件（JSON）：不写 Go 代码即可描述渲染模式、相机、光源、背景和场景对象，
+// 供命令行工具和渲染服务使用。颜色为 0-1 的 RGB，角度以度为单位
+//
+//	{
+//	  "renderMode": "shaded",
+//	  "background": {"type": "gradient", "color": [0.08, 0.09, 0.12], "bottomColor": [0.15, 0.16, 0.2]},
+//	  "camera": {"fov": 43, "path": {"type": "orbit", "radius": 20, "height": 6, "turns": 1}},
+//	  "lights": [{"position": [5, 5, 5], "color": [1, 1, 1], "intensity": 0.8}],
+//	  "objects": [
+//	    {"type": "solarSystem", "trails": 0.5},
+//	    {"type": "torus", "size": 2, "position": [0, 4, 0], "color": [0.9, 0.6, 0.2], "spin": [0, 360, 0]}
+//	  ]
+//	}
+type SceneFile struct {
+	RenderMode string          `json:"renderMode,omitempty"` // 渲染模式名称（见 ParseRenderMode），默认 shaded
+	Background *BackgroundSpec `json:"background,omitempty"`
+	Camera     CameraSpec      `json:"camera"`
+	Lights     []LightSpec     `json:"lights,omitempty"`
+	Objects    []ObjectSpec    `json:"objects"`
+	TimeScale  float64         `json:"timeScale,omitempty"` // 场景时间 = 动画时间（0-1）× TimeScale，0 表示 1
+
+	// Script 每帧运行的场景脚本文件（Lua，需要 lua 构建标签，见 script_lua.go），
+	// 可以在描述的基础上添加对象和光源、设置相机、渲染模式和背景；相对路径相对于场景文件所在目录
+	Script string `json:"script,omitempty"`
+
+	script sceneScript // 编译后的场景脚本
+}
+
+// sceneScript 场景脚本：按帧号和时间修改该帧的场景描述（副本）
+type sceneScript func(frame *SceneFile, index int, t float64) error
+
+// errScriptUnsupported 没有使用 lua 构建标签编译时，场景描述中的脚本无法运行
+var errScriptUnsupported = errors.New("场景脚本需要使用 lua 构建标签编译（go build -tags lua）")
+
+// compileSceneScript 编译场景脚本文件，使用 lua 构建标签时由 script_lua.go 设置
+var compileSceneScript func(filename string) (sceneScript, error)
+
+// BackgroundSpec 背景描述
+type BackgroundSpec struct {
+	Type        string     `json:"type"`                  // solid、gradient、image
+	Color       [3]float64 `json:"color"`                 // 纯色背景的颜色，渐变背景的顶部颜色
+	BottomColor [3]float64 `json:"bottomColor,omitempty"` // 渐变背景的底部颜色
+	Image       string     `json:"image,omitempty"`       // 图像背景的文件路径
+	Fit         string     `json:"fit,omitempty"`         // 图像缩放方式：stretch、cover（默认）、contain
+}
+
+// CameraSpec 相机描述：固定的位置和目标，或随时间运动的相机路径
+type CameraSpec struct {
+	Position [3]float64      `json:"position"`
+	Target   [3]float64      `json:"target"`
+	FOV      float64         `json:"fov,omitempty"` // 垂直视场角（度），0 表示 43
+	Path     *CameraPathSpec `json:"path,omitempty"`
+}
+
+// CameraPathSpec 相机路径描述
+type CameraPathSpec struct {
+	Type string `json:"type"` // orbit（环绕）或 keyframes（关键帧插值）
+
+	// orbit：在高度 Height 处绕 Center 以半径 Radius 环绕 Turns 圈
+	Center [3]float64 `json:"center,omitempty"`
+	Radius float64    `json:"radius,omitempty"`
+	Height float64    `json:"height,omitempty"`
+	Turns  float64    `json:"turns,omitempty"`
+
+	// keyframes：按时间（0-1）插值，Interpolation 为 smooth（默认，每段两端缓入缓出）或 linear（匀速，适合密集采样的录制路径）
+	Keyframes     []CameraKeyframeSpec `json:"keyframes,omitempty"`
+	Interpolation string               `json:"interpolation,omitempty"`
+}
+
+// CameraKeyframeSpec 相机关键帧描述
+type CameraKeyframeSpec struct {
+	Time     float64     `json:"time"`
+	Position [3]float64  `json:"position"`
+	Target   [3]float64  `json:"target"`
+	FOV      float64     `json:"fov,omitempty"` // 度，0 表示使用相机的 fov
+	Up       *[3]float64 `json:"up,omitempty"`  // 相机上方向（滚转），省略时不修改
+}
+
+// LightSpec 光源描述
+type LightSpec struct {
+	Position    [3]float64  `json:"position"`
+	Color       [3]float64  `json:"color"`
+	Intensity   float64     `json:"intensity"`
+	Kelvin      float64     `json:"kelvin,omitempty"`      // 色温（K），设置后代替 color
+	Attenuation *[3]float64 `json:"attenuation,omitempty"` // 距离衰减系数（常数、一次、二次）
+	Range       float64     `json:"range,omitempty"`       // 作用范围，0 表示不限
+	Layers      uint32      `json:"layers,omitempty"`      // 照亮的光照层（位掩码），0 表示默认层
+	Disabled    bool        `json:"disabled,omitempty"`
+}
+
+// ObjectSpec 场景对象描述，Type 决定使用哪些字段：
+//
+//	solarSystem        Trails（轨迹长度，0 表示不绘制）、DwarfPlanets、Comet
+//	coordinateSystem   Size（轴长）
+//	stars              Count、Distance
+//	label              Text、Position、Color、FontSize
+//	ground             Position（Y 为地面高度）、Color；地面先于其它对象绘制，应放在对象列表最前面
+//	sphere、cube、cylinder、cone、torus、torusKnot、plane、text
+//	                   Size（半径或边长）、Text（text 类型）、Position、Rotation、Scale、Color、Spin、
+//	                   Emissive、Additive
+type ObjectSpec struct {
+	Type string `json:"type"`
+
+	Position [3]float64 `json:"position,omitempty"`
+	Rotation [3]float64 `json:"rotation,omitempty"` // 绕 X、Y、Z 轴的旋转（度）
+	Scale    float64    `json:"scale,omitempty"`    // 统一缩放，0 表示 1
+	Spin     [3]float64 `json:"spin,omitempty"`     // 每单位场景时间绕 X、Y、Z 轴旋转的角度（度）
+	Color    [3]float64 `json:"color,omitempty"`
+	Size     float64    `json:"size,omitempty"`
+	Text     string     `json:"text,omitempty"`
+	FontSize float64    `json:"fontSize,omitempty"`
+	Emissive [3]float64 `json:"emissive,omitempty"` // 自发光颜色（见 Mesh.Emissive）
+	Additive bool       `json:"additive,omitempty"` // 以加法合成绘制（见 Mesh.Additive）
+
+	Trails       float64 `json:"trails,omitempty"`
+	DwarfPlanets bool    `json:"dwarfPlanets,omitempty"`
+	Comet        bool    `json:"comet,omitempty"`
+
+	Count    int     `json:"count,omitempty"`
+	Distance float64 `json:"distance,omitempty"`
+
+	Layers uint32 `json:"layers,omitempty"` // 所在的光照层（位掩码），0 表示默认层
+}
+
+// renderModeNames 渲染模式名称
+var renderModeNames = map[string]RenderMode{
+	"wireframe":  RenderWireframe,
+	"flat":       RenderFlat,
+	"shaded":     RenderShaded,
+	"gouraud":    RenderGouraud,
+	"toon":       RenderToon,
+	"raytraced":  RenderRaytraced,
+	"pathtraced": RenderPathTraced,
+	"objectid":   RenderObjectID,
+}
+
+// ParseRenderMode 按名称（wireframe、flat、shaded、gouraud、toon、raytraced、pathtraced、objectid，
+// 不区分大小写）查找渲染模式
+func ParseRenderMode(name string) (RenderMode, error) {
+	mode, ok := renderModeNames[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("未知的渲染模式 %q（可选: %s）", name, strings.Join(sortedKeys(renderModeNames), ", "))
+	}
+	return mode, nil
+}
+
+// sortedKeys 按字母顺序返回映射的键，用于错误信息
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// LoadSceneFile 读取并校验场景描述文件
+func LoadSceneFile(filename string) (*SceneFile, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("读取场景文件失败: %w", err)
+	}
+	sf, err := ParseSceneFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	if sf.Script != "" && !filepath.IsAbs(sf.Script) {
+		sf.Script = filepath.Join(filepath.Dir(filename), sf.Script)
+	}
+	// 提前编译脚本，尽早报告语法错误
+	if _, err := sf.loadScript(); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return sf, nil
+}
+
+// ParseSceneFile 解析并校验 JSON 场景描述，未知字段视为错误（通常是拼写错误）
+// 场景脚本在第一次创建帧渲染函数时才读取和编译
+func ParseSceneFile(data []byte) (*SceneFile, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var sf SceneFile
+	if err := decoder.Decode(&sf); err != nil {
+		return nil, fmt.Errorf("解析场景描述失败: %w", err)
+	}
+	if err := sf.Validate(); err != nil {
+		return nil, err
+	}
+	return &sf, nil
+}
+
+// Validate 检查渲染模式、背景、相机路径和对象类型是否有效
+func (sf *SceneFile) Validate() error {
+	if sf.Script != "" && compileSceneScript == nil {
+		return errScriptUnsupported
+	}
+	if sf.RenderMode != "" {
+		if _, err := ParseRenderMode(sf.RenderMode); err != nil {
+			return err
+		}
+	}
+	if bg := sf.Background; bg != nil {
+		switch bg.Type {
+		case "solid", "gradient":
+		case "image":
+			if bg.Image == "" {
+				return fmt.Errorf("图像背景缺少 image")
+			}
+			if _, ok := backgroundFits[bg.Fit]; !ok {
+				return fmt.Errorf("未知的图像缩放方式 %q", bg.Fit)
+			}
+		default:
+			return fmt.Errorf("未知的背景类型 %q", bg.Type)
+		}
+	}
+	if path := sf.Camera.Path; path != nil {
+		if err := path.validate(); err != nil {
+			return err
+		}
+	}
+	for i, obj := range sf.Objects {
+		if _, ok := objectBuilders[obj.Type]; !ok {
+			return fmt.Errorf("第 %d 个对象: 未知的对象类型 %q（可选: %s）", i+1, obj.Type, strings.Join(sortedKeys(objectBuilders), ", "))
+		}
+	}
+	return nil
+}
+
+// SceneLimits 场景描述的规模上限，用于限制不可信的描述（如渲染服务收到的请求），0 表示不限。
+// 网格对象的细分数是固定的，场景的绘制量由对象数、星星数和文字长度决定
+type SceneLimits struct {
+	Objects   int // 对象数
+	Lights    int // 光源数
+	Stars     int // 所有 stars 对象的星星总数（count 省略时按 500 计算）
+	TextRunes int // 每个对象的文字字符数（label 和 text 类型）
+	Keyframes int // 相机关键帧数
+}
+
+// CheckLimits 检查描述是否超出规模上限，超出时返回说明具体项目的错误
+// 场景脚本每帧添加的对象不在检查范围内，处理不可信的描述时不应运行脚本
+func (sf *SceneFile) CheckLimits(limits SceneLimits) error {
+	exceeds := func(value, limit int) bool { return limit > 0 && value > limit }
+	if exceeds(len(sf.Objects), limits.Objects) {
+		return fmt.Errorf("对象数 %d 超过上限 %d", len(sf.Objects), limits.Objects)
+	}
+	if exceeds(len(sf.Lights), limits.Lights) {
+		return fmt.Errorf("光源数 %d 超过上限 %d", len(sf.Lights), limits.Lights)
+	}
+	if path := sf.Camera.Path; path != nil && exceeds(len(path.Keyframes), limits.Keyframes) {
+		return fmt.Errorf("相机关键帧数 %d 超过上限 %d", len(path.Keyframes), limits.Keyframes)
+	}
+	stars := 0
+	for i, obj := range sf.Objects {
+		if n := utf8.RuneCountInString(obj.Text); exceeds(n, limits.TextRunes) {
+			return fmt.Errorf("第 %d 个对象: 文字长度 %d 超过上限 %d", i+1, n, limits.TextRunes)
+		}
+		if obj.Type == "stars" {
+			stars += starCount(obj)
+			if exceeds(stars, limits.Stars) {
+				return fmt.Errorf("第 %d 个对象: 星星总数 %d 超过上限 %d", i+1, stars, limits.Stars)
+			}
+		}
+	}
+	return nil
+}
+
+// starCount stars 对象的星星数，count 不大于 0 时为 500
+func starCount(spec ObjectSpec) int {
+	if spec.Count <= 0 {
+		return 500
+	}
+	return spec.Count
+}
+
+// backgroundFits 图像背景缩放方式名称，空字符串为默认值
+var backgroundFits = map[string]BackgroundFit{
+	"":        FitCover,
+	"stretch": FitStretch,
+	"cover":   FitCover,
+	"contain": FitContain,
+}
+
+// BuildScene 按描述创建场景（背景、光源和对象）
+// 场景对象可能带有缓存，并行渲染时每一帧应各自创建场景
+func (sf *SceneFile) BuildScene() (*Scene, error) {
+	scene := NewScene()
+
+	if bg := sf.Background; bg != nil {
+		switch bg.Type {
+		case "solid":
+			scene.SetBackground(NewSolidBackground(bg.Color))
+		case "gradient":
+			scene.SetBackground(NewGradientBackground(bg.Color, bg.BottomColor))
+		case "image":
+			background, err := LoadImageBackground(bg.Image, backgroundFits[bg.Fit])
+			if err != nil {
+				return nil, err
+			}
+			scene.SetBackground(background)
+		}
+	}
+
+	for _, spec := range sf.Lights {
+		light := NewLight(vectorFromArray(spec.Position), spec.Color, spec.Intensity)
+		if spec.Kelvin > 0 {
+			light.Color = ColorTemperature(spec.Kelvin)
+		}
+		if a := spec.Attenuation; a != nil {
+			light.SetAttenuation(a[0], a[1], a[2])
+		}
+		light.Layers, light.Disabled = spec.Layers, spec.Disabled
+		scene.AddLight(light.SetRange(spec.Range))
+	}
+
+	for i, obj := range sf.Objects {
+		build, ok := objectBuilders[obj.Type]
+		if !ok {
+			return nil, fmt.Errorf("第 %d 个对象: 未知的对象类型 %q", i+1, obj.Type)
+		}
+		object := build(obj)
+		if ground, ok := object.(*GroundPlane); ok {
+			ground.Scene = scene
+		}
+		if obj.Layers != 0 {
+			object = OnLightLayers(object, obj.Layers)
+		}
+		scene.AddObject(object)
+	}
+	return scene, nil
+}
+
+// CameraPath 相机描述对应的相机路径；没有路径时返回固定在 Position 和 Target 的路径
+func (sf *SceneFile) CameraPath() CameraPath {
+	fov := sf.fov()
+	path := sf.Camera.Path
+	if path == nil {
+		return NewInterpolatedCameraPath([]CameraKeyframe{{
+			Position: vectorFromArray(sf.Camera.Position),
+			Target:   vectorFromArray(sf.Camera.Target),
+			FOV:      fov,
+		}})
+	}
+
+	return path.build(fov)
+}
+
+// validate 检查相机路径的类型和参数
+func (spec *CameraPathSpec) validate() error {
+	switch spec.Type {
+	case "orbit":
+		if spec.Radius <= 0 {
+			return fmt.Errorf("环绕相机路径的 radius 必须大于 0")
+		}
+	case "keyframes":
+		if len(spec.Keyframes) == 0 {
+			return fmt.Errorf("关键帧相机路径没有关键帧")
+		}
+		if spec.Interpolation != "" && spec.Interpolation != "smooth" && spec.Interpolation != "linear" {
+			return fmt.Errorf("未知的关键帧插值方式 %q（可选: smooth, linear）", spec.Interpolation)
+		}
+	default:
+		return fmt.Errorf("未知的相机路径类型 %q", spec.Type)
+	}
+	return nil
+}
+
+// build 按描述创建相机路径，fov 为没有指定视场角时使用的值（弧度）
+func (spec *CameraPathSpec) build(fov float64) CameraPath {
+	switch spec.Type {
+	case "orbit":
+		return NewOrbitCameraPath(vectorFromArray(spec.Center), spec.Radius, spec.Height, spec.Turns, fov)
+	default:
+		keyframes := make([]CameraKeyframe, len(spec.Keyframes))
+		for i, kf := range spec.Keyframes {
+			keyframes[i] = CameraKeyframe{
+				Time:     kf.Time,
+				Position: vectorFromArray(kf.Position),
+				Target:   vectorFromArray(kf.Target),
+				FOV:      fov,
+			}
+			if kf.FOV > 0 {
+				keyframes[i].FOV = kf.FOV * math.Pi / 180
+			}
+			if kf.Up != nil {
+				keyframes[i].Up = vectorFromArray(*kf.Up)
+			}
+		}
+		sort.SliceStable(keyframes, func(a, b int) bool { return keyframes[a].Time < keyframes[b].Time })
+		path := NewInterpolatedCameraPath(keyframes)
+		if spec.Interpolation == "linear" {
+			path.SmoothFunction = nil
+		}
+		return path
+	}
+}
+
+// fov 相机视场角（弧度）
+func (sf *SceneFile) fov() float64 {
+	if sf.Camera.FOV > 0 {
+		return sf.Camera.FOV * math.Pi / 180
+	}
+	return 0.75
+}
+
+// renderMode 描述中的渲染模式，默认光照着色
+func (sf *SceneFile) renderMode() RenderMode {
+	if mode, err := ParseRenderMode(sf.RenderMode); err == nil {
+		return mode
+	}
+	return RenderShaded
+}
+
+// FrameRenderer 按描述渲染每一帧的帧渲染函数，可直接交给 AnimationGenerator
+// 每一帧各自创建场景（有场景脚本时先由脚本修改该帧的描述），可以安全地并行渲染；
+// path 为 nil 时使用描述中（或脚本设置）的相机。加载或运行脚本、创建场景失败时
+// 不绘制该帧，错误通过 renderer.ReportError 报告，AnimationGenerator 随即停止并返回它
+func (sf *SceneFile) FrameRenderer(path CameraPath) FrameRenderer {
+	script, scriptErr := sf.loadScript()
+	if path == nil && script == nil {
+		path = sf.CameraPath()
+	}
+	return func(renderer *Renderer, frame int, t float64) {
+		if scriptErr != nil {
+			renderer.ReportError(scriptErr)
+			return
+		}
+		desc := sf
+		if script != nil {
+			desc = sf.clone()
+			if err := script(desc, frame, t); err != nil {
+				renderer.ReportError(fmt.Errorf("运行场景脚本失败: %w", err))
+				return
+			}
+		}
+		scene, err := desc.BuildScene()
+		if err != nil {
+			renderer.ReportError(fmt.Errorf("创建场景失败: %w", err))
+			return
+		}
+		framePath := path
+		if framePath == nil {
+			framePath = desc.CameraPath()
+		}
+		renderer.SetRenderMode(desc.renderMode())
+		ApplyCameraPath(renderer, framePath, t)
+		renderer.Camera.AutoUp = true // 描述中没有 Up，俯视等视线竖直的相机自动选择上方向
+		scene.Render(renderer, t*desc.timeScale())
+	}
+}
+
+// timeScale 场景时间与动画时间之比
+func (sf *SceneFile) timeScale() float64 {
+	if sf.TimeScale == 0 {
+		return 1
+	}
+	return sf.TimeScale
+}
+
+// clone 复制描述，对象和光源列表各自独立，供场景脚本修改
+func (sf *SceneFile) clone() *SceneFile {
+	c := *sf
+	c.Lights = slices.Clone(sf.Lights)
+	c.Objects = slices.Clone(sf.Objects)
+	return &c
+}
+
+// loadScript 读取并编译场景脚本（只编译一次），没有脚本时返回 nil
+func (sf *SceneFile) loadScript() (sceneScript, error) {
+	if sf.Script == "" || sf.script != nil {
+		return sf.script, nil
+	}
+	if compileSceneScript == nil {
+		return nil, errScriptUnsupported
+	}
+	script, err := compileSceneScript(sf.Script)
+	if err != nil {
+		return nil, err
+	}
+	sf.script = script
+	return script, nil
+}
+
+// vectorFromArray 把 JSON 中的 [x, y, z] 转换为向量
+func vectorFromArray(a [3]float64) Vector3 {
+	return NewVector3(a[0], a[1], a[2])
+}
+
+// objectBuilders 各对象类型的创建函数
+var objectBuilders = map[string]func(spec ObjectSpec) SceneObject{
+	"solarSystem": func(spec ObjectSpec) SceneObject {
+		ss := CreateDefaultSolarSystem()
+		if spec.DwarfPlanets {
+			ss.AddDwarfPlanets()
+		}
+		if spec.Comet {
+			ss.AddComet(NewHalleyComet())
+		}
+		if spec.Trails > 0 {
+			ss.AddPlanetTrails(spec.Trails)
+		}
+		return ss
+	},
+	"coordinateSystem": func(spec ObjectSpec) SceneObject {
+		return NewCoordinateSystem(orDefault(spec.Size, 5))
+	},
+	"stars": func(spec ObjectSpec) SceneObject {
+		return NewStarField(starCount(spec), orDefault(spec.Distance, 50))
+	},
+	"ground": func(spec ObjectSpec) SceneObject {
+		ground := NewGroundPlane(nil, spec.Position[1])
+		if spec.Color != ([3]float64{}) {
+			ground.Color = spec.Color
+		}
+		return ground
+	},
+	"label": func(spec ObjectSpec) SceneObject {
+		label := NewLabel3D(vectorFromArray(spec.Position), spec.Text, spec.Color)
+		if spec.FontSize > 0 {
+			label.FontSize = spec.FontSize
+		}
+		return label
+	},
+	"sphere":    meshBuilder(func(size float64, _ string) *Mesh { return CreateSphere(size, 32, 16) }),
+	"cube":      meshBuilder(func(size float64, _ string) *Mesh { return CreateCube(size) }),
+	"cylinder":  meshBuilder(func(size float64, _ string) *Mesh { return CreateCylinder(size/2, size, 32) }),
+	"cone":      meshBuilder(func(size float64, _ string) *Mesh { return CreateCone(size/2, size, 32) }),
+	"torus":     meshBuilder(func(size float64, _ string) *Mesh { return CreateTorus(size, size/3, 48, 24) }),
+	"torusKnot": meshBuilder(func(size float64, _ string) *Mesh { return CreateTorusKnot(2, 3, size, size/4, 128, 16) }),
+	"plane":     meshBuilder(func(size float64, _ string) *Mesh { return CreatePlane(size, size, 1) }),
+	"text":      meshBuilder(func(size float64, text string) *Mesh { return CreateTextMesh(text, "sans-serif", size, size/4) }),
+}
+
+// meshBuilder 创建网格对象的创建函数，Size 为 0 时使用 1
+func meshBuilder(create func(size float64, text string) *Mesh) func(spec ObjectSpec) SceneObject {
+	return func(spec ObjectSpec) SceneObject {
+		mesh := create(orDefault(spec.Size, 1), spec.Text)
+		mesh.Emissive, mesh.Additive = spec.Emissive, spec.Additive
+		return &meshObject{spec: spec, mesh: mesh}
+	}
+}
+
+// orDefault 值为 0 时返回默认值
+func orDefault(value, fallback float64) float64 {
+	if value == 0 {
+		return fallback
+	}
+	return value
+}
+
+// meshObject 场景描述中的网格对象：带平移、旋转、缩放和匀速自转
+type meshObject struct {
+	spec ObjectSpec
+	mesh *Mesh
+}
+
+// Render 按时间 t 的自转角度绘制网格
+func (mo *meshObject) Render(renderer *Renderer, t float64) {
+	renderer.DrawMeshTransformed(mo.mesh, mo.model(t), mo.spec.Color)
+}
+
+// WorldBounds 网格在时间 t 的包围盒
+func (mo *meshObject) WorldBounds(t float64) AABB {
+	return transformBounds(mo.mesh.BoundingBox(), mo.model(t))
+}
+
+// model 时间 t 的模型矩阵
+func (mo *meshObject) model(t float64) Matrix4 {
+	const degree = math.Pi / 180
+	s := orDefault(mo.spec.Scale, 1)
+	rotation := QuaternionFromEuler(
+		(mo.spec.Rotation[0]+mo.spec.Spin[0]*t)*degree,
+		(mo.spec.Rotation[1]+mo.spec.Spin[1]*t)*degree,
+		(mo.spec.Rotation[2]+mo.spec.Spin[2]*t)*degree,
+	)
+	return NewTransform().
+		SetPosition(vectorFromArray(mo.spec.Position)).
+		SetRotation(rotation).
+		SetUniformScale(s).
+		Matrix()
+}
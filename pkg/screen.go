@@ -0,0 +1,39 @@
+package go3d
+
+import "math"
+
+// WorldToScreen 世界坐标在画布上的像素位置，visible 为 false 表示点在相机后方或超出近远裁剪面
+// （此时位置没有意义）。用于在三维对象旁放置 HUD 元素，位置与 ProjectToScreen 完全一致
+func (r *Renderer) WorldToScreen(v Vector3) (screen Vector2, visible bool) {
+	forward, _, _ := r.Camera.Basis()
+	depth := v.Sub(r.Camera.Position).Dot(forward)
+	x, y, _ := r.ProjectToScreen(v)
+	return NewVector2(x, y), depth >= r.Camera.Near && depth <= r.Camera.Far
+}
+
+// ScreenToWorldRay 穿过像素坐标 (x, y) 的视线：起点为相机位置，direction 为单位向量。
+// 用于拾取（与 BVH.Intersect 或包围球求交）和把鼠标位置换算为三维方向；像素中心为 (i+0.5, j+0.5)
+func (r *Renderer) ScreenToWorldRay(x, y float64) (origin, direction Vector3) {
+	return r.Camera.Position, r.cameraRay(x, y)
+}
+
+// Unproject 把像素坐标和 ProjectToScreen 返回的深度（标准化设备坐标 z，近裁剪面为 -1，远裁剪面为 1）
+// 还原为世界坐标，是 ProjectToScreen 的逆变换。深度可以来自深度通道或同一点之前的投影结果
+func (r *Renderer) Unproject(x, y, depth float64) Vector3 {
+	near, far := r.Camera.Near, r.Camera.Far
+	// 透视投影中 z_ndc = (A·z + B) / -z，反解出视图空间的 z（相机看向 -z）
+	a := (far + near) / (near - far)
+	b := 2 * far * near / (near - far)
+	distance := b / (depth + a) // = -z，沿视线方向的距离
+
+	forward, right, up := r.Camera.Basis()
+	aspect := float64(r.Width) / float64(r.Height)
+	tanHalf := math.Tan(r.Camera.FOV / 2)
+	ndcX := 2*x/float64(r.Width) - 1
+	ndcY := 1 - 2*y/float64(r.Height)
+
+	return r.Camera.Position.
+		Add(forward.Scale(distance)).
+		Add(right.Scale(ndcX * tanHalf * aspect * distance)).
+		Add(up.Scale(ndcY * tanHalf * distance))
+}
@@ -0,0 +1,171 @@
+//go:build lua
+
+// 场景脚本（Lua，基于 gopher-lua）：场景描述的 script 字段指向的脚本每帧运行一次，
+// 在描述的基础上添加对象和光源、设置相机、渲染模式和背景，适合不写 Go 代码的用户编写动画：
+//
+//	go build -tags lua ./cmd/go3d
+//
+// 脚本需要定义 frame(t, n)：t 为动画时间（0-1），n 为帧号（从 1 开始）。可用的函数：
+//
+//	add{type = "torus", size = 2, position = {0, 5, 0}, color = {0.9, 0.6, 0.2}}  -- 添加对象（字段同 ObjectSpec）
+//	light{position = {5, 5, 5}, color = {1, 1, 1}, intensity = 0.8}               -- 添加光源（LightSpec）
+//	camera{position = {0, 8, 20}, target = {0, 0, 0}, fov = 40}                    -- 设置相机（CameraSpec）
+//	background{type = "solid", color = {0, 0, 0}}                                  -- 设置背景（BackgroundSpec）
+//	mode("toon")                                                                    -- 设置渲染模式
+//
+// 每一帧都在新的解释器中运行，帧之间不保留全局变量，画面只由 t 和 n 决定，可以并行渲染。
+// 脚本只能使用 base、table、string、math 标准库，不能读写文件
+//
+//	function frame(t, n)
+//	  local angle = t * 2 * math.pi
+//	  camera{position = {20 * math.cos(angle), 6, 20 * math.sin(angle)}, target = {0, 0, 0}}
+//	  for i = 1, 5 do
+//	    add{type = "sphere", size = 0.5, position = {i * 2 - 6, math.sin(angle + i), 0}, color = {i / 5, 0.5, 1 - i / 5}}
+//	  end
+//	end
+
+package go3d
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/parse"
+)
+
+func init() {
+	compileSceneScript = compileLuaScript
+}
+
+// compileLuaScript 读取并编译 Lua 脚本，编译结果在各帧的解释器之间共享
+func compileLuaScript(filename string) (sceneScript, error) {
+	source, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("读取场景脚本失败: %w", err)
+	}
+	chunk, err := parse.Parse(bytes.NewReader(source), filename)
+	if err != nil {
+		return nil, fmt.Errorf("解析场景脚本失败: %w", err)
+	}
+	proto, err := lua.Compile(chunk, filename)
+	if err != nil {
+		return nil, fmt.Errorf("编译场景脚本失败: %w", err)
+	}
+
+	return func(frame *SceneFile, index int, t float64) error {
+		L := newScriptState(frame)
+		defer L.Close()
+
+		L.Push(L.NewFunctionFromProto(proto))
+		if err := L.PCall(0, 0, nil); err != nil {
+			return err
+		}
+		fn, ok := L.GetGlobal("frame").(*lua.LFunction)
+		if !ok {
+			return fmt.Errorf("%s: 脚本没有定义 frame(t, n)", filename)
+		}
+		return L.CallByParam(lua.P{Fn: fn, Protect: true}, lua.LNumber(t), lua.LNumber(index))
+	}, nil
+}
+
+// newScriptState 创建只包含安全标准库的解释器，并注册修改 frame 的场景函数
+func newScriptState(frame *SceneFile) *lua.LState {
+	L := lua.NewState(lua.Options{SkipOpenLibs: true})
+	for _, lib := range []struct {
+		name string
+		open lua.LGFunction
+	}{
+		{lua.BaseLibName, lua.OpenBase},
+		{lua.TabLibName, lua.OpenTable},
+		{lua.StringLibName, lua.OpenString},
+		{lua.MathLibName, lua.OpenMath},
+	} {
+		L.Push(L.NewFunction(lib.open))
+		L.Push(lua.LString(lib.name))
+		L.Call(1, 0)
+	}
+	// 基础库中能访问文件系统的函数
+	for _, name := range []string{"dofile", "loadfile", "require", "module"} {
+		L.SetGlobal(name, lua.LNil)
+	}
+
+	L.SetGlobal("add", L.NewFunction(func(L *lua.LState) int {
+		var spec ObjectSpec
+		checkSpec(L, &spec)
+		if _, ok := objectBuilders[spec.Type]; !ok {
+			L.ArgError(1, fmt.Sprintf("未知的对象类型 %q", spec.Type))
+		}
+		frame.Objects = append(frame.Objects, spec)
+		return 0
+	}))
+	L.SetGlobal("light", L.NewFunction(func(L *lua.LState) int {
+		var spec LightSpec
+		checkSpec(L, &spec)
+		frame.Lights = append(frame.Lights, spec)
+		return 0
+	}))
+	L.SetGlobal("camera", L.NewFunction(func(L *lua.LState) int {
+		var spec CameraSpec
+		checkSpec(L, &spec)
+		frame.Camera = spec
+		return 0
+	}))
+	L.SetGlobal("background", L.NewFunction(func(L *lua.LState) int {
+		var spec BackgroundSpec
+		checkSpec(L, &spec)
+		frame.Background = &spec
+		return 0
+	}))
+	L.SetGlobal("mode", L.NewFunction(func(L *lua.LState) int {
+		name := L.CheckString(1)
+		if _, err := ParseRenderMode(name); err != nil {
+			L.ArgError(1, err.Error())
+		}
+		frame.RenderMode = name
+		return 0
+	}))
+	return L
+}
+
+// checkSpec 把第一个参数（表）按 JSON 字段名转换为描述结构体，字段名或类型不对时抛出 Lua 错误
+func checkSpec(L *lua.LState, spec any) {
+	data, err := json.Marshal(luaToGo(L.CheckTable(1)))
+	if err == nil {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(spec)
+	}
+	if err != nil {
+		L.ArgError(1, err.Error())
+	}
+}
+
+// luaToGo 把 Lua 值转换为可以编码为 JSON 的 Go 值：连续整数键的表转换为数组，其他表转换为对象
+func luaToGo(value lua.LValue) any {
+	switch v := value.(type) {
+	case lua.LBool:
+		return bool(v)
+	case lua.LNumber:
+		return float64(v)
+	case lua.LString:
+		return string(v)
+	case *lua.LTable:
+		if n := v.MaxN(); n > 0 && n == v.Len() {
+			array := make([]any, n)
+			for i := range n {
+				array[i] = luaToGo(v.RawGetInt(i + 1))
+			}
+			return array
+		}
+		object := make(map[string]any)
+		v.ForEach(func(key, value lua.LValue) {
+			object[key.String()] = luaToGo(value)
+		})
+		return object
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,413 @@
+package go3d
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WGS-72 地球常数（SGP4 模型使用）
+const (
+	sgp4EarthRadius = 6378.135     // 地球赤道半径（km）
+	sgp4XKE         = 0.0743669161 // sqrt(GM) ，单位为地球半径^1.5/分钟
+	sgp4J2          = 0.001082616
+	sgp4J3          = -0.00000253881
+	sgp4J4          = -0.00000165597
+	sgp4J3OJ2       = sgp4J3 / sgp4J2
+)
+
+// TLE 两行轨道根数（Two-Line Element set），角度为度，平均运动为圈/天
+type TLE struct {
+	Name          string
+	CatalogNumber int
+	Epoch         time.Time // 根数历元（UTC）
+	Inclination   float64
+	RAAN          float64 // 升交点赤经
+	Eccentricity  float64
+	ArgPerigee    float64
+	MeanAnomaly   float64
+	MeanMotion    float64 // 平均运动（圈/天）
+	BStar         float64 // 大气阻力项（地球半径的倒数）
+}
+
+// ParseTLE 解析一组 TLE：两行根数，前面可以有一行名称。校验行号和校验和
+func ParseTLE(text string) (TLE, error) {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, strings.TrimRight(line, " \t\r"))
+		}
+	}
+	name := ""
+	if len(lines) == 3 {
+		name = strings.TrimSpace(strings.TrimPrefix(lines[0], "0 "))
+		lines = lines[1:]
+	}
+	if len(lines) != 2 {
+		return TLE{}, errors.New("TLE: 需要两行根数")
+	}
+	return parseTLELines(name, lines[0], lines[1])
+}
+
+// ParseTLEs 读取 CelesTrak 等来源的 TLE 文件，每组为可选的名称行加两行根数
+func ParseTLEs(r io.Reader) ([]TLE, error) {
+	var tles []TLE
+	var name, line1 string
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		switch {
+		case strings.TrimSpace(line) == "":
+		case strings.HasPrefix(line, "1 ") && line1 == "":
+			line1 = line
+		case strings.HasPrefix(line, "2 ") && line1 != "":
+			tle, err := parseTLELines(name, line1, line)
+			if err != nil {
+				return nil, fmt.Errorf("第 %d 行: %w", number, err)
+			}
+			tles = append(tles, tle)
+			name, line1 = "", ""
+		case line1 == "":
+			name = strings.TrimSpace(strings.TrimPrefix(line, "0 "))
+		default:
+			return nil, fmt.Errorf("TLE: 第 %d 行应为第二行根数", number)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if line1 != "" {
+		return nil, errors.New("TLE: 文件末尾缺少第二行根数")
+	}
+	return tles, nil
+}
+
+// LoadTLEs 从文件读取所有 TLE
+func LoadTLEs(filename string) ([]TLE, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseTLEs(file)
+}
+
+// parseTLELines 按固定列位置解析两行根数
+func parseTLELines(name, line1, line2 string) (TLE, error) {
+	for i, line := range []string{line1, line2} {
+		if len(line) < 69 || line[0] != byte('1'+i) {
+			return TLE{}, fmt.Errorf("TLE: 第 %d 行根数应有 69 列并以 %d 开头", i+1, i+1)
+		}
+		if sum := tleChecksum(line[:68]); int(line[68]-'0') != sum {
+			return TLE{}, fmt.Errorf("TLE: 第 %d 行根数的校验和为 %c，计算结果为 %d", i+1, line[68], sum)
+		}
+	}
+
+	var firstErr error
+	number := func(text string) float64 {
+		value, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("TLE: 无效的数值 %q", text)
+		}
+		return value
+	}
+	tle := TLE{
+		Name:          name,
+		CatalogNumber: int(number(line1[2:7])),
+		Inclination:   number(line2[8:16]),
+		RAAN:          number(line2[17:25]),
+		Eccentricity:  number("0." + strings.TrimSpace(line2[26:33])),
+		ArgPerigee:    number(line2[34:42]),
+		MeanAnomaly:   number(line2[43:51]),
+		MeanMotion:    number(line2[52:63]),
+		BStar:         tleExponent(line1[53:61], number),
+	}
+	year := int(number(line1[18:20]))
+	if year < 57 {
+		year += 2000
+	} else {
+		year += 1900
+	}
+	day := number(line1[20:32])
+	tle.Epoch = time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration((day - 1) * 86400 * float64(time.Second)))
+	if firstErr != nil {
+		return TLE{}, firstErr
+	}
+	if tle.Name == "" {
+		tle.Name = strconv.Itoa(tle.CatalogNumber)
+	}
+	return tle, nil
+}
+
+// tleChecksum TLE 校验和：数字之和加上负号个数，对 10 取模
+func tleChecksum(text string) int {
+	sum := 0
+	for _, c := range text {
+		switch {
+		case c >= '0' && c <= '9':
+			sum += int(c - '0')
+		case c == '-':
+			sum++
+		}
+	}
+	return sum % 10
+}
+
+// tleExponent 解析省略小数点的指数记法，如 " 28098-4" 表示 0.28098e-4
+func tleExponent(text string, number func(string) float64) float64 {
+	text = strings.TrimSpace(text)
+	if len(text) < 2 {
+		return 0
+	}
+	mantissa, exponent := text[:len(text)-2], text[len(text)-2:]
+	sign := ""
+	if mantissa != "" && (mantissa[0] == '-' || mantissa[0] == '+') {
+		sign, mantissa = mantissa[:1], mantissa[1:]
+	}
+	return number(sign+"0."+mantissa) * math.Pow(10, number(exponent))
+}
+
+// sgp4Propagator 按 SGP4 模型初始化后的轨道常数（Vallado 等，Revisiting Spacetrack Report #3）。
+// 只实现近地模型；周期超过 225 分钟的深空目标同样按近地公式外推，不计日月引力摄动和共振项
+type sgp4Propagator struct {
+	inclo, nodeo, ecco, argpo, mo       float64
+	noUnkozai, bstar                    float64
+	isimp                               bool
+	aycof, con41, cc1, cc4, cc5         float64
+	d2, d3, d4, delmo, eta              float64
+	argpdot, omgcof, sinmao, t2cof      float64
+	t3cof, t4cof, t5cof, x1mth2         float64
+	x7thm1, mdot, nodedot, xlcof, xmcof float64
+	nodecf                              float64
+}
+
+// newSGP4 由 TLE 初始化传播器
+func newSGP4(tle TLE) (*sgp4Propagator, error) {
+	const deg = math.Pi / 180
+	p := &sgp4Propagator{
+		inclo: tle.Inclination * deg,
+		nodeo: tle.RAAN * deg,
+		ecco:  tle.Eccentricity,
+		argpo: tle.ArgPerigee * deg,
+		mo:    tle.MeanAnomaly * deg,
+		bstar: tle.BStar,
+	}
+	noKozai := tle.MeanMotion * 2 * math.Pi / 1440 // 弧度/分钟
+	if noKozai <= 0 || p.ecco < 0 || p.ecco >= 1 {
+		return nil, errors.New("SGP4: 平均运动应为正数，偏心率应在 [0, 1) 内")
+	}
+
+	// 从 TLE 的 Kozai 平均运动恢复 Brouwer 平均运动
+	eccsq := p.ecco * p.ecco
+	omeosq := 1 - eccsq
+	rteosq := math.Sqrt(omeosq)
+	cosio := math.Cos(p.inclo)
+	cosio2 := cosio * cosio
+	ak := math.Pow(sgp4XKE/noKozai, 2.0/3)
+	d1 := 0.75 * sgp4J2 * (3*cosio2 - 1) / (rteosq * omeosq)
+	del := d1 / (ak * ak)
+	adel := ak * (1 - del*del - del*(1.0/3+134*del*del/81))
+	del = d1 / (adel * adel)
+	p.noUnkozai = noKozai / (1 + del)
+
+	ao := math.Pow(sgp4XKE/p.noUnkozai, 2.0/3)
+	sinio := math.Sin(p.inclo)
+	po := ao * omeosq
+	con42 := 1 - 5*cosio2
+	p.con41 = -con42 - cosio2 - cosio2
+	posq := po * po
+	rp := ao * (1 - p.ecco)
+	p.isimp = rp < 220/sgp4EarthRadius+1 || 2*math.Pi/p.noUnkozai >= 225
+
+	// 大气密度函数参数，近地点低于 156 km 时调整
+	sfour := 78/sgp4EarthRadius + 1
+	qzms24 := math.Pow((120-78)/sgp4EarthRadius, 4)
+	if perigee := (rp - 1) * sgp4EarthRadius; perigee < 156 {
+		s := perigee - 78
+		if perigee < 98 {
+			s = 20
+		}
+		qzms24 = math.Pow((120-s)/sgp4EarthRadius, 4)
+		sfour = s/sgp4EarthRadius + 1
+	}
+	pinvsq := 1 / posq
+	tsi := 1 / (ao - sfour)
+	p.eta = ao * p.ecco * tsi
+	etasq := p.eta * p.eta
+	eeta := p.ecco * p.eta
+	psisq := math.Abs(1 - etasq)
+	coef := qzms24 * math.Pow(tsi, 4)
+	coef1 := coef / math.Pow(psisq, 3.5)
+	cc2 := coef1 * p.noUnkozai * (ao*(1+1.5*etasq+eeta*(4+etasq)) +
+		0.375*sgp4J2*tsi/psisq*p.con41*(8+3*etasq*(8+etasq)))
+	p.cc1 = p.bstar * cc2
+	cc3 := 0.0
+	if p.ecco > 1e-4 {
+		cc3 = -2 * coef * tsi * sgp4J3OJ2 * p.noUnkozai * sinio / p.ecco
+	}
+	p.x1mth2 = 1 - cosio2
+	p.cc4 = 2 * p.noUnkozai * coef1 * ao * omeosq * (p.eta*(2+0.5*etasq) + p.ecco*(0.5+2*etasq) -
+		sgp4J2*tsi/(ao*psisq)*(-3*p.con41*(1-2*eeta+etasq*(1.5-0.5*eeta))+
+			0.75*p.x1mth2*(2*etasq-eeta*(1+etasq))*math.Cos(2*p.argpo)))
+	p.cc5 = 2 * coef1 * ao * omeosq * (1 + 2.75*(etasq+eeta) + eeta*etasq)
+
+	// 地球扁率引起的长期变化率
+	cosio4 := cosio2 * cosio2
+	temp1 := 1.5 * sgp4J2 * pinvsq * p.noUnkozai
+	temp2 := 0.5 * temp1 * sgp4J2 * pinvsq
+	temp3 := -0.46875 * sgp4J4 * pinvsq * pinvsq * p.noUnkozai
+	p.mdot = p.noUnkozai + 0.5*temp1*rteosq*p.con41 + 0.0625*temp2*rteosq*(13-78*cosio2+137*cosio4)
+	p.argpdot = -0.5*temp1*con42 + 0.0625*temp2*(7-114*cosio2+395*cosio4) + temp3*(3-36*cosio2+49*cosio4)
+	xhdot1 := -temp1 * cosio
+	p.nodedot = xhdot1 + (0.5*temp2*(4-19*cosio2)+2*temp3*(3-7*cosio2))*cosio
+	p.omgcof = p.bstar * cc3 * math.Cos(p.argpo)
+	if p.ecco > 1e-4 {
+		p.xmcof = -2.0 / 3 * coef * p.bstar / eeta
+	}
+	p.nodecf = 3.5 * omeosq * xhdot1 * p.cc1
+	p.t2cof = 1.5 * p.cc1
+	denominator := 1 + cosio
+	if math.Abs(denominator) < 1.5e-12 {
+		denominator = 1.5e-12
+	}
+	p.xlcof = -0.25 * sgp4J3OJ2 * sinio * (3 + 5*cosio) / denominator
+	p.aycof = -0.5 * sgp4J3OJ2 * sinio
+	p.delmo = math.Pow(1+p.eta*math.Cos(p.mo), 3)
+	p.sinmao = math.Sin(p.mo)
+	p.x7thm1 = 7*cosio2 - 1
+
+	if !p.isimp {
+		cc1sq := p.cc1 * p.cc1
+		p.d2 = 4 * ao * tsi * cc1sq
+		temp := p.d2 * tsi * p.cc1 / 3
+		p.d3 = (17*ao + sfour) * temp
+		p.d4 = 0.5 * temp * ao * tsi * (221*ao + 31*sfour) * p.cc1
+		p.t3cof = p.d2 + 2*cc1sq
+		p.t4cof = 0.25 * (3*p.d3 + p.cc1*(12*p.d2+10*cc1sq))
+		p.t5cof = 0.2 * (3*p.d4 + 12*p.cc1*p.d3 + 6*p.d2*p.d2 + 15*cc1sq*(2*p.d2+cc1sq))
+	}
+	return p, nil
+}
+
+// propagate 历元后 minutes 分钟的位置（km）和速度（km/s），TEME 惯性坐标系（Z 指向北极）
+func (p *sgp4Propagator) propagate(minutes float64) (position, velocity Vector3, err error) {
+	t := minutes
+	xmdf := p.mo + p.mdot*t
+	argpdf := p.argpo + p.argpdot*t
+	nodedf := p.nodeo + p.nodedot*t
+	argpm, mm := argpdf, xmdf
+	t2 := t * t
+	nodem := nodedf + p.nodecf*t2
+	tempa := 1 - p.cc1*t
+	tempe := p.bstar * p.cc4 * t
+	templ := p.t2cof * t2
+	if !p.isimp {
+		delomg := p.omgcof * t
+		delm := p.xmcof * (math.Pow(1+p.eta*math.Cos(xmdf), 3) - p.delmo)
+		temp := delomg + delm
+		mm = xmdf + temp
+		argpm = argpdf - temp
+		t3 := t2 * t
+		t4 := t3 * t
+		tempa -= p.d2*t2 + p.d3*t3 + p.d4*t4
+		tempe += p.bstar * p.cc5 * (math.Sin(mm) - p.sinmao)
+		templ += p.t3cof*t3 + t4*(p.t4cof+t*p.t5cof)
+	}
+
+	am := math.Pow(sgp4XKE/p.noUnkozai, 2.0/3) * tempa * tempa
+	nm := sgp4XKE / math.Pow(am, 1.5)
+	em := p.ecco - tempe
+	if em >= 1 || em < -0.001 || am < 0.95 {
+		return Vector3{}, Vector3{}, errors.New("SGP4: 轨道已衰减或发散")
+	}
+	em = math.Max(em, 1e-6)
+	mm += p.noUnkozai * templ
+	xlm := mm + argpm + nodem
+	nodem = math.Mod(nodem, 2*math.Pi)
+	argpm = math.Mod(argpm, 2*math.Pi)
+	xlm = math.Mod(xlm, 2*math.Pi)
+	mm = math.Mod(xlm-argpm-nodem, 2*math.Pi)
+
+	// 长周期项
+	sinip, cosip := math.Sin(p.inclo), math.Cos(p.inclo)
+	axnl := em * math.Cos(argpm)
+	temp := 1 / (am * (1 - em*em))
+	aynl := em*math.Sin(argpm) + temp*p.aycof
+	xl := mm + argpm + nodem + temp*p.xlcof*axnl
+
+	// 解开普勒方程
+	u := math.Mod(xl-nodem, 2*math.Pi)
+	eo1 := u
+	var sineo1, coseo1 float64
+	for range 10 {
+		sineo1, coseo1 = math.Sin(eo1), math.Cos(eo1)
+		delta := (u - aynl*coseo1 + axnl*sineo1 - eo1) / (1 - coseo1*axnl - sineo1*aynl)
+		delta = math.Max(-0.95, math.Min(0.95, delta))
+		eo1 += delta
+		if math.Abs(delta) < 1e-12 {
+			break
+		}
+	}
+	sineo1, coseo1 = math.Sin(eo1), math.Cos(eo1)
+
+	// 短周期项
+	ecose := axnl*coseo1 + aynl*sineo1
+	esine := axnl*sineo1 - aynl*coseo1
+	el2 := axnl*axnl + aynl*aynl
+	pl := am * (1 - el2)
+	if pl < 0 {
+		return Vector3{}, Vector3{}, errors.New("SGP4: 半通径为负")
+	}
+	rl := am * (1 - ecose)
+	rdotl := math.Sqrt(am) * esine / rl
+	rvdotl := math.Sqrt(pl) / rl
+	betal := math.Sqrt(1 - el2)
+	temp = esine / (1 + betal)
+	sinu := am / rl * (sineo1 - aynl - axnl*temp)
+	cosu := am / rl * (coseo1 - axnl + aynl*temp)
+	su := math.Atan2(sinu, cosu)
+	sin2u := (cosu + cosu) * sinu
+	cos2u := 1 - 2*sinu*sinu
+	temp = 1 / pl
+	temp1 := 0.5 * sgp4J2 * temp
+	temp2 := temp1 * temp
+
+	mrt := rl*(1-1.5*temp2*betal*p.con41) + 0.5*temp1*p.x1mth2*cos2u
+	if mrt < 1 {
+		return Vector3{}, Vector3{}, errors.New("SGP4: 卫星已陨落")
+	}
+	su -= 0.25 * temp2 * p.x7thm1 * sin2u
+	xnode := nodem + 1.5*temp2*cosip*sin2u
+	xinc := p.inclo + 1.5*temp2*cosip*sinip*cos2u
+	mvt := rdotl - nm*temp1*p.x1mth2*sin2u/sgp4XKE
+	rvdot := rvdotl + nm*temp1*(p.x1mth2*cos2u+1.5*p.con41)/sgp4XKE
+
+	sinsu, cossu := math.Sin(su), math.Cos(su)
+	snod, cnod := math.Sin(xnode), math.Cos(xnode)
+	sini, cosi := math.Sin(xinc), math.Cos(xinc)
+	xmx, xmy := -snod*cosi, cnod*cosi
+	ux := NewVector3(xmx*sinsu+cnod*cossu, xmy*sinsu+snod*cossu, sini*sinsu)
+	vx := NewVector3(xmx*cossu-cnod*sinsu, xmy*cossu-snod*sinsu, sini*cossu)
+
+	const kmPerSecond = sgp4EarthRadius * sgp4XKE / 60
+	position = ux.Scale(mrt * sgp4EarthRadius)
+	velocity = ux.Scale(mvt).Add(vx.Scale(rvdot)).Scale(kmPerSecond)
+	return position, velocity, nil
+}
+
+// GreenwichSiderealTime 格林尼治平恒星时（弧度，IAU-82），用于把 TEME 惯性坐标转换为随地球转动的坐标
+func GreenwichSiderealTime(date time.Time) float64 {
+	tut1 := (JulianDate(date) - 2451545.0) / 36525
+	seconds := -6.2e-6*tut1*tut1*tut1 + 0.093104*tut1*tut1 + (876600*3600+8640184.812866)*tut1 + 67310.54841
+	gmst := math.Mod(seconds*math.Pi/180/240, 2*math.Pi)
+	if gmst < 0 {
+		gmst += 2 * math.Pi
+	}
+	return gmst
+}
@@ -0,0 +1,65 @@
+package go3d
+
+import "math"
+
+// SphereOccluder 球形遮挡体，用于解析式阴影测试
+type SphereOccluder struct {
+	Center Vector3
+	Radius float64
+}
+
+// ShadowCaster 可投射阴影的场景对象
+type ShadowCaster interface {
+	ShadowSpheres(t float64) []SphereOccluder
+}
+
+// SetOccluders 设置参与阴影测试的遮挡体
+func (r *Renderer) SetOccluders(occluders []SphereOccluder) {
+	r.occluders = occluders
+}
+
+// occludes 判断线段 from -> to 是否被遮挡体挡住
+// 包含任一端点的遮挡体会被忽略：前者是表面所属的天体自身（三角形中心略在球面内侧），
+// 后者是包裹光源的天体（如太阳）
+func (o SphereOccluder) occludes(from, to Vector3) bool {
+	radius2 := o.Radius * o.Radius * 1.0001
+	if from.Sub(o.Center).Dot(from.Sub(o.Center)) <= radius2 ||
+		to.Sub(o.Center).Dot(to.Sub(o.Center)) <= radius2 {
+		return false
+	}
+
+	// 求线段上离球心最近的点
+	dir := to.Sub(from)
+	length2 := dir.Dot(dir)
+	if length2 < 1e-20 {
+		return false
+	}
+	s := math.Max(0, math.Min(1, o.Center.Sub(from).Dot(dir)/length2))
+	closest := from.Add(dir.Scale(s))
+	offset := closest.Sub(o.Center)
+	return offset.Dot(offset) < o.Radius*o.Radius
+}
+
+// inShadow 判断位置相对于光源是否处于阴影中
+func (r *Renderer) inShadow(position, lightPos Vector3) bool {
+	if !r.Shadows {
+		return false
+	}
+	for _, occluder := range r.occluders {
+		if occluder.occludes(position, lightPos) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectOccluders 收集场景中所有投射阴影的遮挡体
+func collectOccluders(objects []SceneObject, t float64) []SphereOccluder {
+	var occluders []SphereOccluder
+	for _, obj := range objects {
+		if caster, ok := obj.(ShadowCaster); ok {
+			occluders = append(occluders, caster.ShadowSpheres(t)...)
+		}
+	}
+	return occluders
+}
@@ -0,0 +1,152 @@
+package go3d
+
+import "math"
+
+// CameraShake 给任意相机路径叠加 Perlin 噪声抖动的装饰器：位置平移、视线偏转和绕视线滚转，
+// 用于撞击瞬间的震屏或手持摄影的晃动感。Envelope 和 FrequencyEnvelope 控制抖动强度和快慢随时间的变化，
+// 同一种子每次得到相同的抖动
+type CameraShake struct {
+	Path CameraPath
+
+	PositionAmplitude float64 // 位置抖动幅度（世界单位）
+	RotationAmplitude float64 // 视线偏航、俯仰的抖动幅度（弧度）
+	RollAmplitude     float64 // 绕视线滚转的抖动幅度（弧度）
+
+	Frequency float64 // 每单位路径时间的抖动次数；路径时间通常是 0-1 的视频进度，10 秒视频要每秒晃 2 次取 20
+	Octaves   int     // 噪声层数，越多越杂乱
+	Seed      int64
+
+	Envelope          func(t float64) float64 // 幅度包络（0-1），nil 表示始终满幅
+	FrequencyEnvelope func(t float64) float64 // 频率倍数包络，nil 表示恒定频率
+}
+
+// NewCameraShake 创建手持风格的抖动：两层噪声，滚转幅度为视线偏转的一半
+func NewCameraShake(path CameraPath, positionAmplitude, rotationAmplitude, frequency float64) *CameraShake {
+	return &CameraShake{
+		Path:              path,
+		PositionAmplitude: positionAmplitude,
+		RotationAmplitude: rotationAmplitude,
+		RollAmplitude:     rotationAmplitude / 2,
+		Frequency:         frequency,
+		Octaves:           2,
+		Seed:              1,
+	}
+}
+
+// SetSeed 设置噪声种子
+func (cs *CameraShake) SetSeed(seed int64) *CameraShake {
+	cs.Seed = seed
+	return cs
+}
+
+// SetEnvelope 设置幅度包络
+func (cs *CameraShake) SetEnvelope(envelope func(t float64) float64) *CameraShake {
+	cs.Envelope = envelope
+	return cs
+}
+
+// SetFrequencyEnvelope 设置频率倍数包络
+func (cs *CameraShake) SetFrequencyEnvelope(envelope func(t float64) float64) *CameraShake {
+	cs.FrequencyEnvelope = envelope
+	return cs
+}
+
+// ImpactEnvelope 撞击包络：at 之前为 0，at 时跳到 1，之后在 duration 内按二次曲线衰减到 0
+func ImpactEnvelope(at, duration float64) func(t float64) float64 {
+	return func(t float64) float64 {
+		if t < at || duration <= 0 || t >= at+duration {
+			return 0
+		}
+		remaining := 1 - (t-at)/duration
+		return remaining * remaining
+	}
+}
+
+// phase 时间 t 的噪声相位，即频率对时间的积分；频率变化时直接用频率乘 t 会让抖动忽快忽慢
+func (cs *CameraShake) phase(t float64) float64 {
+	if cs.FrequencyEnvelope == nil {
+		return cs.Frequency * t
+	}
+	steps := max(16, int(math.Ceil(math.Abs(t)*256)))
+	h := t / float64(steps)
+	sum := 0.0
+	for i := range steps {
+		sum += cs.FrequencyEnvelope((float64(i) + 0.5) * h)
+	}
+	return cs.Frequency * sum * h
+}
+
+// offsets 时间 t 的位置偏移和偏航、俯仰、滚转角，每个分量取噪声场中不同的一行
+func (cs *CameraShake) offsets(t float64) (position Vector3, yaw, pitch, roll float64) {
+	amount := 1.0
+	if cs.Envelope != nil {
+		amount = cs.Envelope(t)
+	}
+	if amount == 0 {
+		return Vector3{}, 0, 0, 0
+	}
+	noise := NewPerlinNoise(cs.Seed)
+	x := cs.phase(t)
+	// Perlin 噪声大多落在 [-0.5, 0.5] 之内，放大一倍使幅度参数大致对应抖动的峰值
+	channel := func(row int) float64 {
+		return 2 * noise.Fractal2D(x, float64(row)*7.31+0.5, cs.Octaves) * amount
+	}
+	position = NewVector3(channel(0), channel(1), channel(2)).Scale(cs.PositionAmplitude)
+	return position, channel(3) * cs.RotationAmplitude, channel(4) * cs.RotationAmplitude, channel(5) * cs.RollAmplitude
+}
+
+// baseUp 原路径的上方向，原路径不提供时取 +Y
+func (cs *CameraShake) baseUp(t float64) Vector3 {
+	if upPath, ok := cs.Path.(CameraUpPath); ok {
+		if up := upPath.GetUp(t); up.Length() > 0 {
+			return up
+		}
+	}
+	return NewVector3(0, 1, 0)
+}
+
+// frame 抖动后的相机位置、视线方向（保持原来的长度）和上方向
+func (cs *CameraShake) frame(t float64) (position, forward, up Vector3) {
+	offset, yaw, pitch, roll := cs.offsets(t)
+	position = cs.Path.GetPosition(t)
+	forward = cs.Path.GetTarget(t).Sub(position)
+	up = cs.baseUp(t)
+	position = position.Add(offset)
+
+	right := forward.Cross(up)
+	if right.Length() < 1e-10 {
+		right = forward.Cross(stableUp(forward))
+	}
+	right = right.Normalize()
+	turn := QuaternionFromAxisAngle(right.Cross(forward).Normalize(), yaw).
+		Multiply(QuaternionFromAxisAngle(right, pitch))
+	forward = turn.Rotate(forward)
+	up = turn.Rotate(up)
+	if forward.Length() > 0 {
+		up = QuaternionFromAxisAngle(forward.Normalize(), roll).Rotate(up)
+	}
+	return position, forward, up
+}
+
+// GetPosition 抖动后的相机位置
+func (cs *CameraShake) GetPosition(t float64) Vector3 {
+	position, _, _ := cs.frame(t)
+	return position
+}
+
+// GetTarget 抖动后的目标点：随相机一起平移，再按偏航和俯仰转动视线
+func (cs *CameraShake) GetTarget(t float64) Vector3 {
+	position, forward, _ := cs.frame(t)
+	return position.Add(forward)
+}
+
+// GetFOV 原路径的视场角
+func (cs *CameraShake) GetFOV(t float64) float64 {
+	return cs.Path.GetFOV(t)
+}
+
+// GetUp 绕视线滚转后的上方向
+func (cs *CameraShake) GetUp(t float64) Vector3 {
+	_, _, up := cs.frame(t)
+	return up
+}
@@ -0,0 +1,193 @@
+package go3d
+
+import "math"
+
+// Bone 骨骼（关节）：Transform 是相对父骨骼的静止位姿，Angle 是绕本体 Axis 的关节角。
+// 绑定的网格随骨骼刚性运动，子骨骼继承父骨骼的全部运动，适合机械臂、钟表齿轮等铰接机构。
+// Angle 和 Transform 的各个分量都可以用时间线轨道驱动（FloatTrack、Vector3Track、QuaternionTrack）
+type Bone struct {
+	Name        string
+	Transform   *Transform // 相对父骨骼（根骨骼相对骨架）的位姿，Transform.Parent 不参与计算
+	Axis        Vector3    // 关节转轴（骨骼本体坐标），默认 +Z
+	Angle       float64    // 绕 Axis 的转角（弧度）
+	Attachments []BoneAttachment
+
+	skeleton *Skeleton
+	parent   *Bone
+	index    int
+}
+
+// BoneAttachment 刚性绑定在骨骼上的网格，顶点位于骨骼本体坐标系
+type BoneAttachment struct {
+	Mesh  *Mesh
+	Color [3]float64
+}
+
+// Skeleton 骨架：按添加顺序保存的骨骼层级，整体放置在 Transform 处
+type Skeleton struct {
+	Bones     []*Bone
+	Transform *Transform
+
+	ShowBones bool       // 绘制关节点和骨骼连线，便于调试层级
+	BoneColor [3]float64 // 骨骼连线的颜色
+}
+
+// NewSkeleton 创建空骨架
+func NewSkeleton() *Skeleton {
+	return &Skeleton{
+		Transform: NewTransform(),
+		BoneColor: [3]float64{1, 0.8, 0.2},
+	}
+}
+
+// AddBone 添加骨骼，关节位于父骨骼本体坐标的 position 处；parent 为 nil 时是根骨骼，position 相对骨架。
+// 父骨骼必须已经属于该骨架
+func (s *Skeleton) AddBone(name string, parent *Bone, position Vector3) *Bone {
+	if parent != nil && parent.skeleton != s {
+		panic("go3d: 父骨骼不属于该骨架")
+	}
+	bone := &Bone{
+		Name:      name,
+		Transform: NewTransform().SetPosition(position),
+		Axis:      NewVector3(0, 0, 1),
+		skeleton:  s,
+		parent:    parent,
+		index:     len(s.Bones),
+	}
+	s.Bones = append(s.Bones, bone)
+	return bone
+}
+
+// Bone 按名称查找骨骼，找不到时返回 nil
+func (s *Skeleton) Bone(name string) *Bone {
+	for _, bone := range s.Bones {
+		if bone.Name == name {
+			return bone
+		}
+	}
+	return nil
+}
+
+// Parent 父骨骼，根骨骼返回 nil
+func (b *Bone) Parent() *Bone {
+	return b.parent
+}
+
+// SetAxis 设置关节转轴
+func (b *Bone) SetAxis(axis Vector3) *Bone {
+	b.Axis = axis
+	return b
+}
+
+// SetAngle 设置关节角（弧度）
+func (b *Bone) SetAngle(angle float64) *Bone {
+	b.Angle = angle
+	return b
+}
+
+// Attach 把网格刚性绑定到骨骼上，网格顶点按骨骼本体坐标给出（关节位于原点）
+func (b *Bone) Attach(mesh *Mesh, color [3]float64) *Bone {
+	b.Attachments = append(b.Attachments, BoneAttachment{Mesh: mesh, Color: color})
+	return b
+}
+
+// LocalMatrix 相对父骨骼的变换：静止位姿之后再绕 Axis 转过 Angle
+func (b *Bone) LocalMatrix() Matrix4 {
+	local := b.Transform.Matrix()
+	if b.Angle != 0 && b.Axis.Length() > 0 {
+		local = local.Multiply(QuaternionFromAxisAngle(b.Axis.Normalize(), b.Angle).Matrix())
+	}
+	return local
+}
+
+// WorldMatrix 骨骼本体坐标到世界坐标的变换，叠加所有父骨骼和骨架的变换
+func (b *Bone) WorldMatrix() Matrix4 {
+	matrix := b.LocalMatrix()
+	for parent := b.parent; parent != nil; parent = parent.parent {
+		matrix = parent.LocalMatrix().Multiply(matrix)
+	}
+	if b.skeleton != nil && b.skeleton.Transform != nil {
+		matrix = b.skeleton.Transform.WorldMatrix().Multiply(matrix)
+	}
+	return matrix
+}
+
+// WorldPosition 关节在世界坐标中的位置，可用于让相机或轨迹跟随机械臂末端
+func (b *Bone) WorldPosition() Vector3 {
+	return b.WorldMatrix().TransformVector(Vector3{})
+}
+
+// worldMatrices 按添加顺序求出所有骨骼的世界变换，父骨骼总是先于子骨骼添加，每根骨骼只计算一次
+func (s *Skeleton) worldMatrices() []Matrix4 {
+	root := Identity()
+	if s.Transform != nil {
+		root = s.Transform.WorldMatrix()
+	}
+	matrices := make([]Matrix4, len(s.Bones))
+	for i, bone := range s.Bones {
+		parent := root
+		if bone.parent != nil {
+			parent = matrices[bone.parent.index]
+		}
+		matrices[i] = parent.Multiply(bone.LocalMatrix())
+	}
+	return matrices
+}
+
+// Render 按当前关节角绘制所有绑定的网格，ShowBones 为 true 时叠加骨骼连线
+func (s *Skeleton) Render(renderer *Renderer, t float64) {
+	matrices := s.worldMatrices()
+	for i, bone := range s.Bones {
+		for _, attachment := range bone.Attachments {
+			if attachment.Mesh != nil {
+				renderer.DrawMeshTransformed(attachment.Mesh, matrices[i], attachment.Color)
+			}
+		}
+	}
+
+	if !s.ShowBones {
+		return
+	}
+	joints := make([]Vector3, len(s.Bones))
+	for i, bone := range s.Bones {
+		joints[i] = matrices[i].TransformVector(Vector3{})
+		if bone.parent != nil {
+			renderer.DrawLine3D(joints[bone.parent.index], joints[i], s.BoneColor, 2)
+		}
+	}
+	renderer.DrawOverlay(func() {
+		renderer.Context.Save()
+		defer renderer.Context.Restore()
+		renderer.Context.SetSourceRGB(s.BoneColor[0], s.BoneColor[1], s.BoneColor[2])
+		for _, joint := range joints {
+			x, y, z := renderer.ProjectToScreen(joint)
+			if z < -1 || z > 1 {
+				continue
+			}
+			renderer.Context.NewSubPath()
+			renderer.Context.Arc(x, y, 4, 0, 2*math.Pi)
+			renderer.Context.Fill()
+		}
+	})
+}
+
+// WorldBounds 所有绑定网格和关节点在时间 t 的包围盒
+func (s *Skeleton) WorldBounds(t float64) AABB {
+	matrices := s.worldMatrices()
+	var box AABB
+	for i, bone := range s.Bones {
+		joint := matrices[i].TransformVector(Vector3{})
+		part := AABB{Min: joint, Max: joint}
+		for _, attachment := range bone.Attachments {
+			if attachment.Mesh != nil && len(attachment.Mesh.Vertices) > 0 {
+				part = part.union(transformBounds(attachment.Mesh.BoundingBox(), matrices[i]))
+			}
+		}
+		if i == 0 {
+			box = part
+		} else {
+			box = box.union(part)
+		}
+	}
+	return box
+}
@@ -0,0 +1,271 @@
+package go3d
+
+import "math"
+
+// sliceEpsilon 判断顶点是否在切割平面上的容差
+const sliceEpsilon = 1e-9
+
+// SliceByPlane 用平面把网格切成两半
+// front 为平面正侧（Normal 指向的一侧）的部分，back 为负侧的部分；sections 为截面轮廓折线，
+// 闭合轮廓的首尾点相同。caps 为 true 时用闭合截面轮廓填充两半的切口（带孔洞的截面也能正确填充，
+// 平面与网格原有的面重合处不重复填充），
+// 端面沿用第一个三角形的逐面颜色和不透明度。被切开的三角形按重心坐标插值顶点颜色和纹理坐标
+func (m *Mesh) SliceByPlane(plane Plane, caps bool) (front, back *Mesh, sections [][]Vector3) {
+	front, back = NewMesh(), NewMesh()
+	var segments [][2]Vector3
+
+	// 位于平面上的三角形的边：这些边围成的区域已经有面，不再生成端面
+	covered := make(map[[2]vertexKey]bool)
+	for _, tri := range m.Triangles {
+		if math.Abs(plane.Distance(tri.V0)) <= sliceEpsilon &&
+			math.Abs(plane.Distance(tri.V1)) <= sliceEpsilon &&
+			math.Abs(plane.Distance(tri.V2)) <= sliceEpsilon {
+			verts := [3]Vector3{tri.V0, tri.V1, tri.V2}
+			for k := range 3 {
+				if key, ok := undirectedEdgeKey(verts[k], verts[(k+1)%3]); ok {
+					covered[key] = true
+				}
+			}
+		}
+	}
+	var capSegments [][2]Vector3
+
+	for i, tri := range m.Triangles {
+		verts := [3]Vector3{tri.V0, tri.V1, tri.V2}
+		var dist [3]float64
+		frontCount, backCount := 0, 0
+		for k, v := range verts {
+			dist[k] = plane.Distance(v)
+			if dist[k] > sliceEpsilon {
+				frontCount++
+			} else if dist[k] < -sliceEpsilon {
+				backCount++
+			}
+		}
+
+		switch {
+		case frontCount == 0 && backCount == 0:
+			// 位于平面上的三角形：法线与平面同向的属于负侧部分的切口，反之属于正侧
+			if tri.Normal().Dot(plane.Normal) > 0 {
+				m.appendSlicePiece(back, i, verts[:])
+			} else {
+				m.appendSlicePiece(front, i, verts[:])
+			}
+			continue
+		case backCount == 0:
+			// 贴在平面上的边由负侧的相邻三角形记录截面线段，避免重复
+			m.appendSlicePiece(front, i, verts[:])
+			continue
+		case frontCount == 0:
+			m.appendSlicePiece(back, i, verts[:])
+			if onPlane := slicePointsOnPlane(verts, dist); len(onPlane) == 2 {
+				segment := [2]Vector3{onPlane[0], onPlane[1]}
+				segments = append(segments, segment)
+				if key, ok := undirectedEdgeKey(segment[0], segment[1]); ok && !covered[key] {
+					capSegments = append(capSegments, segment)
+				}
+			}
+			continue
+		}
+
+		// 跨越平面：切分为正负两侧的凸多边形
+		var frontPoly, backPoly, cut []Vector3
+		for k := range 3 {
+			j := (k + 1) % 3
+			vi, vj := verts[k], verts[j]
+			di, dj := dist[k], dist[j]
+
+			if di >= -sliceEpsilon {
+				frontPoly = append(frontPoly, vi)
+			}
+			if di <= sliceEpsilon {
+				backPoly = append(backPoly, vi)
+			}
+			if di >= -sliceEpsilon && di <= sliceEpsilon {
+				cut = append(cut, vi)
+			}
+
+			if (di > sliceEpsilon && dj < -sliceEpsilon) || (di < -sliceEpsilon && dj > sliceEpsilon) {
+				p := vi.Add(vj.Sub(vi).Scale(di / (di - dj)))
+				frontPoly = append(frontPoly, p)
+				backPoly = append(backPoly, p)
+				cut = append(cut, p)
+			}
+		}
+
+		m.appendSlicePiece(front, i, frontPoly)
+		m.appendSlicePiece(back, i, backPoly)
+		if len(cut) == 2 {
+			segments = append(segments, [2]Vector3{cut[0], cut[1]})
+			capSegments = append(capSegments, [2]Vector3{cut[0], cut[1]})
+		}
+	}
+
+	sections = chainSegments(segments)
+	if caps {
+		m.addSliceCaps(front, back, plane, chainSegments(capSegments))
+	}
+	return front, back, sections
+}
+
+// slicePointsOnPlane 返回位于平面上的顶点
+func slicePointsOnPlane(verts [3]Vector3, dist [3]float64) []Vector3 {
+	var points []Vector3
+	for k, v := range verts {
+		if dist[k] >= -sliceEpsilon && dist[k] <= sliceEpsilon {
+			points = append(points, v)
+		}
+	}
+	return points
+}
+
+// appendSlicePiece 将第 i 个三角形的一部分（凸多边形）按扇形三角化加入 dst，并带上该三角形的属性
+func (m *Mesh) appendSlicePiece(dst *Mesh, i int, poly []Vector3) {
+	src := m.Triangles[i]
+	for k := 1; k+1 < len(poly); k++ {
+		tri := Triangle{V0: poly[0], V1: poly[k], V2: poly[k+1]}
+		dst.AddTriangle(tri)
+		if m.HasFaceColors() {
+			dst.FaceColors = append(dst.FaceColors, m.FaceColors[i])
+		}
+		if m.HasFaceAlpha() {
+			dst.FaceAlpha = append(dst.FaceAlpha, m.FaceAlpha[i])
+		}
+
+		// 新顶点的逐顶点属性按原三角形的重心坐标插值
+		var weights [3][3]float64
+		for j, v := range [3]Vector3{tri.V0, tri.V1, tri.V2} {
+			b0, b1, b2 := src.Barycentric(v)
+			weights[j] = [3]float64{b0, b1, b2}
+		}
+		if m.HasVertexColors() {
+			c := m.VertexColors[i]
+			var colors [3][3]float64
+			for j, w := range weights {
+				for ch := range 3 {
+					colors[j][ch] = w[0]*c[0][ch] + w[1]*c[1][ch] + w[2]*c[2][ch]
+				}
+			}
+			dst.VertexColors = append(dst.VertexColors, colors)
+		}
+		if m.HasUVs() {
+			uv := m.UVs[i]
+			var uvs [3][2]float64
+			for j, w := range weights {
+				for ch := range 2 {
+					uvs[j][ch] = w[0]*uv[0][ch] + w[1]*uv[1][ch] + w[2]*uv[2][ch]
+				}
+			}
+			dst.UVs = append(dst.UVs, uvs)
+		}
+	}
+	for _, v := range poly {
+		dst.AddVertex(v)
+	}
+}
+
+// chainSegments 把首尾相接的线段连成折线：先从端点（只连一条线段的点）出发得到开放折线，
+// 剩下的都是闭合轮廓
+func chainSegments(segments [][2]Vector3) [][]Vector3 {
+	byPoint := make(map[vertexKey][]int)
+	for i, s := range segments {
+		for _, p := range s {
+			key := quantizeVertex(p)
+			byPoint[key] = append(byPoint[key], i)
+		}
+	}
+
+	used := make([]bool, len(segments))
+	walk := func(start int, from Vector3) []Vector3 {
+		polyline := []Vector3{from}
+		current, at := start, from
+		for current >= 0 {
+			used[current] = true
+			s := segments[current]
+			next := s[0]
+			if quantizeVertex(s[0]) == quantizeVertex(at) {
+				next = s[1]
+			}
+			polyline = append(polyline, next)
+			at = next
+
+			current = -1
+			for _, j := range byPoint[quantizeVertex(at)] {
+				if !used[j] {
+					current = j
+					break
+				}
+			}
+		}
+		return polyline
+	}
+
+	var polylines [][]Vector3
+	for i, s := range segments {
+		if used[i] {
+			continue
+		}
+		for _, p := range s {
+			if len(byPoint[quantizeVertex(p)]) == 1 {
+				polylines = append(polylines, walk(i, p))
+				break
+			}
+		}
+	}
+	for i, s := range segments {
+		if !used[i] {
+			polylines = append(polylines, walk(i, s[0]))
+		}
+	}
+	return polylines
+}
+
+// addSliceCaps 用闭合截面轮廓填充切口：负侧部分的端面法线与平面法线同向，正侧部分反向
+func (m *Mesh) addSliceCaps(front, back *Mesh, plane Plane, sections [][]Vector3) {
+	u, v := plane.basis()
+	origin := plane.Normal.Scale(plane.D)
+
+	var contours [][]Vector2
+	for _, section := range sections {
+		n := len(section)
+		if n < 4 || quantizeVertex(section[0]) != quantizeVertex(section[n-1]) {
+			continue // 开放折线无法填充
+		}
+		contour := make([]Vector2, n-1)
+		for k, p := range section[:n-1] {
+			d := p.Sub(origin)
+			contour[k] = NewVector2(d.Dot(u), d.Dot(v))
+		}
+		contours = append(contours, contour)
+	}
+
+	to3D := func(p Vector2) Vector3 {
+		return origin.Add(u.Scale(p.X)).Add(v.Scale(p.Y))
+	}
+	for _, shape := range groupContours(contours) {
+		for _, tri := range TriangulatePolygon(shape[0], shape[1:]) {
+			a, b, c := to3D(tri[0]), to3D(tri[1]), to3D(tri[2])
+			m.appendCapTriangle(back, Triangle{V0: a, V1: b, V2: c})
+			m.appendCapTriangle(front, Triangle{V0: a, V1: c, V2: b})
+		}
+	}
+}
+
+// appendCapTriangle 加入端面三角形，保持逐面属性数组与三角形一一对应
+// dst 的属性与原网格一致（由 appendSlicePiece 生成），端面沿用第一个三角形的属性
+func (m *Mesh) appendCapTriangle(dst *Mesh, tri Triangle) {
+	dst.AddTriangle(tri)
+	if m.HasFaceColors() {
+		dst.FaceColors = append(dst.FaceColors, m.FaceColors[0])
+	}
+	if m.HasFaceAlpha() {
+		dst.FaceAlpha = append(dst.FaceAlpha, m.FaceAlpha[0])
+	}
+	if m.HasVertexColors() {
+		c := m.VertexColors[0][0]
+		dst.VertexColors = append(dst.VertexColors, [3][3]float64{c, c, c})
+	}
+	if m.HasUVs() {
+		dst.UVs = append(dst.UVs, [3][2]float64{})
+	}
+}
@@ -0,0 +1,675 @@
+//go:build purego
+
+package go3d
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"os"
+
+	"golang.org/x/image/vector"
+)
+
+// Operator 合成模式
+type Operator int
+
+const (
+	OperatorOver   Operator = iota // 按 alpha 叠加到已有内容上
+	OperatorSource                 // 用源替换已有内容
+	OperatorAdd                    // 与已有内容逐通道相加（饱和到最大值），用于发光效果
+)
+
+// LineCap 线段端点样式
+type LineCap int
+
+const (
+	LineCapButt   LineCap = iota // 平头，止于端点
+	LineCapRound                 // 圆头
+	LineCapSquare                // 方头，越过端点半个线宽
+)
+
+// LineJoin 折线拐角样式
+type LineJoin int
+
+const (
+	LineJoinMiter LineJoin = iota // 尖角，过长时退化为斜角
+	LineJoinRound                 // 圆角
+	LineJoinBevel                 // 斜角
+)
+
+// Antialias 抗锯齿方式
+type Antialias int
+
+const (
+	AntialiasDefault Antialias = iota
+	AntialiasNone              // 按覆盖率阈值输出硬边缘
+	AntialiasGood
+)
+
+// miterLimit 尖角长度与线宽之比的上限，与 cairo 的默认值一致
+const miterLimit = 10
+
+// SoftSurface 软件光栅化使用的图像表面，像素为预乘 alpha 的 RGBA
+type SoftSurface struct {
+	img *image.RGBA
+}
+
+// NewSoftSurface 创建完全透明的图像表面
+func NewSoftSurface(width, height int) *SoftSurface {
+	return &SoftSurface{img: image.NewRGBA(image.Rect(0, 0, width, height))}
+}
+
+// GetGoImage 返回表面的像素（*image.RGBA），修改会直接反映到表面上
+func (s *SoftSurface) GetGoImage() image.Image {
+	return s.img
+}
+
+// WriteToPNG 保存为 PNG 文件
+func (s *SoftSurface) WriteToPNG(filename string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(file, s.img); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Destroy 释放表面（像素由垃圾回收器回收）
+func (s *SoftSurface) Destroy() {}
+
+// SoftMeshPattern 网格渐变图案（对应 cairo 的 mesh pattern），每个面片的四个角各有一种颜色，
+// 面片内部按角的颜色插值。只支持直边面片：面片沿对角线 0-2 分成两个三角形，
+// 在三角形内按重心坐标插值（只给出三条边的面片即为 Gouraud 三角形，结果与 cairo 相同）
+type SoftMeshPattern struct {
+	patches []softPatch
+	current *softPatch
+	bounds  image.Rectangle
+}
+
+// softPatch 网格图案中的一个面片
+type softPatch struct {
+	corners [4][2]float64
+	colors  [4][4]float64 // 非预乘的 RGBA
+	sides   int           // 已加入的边数
+}
+
+// NewSoftMeshPattern 创建空的网格渐变图案
+func NewSoftMeshPattern() *SoftMeshPattern {
+	return &SoftMeshPattern{}
+}
+
+// BeginPatch 开始一个新的面片
+func (m *SoftMeshPattern) BeginPatch() {
+	m.current = &softPatch{sides: -1}
+}
+
+// MoveTo 设置面片的第一个角
+func (m *SoftMeshPattern) MoveTo(x, y float64) {
+	if m.current == nil || m.current.sides >= 0 {
+		return
+	}
+	m.current.corners[0] = [2]float64{x, y}
+	m.current.sides = 0
+}
+
+// LineTo 加入面片的一条直边，终点为下一个角
+func (m *SoftMeshPattern) LineTo(x, y float64) {
+	if m.current == nil {
+		return
+	}
+	if m.current.sides < 0 {
+		m.MoveTo(x, y)
+		return
+	}
+	if m.current.sides >= 4 {
+		return
+	}
+	m.current.sides++
+	if m.current.sides < 4 {
+		m.current.corners[m.current.sides] = [2]float64{x, y}
+	}
+}
+
+// SetCornerColorRGB 设置面片第 corner 个角（0-3）的不透明颜色
+func (m *SoftMeshPattern) SetCornerColorRGB(corner uint, r, g, b float64) {
+	m.SetCornerColorRGBA(corner, r, g, b, 1)
+}
+
+// SetCornerColorRGBA 设置面片第 corner 个角（0-3）的颜色
+func (m *SoftMeshPattern) SetCornerColorRGBA(corner uint, r, g, b, a float64) {
+	if m.current == nil || corner > 3 {
+		return
+	}
+	m.current.colors[corner] = [4]float64{r, g, b, a}
+}
+
+// EndPatch 结束当前面片，不足四条边时用直线回到第一个角闭合（第四个角与第一个角重合）
+func (m *SoftMeshPattern) EndPatch() {
+	patch := m.current
+	m.current = nil
+	if patch == nil || patch.sides < 1 {
+		return
+	}
+	for k := patch.sides + 1; k < 4; k++ {
+		patch.corners[k] = patch.corners[0]
+	}
+	m.patches = append(m.patches, *patch)
+
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range patch.corners {
+		minX, maxX = math.Min(minX, p[0]), math.Max(maxX, p[0])
+		minY, maxY = math.Min(minY, p[1]), math.Max(maxY, p[1])
+	}
+	// 边缘的抗锯齿像素中心可能落在面片外，包围盒各向外扩一个像素
+	rect := image.Rect(int(math.Floor(minX))-1, int(math.Floor(minY))-1, int(math.Ceil(maxX))+1, int(math.Ceil(maxY))+1)
+	m.bounds = m.bounds.Union(rect)
+}
+
+// Destroy 释放图案
+func (m *SoftMeshPattern) Destroy() {
+	m.patches = nil
+}
+
+// ColorModel 实现 image.Image
+func (m *SoftMeshPattern) ColorModel() color.Model {
+	return color.RGBAModel
+}
+
+// Bounds 实现 image.Image：所有面片的包围盒
+func (m *SoftMeshPattern) Bounds() image.Rectangle {
+	return m.bounds
+}
+
+// At 实现 image.Image：像素中心处的预乘颜色，后加入的面片覆盖先加入的面片。
+// 落在所有面片外的像素取最近面片钳制后的颜色，使抗锯齿边缘的颜色不外推
+func (m *SoftMeshPattern) At(x, y int) color.Color {
+	px, py := float64(x)+0.5, float64(y)+0.5
+	var result [4]float64
+	clamped := false
+search:
+	for i := len(m.patches) - 1; i >= 0; i-- {
+		for _, t := range [2][3]int{{0, 1, 2}, {0, 2, 3}} {
+			c, inside, ok := m.patches[i].interpolate(t, px, py)
+			if !ok {
+				continue
+			}
+			if inside {
+				result = c
+				break search
+			}
+			if !clamped {
+				result, clamped = c, true
+			}
+		}
+	}
+	a := math.Max(0, math.Min(1, result[3]))
+	channel := func(v float64) uint8 {
+		return uint8(math.Max(0, math.Min(1, v))*a*255 + 0.5)
+	}
+	return color.RGBA{channel(result[0]), channel(result[1]), channel(result[2]), uint8(a*255 + 0.5)}
+}
+
+// interpolate 按重心坐标在面片的三角形 t（三个角的编号）内插值颜色，
+// inside 表示点是否在三角形内，三角形退化时 ok 为 false。三角形外的点钳制重心坐标
+func (p *softPatch) interpolate(t [3]int, x, y float64) (c [4]float64, inside, ok bool) {
+	a, b, d := p.corners[t[0]], p.corners[t[1]], p.corners[t[2]]
+	denom := (b[1]-d[1])*(a[0]-d[0]) + (d[0]-b[0])*(a[1]-d[1])
+	if math.Abs(denom) < 1e-10 {
+		return c, false, false
+	}
+	w0 := ((b[1]-d[1])*(x-d[0]) + (d[0]-b[0])*(y-d[1])) / denom
+	w1 := ((d[1]-a[1])*(x-d[0]) + (a[0]-d[0])*(y-d[1])) / denom
+	w2 := 1 - w0 - w1
+	inside = w0 >= 0 && w1 >= 0 && w2 >= 0
+
+	w0, w1, w2 = math.Max(0, w0), math.Max(0, w1), math.Max(0, w2)
+	sum := w0 + w1 + w2
+	for k := range 4 {
+		c[k] = (w0*p.colors[t[0]][k] + w1*p.colors[t[1]][k] + w2*p.colors[t[2]][k]) / sum
+	}
+	return c, inside, true
+}
+
+// softState Save/Restore 保存的绘图状态
+type softState struct {
+	source    image.Image // *image.Uniform（预乘颜色）、源表面或网格渐变图案
+	origin    image.Point // 源表面左上角在画布上的位置
+	lineWidth float64
+	lineCap   LineCap
+	lineJoin  LineJoin
+	operator  Operator
+	antialias Antialias
+}
+
+// softSubpath 路径中的一段连续折线
+type softSubpath struct {
+	points [][2]float64
+	closed bool
+}
+
+// SoftContext 纯 Go 的软件光栅化绘图上下文，实现渲染器用到的 cairo 绘图操作子集：
+// 路径（直线、圆弧、矩形）的填充和描边、纯色、图像表面与网格渐变作为源、OVER/SOURCE/ADD 合成
+// 曲线路径在加入时即折线化，不支持变换矩阵、虚线和裁剪
+type SoftContext struct {
+	target *SoftSurface
+	state  softState
+	saved  []softState
+
+	subpaths   []softSubpath
+	hasCurrent bool // 是否有当前点（NewSubPath、Fill、Stroke 之后没有）
+
+	raster vector.Rasterizer
+	mask   image.Alpha // 覆盖率缓冲，尺寸不足时重新分配
+}
+
+// NewSoftContext 创建绘制到 target 的绘图上下文
+func NewSoftContext(target *SoftSurface) *SoftContext {
+	return &SoftContext{
+		target: target,
+		state: softState{
+			source:    image.NewUniform(color.RGBA{0, 0, 0, 255}),
+			lineWidth: 2,
+			antialias: AntialiasDefault,
+		},
+	}
+}
+
+// Save 保存绘图状态（不包括路径）
+func (c *SoftContext) Save() {
+	c.saved = append(c.saved, c.state)
+}
+
+// Restore 恢复最近一次 Save 保存的绘图状态
+func (c *SoftContext) Restore() {
+	if n := len(c.saved); n > 0 {
+		c.state = c.saved[n-1]
+		c.saved = c.saved[:n-1]
+	}
+}
+
+// Destroy 释放上下文的缓冲
+func (c *SoftContext) Destroy() {
+	c.subpaths = nil
+	c.mask = image.Alpha{}
+}
+
+// SetSourceRGB 设置不透明的纯色源
+func (c *SoftContext) SetSourceRGB(r, g, b float64) {
+	c.SetSourceRGBA(r, g, b, 1)
+}
+
+// SetSourceRGBA 设置带透明度的纯色源
+func (c *SoftContext) SetSourceRGBA(r, g, b, a float64) {
+	a = math.Max(0, math.Min(1, a))
+	channel := func(v float64) uint8 {
+		return uint8(math.Max(0, math.Min(1, v))*a*255 + 0.5)
+	}
+	c.state.source = image.NewUniform(color.RGBA{channel(r), channel(g), channel(b), uint8(a*255 + 0.5)})
+	c.state.origin = image.Point{}
+}
+
+// SetSourceSurface 以图像表面为源，表面左上角位于 (x, y)（取整到像素）
+func (c *SoftContext) SetSourceSurface(surface *SoftSurface, x, y float64) {
+	c.state.source = surface.img
+	c.state.origin = image.Pt(int(math.Round(x)), int(math.Round(y)))
+}
+
+// SetSource 以网格渐变图案为源，图案坐标即画布坐标
+func (c *SoftContext) SetSource(pattern *SoftMeshPattern) {
+	c.state.source = pattern
+	c.state.origin = image.Point{}
+}
+
+// SetOperator 设置合成模式
+func (c *SoftContext) SetOperator(op Operator) {
+	c.state.operator = op
+}
+
+// SetAntialias 设置抗锯齿方式
+func (c *SoftContext) SetAntialias(antialias Antialias) {
+	c.state.antialias = antialias
+}
+
+// SetLineWidth 设置描边线宽（像素）
+func (c *SoftContext) SetLineWidth(width float64) {
+	c.state.lineWidth = width
+}
+
+// SetLineCap 设置线段端点样式
+func (c *SoftContext) SetLineCap(lineCap LineCap) {
+	c.state.lineCap = lineCap
+}
+
+// SetLineJoin 设置折线拐角样式
+func (c *SoftContext) SetLineJoin(lineJoin LineJoin) {
+	c.state.lineJoin = lineJoin
+}
+
+// MoveTo 开始新的子路径
+func (c *SoftContext) MoveTo(x, y float64) {
+	c.subpaths = append(c.subpaths, softSubpath{points: [][2]float64{{x, y}}})
+	c.hasCurrent = true
+}
+
+// LineTo 从当前点画直线到 (x, y)，没有当前点时等同于 MoveTo
+func (c *SoftContext) LineTo(x, y float64) {
+	if !c.hasCurrent {
+		c.MoveTo(x, y)
+		return
+	}
+	last := &c.subpaths[len(c.subpaths)-1]
+	if last.closed {
+		// 闭合后继续绘制时，从闭合子路径的起点开始新的子路径
+		c.MoveTo(last.points[0][0], last.points[0][1])
+		last = &c.subpaths[len(c.subpaths)-1]
+	}
+	last.points = append(last.points, [2]float64{x, y})
+}
+
+// NewSubPath 清除当前点，下一个绘制操作开始新的子路径
+func (c *SoftContext) NewSubPath() {
+	c.hasCurrent = false
+}
+
+// ClosePath 闭合当前子路径
+func (c *SoftContext) ClosePath() {
+	if c.hasCurrent {
+		c.subpaths[len(c.subpaths)-1].closed = true
+	}
+}
+
+// Rectangle 加入一个闭合的矩形子路径
+func (c *SoftContext) Rectangle(x, y, width, height float64) {
+	c.MoveTo(x, y)
+	c.LineTo(x+width, y)
+	c.LineTo(x+width, y+height)
+	c.LineTo(x, y+height)
+	c.ClosePath()
+}
+
+// Arc 加入以 (xc, yc) 为圆心、从 angle1 顺时针（屏幕坐标）到 angle2 的圆弧
+// 有当前点时先画直线连接到圆弧起点
+func (c *SoftContext) Arc(xc, yc, radius, angle1, angle2 float64) {
+	for angle2 < angle1 {
+		angle2 += 2 * math.Pi
+	}
+	steps := max(1, int(math.Ceil((angle2-angle1)/arcStep(radius))))
+	for i := 0; i <= steps; i++ {
+		angle := angle1 + (angle2-angle1)*float64(i)/float64(steps)
+		c.LineTo(xc+radius*math.Cos(angle), yc+radius*math.Sin(angle))
+	}
+}
+
+// arcStep 半径为 radius 的圆弧折线化时每段的角度，保证弦高不超过 0.1 像素
+func arcStep(radius float64) float64 {
+	if radius <= 0.2 {
+		return math.Pi / 4
+	}
+	return math.Max(2*math.Acos(1-0.1/radius), 2*math.Pi/256)
+}
+
+// Fill 按非零环绕规则填充当前路径（子路径自动闭合），之后清空路径
+func (c *SoftContext) Fill() {
+	polygons := make([][][2]float64, 0, len(c.subpaths))
+	for _, sp := range c.subpaths {
+		if len(sp.points) >= 3 {
+			polygons = append(polygons, sp.points)
+		}
+	}
+	c.fillPolygons(polygons)
+	c.newPath()
+}
+
+// Stroke 以当前线宽、端点和拐角样式描边当前路径，之后清空路径
+func (c *SoftContext) Stroke() {
+	var polygons [][][2]float64
+	for _, sp := range c.subpaths {
+		polygons = c.strokeSubpath(polygons, sp)
+	}
+	c.fillPolygons(polygons)
+	c.newPath()
+}
+
+// Paint 用当前源覆盖整个画布
+func (c *SoftContext) Paint() {
+	bounds := c.target.img.Bounds()
+	if c.state.operator == OperatorAdd {
+		c.drawAdd(bounds, nil)
+		return
+	}
+	draw.Draw(c.target.img, bounds, c.state.source, bounds.Min.Sub(c.state.origin), c.drawOp())
+}
+
+// newPath 清空路径
+func (c *SoftContext) newPath() {
+	c.subpaths = c.subpaths[:0]
+	c.hasCurrent = false
+}
+
+// drawOp 当前合成模式对应的 draw.Op
+func (c *SoftContext) drawOp() draw.Op {
+	if c.state.operator == OperatorSource {
+		return draw.Src
+	}
+	return draw.Over
+}
+
+// fillPolygons 光栅化多边形并用当前源合成到画布上
+// 只处理多边形包围盒与画布相交的部分，各多边形按非零环绕规则合并
+func (c *SoftContext) fillPolygons(polygons [][][2]float64) {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, polygon := range polygons {
+		for _, p := range polygon {
+			minX, maxX = math.Min(minX, p[0]), math.Max(maxX, p[0])
+			minY, maxY = math.Min(minY, p[1]), math.Max(maxY, p[1])
+		}
+	}
+	if minX > maxX {
+		return
+	}
+	bounds := image.Rect(int(math.Floor(minX)), int(math.Floor(minY)), int(math.Ceil(maxX)), int(math.Ceil(maxY))).
+		Intersect(c.target.img.Bounds())
+	if bounds.Empty() {
+		return
+	}
+
+	// 在包围盒大小的覆盖率缓冲中光栅化
+	width, height := bounds.Dx(), bounds.Dy()
+	c.raster.Reset(width, height)
+	c.raster.DrawOp = draw.Src
+	ox, oy := float64(bounds.Min.X), float64(bounds.Min.Y)
+	for _, polygon := range polygons {
+		c.raster.MoveTo(float32(polygon[0][0]-ox), float32(polygon[0][1]-oy))
+		for _, p := range polygon[1:] {
+			c.raster.LineTo(float32(p[0]-ox), float32(p[1]-oy))
+		}
+		c.raster.ClosePath()
+	}
+	if cap(c.mask.Pix) < width*height {
+		c.mask.Pix = make([]uint8, width*height)
+	}
+	c.mask.Pix = c.mask.Pix[:width*height]
+	c.mask.Stride = width
+	c.mask.Rect = image.Rect(0, 0, width, height)
+	c.raster.Draw(&c.mask, c.mask.Rect, image.Opaque, image.Point{})
+
+	if c.state.antialias == AntialiasNone {
+		for i, v := range c.mask.Pix {
+			if v >= 128 {
+				c.mask.Pix[i] = 255
+			} else {
+				c.mask.Pix[i] = 0
+			}
+		}
+	}
+
+	if c.state.operator == OperatorAdd {
+		c.drawAdd(bounds, &c.mask)
+		return
+	}
+	draw.DrawMask(c.target.img, bounds, c.state.source, bounds.Min.Sub(c.state.origin), &c.mask, image.Point{}, c.drawOp())
+}
+
+// drawAdd 把源乘以覆盖率后逐通道加到画布的 bounds 区域上（预乘 alpha，饱和到 255），
+// mask 为空时覆盖率为 1。image/draw 没有加法合成，这里逐像素计算
+func (c *SoftContext) drawAdd(bounds image.Rectangle, mask *image.Alpha) {
+	dst, origin := c.target.img, c.state.origin
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			coverage := uint32(0xffff)
+			if mask != nil {
+				coverage = uint32(mask.Pix[(y-bounds.Min.Y)*mask.Stride+x-bounds.Min.X]) * 0x101
+				if coverage == 0 {
+					continue
+				}
+			}
+			sr, sg, sb, sa := c.state.source.At(x-origin.X, y-origin.Y).RGBA()
+			i := dst.PixOffset(x, y)
+			for k, v := range [4]uint32{sr, sg, sb, sa} {
+				sum := uint32(dst.Pix[i+k]) + (v*coverage/0xffff)>>8
+				dst.Pix[i+k] = uint8(min(sum, 0xff))
+			}
+		}
+	}
+}
+
+// strokeSubpath 把一段子路径的描边转换为多边形（线段矩形、拐角和端点），追加到 polygons
+// 所有多边形统一为同一绕向，重叠部分按非零环绕规则合并，半透明描边不会重复叠加
+func (c *SoftContext) strokeSubpath(polygons [][][2]float64, sp softSubpath) [][][2]float64 {
+	half := c.state.lineWidth / 2
+	if half <= 0 {
+		return polygons
+	}
+
+	// 去掉重复点，闭合路径去掉与起点重合的终点
+	points := make([][2]float64, 0, len(sp.points))
+	for _, p := range sp.points {
+		if len(points) == 0 || math.Hypot(p[0]-points[len(points)-1][0], p[1]-points[len(points)-1][1]) > 1e-9 {
+			points = append(points, p)
+		}
+	}
+	closed := sp.closed && len(points) > 2
+	if closed && points[0] == points[len(points)-1] {
+		points = points[:len(points)-1]
+	}
+
+	if len(points) == 1 {
+		// 零长度子路径只在圆头端点时绘制一个圆点
+		if c.state.lineCap == LineCapRound {
+			polygons = append(polygons, circlePolygon(points[0], half))
+		}
+		return polygons
+	}
+
+	segments := len(points) - 1
+	if closed {
+		segments = len(points)
+	}
+	for i := range segments {
+		a, b := points[i], points[(i+1)%len(points)]
+		dx, dy := b[0]-a[0], b[1]-a[1]
+		length := math.Hypot(dx, dy)
+		ux, uy := dx/length, dy/length
+		// 方头端点沿线段方向延长半个线宽
+		if !closed && c.state.lineCap == LineCapSquare {
+			if i == 0 {
+				a = [2]float64{a[0] - ux*half, a[1] - uy*half}
+			}
+			if i == segments-1 {
+				b = [2]float64{b[0] + ux*half, b[1] + uy*half}
+			}
+		}
+		nx, ny := -uy*half, ux*half
+		polygons = appendOriented(polygons, [][2]float64{
+			{a[0] + nx, a[1] + ny}, {b[0] + nx, b[1] + ny}, {b[0] - nx, b[1] - ny}, {a[0] - nx, a[1] - ny},
+		})
+	}
+
+	// 拐角
+	for i, p := range points {
+		if !closed && (i == 0 || i == len(points)-1) {
+			continue
+		}
+		prev, next := points[(i+len(points)-1)%len(points)], points[(i+1)%len(points)]
+		polygons = c.appendJoin(polygons, prev, p, next, half)
+	}
+
+	// 圆头端点
+	if !closed && c.state.lineCap == LineCapRound {
+		polygons = append(polygons, circlePolygon(points[0], half), circlePolygon(points[len(points)-1], half))
+	}
+	return polygons
+}
+
+// appendJoin 追加折线在顶点 p 处的拐角多边形（填补两条线段矩形外侧的缺口）
+func (c *SoftContext) appendJoin(polygons [][][2]float64, prev, p, next [2]float64, half float64) [][][2]float64 {
+	if c.state.lineJoin == LineJoinRound {
+		return append(polygons, circlePolygon(p, half))
+	}
+
+	d0x, d0y := p[0]-prev[0], p[1]-prev[1]
+	d1x, d1y := next[0]-p[0], next[1]-p[1]
+	l0, l1 := math.Hypot(d0x, d0y), math.Hypot(d1x, d1y)
+	d0x, d0y, d1x, d1y = d0x/l0, d0y/l0, d1x/l1, d1y/l1
+	n0x, n0y := -d0y, d0x
+	n1x, n1y := -d1y, d1x
+
+	// 外侧为下一条线段偏离的一侧
+	side := -1.0
+	if n0x*d1x+n0y*d1y < 0 {
+		side = 1
+	}
+	if math.Abs(d0x*d1y-d0y*d1x) < 1e-9 && d0x*d1x+d0y*d1y > 0 {
+		return polygons // 共线，没有缺口
+	}
+	e0 := [2]float64{p[0] + side*n0x*half, p[1] + side*n0y*half}
+	e1 := [2]float64{p[0] + side*n1x*half, p[1] + side*n1y*half}
+
+	if c.state.lineJoin == LineJoinMiter {
+		mx, my := n0x+n1x, n0y+n1y
+		if ml := math.Hypot(mx, my); ml > 1e-9 {
+			mx, my = mx/ml, my/ml
+			// 尖角长度为 half / cos(θ/2)，θ 为两条线段法线的夹角
+			if cosHalf := mx*n0x + my*n0y; cosHalf > 1e-9 && 1/cosHalf <= miterLimit {
+				length := half / cosHalf
+				tip := [2]float64{p[0] + side*mx*length, p[1] + side*my*length}
+				return appendOriented(polygons, [][2]float64{p, e0, tip, e1})
+			}
+		}
+	}
+	return appendOriented(polygons, [][2]float64{p, e0, e1})
+}
+
+// appendOriented 把多边形统一为正向绕向后追加
+func appendOriented(polygons [][][2]float64, polygon [][2]float64) [][][2]float64 {
+	area := 0.0
+	for i, a := range polygon {
+		b := polygon[(i+1)%len(polygon)]
+		area += a[0]*b[1] - b[0]*a[1]
+	}
+	if area < 0 {
+		for i, j := 0, len(polygon)-1; i < j; i, j = i+1, j-1 {
+			polygon[i], polygon[j] = polygon[j], polygon[i]
+		}
+	}
+	return append(polygons, polygon)
+}
+
+// circlePolygon 以 center 为圆心的圆的折线近似（正向绕向）
+func circlePolygon(center [2]float64, radius float64) [][2]float64 {
+	steps := max(8, int(math.Ceil(2*math.Pi/arcStep(radius))))
+	polygon := make([][2]float64, steps)
+	for i := range polygon {
+		angle := 2 * math.Pi * float64(i) / float64(steps)
+		polygon[i] = [2]float64{center[0] + radius*math.Cos(angle), center[1] + radius*math.Sin(angle)}
+	}
+	return polygon
+}
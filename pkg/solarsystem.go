@@ -1,10 +1,15 @@
 package go3d
 
+import "math"
+
 // SolarSystem 太阳系
 type SolarSystem struct {
 	Sun     *CelestialBody
 	Planets []*Planet
 	Orbits  []*Orbit
+	Trails  []*Trail
+	Belts   []*AsteroidBelt
+	Comets  []*Comet
 	Stars   *StarField
 }
 
@@ -21,7 +26,7 @@ func NewSolarSystem() *SolarSystem {
 	ss.Sun.RotationSpeed = 1.0
 
 	// 创建星空背景
-	ss.Stars = NewStarField(50, 20.0)
+	ss.Stars = NewStarField(300, 20.0)
 
 	return ss
 }
@@ -36,6 +41,33 @@ func (ss *SolarSystem) AddOrbit(orbit *Orbit) {
 	ss.Orbits = append(ss.Orbits, orbit)
 }
 
+// AddAsteroidBelt 添加小行星带
+func (ss *SolarSystem) AddAsteroidBelt(belt *AsteroidBelt) {
+	ss.Belts = append(ss.Belts, belt)
+}
+
+// AddComet 添加彗星
+func (ss *SolarSystem) AddComet(comet *Comet) {
+	ss.Comets = append(ss.Comets, comet)
+}
+
+// AddDwarfPlanets 添加谷神星和冥王星，以及火星与木星之间的小行星带
+func (ss *SolarSystem) AddDwarfPlanets() {
+	orbitColor := [3]float64{0.26, 0.27, 0.29}
+	for _, planet := range []*Planet{NewCeres(), NewPluto()} {
+		ss.AddPlanet(planet)
+		ss.AddOrbit(NewOrbitForPlanet(planet, orbitColor))
+	}
+	ss.AddAsteroidBelt(NewAsteroidBelt(5.6, 6.4, 300, 1))
+}
+
+// AddPlanetTrails 为所有行星添加运动拖尾，length 为拖尾覆盖的时间长度
+func (ss *SolarSystem) AddPlanetTrails(length float64) {
+	for _, planet := range ss.Planets {
+		ss.Trails = append(ss.Trails, NewTrail(planet.GetPosition, planet.Color, length))
+	}
+}
+
 // CreateDefaultSolarSystem 创建默认太阳系（8大行星）
 func CreateDefaultSolarSystem() *SolarSystem {
 	ss := NewSolarSystem()
@@ -63,9 +95,16 @@ func CreateDefaultSolarSystem() *SolarSystem {
 		{"Neptune", "海王星", 0.33, 12.5, 0.3, 8.0, [3]float64{0.25, 0.32, 0.71}, true, [3]float64{0.16, 0.25, 0.63}, false, false, nil}, // Indigo 600 -> 800
 	}
 
+	// 自转轴倾角（度）：金星逆向自转，天王星几乎“躺着”自转
+	axialTilts := map[string]float64{
+		"Mercury": 0.03, "Venus": 177.4, "Earth": 23.44, "Mars": 25.19,
+		"Jupiter": 3.13, "Saturn": 26.73, "Uranus": 97.77, "Neptune": 28.32,
+	}
+
 	// 添加行星和轨道
 	for _, pd := range planetsData {
 		planet := NewPlanet(pd.name, pd.nameCN, pd.radius, pd.orbitRadius, pd.orbitSpeed, pd.rotationSpeed, pd.color)
+		planet.AxialTilt = axialTilts[pd.name] * math.Pi / 180
 
 		if pd.useGradient {
 			planet.SetGradient(pd.color, pd.gradientColor)
@@ -78,7 +117,7 @@ func CreateDefaultSolarSystem() *SolarSystem {
 		}
 
 		ss.AddPlanet(planet)
-		ss.AddOrbit(NewOrbit(pd.orbitRadius, [3]float64{0.26, 0.27, 0.29})) // MUI Grey 800 (更柔和的轨道线)
+		ss.AddOrbit(NewOrbitForPlanet(planet, [3]float64{0.26, 0.27, 0.29})) // MUI Grey 800 (更柔和的轨道线)
 	}
 
 	return ss
@@ -101,12 +140,69 @@ func (ss *SolarSystem) Render(renderer *Renderer, t float64) {
 		orbit.Render(renderer, t)
 	}
 
+	// 渲染小行星带
+	for _, belt := range ss.Belts {
+		belt.Render(renderer, t)
+	}
+
+	// 渲染彗星
+	for _, comet := range ss.Comets {
+		comet.Render(renderer, t)
+	}
+
+	// 渲染拖尾
+	for _, trail := range ss.Trails {
+		trail.Render(renderer, t)
+	}
+
 	// 渲染行星
 	for _, planet := range ss.Planets {
 		planet.Render(renderer, t)
 	}
 }
 
+// ShadowSpheres 太阳系中所有天体的阴影遮挡体
+func (ss *SolarSystem) ShadowSpheres(t float64) []SphereOccluder {
+	var spheres []SphereOccluder
+	if ss.Sun != nil {
+		spheres = append(spheres, ss.Sun.ShadowSpheres(t)...)
+	}
+	for _, planet := range ss.Planets {
+		spheres = append(spheres, planet.ShadowSpheres(t)...)
+	}
+	return spheres
+}
+
+// WorldBounds 太阳、行星、完整的轨道线、小行星带和彗星的包围盒（不包括星空和拖尾）
+func (ss *SolarSystem) WorldBounds(t float64) AABB {
+	var parts []Bounded
+	if ss.Sun != nil {
+		parts = append(parts, ss.Sun)
+	}
+	for _, planet := range ss.Planets {
+		parts = append(parts, planet)
+	}
+	for _, orbit := range ss.Orbits {
+		parts = append(parts, orbit)
+	}
+	for _, belt := range ss.Belts {
+		parts = append(parts, belt)
+	}
+	for _, comet := range ss.Comets {
+		parts = append(parts, comet)
+	}
+
+	var box AABB
+	for i, part := range parts {
+		if i == 0 {
+			box = part.WorldBounds(t)
+		} else {
+			box = box.union(part.WorldBounds(t))
+		}
+	}
+	return box
+}
+
 // CelestialBody 天体（太阳、恒星等）
 type CelestialBody struct {
 	Name          string
@@ -117,6 +213,12 @@ type CelestialBody struct {
 	GradientColor [3]float64
 	RotationSpeed float64
 	Position      Vector3
+	Texture       *Texture // 表面纹理（等距圆柱投影贴图）
+
+	Emissive [3]float64 // 自发光颜色（见 Mesh.Emissive），太阳等发光天体不随光照变暗
+	Additive bool       // 以加法合成绘制（见 Mesh.Additive），天体叠加在背后的画面上发光
+
+	mesh meshCache // 未变换的球体，只在半径改变时重新生成
 }
 
 // NewCelestialBody 创建天体
@@ -132,6 +234,13 @@ func NewCelestialBody(name, nameCN string, radius float64, color [3]float64) *Ce
 	}
 }
 
+// SetGlow 设置自发光颜色并以加法合成绘制，使天体照亮背后的星空、轨道和光晕
+func (cb *CelestialBody) SetGlow(emissive [3]float64) *CelestialBody {
+	cb.Emissive = emissive
+	cb.Additive = true
+	return cb
+}
+
 // SetGradient 设置渐变色
 func (cb *CelestialBody) SetGradient(color1, color2 [3]float64) *CelestialBody {
 	cb.UseGradient = true
@@ -140,9 +249,31 @@ func (cb *CelestialBody) SetGradient(color1, color2 [3]float64) *CelestialBody {
 	return cb
 }
 
+// SetTexture 设置表面纹理
+func (cb *CelestialBody) SetTexture(texture *Texture) *CelestialBody {
+	cb.Texture = texture
+	return cb
+}
+
+// ShadowSpheres 天体的阴影遮挡体
+func (cb *CelestialBody) ShadowSpheres(t float64) []SphereOccluder {
+	return []SphereOccluder{{Center: cb.Position, Radius: cb.Radius}}
+}
+
+// WorldBounds 天体的包围盒
+func (cb *CelestialBody) WorldBounds(t float64) AABB {
+	return sphereBounds(cb.Position, cb.Radius)
+}
+
 // Render 渲染天体
 func (cb *CelestialBody) Render(renderer *Renderer, t float64) {
-	body := CreateSphere(cb.Radius, 20, 20)
+	body := cb.mesh.sphere(cb.Radius, 20)
+	if cb.Emissive != ([3]float64{}) || cb.Additive {
+		// 缓存的球体可能被多个渲染线程共享，在浅拷贝上设置发光属性
+		glowing := *body
+		glowing.Emissive, glowing.Additive = cb.Emissive, cb.Additive
+		body = &glowing
+	}
 
 	transform := Identity()
 	transform = transform.Multiply(Translation(cb.Position.X, cb.Position.Y, cb.Position.Z))
@@ -150,12 +281,12 @@ func (cb *CelestialBody) Render(renderer *Renderer, t float64) {
 		transform = transform.Multiply(RotationY(t * cb.RotationSpeed * 3.14159))
 	}
 
-	transformedBody := body.Transform(transform)
-
-	if cb.UseGradient {
-		renderer.DrawMeshWithGradient(transformedBody, cb.Color, cb.GradientColor)
+	if cb.Texture != nil {
+		renderer.DrawTexturedMeshTransformed(body, transform, cb.Texture, cb.Color)
+	} else if cb.UseGradient {
+		renderer.DrawMeshWithGradientTransformed(body, transform, cb.Color, cb.GradientColor)
 	} else {
-		renderer.DrawMesh(transformedBody, cb.Color)
+		renderer.DrawMeshTransformed(body, transform, cb.Color)
 	}
 
 	// 渲染标签
@@ -0,0 +1,91 @@
+package go3d
+
+import (
+	"image"
+	"sync"
+)
+
+// StaticLayer 静态图层：相机静止时的整帧缓存。把背景和静止的对象（星空、轨道线、坐标轴等）
+// 渲染一次并缓存整个画面，之后每帧把缓存的整帧像素复制到画布上，再绘制运动的对象。
+// 不跟踪运动对象的脏区域，运动对象每帧照常绘制，节省的只是静态内容的渲染时间。作为场景背景使用：
+//
+//	static := go3d.NewScene()
+//	static.SetBackground(background)
+//	static.AddObject(starField)
+//	scene.SetBackground(go3d.NewStaticLayer(static)) // scene 中只放运动的对象
+//
+// 画布尺寸、相机、渲染模式或透明背景改变时自动重新渲染缓存，因此相机运动的动画每帧都重新渲染，不会变快。
+// 运动的对象总是绘制在静态图层之上，因此只适合运动对象位于静态内容前方的场景；
+// 静态对象不参与阴影、光线追踪的反射以及物体编号和深度通道。多个线程可以共享同一个静态图层
+type StaticLayer struct {
+	Scene *Scene  // 静态部分的场景，没有光源时使用渲染器当前的光源
+	Time  float64 // 渲染静态场景使用的时间
+
+	mu     sync.Mutex
+	key    staticLayerKey
+	pixels []uint8 // 缓存的画面（与画布相同的预乘 RGBA 格式）
+}
+
+// staticLayerKey 决定缓存是否仍然有效的渲染参数
+type staticLayerKey struct {
+	width, height int
+	camera        Camera
+	mode          RenderMode
+	transparent   bool
+	time          float64
+}
+
+// NewStaticLayer 创建静态图层
+func NewStaticLayer(scene *Scene) *StaticLayer {
+	return &StaticLayer{Scene: scene}
+}
+
+// Invalidate 丢弃缓存，下一帧重新渲染静态场景（修改了静态对象时调用）
+func (sl *StaticLayer) Invalidate() {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	sl.pixels = nil
+}
+
+// Render 把静态图层绘制到画布上：缓存有效时复制像素，否则先渲染静态场景再缓存
+func (sl *StaticLayer) Render(renderer *Renderer, t float64) {
+	img, ok := renderer.Surface.GetGoImage().(*image.RGBA)
+	if !ok || sl.Scene == nil {
+		return
+	}
+	key := staticLayerKey{
+		width:       renderer.Width,
+		height:      renderer.Height,
+		mode:        renderer.RenderMode,
+		transparent: renderer.TransparentBackground,
+		time:        sl.Time,
+	}
+	if renderer.Camera != nil {
+		key.camera = *renderer.Camera
+	}
+
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	if sl.pixels != nil && sl.key == key {
+		copy(img.Pix, sl.pixels)
+		return
+	}
+
+	sl.renderStatic(renderer)
+	sl.pixels = append(sl.pixels[:0], img.Pix...)
+	sl.key = key
+}
+
+// renderStatic 把静态场景渲染到画布上，之后恢复外层场景设置的光源和阴影遮挡体
+func (sl *StaticLayer) renderStatic(renderer *Renderer) {
+	lights, occluders := renderer.Lights, renderer.occluders
+	defer func() {
+		renderer.Lights, renderer.occluders = lights, occluders
+	}()
+
+	scene := *sl.Scene
+	if len(scene.Lights) == 0 {
+		scene.Lights = lights
+	}
+	scene.Render(renderer, sl.Time)
+}
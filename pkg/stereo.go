@@ -0,0 +1,163 @@
+package go3d
+
+import (
+	"image"
+	"math"
+)
+
+// StereoMode 立体画面的合成方式
+type StereoMode int
+
+const (
+	StereoAnaglyph     StereoMode = iota // 红青立体图：左眼取红色通道，右眼取绿色和蓝色通道
+	StereoAnaglyphGray                   // 灰度红青立体图：先转为亮度再分通道，减轻彩色物体的双眼竞争
+	StereoSideBySide                     // 左右并排：两眼画面水平压缩到一半宽度，适用于 3D 电视和 VR 播放器
+)
+
+// StereoOptions 立体渲染参数
+type StereoOptions struct {
+	Mode          StereoMode
+	EyeSeparation float64 // 两眼间距（世界单位），沿相机右方向分开
+	Parallel      bool    // 两眼视线平行；否则两眼都看向 Camera.Target，目标处视差为 0
+}
+
+// NewStereoOptions 创建立体渲染参数，两眼默认会聚到相机目标点
+func NewStereoOptions(mode StereoMode, eyeSeparation float64) *StereoOptions {
+	return &StereoOptions{
+		Mode:          mode,
+		EyeSeparation: eyeSeparation,
+	}
+}
+
+// RenderStereo 以左右两眼的相机分别调用 draw 渲染，再按 options 合成到画布上
+// draw 只负责绘制，不应修改相机；两眼的画布都从当前画布内容开始（如已清屏的背景色），
+// 其余设置（渲染模式、光源、环境贴图等）与当前渲染器相同，后期处理在合成后的画面上应用
+func (r *Renderer) RenderStereo(options *StereoOptions, draw func(eye *Renderer)) {
+	if options == nil {
+		return
+	}
+	img, ok := r.Surface.GetGoImage().(*image.RGBA)
+	if !ok {
+		return
+	}
+
+	var eyes [2]*image.RGBA
+	for i, side := range [2]float64{-0.5, 0.5} {
+		eye := r.newRendererLike(r.Camera.eyeCamera(side*options.EyeSeparation, options.Parallel), r.Width, r.Height)
+		defer eye.Destroy()
+		eyeImg, ok := eye.Surface.GetGoImage().(*image.RGBA)
+		if !ok {
+			return
+		}
+		for y := range r.Height {
+			copy(eyeImg.Pix[y*eyeImg.Stride:y*eyeImg.Stride+r.Width*4], img.Pix[y*img.Stride:y*img.Stride+r.Width*4])
+		}
+		draw(eye)
+		eyes[i] = eyeImg
+	}
+
+	switch options.Mode {
+	case StereoSideBySide:
+		composeSideBySide(img, eyes[0], eyes[1], r.Width, r.Height)
+	default:
+		composeAnaglyph(img, eyes[0], eyes[1], r.Width, r.Height, options.Mode == StereoAnaglyphGray)
+	}
+}
+
+// RenderStereo 以立体方式渲染整个场景，相机应事先设置好
+func (s *Scene) RenderStereo(renderer *Renderer, t float64, options *StereoOptions) {
+	renderer.RenderStereo(options, func(eye *Renderer) {
+		s.Render(eye, t)
+	})
+}
+
+// eyeCamera 沿相机右方向平移 offset 后的相机副本
+// parallel 为 true 时目标点一起平移（视线平行），否则保持目标点不变（视线会聚）
+func (c *Camera) eyeCamera(offset float64, parallel bool) *Camera {
+	eye := *c
+	_, right, _ := c.Basis()
+	shift := right.Scale(offset)
+	eye.Position = c.Position.Add(shift)
+	if parallel {
+		eye.Target = c.Target.Add(shift)
+	}
+	return &eye
+}
+
+// newRendererLike 创建给定尺寸、同设置的离屏渲染器，使用给定相机
+// 不复制画布内容、后期处理和渲染通道设置
+func (r *Renderer) newRendererLike(camera *Camera, width, height int) *Renderer {
+	other := NewRenderer(width, height)
+	other.Camera = camera
+	other.Lights = r.Lights
+	other.RenderMode = r.RenderMode
+	other.SortMode = r.SortMode
+	other.SetAntialias(r.Antialias)
+	other.WireframeCreaseAngle = r.WireframeCreaseAngle
+	other.ToonBands = r.ToonBands
+	other.OutlineWidth = r.OutlineWidth
+	other.OutlineColor = r.OutlineColor
+	other.Shadows = r.Shadows
+	other.LabelLayout = r.LabelLayout
+	other.LinearLighting = r.LinearLighting
+	other.ToneMapping = r.ToneMapping
+	other.Debug = r.Debug
+	other.AxisGizmo = r.AxisGizmo
+	other.FrustumCulling = r.FrustumCulling
+	other.Raytrace = r.Raytrace
+	other.Environment = r.Environment
+	other.TransparentBackground = r.TransparentBackground
+	other.occluders = r.occluders
+	return other
+}
+
+// composeAnaglyph 把两眼画面合成为红青立体图
+func composeAnaglyph(dst, left, right *image.RGBA, width, height int, gray bool) {
+	for y := range height {
+		for x := range width {
+			d := y*dst.Stride + x*4
+			l := y*left.Stride + x*4
+			r := y*right.Stride + x*4
+			if gray {
+				dst.Pix[d] = luminanceByte(left.Pix[l : l+3])
+				lum := luminanceByte(right.Pix[r : r+3])
+				dst.Pix[d+1], dst.Pix[d+2] = lum, lum
+			} else {
+				dst.Pix[d] = left.Pix[l]
+				dst.Pix[d+1], dst.Pix[d+2] = right.Pix[r+1], right.Pix[r+2]
+			}
+			dst.Pix[d+3] = max(left.Pix[l+3], right.Pix[r+3])
+		}
+	}
+}
+
+// composeSideBySide 把两眼画面水平压缩一半后左右并排，相邻两列取平均
+func composeSideBySide(dst, left, right *image.RGBA, width, height int) {
+	half := width / 2
+	for y := range height {
+		for i, src := range [2]*image.RGBA{left, right} {
+			start, columns := 0, half
+			if i == 1 {
+				start, columns = half, width-half
+			}
+			for x := range columns {
+				// 目标列覆盖源图中 [x0, x1) 范围的像素
+				x0 := x * width / columns
+				x1 := max(x0+1, (x+1)*width/columns)
+				d := y*dst.Stride + (start+x)*4
+				for k := range 4 {
+					sum := 0
+					for sx := x0; sx < x1; sx++ {
+						sum += int(src.Pix[y*src.Stride+sx*4+k])
+					}
+					dst.Pix[d+k] = uint8((sum + (x1-x0)/2) / (x1 - x0))
+				}
+			}
+		}
+	}
+}
+
+// luminanceByte 按 Rec. 709 权重计算像素亮度
+func luminanceByte(rgb []uint8) uint8 {
+	return uint8(math.Min(255, 0.2126*float64(rgb[0])+0.7152*float64(rgb[1])+0.0722*float64(rgb[2])+0.5))
+}
@@ -0,0 +1,104 @@
+package go3d
+
+import "math"
+
+// CreateParametricSurface 对参数曲面 f(u, v) 采样生成网格
+// u 在 uRange 内分 uSegs 段，v 在 vRange 内分 vSegs 段；三角形法线方向为 ∂f/∂u × ∂f/∂v，
+// 纹理坐标为归一化后的 (u, v)。闭合曲面的接缝处两侧顶点按 f 各自采样，首尾重合时自然闭合
+func CreateParametricSurface(f func(u, v float64) Vector3, uSegs, vSegs int, uRange, vRange [2]float64) *Mesh {
+	mesh := NewMesh()
+	uSegs, vSegs = max(1, uSegs), max(1, vSegs)
+
+	param := func(r [2]float64, i, segs int) float64 {
+		return r[0] + (r[1]-r[0])*float64(i)/float64(segs)
+	}
+
+	for i := 0; i <= vSegs; i++ {
+		v := param(vRange, i, vSegs)
+		for j := 0; j <= uSegs; j++ {
+			mesh.AddVertex(f(param(uRange, j, uSegs), v))
+		}
+	}
+
+	addGridTriangles(mesh, vSegs, uSegs)
+
+	for i := range vSegs {
+		t0 := float64(i) / float64(vSegs)
+		t1 := float64(i+1) / float64(vSegs)
+		for j := range uSegs {
+			s0 := float64(j) / float64(uSegs)
+			s1 := float64(j+1) / float64(uSegs)
+			// 与 addGridTriangles 的顶点顺序一致
+			mesh.UVs = append(mesh.UVs,
+				[3][2]float64{{s0, t0}, {s1, t0}, {s0, t1}},
+				[3][2]float64{{s0, t1}, {s1, t0}, {s1, t1}},
+			)
+		}
+	}
+
+	return mesh
+}
+
+// appendBackFaces 为每个三角形追加一个反向的副本，使单侧曲面从两面都可见
+func appendBackFaces(mesh *Mesh) *Mesh {
+	count := len(mesh.Triangles)
+	for i := range count {
+		tri := mesh.Triangles[i]
+		mesh.AddTriangle(Triangle{V0: tri.V0, V1: tri.V2, V2: tri.V1})
+		if mesh.HasUVs() {
+			uv := mesh.UVs[i]
+			mesh.UVs = append(mesh.UVs, [3][2]float64{uv[0], uv[2], uv[1]})
+		}
+	}
+	return mesh
+}
+
+// CreateMobiusStrip 创建莫比乌斯带：中心圆半径 radius，带宽 width，位于 XY 平面
+// 单侧曲面，生成正反两面
+func CreateMobiusStrip(radius, width float64, segments int) *Mesh {
+	f := func(u, v float64) Vector3 {
+		r := radius + v*math.Cos(u/2)
+		return NewVector3(r*math.Cos(u), r*math.Sin(u), v*math.Sin(u/2))
+	}
+	mesh := CreateParametricSurface(f, segments, max(1, segments/16), [2]float64{0, 2 * math.Pi}, [2]float64{-width / 2, width / 2})
+	return appendBackFaces(mesh)
+}
+
+// CreateKleinBottle 创建克莱因瓶（“8 字形”浸入），scale 为中心圆半径
+// 不可定向曲面，生成正反两面
+func CreateKleinBottle(scale float64, segments int) *Mesh {
+	f := func(u, v float64) Vector3 {
+		r := scale * (1 + math.Cos(u/2)*math.Sin(v)/2 - math.Sin(u/2)*math.Sin(2*v)/2)
+		return NewVector3(
+			r*math.Cos(u),
+			r*math.Sin(u),
+			scale*(math.Sin(u/2)*math.Sin(v)+math.Cos(u/2)*math.Sin(2*v))/2,
+		)
+	}
+	mesh := CreateParametricSurface(f, segments, max(3, segments/2), [2]float64{0, 2 * math.Pi}, [2]float64{0, 2 * math.Pi})
+	return appendBackFaces(mesh)
+}
+
+// CreateSaddleSurface 创建马鞍面 y = (x² - z²) / (2·size)，在 XZ 平面上占据 size × size
+// 开放曲面，生成正反两面
+func CreateSaddleSurface(size float64, segments int) *Mesh {
+	f := func(u, v float64) Vector3 {
+		return NewVector3(u, (u*u-v*v)/(2*size), v)
+	}
+	half := size / 2
+	mesh := CreateParametricSurface(f, segments, segments, [2]float64{-half, half}, [2]float64{-half, half})
+	return appendBackFaces(mesh)
+}
+
+// CreateLathe 将二维轮廓绕 Y 轴旋转一周生成回转体（花瓶、酒杯、棋子等）
+// 轮廓点的 X 为到轴的距离，Y 为高度；轮廓自下而上排列时法线朝外。轮廓端点位于轴上时自然封口
+func CreateLathe(profile []Vector2, segments int) *Mesh {
+	if len(profile) < 2 {
+		return NewMesh()
+	}
+	f := func(u, v float64) Vector3 {
+		p := profile[int(math.Round(v))]
+		return NewVector3(p.X*math.Cos(u), p.Y, -p.X*math.Sin(u))
+	}
+	return CreateParametricSurface(f, max(3, segments), len(profile)-1, [2]float64{0, 2 * math.Pi}, [2]float64{0, float64(len(profile) - 1)})
+}
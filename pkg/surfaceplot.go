@@ -0,0 +1,109 @@
+package go3d
+
+import "math"
+
+// SurfacePlot 函数 z = f(x, y) 的三维曲面图：曲面按高度着色，带坐标框（网格线、坐标轴、刻度）和颜色图例。
+// 函数只在创建时采样一次；采样结果为 NaN 或无穷大的格子留空，可以用来表示定义域之外的区域
+type SurfacePlot struct {
+	Axes     *PlotAxes
+	Colorbar *Colorbar // 颜色图例，nil 表示不绘制
+
+	ramp ColorRamp
+	mesh *Mesh // 归一化到 [-0.5, 0.5] 的曲面，按 Axes 的位置和尺寸绘制
+}
+
+// NewSurfacePlot 在 xRange × yRange 上按 resolution × resolution 个格子采样 f，创建曲面图。
+// 坐标框的 z 范围为采样到的最小值和最大值，颜色使用 ViridisRamp
+func NewSurfacePlot(f func(x, y float64) float64, xRange, yRange [2]float64, resolution int) *SurfacePlot {
+	n := max(1, resolution)
+	heights := make([]float64, (n+1)*(n+1))
+	zMin, zMax := math.Inf(1), math.Inf(-1)
+	for i := 0; i <= n; i++ {
+		y := yRange[0] + (yRange[1]-yRange[0])*float64(i)/float64(n)
+		for j := 0; j <= n; j++ {
+			x := xRange[0] + (xRange[1]-xRange[0])*float64(j)/float64(n)
+			z := f(x, y)
+			heights[i*(n+1)+j] = z
+			if finite(z) {
+				zMin, zMax = math.Min(zMin, z), math.Max(zMax, z)
+			}
+		}
+	}
+	switch {
+	case zMin > zMax: // 没有有效的采样
+		zMin, zMax = 0, 1
+	case zMin == zMax:
+		zMin, zMax = zMin-0.5, zMax+0.5
+	}
+
+	sp := &SurfacePlot{
+		Axes: NewPlotAxes(NewVector3(xRange[0], yRange[0], zMin), NewVector3(xRange[1], yRange[1], zMax)),
+	}
+	sp.mesh = sp.buildMesh(heights, n)
+	sp.Colorbar = NewColorbar(nil, zMin, zMax)
+	sp.Colorbar.Title = sp.Axes.Labels[2]
+	return sp.SetRamp(ViridisRamp())
+}
+
+// buildMesh 把采样高度构造成归一化网格：顶点按世界轴排列（X 为 x，Y 为 z，Z 为 -y），三角形法线朝上
+func (sp *SurfacePlot) buildMesh(heights []float64, n int) *Mesh {
+	mesh := NewMesh()
+	vertex := func(i, j int) Vector3 {
+		z := heights[i*(n+1)+j]
+		return NewVector3(float64(j)/float64(n)-0.5, sp.Axes.normalize(2, z), 0.5-float64(i)/float64(n))
+	}
+	for i := 0; i <= n; i++ {
+		for j := 0; j <= n; j++ {
+			if finite(heights[i*(n+1)+j]) {
+				mesh.AddVertex(vertex(i, j))
+			}
+		}
+	}
+
+	for i := range n {
+		for j := range n {
+			corners := [4][2]int{{i, j}, {i, j + 1}, {i + 1, j}, {i + 1, j + 1}}
+			valid := true
+			for _, c := range corners {
+				valid = valid && finite(heights[c[0]*(n+1)+c[1]])
+			}
+			if !valid {
+				continue
+			}
+			v00, v01, v10, v11 := vertex(i, j), vertex(i, j+1), vertex(i+1, j), vertex(i+1, j+1)
+			mesh.AddTriangle(Triangle{V0: v00, V1: v01, V2: v10})
+			mesh.AddTriangle(Triangle{V0: v01, V1: v11, V2: v10})
+		}
+	}
+	return mesh
+}
+
+// SetRamp 设置高度配色的色带（同时用于颜色图例），色带位置 0 对应最低点，1 对应最高点
+func (sp *SurfacePlot) SetRamp(ramp ColorRamp) *SurfacePlot {
+	sp.ramp = ramp
+	sp.mesh.VertexColors = make([][3][3]float64, len(sp.mesh.Triangles))
+	for i, tri := range sp.mesh.Triangles {
+		for k, v := range [3]Vector3{tri.V0, tri.V1, tri.V2} {
+			sp.mesh.VertexColors[i][k] = ramp.At(v.Y + 0.5)
+		}
+	}
+	if sp.Colorbar != nil {
+		sp.Colorbar.Ramp = ramp
+	}
+	return sp
+}
+
+// Render 依次绘制背面的网格线、曲面、坐标轴和颜色图例
+func (sp *SurfacePlot) Render(renderer *Renderer, t float64) {
+	sp.Axes.renderBack(renderer)
+	renderer.DrawMeshTransformed(sp.mesh, sp.Axes.model(), [3]float64{1, 1, 1})
+	sp.Axes.renderFront(renderer)
+	if sp.Colorbar != nil {
+		sp.Colorbar.Render(renderer, t)
+	}
+}
+
+// WorldBounds 坐标框的范围
+func (sp *SurfacePlot) WorldBounds(t float64) AABB {
+	return sp.Axes.WorldBounds(t)
+}
@@ -0,0 +1,186 @@
+package go3d
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"math"
+	"os"
+	"strings"
+)
+
+// svgRecorder 记录一帧画面的 SVG 元素（按绘制顺序，后面的覆盖前面的）
+// 平面着色的三角形和线框边记录为矢量路径，不在画布上光栅化；其余内容仍然画在画布上，
+// 每次记录矢量路径之前把画布上已有的内容作为一层位图（或纯色矩形）取出并清空画布，保持前后遮挡顺序
+type svgRecorder struct {
+	elements []string
+	clean    bool // 画布自上次取出以来确定没有新内容，不必再检查
+}
+
+// RecordSVG 开始记录矢量图元，之后用 SaveToSVG 保存。应在绘制场景之前调用：
+// 平面着色、光照着色和卡通着色的三角形输出为 <path> 多边形，线框模式的边输出为折线；
+// 平滑着色、纹理、加法合成的三角形以及光线追踪、标签、点和背景等直接绘制在画布上的内容
+// 按绘制顺序作为内嵌的 PNG 图层（纯色画面为矩形）插在矢量路径之间。
+// 矢量三角形不再光栅化到画布上，因此之后读取画布像素的功能（静态图层缓存、渲染通道等）看不到它们
+func (r *Renderer) RecordSVG() {
+	r.svg = &svgRecorder{}
+}
+
+// reset 清空已记录的元素（画布被 Clear 覆盖时调用）
+func (s *svgRecorder) reset() {
+	s.elements = s.elements[:0]
+	s.clean = false
+}
+
+// flush 把画布上的内容作为一层取出并清空画布：全部透明时不输出，所有像素相同时输出矩形，否则输出 PNG 图像
+func (s *svgRecorder) flush(r *Renderer) {
+	if s.clean {
+		return
+	}
+	s.clean = true
+	img, ok := r.Surface.GetGoImage().(*image.RGBA)
+	if !ok {
+		return
+	}
+
+	uniform, empty := true, true
+	first := [4]uint8{}
+	for y := range r.Height {
+		row := img.Pix[y*img.Stride : y*img.Stride+r.Width*4]
+		for i := 0; i+3 < len(row); i += 4 {
+			if row[i+3] != 0 {
+				empty = false
+			}
+			if y == 0 && i == 0 {
+				first = [4]uint8{row[0], row[1], row[2], row[3]}
+			} else if row[i] != first[0] || row[i+1] != first[1] || row[i+2] != first[2] || row[i+3] != first[3] {
+				uniform = false
+			}
+		}
+	}
+	switch {
+	case empty:
+		return
+	case uniform:
+		// 画布为预乘 alpha，还原为直通颜色
+		alpha := float64(first[3]) / 255
+		c := [3]float64{float64(first[0]) / 255 / alpha, float64(first[1]) / 255 / alpha, float64(first[2]) / 255 / alpha}
+		s.elements = append(s.elements, fmt.Sprintf(`<rect width="%d" height="%d" fill="%s"%s/>`,
+			r.Width, r.Height, svgColor(c), svgOpacity("fill-opacity", alpha)))
+	default:
+		var encoded bytes.Buffer
+		if err := png.Encode(&encoded, img.SubImage(image.Rect(0, 0, r.Width, r.Height))); err != nil {
+			return
+		}
+		s.elements = append(s.elements, fmt.Sprintf(`<image width="%d" height="%d" href="data:image/png;base64,%s"/>`,
+			r.Width, r.Height, base64.StdEncoding.EncodeToString(encoded.Bytes())))
+	}
+	clear(img.Pix)
+}
+
+// polygon 记录一个填充的多边形；不透明的多边形用同色细描边盖住相邻多边形之间的抗锯齿缝隙
+func (s *svgRecorder) polygon(r *Renderer, pts [][2]float64, color [3]float64, opacity float64) {
+	s.flush(r)
+	fill := svgColor(color)
+	element := fmt.Sprintf(`<path d="%s" fill="%s"`, svgPath(pts, true), fill)
+	if opacity < 1 {
+		element += svgOpacity("fill-opacity", opacity)
+	} else {
+		element += fmt.Sprintf(` stroke="%s" stroke-width="0.5" stroke-linejoin="round"`, fill)
+	}
+	s.elements = append(s.elements, element+"/>")
+}
+
+// lines 记录一组线段（每段两个端点）
+func (s *svgRecorder) lines(r *Renderer, segments [][2][2]float64, color [3]float64, width float64) {
+	if len(segments) == 0 {
+		return
+	}
+	s.flush(r)
+	var d strings.Builder
+	for _, seg := range segments {
+		if d.Len() > 0 {
+			d.WriteByte(' ')
+		}
+		d.WriteString(svgPath(seg[:], false))
+	}
+	s.elements = append(s.elements, fmt.Sprintf(`<path d="%s" fill="none" stroke="%s" stroke-width="%s" stroke-linecap="round" stroke-linejoin="round"/>`,
+		d.String(), svgColor(color), svgNumber(width)))
+}
+
+// svgPath 折线的路径数据，closed 为 true 时闭合
+func svgPath(pts [][2]float64, closed bool) string {
+	var d strings.Builder
+	for i, p := range pts {
+		if i == 0 {
+			d.WriteString("M")
+		} else {
+			d.WriteString(" L")
+		}
+		d.WriteString(svgNumber(p[0]))
+		d.WriteByte(' ')
+		d.WriteString(svgNumber(p[1]))
+	}
+	if closed {
+		d.WriteString(" Z")
+	}
+	return d.String()
+}
+
+// svgNumber 坐标保留两位小数（百分之一像素）
+func svgNumber(v float64) string {
+	s := fmt.Sprintf("%.2f", v)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" || s == "" {
+		return "0"
+	}
+	return s
+}
+
+// svgColor 颜色分量（0-1，超出范围时截断）转换为 SVG 颜色
+func svgColor(c [3]float64) string {
+	channel := func(v float64) int {
+		return int(math.Round(math.Max(0, math.Min(1, v)) * 255))
+	}
+	return fmt.Sprintf("#%02x%02x%02x", channel(c[0]), channel(c[1]), channel(c[2]))
+}
+
+// svgOpacity 不透明度属性，完全不透明时省略
+func svgOpacity(name string, opacity float64) string {
+	if opacity >= 1 {
+		return ""
+	}
+	return fmt.Sprintf(` %s="%s"`, name, svgNumber(math.Max(0, opacity)))
+}
+
+// SaveToSVG 保存为 SVG 文件，需要在绘制之前调用 RecordSVG
+// 后期处理和景深只能作用于位图，设置了它们时返回错误
+func (r *Renderer) SaveToSVG(filename string) error {
+	if r.svg == nil {
+		return errors.New("SVG: 绘制之前没有调用 RecordSVG")
+	}
+	r.ResetViewport()
+	if r.RenderMode != RenderObjectID && (len(r.postEffects) > 0 || r.depthOfField()) {
+		return errors.New("SVG: 矢量输出不支持后期处理和景深")
+	}
+	r.svg.clean = false
+	r.svg.flush(r)
+
+	var svg strings.Builder
+	svg.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d"`, r.Width, r.Height, r.Width, r.Height)
+	if !r.Antialias {
+		svg.WriteString(` shape-rendering="crispEdges"`)
+	}
+	svg.WriteString(">\n")
+	for _, element := range r.svg.elements {
+		svg.WriteString("  ")
+		svg.WriteString(element)
+		svg.WriteByte('\n')
+	}
+	svg.WriteString("</svg>\n")
+	return os.WriteFile(filename, []byte(svg.String()), 0644)
+}
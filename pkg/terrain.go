@@ -0,0 +1,96 @@
+package go3d
+
+import (
+	"image"
+	"math"
+)
+
+// maxTerrainSegments 高度图地形每个方向的最大分段数，超过时对图像降采样
+const maxTerrainSegments = 256
+
+// CreateTerrain 用高度图创建地形网格
+// 地形与 CreatePlane 一样位于 XZ 平面、中心在原点，Y 为高度：像素亮度 0 对应高度 0，亮度 1 对应 maxHeight
+// 图像的 x 方向对应 X 轴（宽度 width），y 方向对应 Z 轴（深度 depth）；网格带纹理坐标，可直接贴上同尺寸的颜色图
+func CreateTerrain(img image.Image, width, depth, maxHeight float64) *Mesh {
+	tex := NewTexture(img)
+	if tex.Width == 0 || tex.Height == 0 {
+		return NewMesh()
+	}
+
+	segX := max(1, min(tex.Width-1, maxTerrainSegments))
+	segZ := max(1, min(tex.Height-1, maxTerrainSegments))
+
+	return createHeightField(width, depth, segX, segZ, func(u, v float64) float64 {
+		return heightmapLuminance(tex, u, v) * maxHeight
+	})
+}
+
+// CreateNoiseTerrain 用分形 Perlin 噪声创建程序化地形
+// scale 为整个地形上噪声的周期数（越大起伏越密），octaves 为叠加层数，高度范围为 0 到 maxHeight
+func CreateNoiseTerrain(width, depth, maxHeight float64, segments int, scale float64, octaves int, seed int64) *Mesh {
+	noise := NewPerlinNoise(seed)
+	return createHeightField(width, depth, segments, segments, func(u, v float64) float64 {
+		n := noise.Fractal2D(u*scale, v*scale, octaves)
+		return math.Max(0, math.Min(1, (n+1)/2)) * maxHeight
+	})
+}
+
+// heightmapLuminance 在高度图上按 (u, v) ∈ [0, 1] 双线性采样亮度
+// 与纹理采样不同，u = 0 和 u = 1 分别落在首列和末列像素中心，边缘不环绕
+func heightmapLuminance(tex *Texture, u, v float64) float64 {
+	x := u * float64(tex.Width-1)
+	y := v * float64(tex.Height-1)
+	x0, y0 := int(math.Floor(x)), int(math.Floor(y))
+	x1, y1 := min(x0+1, tex.Width-1), min(y0+1, tex.Height-1)
+	fx, fy := x-float64(x0), y-float64(y0)
+
+	lum := func(px, py int) float64 {
+		c := tex.texel(px, py)
+		return 0.2126*c[0] + 0.7152*c[1] + 0.0722*c[2]
+	}
+	top := lum(x0, y0)*(1-fx) + lum(x1, y0)*fx
+	bottom := lum(x0, y1)*(1-fx) + lum(x1, y1)*fx
+	return top*(1-fy) + bottom*fy
+}
+
+// createHeightField 创建按高度函数起伏的细分平面，顶点排列与 CreatePlane 相同
+func createHeightField(width, depth float64, segX, segZ int, height func(u, v float64) float64) *Mesh {
+	mesh := NewMesh()
+	segX, segZ = max(1, segX), max(1, segZ)
+
+	for i := 0; i <= segZ; i++ {
+		v := float64(i) / float64(segZ)
+		for j := 0; j <= segX; j++ {
+			u := float64(j) / float64(segX)
+			mesh.AddVertex(NewVector3((u-0.5)*width, height(u, v), (v-0.5)*depth))
+		}
+	}
+
+	for i := 0; i < segZ; i++ {
+		v0 := float64(i) / float64(segZ)
+		v1 := float64(i+1) / float64(segZ)
+		for j := 0; j < segX; j++ {
+			u0 := float64(j) / float64(segX)
+			u1 := float64(j+1) / float64(segX)
+
+			idx := i*(segX+1) + j
+			below := idx + segX + 1
+			mesh.AddTriangle(Triangle{
+				V0: mesh.Vertices[idx],
+				V1: mesh.Vertices[below],
+				V2: mesh.Vertices[idx+1],
+			})
+			mesh.AddTriangle(Triangle{
+				V0: mesh.Vertices[idx+1],
+				V1: mesh.Vertices[below],
+				V2: mesh.Vertices[below+1],
+			})
+			mesh.UVs = append(mesh.UVs,
+				[3][2]float64{{u0, v0}, {u0, v1}, {u1, v0}},
+				[3][2]float64{{u1, v0}, {u0, v1}, {u1, v1}},
+			)
+		}
+	}
+
+	return mesh
+}
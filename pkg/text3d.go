@@ -0,0 +1,184 @@
+package go3d
+
+import (
+	"math"
+	"strings"
+)
+
+// CreateTextMesh 创建拉伸的三维文字网格，带默认倒角
+// 文字位于 XY 平面、沿 Z 轴拉伸，包围盒中心位于原点；size 为字号（世界单位），font 为字体族名
+func CreateTextMesh(text, font string, size, depth float64) *Mesh {
+	return CreateBeveledTextMesh(text, font, size, depth, math.Min(depth*0.2, size*0.02))
+}
+
+// CreateBeveledTextMesh 创建指定倒角宽度的三维文字网格，bevel 为 0 时不倒角
+func CreateBeveledTextMesh(text, font string, size, depth, bevel float64) *Mesh {
+	mesh := NewMesh()
+	contours := TextOutlines(text, font, size)
+	if len(contours) == 0 {
+		return mesh
+	}
+
+	// 整体居中
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, contour := range contours {
+		for _, p := range contour {
+			minX, maxX = math.Min(minX, p.X), math.Max(maxX, p.X)
+			minY, maxY = math.Min(minY, p.Y), math.Max(maxY, p.Y)
+		}
+	}
+	center := NewVector2((minX+maxX)/2, (minY+maxY)/2)
+	for _, contour := range contours {
+		for i := range contour {
+			contour[i] = contour[i].Sub(center)
+		}
+	}
+
+	for _, shape := range groupContours(contours) {
+		mesh.Merge(CreateBeveledExtrusion(shape[0], shape[1:], depth, bevel))
+	}
+	return mesh
+}
+
+// TextOutlines 提取文字的字形轮廓（曲线已折线化），Y 轴向上，第一行基线位于 y = 0
+// 多行文字以换行符分隔，行距为字号的 1.2 倍
+func TextOutlines(text, font string, size float64) [][]Vector2 {
+	var b outlineBuilder
+	for line, lineText := range strings.Split(text, "\n") {
+		if lineText != "" {
+			appendGlyphOutlines(&b, lineText, font, size, float64(line)*size*1.2)
+		}
+	}
+
+	// 字形路径为 Y 轴向下的屏幕坐标，翻转为 Y 轴向上
+	contours := b.finish()
+	for _, contour := range contours {
+		for i := range contour {
+			contour[i].Y = -contour[i].Y
+		}
+	}
+	return contours
+}
+
+// outlineBuilder 把字形路径（直线、二次和三次曲线）折线化为闭合轮廓列表
+type outlineBuilder struct {
+	contours [][]Vector2
+	current  []Vector2
+}
+
+// curveSteps 每段曲线折线化的段数
+const curveSteps = 6
+
+func (b *outlineBuilder) moveTo(p Vector2) {
+	b.closePath()
+	b.add(p)
+}
+
+func (b *outlineBuilder) lineTo(p Vector2) {
+	b.add(p)
+}
+
+func (b *outlineBuilder) quadTo(p1, p2 Vector2) {
+	if len(b.current) == 0 {
+		return
+	}
+	p0 := b.current[len(b.current)-1]
+	for k := 1; k <= curveSteps; k++ {
+		t := float64(k) / curveSteps
+		u := 1 - t
+		b.add(p0.Scale(u * u).Add(p1.Scale(2 * u * t)).Add(p2.Scale(t * t)))
+	}
+}
+
+func (b *outlineBuilder) cubicTo(p1, p2, p3 Vector2) {
+	if len(b.current) == 0 {
+		return
+	}
+	p0 := b.current[len(b.current)-1]
+	for k := 1; k <= curveSteps; k++ {
+		t := float64(k) / curveSteps
+		u := 1 - t
+		b.add(p0.Scale(u * u * u).Add(p1.Scale(3 * u * u * t)).Add(p2.Scale(3 * u * t * t)).Add(p3.Scale(t * t * t)))
+	}
+}
+
+// closePath 结束当前轮廓，退化的轮廓（少于三个点或面积为零）被丢弃
+func (b *outlineBuilder) closePath() {
+	current := b.current
+	b.current = nil
+	// 去掉与起点重合的终点
+	if len(current) > 1 && current[0] == current[len(current)-1] {
+		current = current[:len(current)-1]
+	}
+	if len(current) >= 3 && math.Abs(polygonArea(current)) > 1e-12 {
+		b.contours = append(b.contours, current)
+	}
+}
+
+func (b *outlineBuilder) add(p Vector2) {
+	if len(b.current) == 0 || b.current[len(b.current)-1] != p {
+		b.current = append(b.current, p)
+	}
+}
+
+// finish 结束最后一个轮廓并返回全部轮廓
+func (b *outlineBuilder) finish() [][]Vector2 {
+	b.closePath()
+	return b.contours
+}
+
+// pointInPolygon2D 点是否在多边形内（奇偶规则）
+func pointInPolygon2D(p Vector2, polygon []Vector2) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < a.X+(p.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// groupContours 按嵌套层数把轮廓分为外轮廓及其孔洞
+// 被偶数个轮廓包含的是外轮廓，奇数个的是孔洞，孔洞归属于包含它的最小外轮廓
+// 返回的每组第一个元素为外轮廓，其余为孔洞；与字体的轮廓方向约定无关
+func groupContours(contours [][]Vector2) [][][]Vector2 {
+	depth := make([]int, len(contours))
+	for i, contour := range contours {
+		for j, other := range contours {
+			if i != j && pointInPolygon2D(contour[0], other) {
+				depth[i]++
+			}
+		}
+	}
+
+	var shapes [][][]Vector2
+	shapeIndex := make(map[int]int)
+	for i, contour := range contours {
+		if depth[i]%2 == 0 {
+			shapeIndex[i] = len(shapes)
+			shapes = append(shapes, [][]Vector2{contour})
+		}
+	}
+
+	for i, contour := range contours {
+		if depth[i]%2 == 0 {
+			continue
+		}
+		parent := -1
+		for j, other := range contours {
+			if depth[j] != depth[i]-1 || !pointInPolygon2D(contour[0], other) {
+				continue
+			}
+			if parent < 0 || math.Abs(polygonArea(other)) < math.Abs(polygonArea(contours[parent])) {
+				parent = j
+			}
+		}
+		if parent >= 0 {
+			k := shapeIndex[parent]
+			shapes[k] = append(shapes[k], contour)
+		}
+	}
+	return shapes
+}
@@ -0,0 +1,93 @@
+package go3d
+
+import (
+	"image"
+	"image/draw"
+	_ "image/jpeg" // 注册 JPEG 解码器
+	_ "image/png"  // 注册 PNG 解码器
+	"math"
+	"os"
+)
+
+// Texture 纹理图像
+// 对行星表面使用等距圆柱投影（经纬度）贴图：u 对应经度，v 对应纬度（0 为北极）
+type Texture struct {
+	Image  *image.NRGBA
+	Width  int
+	Height int
+
+	Procedural ProceduralTexture // 程序纹理（见 NewProceduralTexture），非空时 Sample 直接计算颜色，没有图像
+}
+
+// NewTexture 从图像创建纹理
+func NewTexture(img image.Image) *Texture {
+	bounds := img.Bounds()
+	nrgba, ok := img.(*image.NRGBA)
+	if !ok || bounds.Min != (image.Point{}) {
+		nrgba = image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+		draw.Draw(nrgba, nrgba.Bounds(), img, bounds.Min, draw.Src)
+	}
+	return &Texture{
+		Image:  nrgba,
+		Width:  bounds.Dx(),
+		Height: bounds.Dy(),
+	}
+}
+
+// LoadTexture 从 PNG/JPEG 文件加载纹理
+func LoadTexture(filename string) (*Texture, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, err
+	}
+	return NewTexture(img), nil
+}
+
+// texel 读取像素颜色，u 方向环绕，v 方向钳制
+func (tex *Texture) texel(x, y int) [3]float64 {
+	x = ((x % tex.Width) + tex.Width) % tex.Width
+	y = max(0, min(tex.Height-1, y))
+	offset := y*tex.Image.Stride + x*4
+	pix := tex.Image.Pix[offset : offset+3]
+	return [3]float64{
+		float64(pix[0]) / 255,
+		float64(pix[1]) / 255,
+		float64(pix[2]) / 255,
+	}
+}
+
+// Sample 按纹理坐标双线性采样颜色
+func (tex *Texture) Sample(u, v float64) [3]float64 {
+	if tex.Procedural != nil {
+		return tex.Procedural.Sample(u, v)
+	}
+	if tex.Width == 0 || tex.Height == 0 {
+		return [3]float64{1, 1, 1}
+	}
+
+	x := u*float64(tex.Width) - 0.5
+	y := v*float64(tex.Height) - 0.5
+	x0 := math.Floor(x)
+	y0 := math.Floor(y)
+	fx := x - x0
+	fy := y - y0
+
+	c00 := tex.texel(int(x0), int(y0))
+	c10 := tex.texel(int(x0)+1, int(y0))
+	c01 := tex.texel(int(x0), int(y0)+1)
+	c11 := tex.texel(int(x0)+1, int(y0)+1)
+
+	var c [3]float64
+	for k := range 3 {
+		top := c00[k]*(1-fx) + c10[k]*fx
+		bottom := c01[k]*(1-fx) + c11[k]*fx
+		c[k] = top*(1-fy) + bottom*fy
+	}
+	return c
+}
@@ -0,0 +1,282 @@
+package go3d
+
+import "sort"
+
+// Track 属性轨道接口，在时间 t 对目标属性求值并写回
+type Track interface {
+	Apply(t float64)
+}
+
+// FloatKeyframe 浮点属性关键帧
+type FloatKeyframe struct {
+	Time   float64
+	Value  float64
+	Easing func(float64) float64 // 从本关键帧到下一关键帧使用的缓动函数，nil 为线性
+}
+
+// Vector3Keyframe 向量属性关键帧
+type Vector3Keyframe struct {
+	Time   float64
+	Value  Vector3
+	Easing func(float64) float64
+}
+
+// ColorKeyframe 颜色属性关键帧
+type ColorKeyframe struct {
+	Time   float64
+	Value  [3]float64
+	Easing func(float64) float64
+}
+
+// QuaternionKeyframe 旋转属性关键帧
+type QuaternionKeyframe struct {
+	Time   float64
+	Value  Quaternion
+	Easing func(float64) float64
+}
+
+// keyframeSegment 查找时间 t 所在的关键帧区间，返回起始索引和缓动前的局部插值参数
+// 超出范围时钳制到首尾关键帧（localT 为 0）
+func keyframeSegment(count int, timeAt func(int) float64, t float64) (int, float64) {
+	if count == 0 || t <= timeAt(0) {
+		return 0, 0
+	}
+	if t >= timeAt(count-1) {
+		return count - 1, 0
+	}
+
+	i := sort.Search(count, func(i int) bool { return timeAt(i) > t }) - 1
+	span := timeAt(i+1) - timeAt(i)
+	if span < 1e-10 {
+		return i + 1, 0
+	}
+	return i, (t - timeAt(i)) / span
+}
+
+// easeLocal 应用缓动函数
+func easeLocal(easing func(float64) float64, localT float64) float64 {
+	if easing == nil {
+		return localT
+	}
+	return easing(localT)
+}
+
+// FloatTrack 浮点属性轨道（灯光强度、缩放系数等）
+type FloatTrack struct {
+	Target    *float64
+	Keyframes []FloatKeyframe
+}
+
+// NewFloatTrack 创建浮点属性轨道
+func NewFloatTrack(target *float64) *FloatTrack {
+	return &FloatTrack{
+		Target:    target,
+		Keyframes: make([]FloatKeyframe, 0),
+	}
+}
+
+// AddKeyframe 添加关键帧（按时间保持有序）
+func (ft *FloatTrack) AddKeyframe(time, value float64, easing func(float64) float64) *FloatTrack {
+	ft.Keyframes = append(ft.Keyframes, FloatKeyframe{Time: time, Value: value, Easing: easing})
+	sort.SliceStable(ft.Keyframes, func(i, j int) bool {
+		return ft.Keyframes[i].Time < ft.Keyframes[j].Time
+	})
+	return ft
+}
+
+// Evaluate 计算时间 t 的属性值
+func (ft *FloatTrack) Evaluate(t float64) float64 {
+	if len(ft.Keyframes) == 0 {
+		return 0
+	}
+
+	i, localT := keyframeSegment(len(ft.Keyframes), func(i int) float64 { return ft.Keyframes[i].Time }, t)
+	kf := ft.Keyframes[i]
+	if localT == 0 {
+		return kf.Value
+	}
+
+	next := ft.Keyframes[i+1]
+	localT = easeLocal(kf.Easing, localT)
+	return kf.Value*(1-localT) + next.Value*localT
+}
+
+// Apply 将求值结果写回目标属性
+func (ft *FloatTrack) Apply(t float64) {
+	if ft.Target == nil || len(ft.Keyframes) == 0 {
+		return
+	}
+	*ft.Target = ft.Evaluate(t)
+}
+
+// Vector3Track 向量属性轨道（位置、缩放等）
+type Vector3Track struct {
+	Target    *Vector3
+	Keyframes []Vector3Keyframe
+}
+
+// NewVector3Track 创建向量属性轨道
+func NewVector3Track(target *Vector3) *Vector3Track {
+	return &Vector3Track{
+		Target:    target,
+		Keyframes: make([]Vector3Keyframe, 0),
+	}
+}
+
+// AddKeyframe 添加关键帧（按时间保持有序）
+func (vt *Vector3Track) AddKeyframe(time float64, value Vector3, easing func(float64) float64) *Vector3Track {
+	vt.Keyframes = append(vt.Keyframes, Vector3Keyframe{Time: time, Value: value, Easing: easing})
+	sort.SliceStable(vt.Keyframes, func(i, j int) bool {
+		return vt.Keyframes[i].Time < vt.Keyframes[j].Time
+	})
+	return vt
+}
+
+// Evaluate 计算时间 t 的属性值
+func (vt *Vector3Track) Evaluate(t float64) Vector3 {
+	if len(vt.Keyframes) == 0 {
+		return NewVector3(0, 0, 0)
+	}
+
+	i, localT := keyframeSegment(len(vt.Keyframes), func(i int) float64 { return vt.Keyframes[i].Time }, t)
+	kf := vt.Keyframes[i]
+	if localT == 0 {
+		return kf.Value
+	}
+
+	next := vt.Keyframes[i+1]
+	localT = easeLocal(kf.Easing, localT)
+	return kf.Value.Scale(1 - localT).Add(next.Value.Scale(localT))
+}
+
+// Apply 将求值结果写回目标属性
+func (vt *Vector3Track) Apply(t float64) {
+	if vt.Target == nil || len(vt.Keyframes) == 0 {
+		return
+	}
+	*vt.Target = vt.Evaluate(t)
+}
+
+// ColorTrack 颜色属性轨道（材质颜色、灯光颜色等）
+type ColorTrack struct {
+	Target    *[3]float64
+	Keyframes []ColorKeyframe
+}
+
+// NewColorTrack 创建颜色属性轨道
+func NewColorTrack(target *[3]float64) *ColorTrack {
+	return &ColorTrack{
+		Target:    target,
+		Keyframes: make([]ColorKeyframe, 0),
+	}
+}
+
+// AddKeyframe 添加关键帧（按时间保持有序）
+func (ct *ColorTrack) AddKeyframe(time float64, value [3]float64, easing func(float64) float64) *ColorTrack {
+	ct.Keyframes = append(ct.Keyframes, ColorKeyframe{Time: time, Value: value, Easing: easing})
+	sort.SliceStable(ct.Keyframes, func(i, j int) bool {
+		return ct.Keyframes[i].Time < ct.Keyframes[j].Time
+	})
+	return ct
+}
+
+// Evaluate 计算时间 t 的颜色
+func (ct *ColorTrack) Evaluate(t float64) [3]float64 {
+	if len(ct.Keyframes) == 0 {
+		return [3]float64{0, 0, 0}
+	}
+
+	i, localT := keyframeSegment(len(ct.Keyframes), func(i int) float64 { return ct.Keyframes[i].Time }, t)
+	kf := ct.Keyframes[i]
+	if localT == 0 {
+		return kf.Value
+	}
+
+	next := ct.Keyframes[i+1]
+	localT = easeLocal(kf.Easing, localT)
+	return [3]float64{
+		kf.Value[0]*(1-localT) + next.Value[0]*localT,
+		kf.Value[1]*(1-localT) + next.Value[1]*localT,
+		kf.Value[2]*(1-localT) + next.Value[2]*localT,
+	}
+}
+
+// Apply 将求值结果写回目标属性
+func (ct *ColorTrack) Apply(t float64) {
+	if ct.Target == nil || len(ct.Keyframes) == 0 {
+		return
+	}
+	*ct.Target = ct.Evaluate(t)
+}
+
+// QuaternionTrack 旋转属性轨道（Transform.Rotation、骨骼姿态等），关键帧之间球面插值
+type QuaternionTrack struct {
+	Target    *Quaternion
+	Keyframes []QuaternionKeyframe
+}
+
+// NewQuaternionTrack 创建旋转属性轨道
+func NewQuaternionTrack(target *Quaternion) *QuaternionTrack {
+	return &QuaternionTrack{
+		Target:    target,
+		Keyframes: make([]QuaternionKeyframe, 0),
+	}
+}
+
+// AddKeyframe 添加关键帧（按时间保持有序）
+func (qt *QuaternionTrack) AddKeyframe(time float64, value Quaternion, easing func(float64) float64) *QuaternionTrack {
+	qt.Keyframes = append(qt.Keyframes, QuaternionKeyframe{Time: time, Value: value, Easing: easing})
+	sort.SliceStable(qt.Keyframes, func(i, j int) bool {
+		return qt.Keyframes[i].Time < qt.Keyframes[j].Time
+	})
+	return qt
+}
+
+// Evaluate 计算时间 t 的旋转
+func (qt *QuaternionTrack) Evaluate(t float64) Quaternion {
+	if len(qt.Keyframes) == 0 {
+		return IdentityQuaternion()
+	}
+
+	i, localT := keyframeSegment(len(qt.Keyframes), func(i int) float64 { return qt.Keyframes[i].Time }, t)
+	kf := qt.Keyframes[i]
+	if localT == 0 {
+		return kf.Value
+	}
+
+	next := qt.Keyframes[i+1]
+	localT = easeLocal(kf.Easing, localT)
+	return kf.Value.Slerp(next.Value, localT)
+}
+
+// Apply 将求值结果写回目标属性
+func (qt *QuaternionTrack) Apply(t float64) {
+	if qt.Target == nil || len(qt.Keyframes) == 0 {
+		return
+	}
+	*qt.Target = qt.Evaluate(t)
+}
+
+// Timeline 时间线，管理一组属性轨道
+type Timeline struct {
+	Tracks []Track
+}
+
+// NewTimeline 创建时间线
+func NewTimeline() *Timeline {
+	return &Timeline{
+		Tracks: make([]Track, 0),
+	}
+}
+
+// AddTrack 添加属性轨道
+func (tl *Timeline) AddTrack(track Track) {
+	tl.Tracks = append(tl.Tracks, track)
+}
+
+// Evaluate 在时间 t 对所有轨道求值
+func (tl *Timeline) Evaluate(t float64) {
+	for _, track := range tl.Tracks {
+		track.Apply(t)
+	}
+}
@@ -0,0 +1,86 @@
+package go3d
+
+import "math"
+
+// Trail 运动轨迹拖尾
+// 通过位置函数回溯采样最近一段时间的位置，而不是逐帧记录状态，
+// 因此在多线程渲染时每一帧的结果都是确定的
+type Trail struct {
+	Source      func(t float64) Vector3 // 被跟踪对象的位置函数（如 Planet.GetPosition）
+	Color       [3]float64
+	Length      float64 // 拖尾覆盖的时间长度
+	Samples     int     // 采样点数
+	Width       float64 // 头部线宽（像素）
+	TailWidth   float64 // 尾部线宽（像素）
+	Opacity     float64 // 头部不透明度
+	TailOpacity float64 // 尾部不透明度
+}
+
+// NewTrail 创建运动轨迹拖尾
+func NewTrail(source func(t float64) Vector3, color [3]float64, length float64) *Trail {
+	return &Trail{
+		Source:      source,
+		Color:       color,
+		Length:      length,
+		Samples:     48,
+		Width:       3.0,
+		TailWidth:   0.5,
+		Opacity:     0.9,
+		TailOpacity: 0.0,
+	}
+}
+
+// Points 计算时间 t 的拖尾采样点，从尾部（最早）到头部（当前）
+func (tr *Trail) Points(t float64) []Vector3 {
+	samples := tr.Samples
+	if samples < 2 {
+		samples = 2
+	}
+
+	points := make([]Vector3, samples)
+	for i := range samples {
+		ratio := float64(i) / float64(samples-1)
+		points[i] = tr.Source(t - tr.Length*(1-ratio))
+	}
+	return points
+}
+
+// Render 渲染拖尾：线宽和不透明度从头部到尾部逐渐衰减
+func (tr *Trail) Render(renderer *Renderer, t float64) {
+	if tr.Source == nil || tr.Length <= 0 {
+		return
+	}
+
+	points := tr.Points(t)
+	renderer.DrawOverlay(func() {
+		renderer.Context.Save()
+		defer renderer.Context.Restore()
+
+		renderer.Context.SetLineCap(lineCapRound)
+
+		segments := len(points) - 1
+		for i := range segments {
+			a, b, ok := renderer.clipSegmentToNear(points[i], points[i+1])
+			if !ok {
+				continue
+			}
+
+			// 0 为尾部，1 为头部
+			ratio := (float64(i) + 0.5) / float64(segments)
+			alpha := tr.TailOpacity + (tr.Opacity-tr.TailOpacity)*ratio
+			width := tr.TailWidth + (tr.Width-tr.TailWidth)*ratio
+			if alpha <= 0 || width <= 0 {
+				continue
+			}
+
+			x0, y0, _ := renderer.ProjectToScreen(a)
+			x1, y1, _ := renderer.ProjectToScreen(b)
+
+			renderer.Context.SetSourceRGBA(tr.Color[0], tr.Color[1], tr.Color[2], math.Min(1, alpha))
+			renderer.Context.SetLineWidth(width)
+			renderer.Context.MoveTo(x0, y0)
+			renderer.Context.LineTo(x1, y1)
+			renderer.Context.Stroke()
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package go3d
+
+// Transform 平移、旋转、缩放组成的变换，按先缩放、再旋转、最后平移的顺序作用于顶点
+// Parent 不为空时，WorldMatrix 会叠加父变换，用于层级结构（如卫星跟随行星）
+type Transform struct {
+	Position Vector3
+	Rotation Quaternion
+	Scale    Vector3
+	Parent   *Transform
+}
+
+// NewTransform 创建单位变换
+func NewTransform() *Transform {
+	return &Transform{
+		Rotation: IdentityQuaternion(),
+		Scale:    NewVector3(1, 1, 1),
+	}
+}
+
+// SetPosition 设置平移
+func (t *Transform) SetPosition(position Vector3) *Transform {
+	t.Position = position
+	return t
+}
+
+// SetRotation 设置旋转
+func (t *Transform) SetRotation(rotation Quaternion) *Transform {
+	t.Rotation = rotation
+	return t
+}
+
+// SetScale 设置缩放
+func (t *Transform) SetScale(scale Vector3) *Transform {
+	t.Scale = scale
+	return t
+}
+
+// SetUniformScale 设置各轴相同的缩放
+func (t *Transform) SetUniformScale(s float64) *Transform {
+	t.Scale = NewVector3(s, s, s)
+	return t
+}
+
+// SetParent 设置父变换
+func (t *Transform) SetParent(parent *Transform) *Transform {
+	t.Parent = parent
+	return t
+}
+
+// Rotate 在当前旋转之后再叠加一次旋转（绕本体坐标轴）
+func (t *Transform) Rotate(rotation Quaternion) *Transform {
+	t.Rotation = t.Rotation.Multiply(rotation)
+	return t
+}
+
+// Matrix 局部变换矩阵 T·R·S（不含父变换）
+func (t *Transform) Matrix() Matrix4 {
+	return Translation(t.Position.X, t.Position.Y, t.Position.Z).
+		Multiply(t.Rotation.Matrix()).
+		Multiply(Scale(t.Scale.X, t.Scale.Y, t.Scale.Z))
+}
+
+// WorldMatrix 世界变换矩阵：依次叠加所有父变换
+func (t *Transform) WorldMatrix() Matrix4 {
+	matrix := t.Matrix()
+	for parent := t.Parent; parent != nil; parent = parent.Parent {
+		matrix = parent.Matrix().Multiply(matrix)
+	}
+	return matrix
+}
+
+// WorldPosition 原点经世界变换后的位置
+func (t *Transform) WorldPosition() Vector3 {
+	return t.WorldMatrix().TransformVector(Vector3{})
+}
+
+// TransformPoint 用世界变换变换一个点
+func (t *Transform) TransformPoint(p Vector3) Vector3 {
+	return t.WorldMatrix().TransformVector(p)
+}
+
+// Lerp 在两个变换之间插值：平移和缩放线性插值，旋转球面插值。结果沿用 t 的父变换
+func (t *Transform) Lerp(other *Transform, amount float64) *Transform {
+	lerp := func(a, b Vector3) Vector3 {
+		return a.Add(b.Sub(a).Scale(amount))
+	}
+	return &Transform{
+		Position: lerp(t.Position, other.Position),
+		Rotation: t.Rotation.Slerp(other.Rotation, amount),
+		Scale:    lerp(t.Scale, other.Scale),
+		Parent:   t.Parent,
+	}
+}
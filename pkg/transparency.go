@@ -0,0 +1,20 @@
+package go3d
+
+import "image"
+
+// clearTransparent 把画布清空为完全透明
+// Cairo 的 SOURCE 合成模式不会写入 alpha，因此直接清零像素
+func (r *Renderer) clearTransparent() {
+	img, ok := r.Surface.GetGoImage().(*image.RGBA)
+	if !ok {
+		return
+	}
+	clear(img.Pix)
+}
+
+// fixPremultiplied 把每个像素的 alpha 提高到不小于其颜色分量，保证预乘 alpha 格式有效
+func fixPremultiplied(img *image.RGBA) {
+	for i := 0; i+3 < len(img.Pix); i += 4 {
+		img.Pix[i+3] = max(img.Pix[i+3], img.Pix[i], img.Pix[i+1], img.Pix[i+2])
+	}
+}
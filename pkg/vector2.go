@@ -0,0 +1,93 @@
+package go3d
+
+import "math"
+
+// Vector2 表示2D平面中的向量
+type Vector2 struct {
+	X, Y float64
+}
+
+// NewVector2 创建新的2D向量
+func NewVector2(x, y float64) Vector2 {
+	return Vector2{X: x, Y: y}
+}
+
+// Add 向量加法
+func (v Vector2) Add(other Vector2) Vector2 {
+	return Vector2{v.X + other.X, v.Y + other.Y}
+}
+
+// Sub 向量减法
+func (v Vector2) Sub(other Vector2) Vector2 {
+	return Vector2{v.X - other.X, v.Y - other.Y}
+}
+
+// Scale 向量缩放
+func (v Vector2) Scale(s float64) Vector2 {
+	return Vector2{v.X * s, v.Y * s}
+}
+
+// Dot 点积
+func (v Vector2) Dot(other Vector2) float64 {
+	return v.X*other.X + v.Y*other.Y
+}
+
+// Cross 二维叉积（两向量构成的平行四边形的有向面积）
+func (v Vector2) Cross(other Vector2) float64 {
+	return v.X*other.Y - v.Y*other.X
+}
+
+// Length 向量长度
+func (v Vector2) Length() float64 {
+	return math.Sqrt(v.X*v.X + v.Y*v.Y)
+}
+
+// Normalize 向量归一化，零向量返回零向量
+func (v Vector2) Normalize() Vector2 {
+	length := v.Length()
+	if length == 0 {
+		return Vector2{}
+	}
+	return Vector2{v.X / length, v.Y / length}
+}
+
+// Negate 取反向量
+func (v Vector2) Negate() Vector2 {
+	return Vector2{-v.X, -v.Y}
+}
+
+// Mul 逐分量相乘
+func (v Vector2) Mul(other Vector2) Vector2 {
+	return Vector2{v.X * other.X, v.Y * other.Y}
+}
+
+// Distance 两点间距离
+func (v Vector2) Distance(other Vector2) float64 {
+	return v.Sub(other).Length()
+}
+
+// Lerp 线性插值，t 为 0 时返回 v，为 1 时返回 other
+func (v Vector2) Lerp(other Vector2, t float64) Vector2 {
+	return v.Add(other.Sub(v).Scale(t))
+}
+
+// Perp 逆时针旋转 90° 得到的垂直向量
+func (v Vector2) Perp() Vector2 {
+	return Vector2{-v.Y, v.X}
+}
+
+// Rotate 逆时针旋转 angle 弧度
+func (v Vector2) Rotate(angle float64) Vector2 {
+	c, s := math.Cos(angle), math.Sin(angle)
+	return Vector2{v.X*c - v.Y*s, v.X*s + v.Y*c}
+}
+
+// Angle 向量与 X 轴正方向的夹角（弧度，范围 -π 到 π）
+func (v Vector2) Angle() float64 {
+	return math.Atan2(v.Y, v.X)
+}
+
+// Array 转换为 [2]float64，与 Mesh.UVs 等字段的存储格式一致
+func (v Vector2) Array() [2]float64 {
+	return [2]float64{v.X, v.Y}
+}
@@ -0,0 +1,300 @@
+package go3d
+
+import (
+	"math"
+	"sort"
+)
+
+// ArrowStyle 向量场箭头的绘制方式
+type ArrowStyle int
+
+const (
+	ArrowSolid ArrowStyle = iota // 圆柱箭杆加圆锥箭头的实体箭头（默认）
+	ArrowLine                    // 线段箭头，数量多时更快也更清爽
+	ArrowNone                    // 不绘制箭头，只绘制流线
+)
+
+// VectorField 向量场可视化：在 Bounds 内均匀分布的 Density³ 个采样点上绘制箭头，箭头方向为场的方向，
+// 长度与场的大小成正比，颜色按大小在色带上取色。打开 Streamlines 后还会沿场积分出流线，
+// 设置 FlowSpeed 时流线以虚线的形式随时间流动，适合物理和流体示意动画
+type VectorField struct {
+	Field   func(p Vector3) Vector3
+	Bounds  AABB
+	Density int // 每个方向的采样点数
+
+	Arrows     ArrowStyle
+	ArrowScale float64 // 场大小为 1 时箭头的长度（世界单位），0 表示自动：最长的箭头略短于采样间距
+	LineWidth  float64 // 线段箭头和流线的宽度（像素）
+
+	Streamlines bool      // 是否绘制流线
+	Seeds       []Vector3 // 流线起点，空表示从所有采样点出发
+	StreamSteps int       // 每条流线最多的积分步数
+	StreamStep  float64   // 积分步长（世界单位），0 表示采样间距的 1/4
+	FlowSpeed   float64   // 虚线每单位时间流过的距离（世界单位），0 表示画成静止的实线
+	DashLength  float64   // 虚线一个周期（一段实线加一段空白）的长度，0 表示采样间距的 2 倍
+
+	Colorbar *Colorbar // 场大小的颜色图例，nil 表示不绘制
+
+	ramp ColorRamp
+}
+
+// NewVectorField 创建在 bounds 内每个方向采样 density 个点的向量场，颜色使用 ViridisRamp
+func NewVectorField(f func(p Vector3) Vector3, bounds AABB, density int) *VectorField {
+	vf := &VectorField{
+		Field:       f,
+		Bounds:      bounds,
+		Density:     density,
+		LineWidth:   1.5,
+		StreamSteps: 200,
+	}
+	vf.Colorbar = NewColorbar(nil, 0, 1)
+	vf.Colorbar.Title = "|v|"
+	return vf.SetRamp(ViridisRamp())
+}
+
+// SetRamp 设置按场大小着色的色带（同时用于颜色图例），色带位置 0 对应最小值，1 对应最大值
+func (vf *VectorField) SetRamp(ramp ColorRamp) *VectorField {
+	vf.ramp = ramp
+	if vf.Colorbar != nil {
+		vf.Colorbar.Ramp = ramp
+	}
+	return vf
+}
+
+// SetFlow 打开流线并设置流动速度，speed 为 0 时流线静止
+func (vf *VectorField) SetFlow(speed float64) *VectorField {
+	vf.Streamlines = true
+	vf.FlowSpeed = speed
+	return vf
+}
+
+// fieldSample 一个采样点上的场
+type fieldSample struct {
+	position, value Vector3
+	magnitude       float64
+}
+
+// samples 在 Bounds 内均匀采样场，只有一个采样点的方向取中点；同时返回采样间距（各方向的最小值）
+func (vf *VectorField) samples() ([]fieldSample, float64) {
+	n := max(1, vf.Density)
+	size := vf.Bounds.Size()
+	spacing := math.Inf(1)
+	for _, extent := range [3]float64{size.X, size.Y, size.Z} {
+		if extent > 0 && n > 1 {
+			spacing = math.Min(spacing, extent/float64(n-1))
+		}
+	}
+	if math.IsInf(spacing, 1) {
+		spacing = math.Max(size.X, math.Max(size.Y, math.Max(size.Z, 1)))
+	}
+
+	coordinate := func(lo, hi float64, k int) float64 {
+		if n == 1 {
+			return (lo + hi) / 2
+		}
+		return lo + (hi-lo)*float64(k)/float64(n-1)
+	}
+	samples := make([]fieldSample, 0, n*n*n)
+	for i := range n {
+		for j := range n {
+			for k := range n {
+				p := NewVector3(
+					coordinate(vf.Bounds.Min.X, vf.Bounds.Max.X, i),
+					coordinate(vf.Bounds.Min.Y, vf.Bounds.Max.Y, j),
+					coordinate(vf.Bounds.Min.Z, vf.Bounds.Max.Z, k),
+				)
+				v := vf.Field(p)
+				if m := v.Length(); finite(m) {
+					samples = append(samples, fieldSample{p, v, m})
+				}
+			}
+		}
+	}
+	return samples, spacing
+}
+
+// color 场大小在色带上的颜色
+func (vf *VectorField) color(magnitude, lo, hi float64) [3]float64 {
+	if hi <= lo {
+		return vf.ramp.At(1)
+	}
+	return vf.ramp.At((magnitude - lo) / (hi - lo))
+}
+
+// Render 依次绘制箭头、流线和颜色图例
+func (vf *VectorField) Render(renderer *Renderer, t float64) {
+	if vf.Field == nil {
+		return
+	}
+	samples, spacing := vf.samples()
+	lo, hi := math.Inf(1), 0.0
+	for _, s := range samples {
+		lo, hi = math.Min(lo, s.magnitude), math.Max(hi, s.magnitude)
+	}
+	if len(samples) == 0 {
+		lo = 0
+	}
+
+	scale := vf.ArrowScale
+	if scale <= 0 && hi > 0 {
+		scale = spacing * 0.9 / hi
+	}
+	switch vf.Arrows {
+	case ArrowSolid:
+		vf.drawSolidArrows(renderer, samples, scale, spacing, lo, hi)
+	case ArrowLine:
+		for _, s := range samples {
+			vf.drawLineArrow(renderer, s, scale, vf.color(s.magnitude, lo, hi))
+		}
+	}
+
+	if vf.Streamlines {
+		vf.drawStreamlines(renderer, samples, spacing, lo, hi, t)
+	}
+	if vf.Colorbar != nil {
+		vf.Colorbar.Min, vf.Colorbar.Max = lo, hi
+		vf.Colorbar.Render(renderer, t)
+	}
+}
+
+// drawSolidArrows 绘制实体箭头：箭杆和箭头的粗细按采样间距确定，所有箭头粗细相同；
+// 箭头按与相机的距离从远到近绘制
+func (vf *VectorField) drawSolidArrows(renderer *Renderer, samples []fieldSample, scale, spacing, lo, hi float64) {
+	order := make([]int, 0, len(samples))
+	for i, s := range samples {
+		if s.magnitude*scale > 1e-9 {
+			order = append(order, i)
+		}
+	}
+	distance := func(i int) float64 {
+		return samples[i].position.Sub(renderer.Camera.Position).Length()
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return distance(order[a]) > distance(order[b])
+	})
+
+	cylinder := unitPrimitive(primitiveCylinder, 8, 0, 0)
+	cone := unitPrimitive(primitiveCone, 8, 0, 0)
+	shaftRadius, headRadius := spacing*0.035, spacing*0.09
+	for _, i := range order {
+		s := samples[i]
+		length := s.magnitude * scale
+		head := math.Min(length*0.4, spacing*0.3)
+		direction := s.value.Scale(1 / s.magnitude)
+		rotation := alignY(direction)
+		color := vf.color(s.magnitude, lo, hi)
+
+		// 箭头以采样点为中心
+		start := s.position.Sub(direction.Scale(length / 2))
+		shaft := length - head
+		shaftCenter := start.Add(direction.Scale(shaft / 2))
+		headCenter := start.Add(direction.Scale(shaft + head/2))
+		renderer.DrawMeshTransformed(cylinder,
+			NewTransform().SetPosition(shaftCenter).SetRotation(rotation).Matrix().Multiply(Scale(shaftRadius, shaft, shaftRadius)), color)
+		renderer.DrawMeshTransformed(cone,
+			NewTransform().SetPosition(headCenter).SetRotation(rotation).Matrix().Multiply(Scale(headRadius, head, headRadius)), color)
+	}
+}
+
+// drawLineArrow 绘制线段箭头：箭头的两翼在垂直于视线的平面内张开，从任何角度看都清晰
+func (vf *VectorField) drawLineArrow(renderer *Renderer, s fieldSample, scale float64, color [3]float64) {
+	length := s.magnitude * scale
+	if length <= 1e-9 {
+		return
+	}
+	direction := s.value.Scale(1 / s.magnitude)
+	start := s.position.Sub(direction.Scale(length / 2))
+	end := s.position.Add(direction.Scale(length / 2))
+	side := direction.Cross(renderer.Camera.Position.Sub(end))
+	if side.Length() < 1e-9 {
+		renderer.DrawLine3D(start, end, color, vf.LineWidth)
+		return
+	}
+	side = side.Normalize().Scale(length * 0.15)
+	back := end.Sub(direction.Scale(length * 0.3))
+	renderer.DrawPolyline3D([]Vector3{start, end}, color, vf.LineWidth)
+	renderer.DrawPolyline3D([]Vector3{back.Add(side), end, back.Sub(side)}, color, vf.LineWidth)
+}
+
+// streamline 从 seed 出发沿场方向用四阶龙格-库塔法积分，离开 Bounds 或场为零时停止
+func (vf *VectorField) streamline(seed Vector3, step float64) []Vector3 {
+	direction := func(p Vector3) (Vector3, bool) {
+		v := vf.Field(p)
+		m := v.Length()
+		if !(m > 1e-12) || !finite(m) {
+			return Vector3{}, false
+		}
+		return v.Scale(1 / m), true
+	}
+	points := []Vector3{seed}
+	p := seed
+	for range vf.StreamSteps {
+		k1, ok1 := direction(p)
+		k2, ok2 := direction(p.Add(k1.Scale(step / 2)))
+		k3, ok3 := direction(p.Add(k2.Scale(step / 2)))
+		k4, ok4 := direction(p.Add(k3.Scale(step)))
+		if !ok1 || !ok2 || !ok3 || !ok4 {
+			break
+		}
+		p = p.Add(k1.Add(k2.Scale(2)).Add(k3.Scale(2)).Add(k4).Scale(step / 6))
+		if !vf.Bounds.Contains(p) {
+			break
+		}
+		points = append(points, p)
+	}
+	return points
+}
+
+// drawStreamlines 绘制流线，每段按该处的场大小着色；FlowSpeed 不为 0 时只绘制随时间平移的虚线段
+func (vf *VectorField) drawStreamlines(renderer *Renderer, samples []fieldSample, spacing, lo, hi, t float64) {
+	seeds := vf.Seeds
+	if len(seeds) == 0 {
+		for _, s := range samples {
+			seeds = append(seeds, s.position)
+		}
+	}
+	step := vf.StreamStep
+	if step <= 0 {
+		step = spacing / 4
+	}
+	period := vf.DashLength
+	if period <= 0 {
+		period = spacing * 2
+	}
+
+	for _, seed := range seeds {
+		points := vf.streamline(seed, step)
+		travelled := 0.0
+		for i := 0; i+1 < len(points); i++ {
+			a, b := points[i], points[i+1]
+			mid := travelled + a.Sub(b).Length()/2
+			travelled += a.Sub(b).Length()
+			if vf.FlowSpeed != 0 {
+				phase := (mid - t*vf.FlowSpeed) / period
+				if phase-math.Floor(phase) >= 0.5 {
+					continue
+				}
+			}
+			color := vf.color(vf.Field(a.Add(b).Scale(0.5)).Length(), lo, hi)
+			renderer.DrawLine3D(a, b, color, vf.LineWidth)
+		}
+	}
+}
+
+// WorldBounds 采样范围
+func (vf *VectorField) WorldBounds(t float64) AABB {
+	return vf.Bounds
+}
+
+// alignY 把 +Y 方向旋转到单位向量 direction 的旋转，方向与 -Y 相反时绕 X 轴转半圈
+func alignY(direction Vector3) Quaternion {
+	up := NewVector3(0, 1, 0)
+	axis := up.Cross(direction)
+	if axis.Length() < 1e-9 {
+		if direction.Y < 0 {
+			return QuaternionFromAxisAngle(NewVector3(1, 0, 0), math.Pi)
+		}
+		return IdentityQuaternion()
+	}
+	return QuaternionFromAxisAngle(axis.Normalize(), math.Acos(math.Max(-1, math.Min(1, up.Dot(direction)))))
+}
@@ -0,0 +1,104 @@
+package go3d
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// VideoPreset 常用视频编码器的预设
+type VideoPreset int
+
+const (
+	VideoH264             VideoPreset = iota // libx264 编码的 MP4（默认，兼容性最好）
+	VideoH264NVENC                           // NVIDIA 显卡硬件编码的 H.264 MP4
+	VideoHEVCVideoToolbox                    // macOS 硬件编码的 HEVC MP4
+	VideoVP9                                 // libvpx-vp9 编码的 WebM
+	VideoVP9Alpha                            // 带透明通道（yuva420p）的 VP9 WebM，可叠加在网页内容上
+	VideoAV1                                 // libaom-av1 编码的 WebM
+	VideoProRes4444                          // 带透明通道的 ProRes 4444 MOV，用于剪辑软件中合成
+)
+
+// videoPresetSettings 预设对应的编码器、像素格式、扩展名和专用参数
+type videoPresetSettings struct {
+	codec       string
+	pixelFormat string
+	extension   string
+	args        []string
+}
+
+// videoPresets 各预设的设置
+var videoPresets = map[VideoPreset]videoPresetSettings{
+	VideoH264:             {"libx264", "yuv420p", ".mp4", nil},
+	VideoH264NVENC:        {"h264_nvenc", "yuv420p", ".mp4", []string{"-preset", "p5"}},
+	VideoHEVCVideoToolbox: {"hevc_videotoolbox", "yuv420p", ".mp4", []string{"-tag:v", "hvc1"}}, // hvc1 标签让 QuickTime 和 Safari 能够播放
+	VideoVP9:              {"libvpx-vp9", "yuv420p", ".webm", []string{"-row-mt", "1"}},
+	VideoVP9Alpha:         {"libvpx-vp9", "yuva420p", ".webm", []string{"-row-mt", "1", "-auto-alt-ref", "0"}}, // 透明通道不支持替代参考帧
+	VideoAV1:              {"libaom-av1", "yuv420p", ".webm", []string{"-cpu-used", "6", "-row-mt", "1"}},
+	VideoProRes4444:       {"prores_ks", "yuva444p10le", ".mov", []string{"-profile:v", "4444", "-vendor", "apl0"}},
+}
+
+// videoPresetNames 预设名称，用于命令行参数和场景服务
+var videoPresetNames = map[string]VideoPreset{
+	"h264":       VideoH264,
+	"h264-nvenc": VideoH264NVENC,
+	"hevc-vt":    VideoHEVCVideoToolbox,
+	"vp9":        VideoVP9,
+	"vp9-alpha":  VideoVP9Alpha,
+	"av1":        VideoAV1,
+	"prores4444": VideoProRes4444,
+}
+
+// ParseVideoPreset 按名称（h264、h264-nvenc、hevc-vt、vp9、vp9-alpha、av1、prores4444，不区分大小写）查找视频预设
+func ParseVideoPreset(name string) (VideoPreset, error) {
+	preset, ok := videoPresetNames[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("未知的视频预设 %q（可选: %s）", name, strings.Join(sortedKeys(videoPresetNames), ", "))
+	}
+	return preset, nil
+}
+
+// ApplyVideoPreset 按预设设置编码器、像素格式和专用参数，并把输出文件的扩展名改为预设的容器格式
+// 会替换 ExtraArgs；需要追加参数时在调用之后再修改
+func (c *AnimationConfig) ApplyVideoPreset(preset VideoPreset) error {
+	settings, ok := videoPresets[preset]
+	if !ok {
+		return fmt.Errorf("未知的视频预设 %d", preset)
+	}
+	c.VideoCodec = settings.codec
+	c.PixelFormat = settings.pixelFormat
+	c.ExtraArgs = append([]string(nil), settings.args...)
+	if c.OutputFile != "" {
+		c.OutputFile = strings.TrimSuffix(c.OutputFile, filepath.Ext(c.OutputFile)) + settings.extension
+	}
+	return nil
+}
+
+// videoQualityArgs 把 Quality（CRF 风格，0-51，越小质量越高）转换为编码器各自的质量参数
+func videoQualityArgs(codec string, quality int) []string {
+	switch {
+	case strings.HasSuffix(codec, "_nvenc"):
+		return []string{"-rc", "vbr", "-cq", fmt.Sprintf("%d", quality)}
+	case strings.HasSuffix(codec, "_videotoolbox"):
+		// VideoToolbox 的 -q:v 为 1-100，越大质量越高
+		return []string{"-q:v", fmt.Sprintf("%d", max(1, min(100, 100-quality*100/51)))}
+	case strings.HasPrefix(codec, "prores"):
+		// ProRes 的码率由 profile 决定
+		return nil
+	case codec == "libvpx-vp9", codec == "libaom-av1":
+		// 恒定质量模式需要把码率设为 0；这两个编码器的 CRF 范围为 0-63
+		return []string{"-crf", fmt.Sprintf("%d", min(63, quality*63/51)), "-b:v", "0"}
+	default:
+		return []string{"-crf", fmt.Sprintf("%d", quality)}
+	}
+}
+
+// pixelFormatHasAlpha 像素格式是否带 alpha 通道（如 yuva420p、yuva444p10le、rgba、gbrap）
+func pixelFormatHasAlpha(format string) bool {
+	for _, prefix := range []string{"yuva", "rgba", "bgra", "argb", "abgr", "gbrap", "ya"} {
+		if strings.HasPrefix(format, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package go3d
+
+import "image"
+
+// viewport 正在绘制的视口：离屏渲染器及其在画布上的位置
+type viewport struct {
+	renderer *Renderer
+	x, y     int
+}
+
+// SetViewport 在画布的矩形区域 (x, y, width, height) 内开始一个视口，返回绘制该视口使用的渲染器。
+// 视口渲染器的尺寸为 width×height，相机是当前相机的副本（可以独立修改），其余设置（渲染模式、光源、
+// 环境贴图等）与画布相同，初始画面是画布上该区域的内容。一帧画面可以包含多个视图，例如
+// 顶视、前视、侧视和透视四视图，或者角落里的小地图：
+//
+//	top := renderer.SetViewport(0, 0, 960, 540)
+//	top.Camera.Position = go3d.NewVector3(0, 30, 0)
+//	top.Camera.AutoUp = true
+//	scene.Render(top, t)
+//	persp := renderer.SetViewport(960, 0, 960, 540)
+//	scene.Render(persp, t)
+//	renderer.ResetViewport()
+//
+// 视口画面在下一次 SetViewport、ResetViewport 或应用后期处理（保存画面）时合成到画布上，
+// 后期处理作用于合成后的整个画面；超出画布的部分被裁掉
+func (r *Renderer) SetViewport(x, y, width, height int) *Renderer {
+	r.ResetViewport()
+
+	camera := *r.Camera
+	view := r.newRendererLike(&camera, width, height)
+	if img, ok := r.Surface.GetGoImage().(*image.RGBA); ok {
+		if viewImg, ok := view.Surface.GetGoImage().(*image.RGBA); ok {
+			copyRect(viewImg, 0, 0, img, x, y, width, height)
+		}
+	}
+	r.viewport = &viewport{renderer: view, x: x, y: y}
+	return view
+}
+
+// ResetViewport 把当前视口的画面合成到画布上并释放视口，之后的绘制重新作用于整个画布
+// 视口的相机错误（见 CameraError）会保留到画布上
+func (r *Renderer) ResetViewport() {
+	vp := r.viewport
+	if vp == nil {
+		return
+	}
+	r.viewport = nil
+	defer vp.renderer.Destroy()
+
+	vp.renderer.ApplyPostEffects()
+	img, ok := r.Surface.GetGoImage().(*image.RGBA)
+	if !ok {
+		return
+	}
+	if viewImg, ok := vp.renderer.Surface.GetGoImage().(*image.RGBA); ok {
+		copyRect(img, vp.x, vp.y, viewImg, 0, 0, vp.renderer.Width, vp.renderer.Height)
+	}
+	if r.cameraErr == nil {
+		r.cameraErr = vp.renderer.cameraErr
+	}
+}
+
+// copyRect 把 src 中以 (sx, sy) 为左上角、width×height 的区域复制到 dst 的 (dx, dy) 处，
+// 超出任一图像范围的部分被跳过
+func copyRect(dst *image.RGBA, dx, dy int, src *image.RGBA, sx, sy, width, height int) {
+	for row := range height {
+		dstY, srcY := dy+row, sy+row
+		if dstY < 0 || srcY < 0 || dstY >= dst.Rect.Dy() || srcY >= src.Rect.Dy() {
+			continue
+		}
+		// 左右两端同时裁剪到两张图像的范围内
+		start := max(0, -dx, -sx)
+		end := min(width, dst.Rect.Dx()-dx, src.Rect.Dx()-sx)
+		if start >= end {
+			return
+		}
+		d := dstY*dst.Stride + (dx+start)*4
+		s := srcY*src.Stride + (sx+start)*4
+		copy(dst.Pix[d:d+(end-start)*4], src.Pix[s:s+(end-start)*4])
+	}
+}
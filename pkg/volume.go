@@ -0,0 +1,261 @@
+package go3d
+
+import (
+	"image"
+	"math"
+)
+
+// Volume 体数据：三维标量数组均匀分布在 Bounds 内（数组的第一个和最后一个元素分别位于包围盒的两端），
+// 以与视线最接近垂直的一组轴对齐切片从后往前半透明叠加绘制，适合 CT、MRI 等医学影像和科学计算数据。
+// 数值经 Window 归一化后决定颜色（Ramp）和不透明度：归一化值越大越不透明，Window 下限以下完全透明。
+// Isosurface 抽取等值面网格，可以和体绘制一起使用，也可以单独绘制
+type Volume struct {
+	Bounds  AABB
+	Window  [2]float64 // 显示的数值范围（窗宽窗位），下限及以下透明，上限及以上最不透明
+	Opacity float64    // 归一化值为 1 时每世界单位的吸收系数，越大越不透明
+	Slices  int        // 切片数，0 表示与该方向的体素数相同
+	Ramp    ColorRamp  // 归一化值到颜色的色带
+
+	nx, ny, nz int
+	values     []float64
+}
+
+// NewVolume 从 data[x][y][z] 创建体数据（各行长度须相同，不足的部分按 0 处理），
+// Window 默认为数据的最小值和最大值，颜色使用 ViridisRamp
+func NewVolume(data [][][]float64, bounds AABB) *Volume {
+	v := &Volume{Bounds: bounds, Opacity: 4, Ramp: ViridisRamp()}
+	v.nx = len(data)
+	for _, plane := range data {
+		v.ny = max(v.ny, len(plane))
+		for _, row := range plane {
+			v.nz = max(v.nz, len(row))
+		}
+	}
+	v.values = make([]float64, v.nx*v.ny*v.nz)
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i, plane := range data {
+		for j, row := range plane {
+			for k, value := range row {
+				if !finite(value) {
+					continue
+				}
+				v.values[(i*v.ny+j)*v.nz+k] = value
+				lo, hi = math.Min(lo, value), math.Max(hi, value)
+			}
+		}
+	}
+	if lo > hi {
+		lo, hi = 0, 1
+	}
+	v.Window = [2]float64{lo, hi}
+	return v
+}
+
+// SetWindow 设置显示的数值范围
+func (v *Volume) SetWindow(lo, hi float64) *Volume {
+	v.Window = [2]float64{lo, hi}
+	return v
+}
+
+// SetRamp 设置归一化值到颜色的色带
+func (v *Volume) SetRamp(ramp ColorRamp) *Volume {
+	v.Ramp = ramp
+	return v
+}
+
+// Dimensions 三个方向的体素数
+func (v *Volume) Dimensions() (nx, ny, nz int) {
+	return v.nx, v.ny, v.nz
+}
+
+// value 格点 (i, j, k) 的数值
+func (v *Volume) value(i, j, k int) float64 {
+	return v.values[(i*v.ny+j)*v.nz+k]
+}
+
+// gridPosition 格点 (i, j, k) 的世界坐标
+func (v *Volume) gridPosition(i, j, k int) Vector3 {
+	size := v.Bounds.Size()
+	at := func(lo, extent float64, index, n int) float64 {
+		if n <= 1 {
+			return lo + extent/2
+		}
+		return lo + extent*float64(index)/float64(n-1)
+	}
+	return NewVector3(
+		at(v.Bounds.Min.X, size.X, i, v.nx),
+		at(v.Bounds.Min.Y, size.Y, j, v.ny),
+		at(v.Bounds.Min.Z, size.Z, k, v.nz),
+	)
+}
+
+// Sample 世界坐标 p 处的三线性插值数值，包围盒之外取最近的边界值
+func (v *Volume) Sample(p Vector3) float64 {
+	if len(v.values) == 0 {
+		return 0
+	}
+	size := v.Bounds.Size()
+	// locate 把坐标换算为格点下标和插值比例
+	locate := func(x, lo, extent float64, n int) (int, int, float64) {
+		if n <= 1 || extent <= 0 {
+			return 0, 0, 0
+		}
+		f := math.Max(0, math.Min(float64(n-1), (x-lo)/extent*float64(n-1)))
+		i := min(int(f), n-2)
+		return i, i + 1, f - float64(i)
+	}
+	i0, i1, fx := locate(p.X, v.Bounds.Min.X, size.X, v.nx)
+	j0, j1, fy := locate(p.Y, v.Bounds.Min.Y, size.Y, v.ny)
+	k0, k1, fz := locate(p.Z, v.Bounds.Min.Z, size.Z, v.nz)
+
+	lerp := func(a, b, s float64) float64 { return a + (b-a)*s }
+	return lerp(
+		lerp(lerp(v.value(i0, j0, k0), v.value(i0, j0, k1), fz), lerp(v.value(i0, j1, k0), v.value(i0, j1, k1), fz), fy),
+		lerp(lerp(v.value(i1, j0, k0), v.value(i1, j0, k1), fz), lerp(v.value(i1, j1, k0), v.value(i1, j1, k1), fz), fy),
+		fx,
+	)
+}
+
+// Isosurface 抽取数值等于 level 的等值面网格，法线指向数值较小的一侧（对于中间密、外面稀的数据即朝外）。
+// 使用行进四面体法，每个方向至少需要两个体素
+func (v *Volume) Isosurface(level float64) *Mesh {
+	if v.nx < 2 || v.ny < 2 || v.nz < 2 {
+		return NewMesh()
+	}
+	return marchTetrahedra([3]int{v.nx, v.ny, v.nz}, v.value, v.gridPosition, level)
+}
+
+// normalized 数值经 Window 归一化到 0-1
+func (v *Volume) normalized(value float64) float64 {
+	span := v.Window[1] - v.Window[0]
+	if span <= 0 {
+		if value > v.Window[0] {
+			return 1
+		}
+		return 0
+	}
+	return math.Max(0, math.Min(1, (value-v.Window[0])/span))
+}
+
+// Render 在几何体之后逐像素叠加切片：切片垂直于与视线方向最接近的坐标轴，位于体素层的中间，
+// 每个像素沿视线从后往前与各切片求交并按前后顺序合成。体数据不写入深度，总是绘制在已有画面之上
+func (v *Volume) Render(renderer *Renderer, t float64) {
+	if len(v.values) == 0 {
+		return
+	}
+	renderer.DrawOverlay(func() {
+		v.draw(renderer)
+	})
+}
+
+// draw 逐像素合成切片，只处理包围盒投影覆盖的像素
+func (v *Volume) draw(renderer *Renderer) {
+	origin := renderer.Camera.Position
+	size := v.Bounds.Size()
+	axis := 0
+	view := v.Bounds.Center().Sub(origin)
+	if math.Abs(view.Y) > math.Abs(component(view, axis)) {
+		axis = 1
+	}
+	if math.Abs(view.Z) > math.Abs(component(view, axis)) {
+		axis = 2
+	}
+	extent := component(size, axis)
+	slices := v.Slices
+	if slices <= 0 {
+		slices = max(2, [3]int{v.nx, v.ny, v.nz}[axis])
+	}
+	spacing := extent / float64(slices)
+	positions := make([]float64, slices)
+	for s := range positions {
+		positions[s] = component(v.Bounds.Min, axis) + extent*(float64(s)+0.5)/float64(slices)
+	}
+
+	x0, y0, x1, y1, ok := v.screenRect(renderer)
+	if !ok {
+		return
+	}
+	surface := newImageSurface(renderer.Width, renderer.Height)
+	defer surface.Destroy()
+	img, ok := surface.GetGoImage().(*image.RGBA)
+	if !ok {
+		return
+	}
+	inv := func(d float64) float64 {
+		if d == 0 {
+			return math.Inf(1)
+		}
+		return 1 / d
+	}
+	for py := y0; py < y1; py++ {
+		for px := x0; px < x1; px++ {
+			dir := renderer.cameraRay(float64(px)+0.5, float64(py)+0.5)
+			near, far, hit := v.Bounds.rayInterval(origin, NewVector3(inv(dir.X), inv(dir.Y), inv(dir.Z)), 0, math.Inf(1))
+			d := component(dir, axis)
+			if !hit || math.Abs(d) < 1e-9 {
+				continue
+			}
+			// 每块切片代表沿视线 spacing/|d| 长的一段
+			length := spacing / math.Abs(d)
+
+			var color [3]float64
+			alpha := 0.0
+			for n := range slices {
+				// 从后往前：视线沿正方向时坐标最大的切片最远
+				s := slices - 1 - n
+				if d < 0 {
+					s = n
+				}
+				distance := (positions[s] - component(origin, axis)) / d
+				if distance < near || distance > far {
+					continue
+				}
+				value := v.normalized(v.Sample(origin.Add(dir.Scale(distance))))
+				if value <= 0 {
+					continue
+				}
+				a := 1 - math.Exp(-v.Opacity*value*length)
+				c := v.Ramp.At(value)
+				for k := range 3 {
+					color[k] = c[k]*a + color[k]*(1-a)
+				}
+				alpha = a + alpha*(1-a)
+			}
+			if alpha <= 0 {
+				continue
+			}
+			offset := py*img.Stride + px*4
+			for k := range 3 {
+				img.Pix[offset+k] = uint8(math.Max(0, math.Min(1, color[k]))*255 + 0.5)
+			}
+			img.Pix[offset+3] = uint8(math.Min(1, alpha)*255 + 0.5)
+		}
+	}
+
+	renderer.Context.Save()
+	defer renderer.Context.Restore()
+	renderer.Context.SetSourceSurface(surface, 0, 0)
+	renderer.Context.Paint()
+}
+
+// screenRect 包围盒在画布上覆盖的像素范围 [x0, x1) × [y0, y1)；有角点在相机后方时返回整个画布
+func (v *Volume) screenRect(renderer *Renderer) (x0, y0, x1, y1 int, ok bool) {
+	lo := NewVector2(math.Inf(1), math.Inf(1))
+	hi := NewVector2(math.Inf(-1), math.Inf(-1))
+	for i := range 8 {
+		p, visible := renderer.WorldToScreen(v.Bounds.corner(i))
+		if !visible {
+			return 0, 0, renderer.Width, renderer.Height, true
+		}
+		lo = NewVector2(math.Min(lo.X, p.X), math.Min(lo.Y, p.Y))
+		hi = NewVector2(math.Max(hi.X, p.X), math.Max(hi.Y, p.Y))
+	}
+	x0, y0 = max(0, int(math.Floor(lo.X))), max(0, int(math.Floor(lo.Y)))
+	x1, y1 = min(renderer.Width, int(math.Ceil(hi.X))+1), min(renderer.Height, int(math.Ceil(hi.Y))+1)
+	return x0, y0, x1, y1, x0 < x1 && y0 < y1
+}
+
+// WorldBounds 体数据的包围盒
+func (v *Volume) WorldBounds(t float64) AABB {
+	return v.Bounds
+}
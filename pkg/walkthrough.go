@@ -0,0 +1,335 @@
+package go3d
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// walkCornerSteps 每个圆滑拐角细分的段数
+const walkCornerSteps = 16
+
+// Waypoint 漫游路线上的路点
+type Waypoint struct {
+	Position Vector3 // 地面上的点，Y 为地面高度（楼梯和坡道上逐点升高）
+	Pause    float64 // 到达后停留的时长（秒）
+}
+
+// LookKey 漫游中的视线关键帧，相邻关键帧之间平滑过渡
+type LookKey struct {
+	Time  float64 // 时间点（0-1）
+	Yaw   float64 // 相对行走方向的水平转角（弧度），正值向左看
+	Pitch float64 // 俯仰角（弧度），正值向上看
+}
+
+// WalkthroughPath 第一人称漫游相机路径，用于建筑漫游动画：相机在眼睛高度沿路点步行，
+// 拐角按 TurnRadius 圆滑，起步和停下时平滑加减速，可以在路点停留；
+// 默认看向行走方向，用 LookKey 在途中环顾四周，相机始终保持水平不滚转
+type WalkthroughPath struct {
+	Waypoints []Waypoint
+	Looks     []LookKey // 按时间排列，为空时始终看向行走方向
+
+	EyeHeight  float64 // 眼睛离地面的高度
+	Speed      float64 // 步行速度（单位/秒）
+	Ramp       float64 // 起步和停下的加减速时长（秒），0 为匀速
+	TurnRadius float64 // 拐角的圆滑半径
+	LookAhead  float64 // 行走方向取前方多远的路线点，越大转身越提前、越平缓
+	FOV        float64
+
+	mu   sync.Mutex
+	key  walkKey
+	plan *walkPlan
+}
+
+// walkKey 决定路线和时间安排的参数，改变时重新规划
+type walkKey struct {
+	waypoints               []Waypoint
+	speed, ramp, turnRadius float64
+}
+
+// walkPlan 规划好的路线：圆滑后的折线和每一段行走或停留的时间
+type walkPlan struct {
+	points   []Vector3 // 地面上的路线折线
+	lengths  []float64 // 每个折线顶点处的累计路程
+	legs     []walkLeg
+	arrivals []float64 // 到达每个路点的时刻（秒）
+	duration float64
+}
+
+// walkLeg 两次停下之间的一段行走（length 为 0 时是原地停留）。
+// 速度先以加速度 accel 升到 speed，匀速行走后再减速停下；路程太短时只加速到一半路程就开始减速
+type walkLeg struct {
+	start, duration float64 // 开始时刻和时长（秒）
+	from, length    float64 // 起点的累计路程和这一段的路程
+	speed, accel    float64 // 最高速度和加速度，accel 为 0 表示匀速
+	ramp            float64 // 加速段的时长
+}
+
+// NewWalkthroughPath 创建漫游路径：眼睛高度 1.6，步速 1.4 单位/秒，加减速 0.8 秒，拐角半径 1
+func NewWalkthroughPath(fov float64) *WalkthroughPath {
+	return &WalkthroughPath{
+		EyeHeight:  1.6,
+		Speed:      1.4,
+		Ramp:       0.8,
+		TurnRadius: 1,
+		LookAhead:  1.5,
+		FOV:        fov,
+	}
+}
+
+// AddWaypoint 添加路点，pause 为到达后停留的秒数
+func (wp *WalkthroughPath) AddWaypoint(position Vector3, pause float64) *WalkthroughPath {
+	wp.Waypoints = append(wp.Waypoints, Waypoint{Position: position, Pause: pause})
+	return wp
+}
+
+// AddLook 添加视线关键帧，保持按时间排列
+func (wp *WalkthroughPath) AddLook(t, yaw, pitch float64) *WalkthroughPath {
+	key := LookKey{Time: t, Yaw: yaw, Pitch: pitch}
+	i := sort.Search(len(wp.Looks), func(i int) bool { return wp.Looks[i].Time > t })
+	wp.Looks = slices.Insert(wp.Looks, i, key)
+	return wp
+}
+
+// Duration 按步速和停留时间计算的漫游总时长（秒），用来设置动画时长使步行速度符合实际
+func (wp *WalkthroughPath) Duration() float64 {
+	return wp.schedule().duration
+}
+
+// WaypointTime 到达第 i 个路点的时间（0-1），便于在停留时安排环顾的 LookKey
+func (wp *WalkthroughPath) WaypointTime(i int) float64 {
+	plan := wp.schedule()
+	if i < 0 || i >= len(plan.arrivals) || plan.duration == 0 {
+		return 0
+	}
+	return plan.arrivals[i] / plan.duration
+}
+
+// schedule 规划路线和时间安排，参数不变时复用上次的结果
+func (wp *WalkthroughPath) schedule() *walkPlan {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	key := walkKey{wp.Waypoints, wp.Speed, wp.Ramp, wp.TurnRadius}
+	if wp.plan != nil && slices.Equal(key.waypoints, wp.key.waypoints) &&
+		key.speed == wp.key.speed && key.ramp == wp.key.ramp && key.turnRadius == wp.key.turnRadius {
+		return wp.plan
+	}
+	key.waypoints = slices.Clone(wp.Waypoints)
+	wp.key = key
+	wp.plan = wp.buildPlan()
+	return wp.plan
+}
+
+// buildPlan 在每个中间路点处用二次贝塞尔曲线圆滑拐角，再按停留的路点把路线分段安排时间
+func (wp *WalkthroughPath) buildPlan() *walkPlan {
+	plan := &walkPlan{}
+	n := len(wp.Waypoints)
+	if n == 0 {
+		return plan
+	}
+
+	stations := make([]int, n) // 每个路点在折线中对应的顶点
+	for i, w := range wp.Waypoints {
+		p := w.Position
+		if i == 0 || i == n-1 {
+			stations[i] = len(plan.points)
+			plan.points = append(plan.points, p)
+			continue
+		}
+		in, out := p.Sub(wp.Waypoints[i-1].Position), wp.Waypoints[i+1].Position.Sub(p)
+		radius := math.Max(0, math.Min(wp.TurnRadius, math.Min(in.Length(), out.Length())/2))
+		if radius == 0 {
+			stations[i] = len(plan.points)
+			plan.points = append(plan.points, p)
+			continue
+		}
+		enter, exit := p.Sub(in.Normalize().Scale(radius)), p.Add(out.Normalize().Scale(radius))
+		for k := range walkCornerSteps + 1 {
+			u := float64(k) / walkCornerSteps
+			if k == walkCornerSteps/2 {
+				stations[i] = len(plan.points)
+			}
+			plan.points = append(plan.points, enter.Scale((1-u)*(1-u)).Add(p.Scale(2*u*(1-u))).Add(exit.Scale(u*u)))
+		}
+	}
+	plan.lengths = make([]float64, len(plan.points))
+	for i := 1; i < len(plan.points); i++ {
+		plan.lengths[i] = plan.lengths[i-1] + plan.points[i].Sub(plan.points[i-1]).Length()
+	}
+
+	speed := wp.Speed
+	if speed <= 0 {
+		speed = 1.4
+	}
+	plan.arrivals = make([]float64, n)
+	clock, last := 0.0, 0
+	for i, w := range wp.Waypoints {
+		if i > 0 && (w.Pause > 0 || i == n-1) {
+			from := plan.lengths[stations[last]]
+			leg := newWalkLeg(clock, from, plan.lengths[stations[i]]-from, speed, wp.Ramp)
+			plan.legs = append(plan.legs, leg)
+			for j := last + 1; j <= i; j++ {
+				plan.arrivals[j] = clock + leg.timeAt(plan.lengths[stations[j]]-from)
+			}
+			clock += leg.duration
+			last = i
+		}
+		if w.Pause > 0 {
+			plan.legs = append(plan.legs, walkLeg{start: clock, duration: w.Pause, from: plan.lengths[stations[i]]})
+			clock += w.Pause
+		}
+	}
+	plan.duration = clock
+	return plan
+}
+
+// newWalkLeg 从路程 from 开始走 length 的一段，加速时长为 ramp
+func newWalkLeg(start, from, length, speed, ramp float64) walkLeg {
+	leg := walkLeg{start: start, from: from, length: length, speed: speed}
+	if ramp <= 0 {
+		leg.duration = length / speed
+		return leg
+	}
+	leg.accel = speed / ramp
+	leg.ramp = math.Min(ramp, math.Sqrt(length/leg.accel))
+	leg.speed = leg.accel * leg.ramp
+	if leg.speed > 0 {
+		leg.duration = length/leg.speed + leg.ramp
+	}
+	return leg
+}
+
+// distanceAt 开始后 u 秒走过的路程
+func (leg walkLeg) distanceAt(u float64) float64 {
+	u = math.Max(0, math.Min(leg.duration, u))
+	switch {
+	case leg.length == 0:
+		return 0
+	case leg.accel == 0:
+		return leg.speed * u
+	case u < leg.ramp:
+		return leg.accel * u * u / 2
+	case u < leg.duration-leg.ramp:
+		return leg.accel*leg.ramp*leg.ramp/2 + leg.speed*(u-leg.ramp)
+	default:
+		rest := leg.duration - u
+		return leg.length - leg.accel*rest*rest/2
+	}
+}
+
+// timeAt 走到路程 d 所需的时间，distanceAt 的反函数
+func (leg walkLeg) timeAt(d float64) float64 {
+	d = math.Max(0, math.Min(leg.length, d))
+	ramped := leg.accel * leg.ramp * leg.ramp / 2
+	switch {
+	case leg.length == 0 || leg.speed == 0:
+		return 0
+	case leg.accel == 0:
+		return d / leg.speed
+	case d < ramped:
+		return math.Sqrt(2 * d / leg.accel)
+	case d < leg.length-ramped:
+		return leg.ramp + (d-ramped)/leg.speed
+	default:
+		return leg.duration - math.Sqrt(2*(leg.length-d)/leg.accel)
+	}
+}
+
+// distance 时间 t（0-1）时走过的路程
+func (plan *walkPlan) distance(t float64) float64 {
+	if len(plan.legs) == 0 {
+		return 0
+	}
+	clock := math.Max(0, math.Min(1, t)) * plan.duration
+	i := sort.Search(len(plan.legs), func(i int) bool { return plan.legs[i].start+plan.legs[i].duration > clock })
+	if i == len(plan.legs) {
+		i--
+	}
+	leg := plan.legs[i]
+	return leg.from + leg.distanceAt(clock-leg.start)
+}
+
+// pointAt 路程 s 处的地面点
+func (plan *walkPlan) pointAt(s float64) Vector3 {
+	if len(plan.points) == 0 {
+		return Vector3{}
+	}
+	i := sort.SearchFloat64s(plan.lengths, s)
+	if i == 0 {
+		return plan.points[0]
+	}
+	if i == len(plan.points) {
+		return plan.points[i-1]
+	}
+	a, b := plan.lengths[i-1], plan.lengths[i]
+	if b <= a {
+		return plan.points[i]
+	}
+	return plan.points[i-1].Add(plan.points[i].Sub(plan.points[i-1]).Scale((s - a) / (b - a)))
+}
+
+// heading 路程 s 处的水平行走方向：指向前方 LookAhead 处的路线点，接近终点时取最后一段的方向
+func (wp *WalkthroughPath) heading(plan *walkPlan, s float64) Vector3 {
+	total := 0.0
+	if len(plan.lengths) > 0 {
+		total = plan.lengths[len(plan.lengths)-1]
+	}
+	ahead := math.Max(wp.LookAhead, 1e-3)
+	from, to := s, s+ahead
+	if to > total {
+		from, to = math.Max(0, total-ahead), total
+	}
+	d := plan.pointAt(to).Sub(plan.pointAt(from))
+	d.Y = 0
+	if d.Length() < 1e-9 {
+		return NewVector3(0, 0, -1)
+	}
+	return d.Normalize()
+}
+
+// look 时间 t 的视线转角和俯仰角，关键帧之间按 Smoothstep 过渡
+func (wp *WalkthroughPath) look(t float64) (yaw, pitch float64) {
+	keys := wp.Looks
+	switch {
+	case len(keys) == 0:
+		return 0, 0
+	case t <= keys[0].Time:
+		return keys[0].Yaw, keys[0].Pitch
+	case t >= keys[len(keys)-1].Time:
+		return keys[len(keys)-1].Yaw, keys[len(keys)-1].Pitch
+	}
+	i := sort.Search(len(keys), func(i int) bool { return keys[i].Time > t })
+	a, b := keys[i-1], keys[i]
+	u := 0.0
+	if b.Time > a.Time {
+		u = Smoothstep((t - a.Time) / (b.Time - a.Time))
+	}
+	return a.Yaw + (b.Yaw-a.Yaw)*u, a.Pitch + (b.Pitch-a.Pitch)*u
+}
+
+// GetPosition 眼睛的位置
+func (wp *WalkthroughPath) GetPosition(t float64) Vector3 {
+	plan := wp.schedule()
+	return plan.pointAt(plan.distance(t)).Add(NewVector3(0, wp.EyeHeight, 0))
+}
+
+// GetTarget 眼睛前方 1 个单位处的目标点：行走方向按 LookKey 转动后的视线方向
+func (wp *WalkthroughPath) GetTarget(t float64) Vector3 {
+	plan := wp.schedule()
+	s := plan.distance(t)
+	yaw, pitch := wp.look(t)
+	h := QuaternionFromAxisAngle(NewVector3(0, 1, 0), yaw).Rotate(wp.heading(plan, s))
+	direction := h.Scale(math.Cos(pitch)).Add(NewVector3(0, math.Sin(pitch), 0))
+	return plan.pointAt(s).Add(NewVector3(0, wp.EyeHeight, 0)).Add(direction)
+}
+
+// GetFOV 获取视场角
+func (wp *WalkthroughPath) GetFOV(t float64) float64 {
+	return wp.FOV
+}
+
+// GetUp 始终竖直向上，漫游镜头不滚转
+func (wp *WalkthroughPath) GetUp(t float64) Vector3 {
+	return NewVector3(0, 1, 0)
+}
@@ -0,0 +1,27 @@
+module github.com/novvoo/go-3d/window
+
+go 1.25.0
+
+require (
+	github.com/hajimehoshi/ebiten/v2 v2.10.4
+	github.com/novvoo/go-3d v0.0.0-00010101000000-000000000000
+)
+
+require (
+	github.com/ebitengine/gomobile v0.0.0-20260820040257-d11f821a26a6 // indirect
+	github.com/ebitengine/hideconsole v1.0.0 // indirect
+	github.com/ebitengine/purego v0.11.0 // indirect
+	github.com/go-text/typesetting v0.3.5 // indirect
+	github.com/novvoo/go-cairo v0.0.0-20251218084434-61cf8da82043 // indirect
+	github.com/yuin/gopher-lua v1.1.2 // indirect
+	golang.org/x/image v0.45.0 // indirect
+	golang.org/x/sync v0.22.0 // indirect
+	golang.org/x/sys v0.47.0 // indirect
+	golang.org/x/text v0.41.0 // indirect
+)
+
+replace github.com/novvoo/go-3d => ../
+
+// go-cairo 使用 typesetting v0.1 的 opentype/api 包，v0.3 中已经移除；
+// 预览窗口用到的 ebiten 包不依赖 typesetting，因此固定为 go-cairo 所需的版本
+replace github.com/go-text/typesetting => github.com/go-text/typesetting v0.1.2
@@ -0,0 +1,125 @@
+// Package window 交互式预览窗口，基于 Ebiten：
+//
+//	go get github.com/novvoo/go-3d/window
+//
+// 这是独立的模块，只有使用窗口的程序才会引入 Ebiten 依赖
+package window
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	go3d "github.com/novvoo/go-3d/pkg"
+)
+
+// Show 打开窗口显示交互式预览，窗口关闭后返回
+//
+//	左键拖动      环绕旋转
+//	右键拖动      平移（或按住 Shift 左键拖动）
+//	滚轮          缩放
+//	空格          播放/暂停
+//	← →          逐步调整时间（按住 Shift 步长更大）
+//	点击底部时间轴 跳转时间
+func Show(v *go3d.InteractiveView, title string) error {
+	width, height := v.Size()
+	ebiten.SetWindowTitle(title)
+	ebiten.SetWindowSize(width, height)
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	defer v.Close()
+	return ebiten.RunGame(&previewWindow{view: v})
+}
+
+// previewWindow 实现 ebiten.Game，把输入转换为 InteractiveView 的操作
+type previewWindow struct {
+	view      *go3d.InteractiveView
+	canvas    *ebiten.Image
+	lastX     int
+	lastY     int
+	scrubbing bool // 正在拖动时间轴
+}
+
+// Update 处理输入并推进播放
+func (w *previewWindow) Update() error {
+	v := w.view
+	x, y := ebiten.CursorPosition()
+	dx, dy := float64(x-w.lastX), float64(y-w.lastY)
+	w.lastX, w.lastY = x, y
+	_, height := v.Size()
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		w.scrubbing = y >= height-go3d.ScrubberHeight
+	}
+	if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		w.scrubbing = false
+	}
+
+	shift := ebiten.IsKeyPressed(ebiten.KeyShift)
+	switch {
+	case w.scrubbing:
+		width, _ := v.Size()
+		v.Seek(float64(x) / float64(max(1, width-1)))
+	case ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight),
+		ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && shift:
+		v.PanPixels(dx, dy)
+	case ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft):
+		v.Drag(dx, dy)
+	}
+
+	_, wheel := ebiten.Wheel()
+	v.Scroll(wheel)
+
+	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		v.TogglePlay()
+	}
+	step := 0.01
+	if shift {
+		step = 0.1
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowRight) {
+		v.Seek(v.Time + step)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyArrowLeft) {
+		v.Seek(v.Time - step)
+	}
+
+	v.Advance(1 / float64(ebiten.TPS()))
+	return nil
+}
+
+// Draw 需要时重新渲染场景，再叠加时间轴和状态文字
+func (w *previewWindow) Draw(screen *ebiten.Image) {
+	v := w.view
+	width, height := v.Size()
+	if w.canvas == nil || w.canvas.Bounds().Dx() != width || w.canvas.Bounds().Dy() != height {
+		w.canvas = ebiten.NewImage(width, height)
+		v.Invalidate()
+	}
+	if v.NeedsRender() {
+		// 渲染器画布与 Ebiten 一样使用预乘 alpha 的 RGBA，可以直接写入
+		w.canvas.WritePixels(v.Render().Pix)
+	}
+	screen.DrawImage(w.canvas, nil)
+
+	// 时间轴
+	top := float32(height - go3d.ScrubberHeight)
+	vector.FillRect(screen, 0, top, float32(width), go3d.ScrubberHeight, color.RGBA{0, 0, 0, 160}, false)
+	vector.FillRect(screen, 0, top+6, float32(v.Time)*float32(width), 4, color.RGBA{90, 160, 255, 255}, false)
+
+	// DebugPrint 的内置字体只包含 ASCII 字符
+	state := "paused"
+	if v.Playing {
+		state = "playing"
+	}
+	ebitenutil.DebugPrint(screen, fmt.Sprintf("t = %.3f  %s  distance %.2f", v.Time, state, v.Orbit.Distance))
+}
+
+// Layout 画面尺寸跟随窗口大小
+func (w *previewWindow) Layout(outsideWidth, outsideHeight int) (int, int) {
+	w.view.Resize(outsideWidth, outsideHeight)
+	return w.view.Size()
+}